@@ -32,12 +32,40 @@ import (
 
 // @schemes   http https
 
+// @securityDefinitions.apikey  BearerAuth
+// @in                          header
+// @name                        Authorization
+// @description                 JWT no formato "Bearer {token}". Exigido quando auth.enabled = true.
+
+// @securityDefinitions.apikey  ApiKeyAuth
+// @in                          header
+// @name                        X-API-Key
+// @description                 Chave de API de integrações servidor a servidor, limitada pelos escopos da chave.
+
 // @tag.name Products
 // @tag.description Operações relacionadas a produtos
 
+// @tag.name Categories
+// @tag.description Operações relacionadas a categorias de produtos
+
 // @tag.name Orders
 // @tag.description Operações relacionadas a pedidos
 
+// @tag.name Coupons
+// @tag.description Operações relacionadas a cupons de desconto
+
+// @tag.name Customers
+// @tag.description Operações relacionadas a clientes
+
+// @tag.name Shipments
+// @tag.description Operações relacionadas a envios e entregas de pedidos
+
+// @tag.name Returns
+// @tag.description Operações relacionadas a devoluções e reembolsos de pedidos
+
+// @tag.name API Keys
+// @tag.description Chaves de API de integrações de parceiros
+
 // @tag.name Health
 // @tag.description Health check da aplicação
 
@@ -61,14 +89,24 @@ func main() {
 	defer cleanup()
 
 	defer func() {
-		if err := app.DB.Disconnect(context.Background()); err != nil {
-			logger.Error("Failed to disconnect from MongoDB", zap.Error(err))
+		// nil with the memory storage and broker drivers
+		if app.DB != nil {
+			if err := app.DB.Disconnect(context.Background()); err != nil {
+				logger.Error("Failed to disconnect from MongoDB", zap.Error(err))
+			}
 		}
-		if err := app.RabbitMQConn.Close(context.Background()); err != nil {
-			logger.Error("Failed to close RabbitMQ connection", zap.Error(err))
+		if app.RabbitMQConn != nil {
+			if err := app.RabbitMQConn.Close(context.Background()); err != nil {
+				logger.Error("Failed to close RabbitMQ connection", zap.Error(err))
+			}
 		}
 	}()
 
+	app.PriceScheduler.Start(ctx)
+	defer app.PriceScheduler.Stop()
+	app.OrderScheduler.Start(ctx)
+	defer app.OrderScheduler.Stop()
+
 	cfg := config.GetAPIConfig()
 	address := fmt.Sprintf("%s:%s", cfg.Host, cfg.Port)
 
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/gvillela7/rank-my-app/configs"
+	dbMongo "github.com/gvillela7/rank-my-app/internal/infra/database/mongo"
+	"github.com/gvillela7/rank-my-app/internal/infra/database/mongo/migrations"
+)
+
+// migrate applies, reverts or lists the migrations of the database shared by
+// api-orders and manager-status.
+func main() {
+	steps := flag.Int("steps", 1, "number of migrations reverted by down")
+	tenant := flag.String("tenant", "", "tenant owning the documents written before multi-tenancy (default: tenant.default of the configuration)")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: migrate [flags] up|down|status\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	command := flag.Arg(0)
+
+	if err := config.Load(); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	defaultTenant := *tenant
+	if defaultTenant == "" {
+		defaultTenant = config.GetTenantConfig().Default
+	}
+
+	ctx := context.Background()
+
+	conn, err := dbMongo.NewMongoDBConnection(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to connect to MongoDB: %v\n", err)
+		os.Exit(1)
+	}
+	defer conn.Disconnect(context.Background())
+
+	db, err := conn.Client()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to get database: %v\n", err)
+		os.Exit(1)
+	}
+
+	runner := migrations.NewRunner(db, migrations.All(defaultTenant))
+
+	switch command {
+	case "up":
+		applied, err := runner.Up(ctx)
+		for _, migration := range applied {
+			fmt.Printf("applied\t%d\t%s\n", migration.Version, migration.Description)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to apply migrations: %v\n", err)
+			os.Exit(1)
+		}
+		if len(applied) == 0 {
+			fmt.Println("database is up to date")
+		}
+
+	case "down":
+		reverted, err := runner.Down(ctx, *steps)
+		for _, migration := range reverted {
+			fmt.Printf("reverted\t%d\t%s\n", migration.Version, migration.Description)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to revert migrations: %v\n", err)
+			os.Exit(1)
+		}
+		if len(reverted) == 0 {
+			fmt.Println("no migration to revert")
+		}
+
+	case "status":
+		statuses, err := runner.Status(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to read migrations: %v\n", err)
+			os.Exit(1)
+		}
+		for _, status := range statuses {
+			applied := "pending"
+			if status.AppliedAt != nil {
+				applied = status.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%d\t%s\t%s\n", status.Version, applied, status.Description)
+		}
+
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/gvillela7/rank-my-app/configs"
+	"github.com/gvillela7/rank-my-app/internal/adapter/audit"
+	mongoRepo "github.com/gvillela7/rank-my-app/internal/adapter/repository/mongo"
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/usecase"
+	dbMongo "github.com/gvillela7/rank-my-app/internal/infra/database/mongo"
+	"go.uber.org/zap"
+)
+
+// reconcile recomputes the quantity of every product of a tenant from the stock
+// ledger and reports the products whose stored quantity drifted from it.
+func main() {
+	apply := flag.Bool("apply", false, "overwrite product quantities with the ledger balance")
+	tenant := flag.String("tenant", "", "tenant to reconcile (default: tenant.default of the configuration)")
+	flag.Parse()
+
+	if err := config.Load(); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	tenantID := *tenant
+	if tenantID == "" {
+		tenantID = config.GetTenantConfig().Default
+	}
+	if err := domain.ValidateTenant(tenantID); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid tenant %q: %v\n", tenantID, err)
+		os.Exit(1)
+	}
+
+	ctx := domain.ContextWithTenant(context.Background(), tenantID)
+
+	conn, err := dbMongo.NewMongoDBConnection(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to connect to MongoDB: %v\n", err)
+		os.Exit(1)
+	}
+	defer conn.Disconnect(context.Background())
+
+	db, err := conn.Client()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to get database: %v\n", err)
+		os.Exit(1)
+	}
+
+	mongoRepo.SetOperationTimeout(config.GetDBMongo().OperationTimeout)
+
+	logger, err := zap.NewProduction()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to create logger: %v\n", err)
+		os.Exit(1)
+	}
+
+	// the fixed quantities are audited as system changes
+	auditor := usecase.NewAuditUseCase(mongoRepo.NewAuditRepository(db))
+	productRepo := audit.NewProductRepository(mongoRepo.NewProductRepository(db), auditor, logger)
+
+	// reconciliation only overwrites quantities, so it runs without publishing stock alerts
+	uc := usecase.NewStockUseCase(productRepo, mongoRepo.NewStockMovementRepository(db), nil)
+
+	drifts, err := uc.Reconcile(ctx, *apply)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to reconcile stock: %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(drifts) == 0 {
+		fmt.Println("no drift found: every product quantity matches its ledger")
+		return
+	}
+
+	for _, drift := range drifts {
+		untracked := ""
+		if drift.Untracked {
+			untracked = "\tuntracked"
+		}
+		fmt.Printf("%s\t%s\tquantity=%d\tledger=%d\tdrift=%+d%s\n",
+			drift.ProductID, drift.ProductName, drift.Quantity, drift.LedgerQuantity, drift.Drift, untracked)
+	}
+
+	if *apply {
+		fmt.Printf("%d product(s) drifted; untracked products got their quantity as opening balance, the other products without variants were fixed with the ledger balance\n", len(drifts))
+		return
+	}
+	fmt.Printf("%d product(s) drifted; run with -apply to fix them\n", len(drifts))
+}
@@ -2,6 +2,8 @@ package config
 
 import (
 	"errors"
+	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -9,24 +11,53 @@ import (
 var cfg *config
 
 type config struct {
-	API      APIConfig
-	DBMongo  DBMongo
-	RabbitMQ RabbitMQConfig
+	API         APIConfig
+	DBMongo     DBMongo
+	RabbitMQ    RabbitMQConfig
+	Exchange    ExchangeConfig
+	Tax         TaxConfig
+	Price       PriceConfig
+	Order       OrderConfig
+	Auth        AuthConfig
+	RateLimit   RateLimitConfig
+	Tenant      TenantConfig
+	OrderNumber OrderNumberConfig
+	Storage     StorageConfig
+	Broker      BrokerConfig
 }
 
 type APIConfig struct {
-	Port          string
-	Environment   string
-	Host          string
-	Origin        string
-	Documentation string
-	LogDir        string
-	TimeZone      string
+	Port           string
+	Environment    string
+	Host           string
+	Origin         string
+	Documentation  string
+	LogDir         string
+	TimeZone       string
+	RequireIfMatch bool
 }
 
 type DBMongo struct {
-	URI      string
-	Database string
+	URI                    string
+	Database               string
+	MigrateOnStartup       bool
+	MaxPoolSize            uint64
+	MinPoolSize            uint64
+	MaxConnIdleTime        time.Duration
+	ConnectTimeout         time.Duration
+	ServerSelectionTimeout time.Duration
+	SocketTimeout          time.Duration
+	OperationTimeout       time.Duration
+	ReadPreference         string
+	WriteConcern           string
+	TLS                    MongoTLSConfig
+}
+
+type MongoTLSConfig struct {
+	Enabled  bool
+	CAFile   string
+	CertFile string
+	KeyFile  string
 }
 
 type RabbitMQConfig struct {
@@ -37,6 +68,87 @@ type RabbitMQConfig struct {
 	VHost    string
 }
 
+type ExchangeConfig struct {
+	Provider     string
+	BaseCurrency string
+	Rates        map[string]float64
+	RatesFile    string
+	HTTPURL      string
+	HTTPTimeout  time.Duration
+	CacheTTL     time.Duration
+}
+
+type TaxConfig struct {
+	DefaultRegion string
+	Rules         []TaxRuleConfig
+}
+
+type PriceConfig struct {
+	SchedulerInterval time.Duration
+}
+
+type OrderConfig struct {
+	ReleaseInterval time.Duration
+}
+
+type AuthConfig struct {
+	Enabled   bool
+	Algorithm string
+	Secret    string
+	JWKSFile  string
+	Issuer    string
+	Audience  string
+	Leeway    time.Duration
+}
+
+type RateLimitConfig struct {
+	Enabled bool
+	Backend string
+	Default RateLimitRuleConfig
+	Routes  []RateLimitRuleConfig
+	IP      RateLimitRuleConfig
+}
+
+type TenantConfig struct {
+	Header  string
+	Default string
+}
+
+type OrderNumberConfig struct {
+	Prefix     string
+	DateLayout string
+	Digits     int
+	CheckDigit bool
+}
+
+// StorageConfig selects where the repositories keep their documents: "mongo" or
+// "memory", which needs no database and loses everything on exit
+type StorageConfig struct {
+	Driver string
+}
+
+// BrokerConfig selects where messages are published: "rabbitmq" or "memory", an
+// in-process broker holding up to QueueCapacity messages per queue
+type BrokerConfig struct {
+	Driver        string
+	QueueCapacity int
+}
+
+type RateLimitRuleConfig struct {
+	Method   string        `mapstructure:"method"`
+	Path     string        `mapstructure:"path"`
+	Requests int           `mapstructure:"requests"`
+	Period   time.Duration `mapstructure:"period"`
+	Burst    int           `mapstructure:"burst"`
+}
+
+type TaxRuleConfig struct {
+	TaxClass  string  `mapstructure:"tax_class"`
+	Region    string  `mapstructure:"region"`
+	Rate      float64 `mapstructure:"rate"`
+	Inclusive bool    `mapstructure:"inclusive"`
+}
+
 func init() {
 	//Service
 	viper.SetDefault("api.port", "8000")
@@ -45,10 +157,25 @@ func init() {
 	viper.SetDefault("api.origin", "*")
 	viper.SetDefault("api.documentation", "http://localhost:8001/swagger/index.html")
 	viper.SetDefault("api.timezone", "America/Sao_Paulo")
+	viper.SetDefault("api.require_if_match", false)
 
 	//MongoDB
 	viper.SetDefault("mongo.uri", "")
 	viper.SetDefault("mongo.database", "")
+	viper.SetDefault("mongo.max_pool_size", 100)
+	viper.SetDefault("mongo.min_pool_size", 0)
+	viper.SetDefault("mongo.max_conn_idle_time", "5m")
+	viper.SetDefault("mongo.connect_timeout", "10s")
+	viper.SetDefault("mongo.server_selection_timeout", "10s")
+	viper.SetDefault("mongo.socket_timeout", "30s")
+	viper.SetDefault("mongo.operation_timeout", "10s")
+	viper.SetDefault("mongo.read_preference", "primary")
+	viper.SetDefault("mongo.write_concern", "")
+	viper.SetDefault("mongo.tls.enabled", false)
+	viper.SetDefault("mongo.tls.ca_file", "")
+	viper.SetDefault("mongo.tls.cert_file", "")
+	viper.SetDefault("mongo.tls.key_file", "")
+	viper.SetDefault("mongo.migrate_on_startup", false)
 
 	//RabbitMQ
 	viper.SetDefault("rabbitmq.host", "localhost")
@@ -57,6 +184,58 @@ func init() {
 	viper.SetDefault("rabbitmq.password", "guest")
 	viper.SetDefault("rabbitmq.vhost", "/")
 
+	//Exchange rates
+	viper.SetDefault("exchange.provider", "static")
+	viper.SetDefault("exchange.base_currency", "BRL")
+	viper.SetDefault("exchange.rates", map[string]float64{})
+	viper.SetDefault("exchange.rates_file", "")
+	viper.SetDefault("exchange.http_url", "")
+	viper.SetDefault("exchange.http_timeout", "5s")
+	viper.SetDefault("exchange.cache_ttl", "1h")
+
+	//Tax
+	viper.SetDefault("tax.default_region", "SP")
+
+	//Prices
+	viper.SetDefault("price.scheduler_interval", "1m")
+
+	//Orders
+	viper.SetDefault("order.release_interval", "1m")
+
+	//Auth
+	viper.SetDefault("auth.enabled", false)
+	viper.SetDefault("auth.algorithm", "HS256")
+	viper.SetDefault("auth.secret", "")
+	viper.SetDefault("auth.jwks_file", "")
+	viper.SetDefault("auth.issuer", "")
+	viper.SetDefault("auth.audience", "")
+	viper.SetDefault("auth.leeway", "30s")
+
+	//Rate limit
+	viper.SetDefault("ratelimit.enabled", false)
+	viper.SetDefault("ratelimit.backend", "memory")
+	viper.SetDefault("ratelimit.requests", 300)
+	viper.SetDefault("ratelimit.period", "1m")
+	viper.SetDefault("ratelimit.burst", 0)
+	viper.SetDefault("ratelimit.ip.requests", 600)
+	viper.SetDefault("ratelimit.ip.period", "1m")
+	viper.SetDefault("ratelimit.ip.burst", 0)
+
+	//Tenant
+	viper.SetDefault("tenant.header", "X-Tenant-ID")
+	viper.SetDefault("tenant.default", "default")
+
+	//Order numbers
+	viper.SetDefault("order_number.prefix", "ORD")
+	viper.SetDefault("order_number.date_layout", "20060102")
+	viper.SetDefault("order_number.digits", 6)
+	viper.SetDefault("order_number.check_digit", true)
+
+	//Drivers
+	viper.SetDefault("storage.driver", "mongo")
+	viper.SetDefault("broker.driver", "rabbitmq")
+	viper.SetDefault("broker.queue_capacity", 1000)
+
 }
 
 func Load(viperPath ...string) error {
@@ -79,17 +258,34 @@ func Load(viperPath ...string) error {
 	cfg = new(config)
 
 	cfg.API = APIConfig{
-		Port:          viper.GetString("api.port"),
-		Environment:   viper.GetString("api.environment"),
-		Host:          viper.GetString("api.host"),
-		Origin:        viper.GetString("api.origins"),
-		TimeZone:      viper.GetString("api.timezone"),
-		Documentation: viper.GetString("api.documentation"),
+		Port:           viper.GetString("api.port"),
+		Environment:    viper.GetString("api.environment"),
+		Host:           viper.GetString("api.host"),
+		Origin:         viper.GetString("api.origins"),
+		TimeZone:       viper.GetString("api.timezone"),
+		Documentation:  viper.GetString("api.documentation"),
+		RequireIfMatch: viper.GetBool("api.require_if_match"),
 	}
 
 	cfg.DBMongo = DBMongo{
-		URI:      viper.GetString("mongo.uri"),
-		Database: viper.GetString("mongo.database"),
+		URI:                    viper.GetString("mongo.uri"),
+		Database:               viper.GetString("mongo.database"),
+		MigrateOnStartup:       viper.GetBool("mongo.migrate_on_startup"),
+		MaxPoolSize:            viper.GetUint64("mongo.max_pool_size"),
+		MinPoolSize:            viper.GetUint64("mongo.min_pool_size"),
+		MaxConnIdleTime:        viper.GetDuration("mongo.max_conn_idle_time"),
+		ConnectTimeout:         viper.GetDuration("mongo.connect_timeout"),
+		ServerSelectionTimeout: viper.GetDuration("mongo.server_selection_timeout"),
+		SocketTimeout:          viper.GetDuration("mongo.socket_timeout"),
+		OperationTimeout:       viper.GetDuration("mongo.operation_timeout"),
+		ReadPreference:         viper.GetString("mongo.read_preference"),
+		WriteConcern:           viper.GetString("mongo.write_concern"),
+		TLS: MongoTLSConfig{
+			Enabled:  viper.GetBool("mongo.tls.enabled"),
+			CAFile:   viper.GetString("mongo.tls.ca_file"),
+			CertFile: viper.GetString("mongo.tls.cert_file"),
+			KeyFile:  viper.GetString("mongo.tls.key_file"),
+		},
 	}
 
 	cfg.RabbitMQ = RabbitMQConfig{
@@ -100,6 +296,97 @@ func Load(viperPath ...string) error {
 		VHost:    viper.GetString("rabbitmq.vhost"),
 	}
 
+	var configuredRates map[string]float64
+	if err := viper.UnmarshalKey("exchange.rates", &configuredRates); err != nil {
+		return err
+	}
+
+	// viper lowercases keys, currency codes are uppercase
+	rates := make(map[string]float64, len(configuredRates))
+	for currency, rate := range configuredRates {
+		rates[strings.ToUpper(currency)] = rate
+	}
+
+	cfg.Exchange = ExchangeConfig{
+		Provider:     viper.GetString("exchange.provider"),
+		BaseCurrency: viper.GetString("exchange.base_currency"),
+		Rates:        rates,
+		RatesFile:    viper.GetString("exchange.rates_file"),
+		HTTPURL:      viper.GetString("exchange.http_url"),
+		HTTPTimeout:  viper.GetDuration("exchange.http_timeout"),
+		CacheTTL:     viper.GetDuration("exchange.cache_ttl"),
+	}
+
+	var taxRules []TaxRuleConfig
+	if err := viper.UnmarshalKey("tax.rules", &taxRules); err != nil {
+		return err
+	}
+
+	cfg.Tax = TaxConfig{
+		DefaultRegion: viper.GetString("tax.default_region"),
+		Rules:         taxRules,
+	}
+
+	cfg.Price = PriceConfig{
+		SchedulerInterval: viper.GetDuration("price.scheduler_interval"),
+	}
+
+	cfg.Order = OrderConfig{
+		ReleaseInterval: viper.GetDuration("order.release_interval"),
+	}
+
+	cfg.Auth = AuthConfig{
+		Enabled:   viper.GetBool("auth.enabled"),
+		Algorithm: strings.ToUpper(viper.GetString("auth.algorithm")),
+		Secret:    viper.GetString("auth.secret"),
+		JWKSFile:  viper.GetString("auth.jwks_file"),
+		Issuer:    viper.GetString("auth.issuer"),
+		Audience:  viper.GetString("auth.audience"),
+		Leeway:    viper.GetDuration("auth.leeway"),
+	}
+
+	var rateLimitRoutes []RateLimitRuleConfig
+	if err := viper.UnmarshalKey("ratelimit.routes", &rateLimitRoutes); err != nil {
+		return err
+	}
+
+	cfg.RateLimit = RateLimitConfig{
+		Enabled: viper.GetBool("ratelimit.enabled"),
+		Backend: viper.GetString("ratelimit.backend"),
+		Default: RateLimitRuleConfig{
+			Requests: viper.GetInt("ratelimit.requests"),
+			Period:   viper.GetDuration("ratelimit.period"),
+			Burst:    viper.GetInt("ratelimit.burst"),
+		},
+		Routes: rateLimitRoutes,
+		IP: RateLimitRuleConfig{
+			Requests: viper.GetInt("ratelimit.ip.requests"),
+			Period:   viper.GetDuration("ratelimit.ip.period"),
+			Burst:    viper.GetInt("ratelimit.ip.burst"),
+		},
+	}
+
+	cfg.Tenant = TenantConfig{
+		Header:  viper.GetString("tenant.header"),
+		Default: viper.GetString("tenant.default"),
+	}
+
+	cfg.OrderNumber = OrderNumberConfig{
+		Prefix:     viper.GetString("order_number.prefix"),
+		DateLayout: viper.GetString("order_number.date_layout"),
+		Digits:     viper.GetInt("order_number.digits"),
+		CheckDigit: viper.GetBool("order_number.check_digit"),
+	}
+
+	cfg.Storage = StorageConfig{
+		Driver: viper.GetString("storage.driver"),
+	}
+
+	cfg.Broker = BrokerConfig{
+		Driver:        viper.GetString("broker.driver"),
+		QueueCapacity: viper.GetInt("broker.queue_capacity"),
+	}
+
 	return nil
 }
 
@@ -114,3 +401,43 @@ func GetDBMongo() DBMongo {
 func GetRabbitMQConfig() RabbitMQConfig {
 	return cfg.RabbitMQ
 }
+
+func GetExchangeConfig() ExchangeConfig {
+	return cfg.Exchange
+}
+
+func GetTaxConfig() TaxConfig {
+	return cfg.Tax
+}
+
+func GetPriceConfig() PriceConfig {
+	return cfg.Price
+}
+
+func GetOrderConfig() OrderConfig {
+	return cfg.Order
+}
+
+func GetAuthConfig() AuthConfig {
+	return cfg.Auth
+}
+
+func GetRateLimitConfig() RateLimitConfig {
+	return cfg.RateLimit
+}
+
+func GetTenantConfig() TenantConfig {
+	return cfg.Tenant
+}
+
+func GetOrderNumberConfig() OrderNumberConfig {
+	return cfg.OrderNumber
+}
+
+func GetStorageConfig() StorageConfig {
+	return cfg.Storage
+}
+
+func GetBrokerConfig() BrokerConfig {
+	return cfg.Broker
+}
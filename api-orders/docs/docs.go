@@ -30,32 +30,68 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/health": {
+        "/api-keys": {
             "get": {
-                "description": "Returns the health status of the API and RabbitMQ connection",
+                "description": "Lists the API keys, most recent first, without the keys themselves",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Health"
+                    "API Keys"
                 ],
-                "summary": "Health check",
+                "summary": "List API keys",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "API keys retrieved successfully",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponseDoc"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.APIKeyResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Role not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
                         }
                     }
-                }
-            }
-        },
-        "/orders": {
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
             "post": {
-                "description": "Creates a new order with the provided items",
+                "description": "Issues an API key for a service-to-service client. The key is returned only in this response; store it safely.",
                 "consumes": [
                     "application/json"
                 ],
@@ -63,23 +99,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "API Keys"
                 ],
-                "summary": "Create a new order",
+                "summary": "Issue an API key",
                 "parameters": [
                     {
-                        "description": "Order information",
-                        "name": "order",
+                        "description": "API key information",
+                        "name": "key",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateOrderRequest"
+                            "$ref": "#/definitions/dto.CreateAPIKeyRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Order created successfully",
+                        "description": "API key issued successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -89,7 +125,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.OrderResponse"
+                                            "$ref": "#/definitions/dto.IssuedAPIKeyResponse"
                                         }
                                     }
                                 }
@@ -99,21 +135,38 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid request body or validation error",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponseDoc"
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Role not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponseDoc"
+                            "$ref": "#/definitions/handlers.Problem"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/orders/{id}": {
-            "get": {
-                "description": "Retrieves an order by its MongoDB ObjectID",
+        "/api-keys/{id}": {
+            "delete": {
+                "description": "Revokes an API key. Revoking an already revoked key has no effect.",
                 "consumes": [
                     "application/json"
                 ],
@@ -121,13 +174,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "API Keys"
                 ],
-                "summary": "Get order by ID",
+                "summary": "Revoke an API key",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Order ID (MongoDB ObjectID)",
+                        "description": "API key ID (MongoDB ObjectID)",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -135,7 +188,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Order retrieved successfully",
+                        "description": "API key revoked successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -145,31 +198,48 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.OrderResponse"
+                                            "$ref": "#/definitions/dto.APIKeyResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
+                    "401": {
+                        "description": "Missing or invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Role not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
                     "404": {
-                        "description": "Order not found",
+                        "description": "API key not found",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponseDoc"
+                            "$ref": "#/definitions/handlers.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponseDoc"
+                            "$ref": "#/definitions/handlers.Problem"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/orders/{id}/status": {
-            "patch": {
-                "description": "Updates the status of an existing order",
+        "/api-keys/{id}/rotate": {
+            "post": {
+                "description": "Replaces the key of an API key, keeping its name, scopes and expiration. The previous key stops working at once.",
                 "consumes": [
                     "application/json"
                 ],
@@ -177,30 +247,154 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "API Keys"
                 ],
-                "summary": "Update order status",
+                "summary": "Rotate an API key",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Order ID (MongoDB ObjectID)",
+                        "description": "API key ID (MongoDB ObjectID)",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "API key rotated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponseDoc"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.IssuedAPIKeyResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
                     },
-                    {
-                        "description": "New status",
-                        "name": "status",
-                        "in": "body",
-                        "required": true,
+                    "401": {
+                        "description": "Missing or invalid bearer token",
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdateOrderStatusRequest"
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Role not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "API key not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "API key revoked",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
                         }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/audit": {
+            "get": {
+                "description": "Lists the recorded product and order mutations, latest first: who made them (actor and source service), when, from which request and client IP, and the changed fields with their previous and new values. Entries are append-only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Audit"
+                ],
+                "summary": "Query the audit trail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subject of the token or API key that made the change",
+                        "name": "actor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Action, such as order.status_updated",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "product",
+                            "order",
+                            "payment"
+                        ],
+                        "type": "string",
+                        "description": "Resource type",
+                        "name": "resource_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Resource ID",
+                        "name": "resource_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "X-Request-ID of the request that made the change",
+                        "name": "request_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Earliest change (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Latest change (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of entries to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size (1-100)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Order status updated successfully",
+                        "description": "Audit entries retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -210,7 +404,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.OrderResponse"
+                                            "$ref": "#/definitions/dto.AuditPageResponse"
                                         }
                                     }
                                 }
@@ -218,29 +412,102 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or validation error",
+                        "description": "Invalid query parameters",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponseDoc"
+                            "$ref": "#/definitions/handlers.Problem"
                         }
                     },
-                    "404": {
-                        "description": "Order not found",
+                    "401": {
+                        "description": "Missing or invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Role not allowed",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponseDoc"
+                            "$ref": "#/definitions/handlers.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponseDoc"
+                            "$ref": "#/definitions/handlers.Problem"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/products": {
+        "/categories": {
+            "get": {
+                "description": "Lists all categories ordered by name. The tree is built from parent_id and path.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categories"
+                ],
+                "summary": "List categories",
+                "responses": {
+                    "200": {
+                        "description": "Categories retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponseDoc"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.CategoryResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token or API key",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "API key scope not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            },
             "post": {
-                "description": "Creates a new product with the provided information",
+                "description": "Creates a category, optionally below a parent category. Products classified in it are also found under its ancestors.",
                 "consumes": [
                     "application/json"
                 ],
@@ -248,23 +515,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Categories"
                 ],
-                "summary": "Create a new product",
+                "summary": "Create a new category",
                 "parameters": [
                     {
-                        "description": "Product information",
-                        "name": "product",
+                        "description": "Category information",
+                        "name": "category",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateProductRequest"
+                            "$ref": "#/definitions/dto.CategoryRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Product created successfully",
+                        "description": "Category created successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -274,7 +541,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.ProductResponse"
+                                            "$ref": "#/definitions/dto.CategoryResponse"
                                         }
                                     }
                                 }
@@ -282,67 +549,4258 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or validation error",
+                        "description": "Invalid request body or parent category not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponseDoc"
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Role not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponseDoc"
+                            "$ref": "#/definitions/handlers.Problem"
                         }
                     }
-                }
-            }
-        }
-    },
-    "definitions": {
-        "dto.CreateOrderRequest": {
-            "type": "object",
-            "required": [
-                "items"
-            ],
-            "properties": {
-                "items": {
-                    "type": "array",
-                    "minItems": 1,
-                    "items": {
-                        "$ref": "#/definitions/dto.OrderItemRequest"
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
                     }
-                }
+                ]
             }
         },
-        "dto.CreateProductRequest": {
-            "type": "object",
+        "/coupons": {
+            "get": {
+                "description": "Lists all coupons, most recent first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Coupons"
+                ],
+                "summary": "List coupons",
+                "responses": {
+                    "200": {
+                        "description": "Coupons retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponseDoc"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.CouponResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Role not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Creates a discount coupon (percentage or fixed) with optional restrictions and usage limits",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Coupons"
+                ],
+                "summary": "Create a new coupon",
+                "parameters": [
+                    {
+                        "description": "Coupon information",
+                        "name": "coupon",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateCouponRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Coupon created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponseDoc"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.CouponResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Role not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Coupon code already exists",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/coupons/{id}": {
+            "get": {
+                "description": "Retrieves a coupon, including its usage count, by its MongoDB ObjectID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Coupons"
+                ],
+                "summary": "Get coupon by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Coupon ID (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Coupon retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponseDoc"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.CouponResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Role not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Coupon not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "Replaces the definition of an existing coupon. The code and usage counters are kept.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Coupons"
+                ],
+                "summary": "Update coupon",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Coupon ID (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Coupon information",
+                        "name": "coupon",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateCouponRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Coupon updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponseDoc"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.CouponResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Role not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Coupon not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Deletes a coupon. Orders that already used it keep the applied discount.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Coupons"
+                ],
+                "summary": "Delete coupon",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Coupon ID (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Coupon deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponseDoc"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Role not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Coupon not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/customers": {
+            "get": {
+                "description": "Lists all customers ordered by name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Customers"
+                ],
+                "summary": "List customers",
+                "responses": {
+                    "200": {
+                        "description": "Customers retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponseDoc"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.CustomerResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Role not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Creates a new customer with name, email, CPF document and addresses",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Customers"
+                ],
+                "summary": "Create a new customer",
+                "parameters": [
+                    {
+                        "description": "Customer information",
+                        "name": "customer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CustomerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Customer created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponseDoc"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.CustomerResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Role not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Customer email or document already registered",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/customers/{id}": {
+            "get": {
+                "description": "Retrieves a customer by its MongoDB ObjectID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Customers"
+                ],
+                "summary": "Get customer by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Customer ID (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Customer retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponseDoc"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.CustomerResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Customer not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "Replaces the data and addresses of an existing customer. Orders keep their shipping address snapshot.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Customers"
+                ],
+                "summary": "Update customer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Customer ID (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Customer information",
+                        "name": "customer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CustomerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Customer updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponseDoc"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.CustomerResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Customer not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Customer email or document already registered",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Deletes a customer. Existing orders keep their customer_id and shipping address snapshot.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Customers"
+                ],
+                "summary": "Delete customer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Customer ID (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Customer deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponseDoc"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Role not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Customer not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/customers/{id}/orders": {
+            "get": {
+                "description": "Lists the orders placed by a customer, most recent first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Customers"
+                ],
+                "summary": "List customer orders",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Customer ID (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Orders retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponseDoc"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.OrderResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Customer not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "Returns the health status of the API and RabbitMQ connection",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Health check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/orders": {
+            "post": {
+                "description": "Creates a new order with the provided items",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Create a new order",
+                "parameters": [
+                    {
+                        "description": "Order information",
+                        "name": "order",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Order created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponseDoc"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.OrderResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token or API key",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Role not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded; see Retry-After",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/orders/by-number/{number}": {
+            "get": {
+                "description": "Retrieves an order by its human-friendly order number, such as ORD-20261016-0001238. Numbers whose check digit does not match are reported as mistyped.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get order by number",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order number",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponseDoc"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.OrderResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Order version, to be sent back in If-Match"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token or API key",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "API key scope not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found or mistyped order number",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/orders/{id}": {
+            "get": {
+                "description": "Retrieves an order by its MongoDB ObjectID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get order by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponseDoc"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.OrderResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Order version, to be sent back in If-Match"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token or API key",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "API key scope not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/orders/{id}/returns": {
+            "get": {
+                "description": "Lists the returns of an order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Returns"
+                ],
+                "summary": "List order returns",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponseDoc"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.ReturnResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Role not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Opens a return (RMA) for lines of a delivered order with reason and quantity. Refund amounts are computed from the prices captured on the order, including exclusive taxes and prorated discounts.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Returns"
+                ],
+                "summary": "Request a return",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Returned items",
+                        "name": "return",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateReturnRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Return requested successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponseDoc"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ReturnResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, order status or quantities",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Role not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/orders/{id}/returns/{returnId}/approve": {
+            "post": {
+                "description": "Approves a pending return, restocks the returned items and publishes the event that triggers the refund",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Returns"
+                ],
+                "summary": "Approve a return",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Return ID (MongoDB ObjectID)",
+                        "name": "returnId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Return approved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponseDoc"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ReturnResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Role not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Order or return not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Return already resolved",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/orders/{id}/returns/{returnId}/reject": {
+            "post": {
+                "description": "Rejects a pending return with a reason",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Returns"
+                ],
+                "summary": "Reject a return",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Return ID (MongoDB ObjectID)",
+                        "name": "returnId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rejection reason",
+                        "name": "rejection",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RejectReturnRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Return rejected successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponseDoc"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ReturnResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Role not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Order or return not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Return already resolved",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/orders/{id}/shipments": {
+            "get": {
+                "description": "Lists the shipments of an order in the order they were shipped",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shipments"
+                ],
+                "summary": "List order shipments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Shipments retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponseDoc"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.ShipmentResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Role not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Registers a shipment with carrier, tracking code and shipped items, moving the order to \"enviado\". Partial shipments are supported; when items is empty every remaining unit is shipped. The order must be \"em_processamento\" or \"enviado\".",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shipments"
+                ],
+                "summary": "Ship order items",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Shipment information",
+                        "name": "shipment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateShipmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Shipment created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponseDoc"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ShipmentResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, order status or quantities",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Role not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/orders/{id}/shipments/{shipmentId}/delivery": {
+            "patch": {
+                "description": "Records the delivery of a shipment with receiver and optional proof. The order moves to \"entregue\" once every item was shipped and every shipment delivered.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shipments"
+                ],
+                "summary": "Confirm shipment delivery",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Shipment ID (MongoDB ObjectID)",
+                        "name": "shipmentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Delivery information",
+                        "name": "delivery",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.DeliverShipmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Shipment delivered successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponseDoc"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ShipmentResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Role not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Order or shipment not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Shipment already delivered",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/orders/{id}/status": {
+            "patch": {
+                "description": "Updates the status of an existing order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Update order status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New status",
+                        "name": "status",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateOrderStatusRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the order read; required when api.require_if_match is enabled",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order status updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponseDoc"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.OrderResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Order version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Role not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Order marked enviado before every item is shipped",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Order changed since the If-Match version",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header required",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/products": {
+            "get": {
+                "description": "Searches products by text on name and description, filtering by category (including subcategories), tag, base price range and stock. The response carries the category and tag counts of all matching products.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Search products",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Text searched in name and description",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Category ID (MongoDB ObjectID)",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tag",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum price in the product base currency",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum price in the product base currency",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only products with quantity greater than zero",
+                        "name": "in_stock",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of products to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Products retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponseDoc"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ProductSearchResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token or API key",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "API key scope not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Creates a new product with the provided information",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Create a new product",
+                "parameters": [
+                    {
+                        "description": "Product information",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateProductRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Product created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponseDoc"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ProductResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Role not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/products/export": {
+            "get": {
+                "description": "Streams the whole catalog as CSV or NDJSON, in the same format accepted by the import. CSV has no columns for variants and prices in other currencies; use NDJSON to export them.",
+                "produces": [
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Export products",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "default": "ndjson",
+                        "description": "Output format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product rows",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid format",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Role not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/products/import": {
+            "post": {
+                "description": "Streams a CSV or NDJSON catalog, upserting every row by SKU. Each row is validated like the product creation; rejected rows are listed in the report and do not stop the import. The format comes from the format query parameter or the Content-Type (text/csv or application/x-ndjson).",
+                "consumes": [
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Import products",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "description": "Input format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "description": "CSV with header or one JSON product per line",
+                        "name": "rows",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Products imported",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponseDoc"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ProductImportReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid format or unreadable input",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Role not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported content type",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/products/{id}": {
+            "get": {
+                "description": "Retrieves a product by its MongoDB ObjectID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get product by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponseDoc"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ProductResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Product version, to be sent back in If-Match"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token or API key",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "API key scope not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/products/{id}/prices": {
+            "get": {
+                "description": "Lists the applied, scheduled and cancelled price changes of a product, latest effective first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "List product price history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Price history retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponseDoc"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.PriceChangeResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token or API key",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "API key scope not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Changes the product base price right away, or schedules the change when effective_at is in the future. Scheduled changes are applied by the price scheduler and already used by orders created after effective_at.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Change or schedule a product price",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Price change",
+                        "name": "change",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PriceChangeRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the product read; required when api.require_if_match is enabled",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Price change registered successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponseDoc"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.PriceChangeResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Role not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Product changed since the If-Match version",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header required",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/products/{id}/prices/{change_id}": {
+            "delete": {
+                "description": "Cancels a price change that is still scheduled",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Cancel a scheduled price change",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Price change ID (MongoDB ObjectID)",
+                        "name": "change_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Price change cancelled successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponseDoc"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.PriceChangeResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Role not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Price change not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Price change already applied or cancelled",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/products/{id}/reorder-threshold": {
+            "put": {
+                "description": "Sets the quantity at or below which a product.low_stock event is published. Zero disables the low stock alert; product.out_of_stock is always published.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Set product reorder threshold",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reorder threshold",
+                        "name": "threshold",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReorderThresholdRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the product read; required when api.require_if_match is enabled",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reorder threshold updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponseDoc"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ProductResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Product version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Role not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Product changed since the If-Match version",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header required",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/products/{id}/stock-adjustments": {
+            "post": {
+                "description": "Adds (positive quantity) or removes (negative quantity) units of a product, recording a manual adjustment in the stock ledger with the reason and the authenticated caller as actor",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Adjust product stock",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Stock adjustment",
+                        "name": "adjustment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.StockAdjustmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Stock adjusted successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponseDoc"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.StockMovementResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or stock would become negative",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Role not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/products/{id}/stock-movements": {
+            "get": {
+                "description": "Lists the stock ledger of a product, most recent first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "List stock movements",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stock movements retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponseDoc"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.StockMovementResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Role not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        }
+    },
+    "definitions": {
+        "dto.APIKeyResponse": {
+            "type": "object",
+            "properties": {
+                "_id": {
+                    "type": "string",
+                    "example": "507f1f77bcf86cd799439011"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-02-10T12:00:00Z"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
+                },
+                "last_used_at": {
+                    "type": "string",
+                    "example": "2024-02-10T12:00:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Parceiro Marketplace"
+                },
+                "prefix": {
+                    "type": "string",
+                    "example": "rma_3fK9x2"
+                },
+                "revoked_at": {
+                    "type": "string",
+                    "example": "2024-02-10T12:00:00Z"
+                },
+                "rotated_at": {
+                    "type": "string",
+                    "example": "2024-02-10T12:00:00Z"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "orders:write",
+                        "orders:read"
+                    ]
+                },
+                "status": {
+                    "type": "string",
+                    "example": "active"
+                }
+            }
+        },
+        "dto.AddressRequest": {
+            "type": "object",
+            "required": [
+                "city",
+                "number",
+                "state",
+                "street",
+                "zip_code"
+            ],
+            "properties": {
+                "city": {
+                    "type": "string",
+                    "example": "São Paulo"
+                },
+                "complement": {
+                    "type": "string",
+                    "example": "Apto 12"
+                },
+                "country": {
+                    "type": "string",
+                    "example": "BR"
+                },
+                "default": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "district": {
+                    "type": "string",
+                    "example": "Bela Vista"
+                },
+                "label": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "example": "Casa"
+                },
+                "number": {
+                    "type": "string",
+                    "example": "1000"
+                },
+                "state": {
+                    "type": "string",
+                    "example": "SP"
+                },
+                "street": {
+                    "type": "string",
+                    "example": "Av. Paulista"
+                },
+                "zip_code": {
+                    "type": "string",
+                    "example": "01310100"
+                }
+            }
+        },
+        "dto.AddressResponse": {
+            "type": "object",
+            "properties": {
+                "_id": {
+                    "type": "string",
+                    "example": "507f1f77bcf86cd799439012"
+                },
+                "city": {
+                    "type": "string",
+                    "example": "São Paulo"
+                },
+                "complement": {
+                    "type": "string",
+                    "example": "Apto 12"
+                },
+                "country": {
+                    "type": "string",
+                    "example": "BR"
+                },
+                "default": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "district": {
+                    "type": "string",
+                    "example": "Bela Vista"
+                },
+                "label": {
+                    "type": "string",
+                    "example": "Casa"
+                },
+                "number": {
+                    "type": "string",
+                    "example": "1000"
+                },
+                "state": {
+                    "type": "string",
+                    "example": "SP"
+                },
+                "street": {
+                    "type": "string",
+                    "example": "Av. Paulista"
+                },
+                "zip_code": {
+                    "type": "string",
+                    "example": "01310100"
+                }
+            }
+        },
+        "dto.AuditChangeResponse": {
+            "type": "object",
+            "properties": {
+                "after": {
+                    "type": "string",
+                    "example": "entregue"
+                },
+                "before": {
+                    "type": "string",
+                    "example": "enviado"
+                },
+                "field": {
+                    "type": "string",
+                    "example": "status"
+                }
+            }
+        },
+        "dto.AuditEntryResponse": {
+            "type": "object",
+            "properties": {
+                "_id": {
+                    "type": "string",
+                    "example": "698c0a0893c94ce530171fff"
+                },
+                "action": {
+                    "type": "string",
+                    "example": "order.status_updated"
+                },
+                "actor": {
+                    "type": "string",
+                    "example": "user-1"
+                },
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AuditChangeResponse"
+                    }
+                },
+                "client_ip": {
+                    "type": "string",
+                    "example": "203.0.113.10"
+                },
+                "occurred_at": {
+                    "type": "string",
+                    "example": "2024-02-10T12:00:00Z"
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "4f9c2d7e8a1b3c5d6e7f8091a2b3c4d5"
+                },
+                "resource_id": {
+                    "type": "string",
+                    "example": "698c0a0893c94ce530171aaa"
+                },
+                "resource_type": {
+                    "type": "string",
+                    "example": "order"
+                },
+                "source": {
+                    "type": "string",
+                    "example": "api-orders"
+                }
+            }
+        },
+        "dto.AuditPageResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AuditEntryResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "dto.CategoryFacetResponse": {
+            "type": "object",
+            "properties": {
+                "_id": {
+                    "type": "string",
+                    "example": "698c0a0893c94ce530171eee"
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Periféricos"
+                }
+            }
+        },
+        "dto.CategoryRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2,
+                    "example": "Periféricos"
+                },
+                "parent_id": {
+                    "type": "string",
+                    "example": "698c0a0893c94ce530171ddd"
+                }
+            }
+        },
+        "dto.CategoryResponse": {
+            "type": "object",
+            "properties": {
+                "_id": {
+                    "type": "string",
+                    "example": "698c0a0893c94ce530171eee"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-02-10T12:00:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Periféricos"
+                },
+                "parent_id": {
+                    "type": "string",
+                    "example": "698c0a0893c94ce530171ddd"
+                },
+                "path": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "698c0a0893c94ce530171ddd"
+                    ]
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-02-10T12:00:00Z"
+                }
+            }
+        },
+        "dto.CouponResponse": {
+            "type": "object",
+            "properties": {
+                "_id": {
+                    "type": "string",
+                    "example": "507f1f77bcf86cd799439011"
+                },
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "category_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "code": {
+                    "type": "string",
+                    "example": "BEMVINDO10"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-02-10T12:00:00Z"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "BRL"
+                },
+                "max_uses": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "max_uses_per_customer": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "min_order_value": {
+                    "type": "number",
+                    "example": 100
+                },
+                "product_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "type": {
+                    "type": "string",
+                    "example": "percentage"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-02-10T12:00:00Z"
+                },
+                "used_count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "valid_from": {
+                    "type": "string",
+                    "example": "2024-02-01T00:00:00Z"
+                },
+                "valid_until": {
+                    "type": "string",
+                    "example": "2024-03-01T00:00:00Z"
+                },
+                "value": {
+                    "type": "number",
+                    "example": 10
+                }
+            }
+        },
+        "dto.CreateAPIKeyRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "expires_at": {
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Parceiro Marketplace"
+                },
+                "scopes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "orders:write",
+                        "orders:read"
+                    ]
+                }
+            }
+        },
+        "dto.CreateCouponRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "type",
+                "value"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "category_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "698c0a0893c94ce530171ccc"
+                    ]
+                },
+                "code": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "minLength": 3,
+                    "example": "BEMVINDO10"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "BRL"
+                },
+                "max_uses": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 100
+                },
+                "max_uses_per_customer": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 1
+                },
+                "min_order_value": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 100
+                },
+                "product_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "698c0a0893c94ce530171bbb"
+                    ]
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "percentage",
+                        "fixed"
+                    ],
+                    "example": "percentage"
+                },
+                "valid_from": {
+                    "type": "string",
+                    "example": "2024-02-01T00:00:00Z"
+                },
+                "valid_until": {
+                    "type": "string",
+                    "example": "2024-03-01T00:00:00Z"
+                },
+                "value": {
+                    "type": "number",
+                    "example": 10
+                }
+            }
+        },
+        "dto.CreateOrderRequest": {
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "coupon_code": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "example": "BEMVINDO10"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "customer_id": {
+                    "type": "string",
+                    "example": "698c0a0893c94ce530171ccc"
+                },
+                "destination_region": {
+                    "type": "string",
+                    "maxLength": 8,
+                    "example": "SP"
+                },
+                "items": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/dto.OrderItemRequest"
+                    }
+                },
+                "shipping_address_id": {
+                    "type": "string",
+                    "example": "507f1f77bcf86cd799439012"
+                }
+            }
+        },
+        "dto.CreateProductRequest": {
+            "type": "object",
+            "required": [
+                "attributes",
+                "description",
+                "name",
+                "price",
+                "tags"
+            ],
+            "properties": {
+                "attributes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "category_id": {
+                    "type": "string",
+                    "example": "698c0a0893c94ce530171eee"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "BRL"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Mouse Gamer RGB 16000 DPI"
+                },
+                "name": {
+                    "type": "string",
+                    "minLength": 3,
+                    "example": "Mouse Gamer"
+                },
+                "price": {
+                    "type": "number",
+                    "example": 199.9
+                },
+                "prices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ProductPriceRequest"
+                    }
+                },
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 50
+                },
+                "reorder_threshold": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 5
+                },
+                "sku": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "MOUSE-RGB"
+                },
+                "tags": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "gamer",
+                        "rgb"
+                    ]
+                },
+                "tax_class": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "example": "standard"
+                },
+                "variants": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "items": {
+                        "$ref": "#/definitions/dto.ProductVariantRequest"
+                    }
+                }
+            }
+        },
+        "dto.CreateReturnRequest": {
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/dto.ReturnItemRequest"
+                    }
+                }
+            }
+        },
+        "dto.CreateShipmentRequest": {
+            "type": "object",
+            "required": [
+                "carrier",
+                "tracking_code"
+            ],
+            "properties": {
+                "carrier": {
+                    "type": "string",
+                    "example": "Correios"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ShipmentItemRequest"
+                    }
+                },
+                "shipped_at": {
+                    "type": "string",
+                    "example": "2024-02-11T09:00:00Z"
+                },
+                "tracking_code": {
+                    "type": "string",
+                    "example": "BR123456789BR"
+                }
+            }
+        },
+        "dto.CustomerRequest": {
+            "type": "object",
+            "required": [
+                "document",
+                "email",
+                "name"
+            ],
+            "properties": {
+                "addresses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AddressRequest"
+                    }
+                },
+                "document": {
+                    "type": "string",
+                    "example": "529.982.247-25"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "maria@example.com"
+                },
+                "name": {
+                    "type": "string",
+                    "minLength": 3,
+                    "example": "Maria da Silva"
+                }
+            }
+        },
+        "dto.CustomerResponse": {
+            "type": "object",
+            "properties": {
+                "_id": {
+                    "type": "string",
+                    "example": "507f1f77bcf86cd799439011"
+                },
+                "addresses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AddressResponse"
+                    }
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-02-10T12:00:00Z"
+                },
+                "document": {
+                    "type": "string",
+                    "example": "52998224725"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "maria@example.com"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Maria da Silva"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-02-10T12:00:00Z"
+                }
+            }
+        },
+        "dto.DeliverShipmentRequest": {
+            "type": "object",
+            "required": [
+                "received_by"
+            ],
+            "properties": {
+                "delivered_at": {
+                    "type": "string",
+                    "example": "2024-02-13T15:30:00Z"
+                },
+                "proof_url": {
+                    "type": "string",
+                    "example": "https://cdn.example.com/pod/123.jpg"
+                },
+                "received_by": {
+                    "type": "string",
+                    "example": "Maria da Silva"
+                }
+            }
+        },
+        "dto.ExchangeRateResponse": {
+            "type": "object",
+            "properties": {
+                "fetched_at": {
+                    "type": "string",
+                    "example": "2024-02-10T12:00:00Z"
+                },
+                "from": {
+                    "type": "string",
+                    "example": "BRL"
+                },
+                "rate": {
+                    "type": "number",
+                    "example": 0.18
+                },
+                "source": {
+                    "type": "string",
+                    "example": "static"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "USD"
+                }
+            }
+        },
+        "dto.FacetCountResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "value": {
+                    "type": "string",
+                    "example": "gamer"
+                }
+            }
+        },
+        "dto.IssuedAPIKeyResponse": {
+            "type": "object",
+            "properties": {
+                "_id": {
+                    "type": "string",
+                    "example": "507f1f77bcf86cd799439011"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-02-10T12:00:00Z"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
+                },
+                "key": {
+                    "type": "string",
+                    "example": "rma_3fK9x2Lq8Vn0Zp4Tb7Yc1Wd6Ue5Rf3Gh2Ji9Kk0Ll"
+                },
+                "last_used_at": {
+                    "type": "string",
+                    "example": "2024-02-10T12:00:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Parceiro Marketplace"
+                },
+                "prefix": {
+                    "type": "string",
+                    "example": "rma_3fK9x2"
+                },
+                "revoked_at": {
+                    "type": "string",
+                    "example": "2024-02-10T12:00:00Z"
+                },
+                "rotated_at": {
+                    "type": "string",
+                    "example": "2024-02-10T12:00:00Z"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "orders:write",
+                        "orders:read"
+                    ]
+                },
+                "status": {
+                    "type": "string",
+                    "example": "active"
+                }
+            }
+        },
+        "dto.OrderItemRequest": {
+            "type": "object",
+            "required": [
+                "quantity"
+            ],
+            "properties": {
+                "product_id": {
+                    "type": "string",
+                    "example": "698c0a0893c94ce530171bbb"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 6
+                },
+                "sku": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "MOUSE-RGB-PRETO"
+                },
+                "variant_id": {
+                    "type": "string",
+                    "example": "507f1f77bcf86cd799439016"
+                }
+            }
+        },
+        "dto.OrderItemResponse": {
+            "type": "object",
+            "properties": {
+                "base_currency": {
+                    "type": "string",
+                    "example": "BRL"
+                },
+                "base_price": {
+                    "type": "number",
+                    "example": 199.9
+                },
+                "discount": {
+                    "type": "number",
+                    "example": 19.99
+                },
+                "price": {
+                    "type": "number",
+                    "example": 199.9
+                },
+                "product_id": {
+                    "type": "string",
+                    "example": "507f1f77bcf86cd799439011"
+                },
+                "product_name": {
+                    "type": "string",
+                    "example": "Mouse Gamer"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "MOUSE-RGB-PRETO"
+                },
+                "tax_amount": {
+                    "type": "number",
+                    "example": 60.99
+                },
+                "tax_class": {
+                    "type": "string",
+                    "example": "standard"
+                },
+                "tax_inclusive": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "tax_rate": {
+                    "type": "number",
+                    "example": 0.18
+                },
+                "variant_id": {
+                    "type": "string",
+                    "example": "507f1f77bcf86cd799439016"
+                }
+            }
+        },
+        "dto.OrderResponse": {
+            "type": "object",
+            "properties": {
+                "_id": {
+                    "type": "string",
+                    "example": "507f1f77bcf86cd799439011"
+                },
+                "api_key_id": {
+                    "type": "string",
+                    "example": "507f1f77bcf86cd799439013"
+                },
+                "coupon_code": {
+                    "type": "string",
+                    "example": "BEMVINDO10"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-02-10T12:00:00Z"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "BRL"
+                },
+                "customer_id": {
+                    "type": "string",
+                    "example": "698c0a0893c94ce530171ccc"
+                },
+                "discount_total": {
+                    "type": "number",
+                    "example": 39.98
+                },
+                "exchange_rates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ExchangeRateResponse"
+                    }
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.OrderItemResponse"
+                    }
+                },
+                "order_number": {
+                    "type": "string",
+                    "example": "ORD-20261016-0001238"
+                },
+                "shipments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ShipmentSummaryResponse"
+                    }
+                },
+                "shipping_address": {
+                    "$ref": "#/definitions/dto.AddressResponse"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "criado"
+                },
+                "subtotal": {
+                    "type": "number",
+                    "example": 399.8
+                },
+                "tax_region": {
+                    "type": "string",
+                    "example": "SP"
+                },
+                "tax_total": {
+                    "type": "number",
+                    "example": 54.89
+                },
+                "total": {
+                    "type": "number",
+                    "example": 359.82
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-02-10T12:00:00Z"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "dto.PriceChangeRequest": {
+            "type": "object",
+            "required": [
+                "actor",
+                "price",
+                "reason"
+            ],
+            "properties": {
+                "actor": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "maria.comercial"
+                },
+                "effective_at": {
+                    "type": "string",
+                    "example": "2024-03-01T00:00:00Z"
+                },
+                "price": {
+                    "type": "number",
+                    "example": 179.9
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Promoção de março"
+                }
+            }
+        },
+        "dto.PriceChangeResponse": {
+            "type": "object",
+            "properties": {
+                "_id": {
+                    "type": "string",
+                    "example": "507f1f77bcf86cd799439017"
+                },
+                "actor": {
+                    "type": "string",
+                    "example": "maria.comercial"
+                },
+                "applied_at": {
+                    "type": "string",
+                    "example": "2024-03-01T00:00:30Z"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-02-10T12:00:00Z"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "BRL"
+                },
+                "effective_at": {
+                    "type": "string",
+                    "example": "2024-03-01T00:00:00Z"
+                },
+                "previous_price": {
+                    "type": "number",
+                    "example": 199.9
+                },
+                "price": {
+                    "type": "number",
+                    "example": 179.9
+                },
+                "product_id": {
+                    "type": "string",
+                    "example": "698c0a0893c94ce530171bbb"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Promoção de março"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "scheduled"
+                }
+            }
+        },
+        "dto.ProductFacetsResponse": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CategoryFacetResponse"
+                    }
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.FacetCountResponse"
+                    }
+                }
+            }
+        },
+        "dto.ProductImportError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "duplicate_sku"
+                },
+                "error": {
+                    "type": "string",
+                    "example": "Price must be greater than 0"
+                },
+                "line": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "MOUSE-RGB"
+                }
+            }
+        },
+        "dto.ProductImportReport": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ProductImportError"
+                    }
+                },
+                "failed": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "processed": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "updated": {
+                    "type": "integer",
+                    "example": 18
+                }
+            }
+        },
+        "dto.ProductPriceRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "currency"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 39.9
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                }
+            }
+        },
+        "dto.ProductPriceResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 39.9
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                }
+            }
+        },
+        "dto.ProductResponse": {
+            "type": "object",
+            "properties": {
+                "_id": {
+                    "type": "string",
+                    "example": "507f1f77bcf86cd799439011"
+                },
+                "attributes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "category_id": {
+                    "type": "string",
+                    "example": "698c0a0893c94ce530171eee"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-02-10T12:00:00Z"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "BRL"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Mouse Gamer RGB 16000 DPI"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Mouse Gamer"
+                },
+                "price": {
+                    "type": "number",
+                    "example": 199.9
+                },
+                "prices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ProductPriceResponse"
+                    }
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "reorder_threshold": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "MOUSE-RGB"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "gamer",
+                        "rgb"
+                    ]
+                },
+                "tax_class": {
+                    "type": "string",
+                    "example": "standard"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-02-10T12:00:00Z"
+                },
+                "variants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ProductVariantResponse"
+                    }
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "dto.ProductSearchResponse": {
+            "type": "object",
+            "properties": {
+                "facets": {
+                    "$ref": "#/definitions/dto.ProductFacetsResponse"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ProductResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "dto.ProductVariantRequest": {
+            "type": "object",
+            "required": [
+                "attributes",
+                "sku"
+            ],
+            "properties": {
+                "attributes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "price": {
+                    "type": "number",
+                    "example": 209.9
+                },
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 20
+                },
+                "sku": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "MOUSE-RGB-PRETO"
+                }
+            }
+        },
+        "dto.ProductVariantResponse": {
+            "type": "object",
+            "properties": {
+                "_id": {
+                    "type": "string",
+                    "example": "507f1f77bcf86cd799439016"
+                },
+                "attributes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "price": {
+                    "type": "number",
+                    "example": 209.9
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "MOUSE-RGB-PRETO"
+                }
+            }
+        },
+        "dto.RejectReturnRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Produto fora do prazo de devolução"
+                }
+            }
+        },
+        "dto.ReorderThresholdRequest": {
+            "type": "object",
+            "required": [
+                "reorder_threshold"
+            ],
+            "properties": {
+                "reorder_threshold": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 5
+                }
+            }
+        },
+        "dto.ReturnItemRequest": {
+            "type": "object",
             "required": [
-                "description",
-                "name",
-                "price",
-                "quantity"
+                "product_id",
+                "quantity",
+                "reason"
             ],
             "properties": {
-                "description": {
+                "product_id": {
                     "type": "string",
-                    "example": "Mouse Gamer RGB 16000 DPI"
+                    "example": "698c0a0893c94ce530171bbb"
                 },
-                "name": {
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                },
+                "reason": {
                     "type": "string",
-                    "minLength": 3,
-                    "example": "Mouse Gamer"
+                    "maxLength": 500,
+                    "example": "Produto com defeito"
                 },
-                "price": {
-                    "type": "number",
-                    "example": 199.9
+                "variant_id": {
+                    "type": "string",
+                    "example": "507f1f77bcf86cd799439016"
+                }
+            }
+        },
+        "dto.ReturnItemResponse": {
+            "type": "object",
+            "properties": {
+                "product_id": {
+                    "type": "string",
+                    "example": "698c0a0893c94ce530171bbb"
                 },
                 "quantity": {
                     "type": "integer",
-                    "minimum": 0,
-                    "example": 50
+                    "example": 1
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Produto com defeito"
+                },
+                "refund_amount": {
+                    "type": "number",
+                    "example": 179.91
+                },
+                "unit_refund": {
+                    "type": "number",
+                    "example": 179.91
+                },
+                "variant_id": {
+                    "type": "string",
+                    "example": "507f1f77bcf86cd799439016"
                 }
             }
         },
-        "dto.OrderItemRequest": {
+        "dto.ReturnResponse": {
+            "type": "object",
+            "properties": {
+                "_id": {
+                    "type": "string",
+                    "example": "507f1f77bcf86cd799439014"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-02-18T10:00:00Z"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "BRL"
+                },
+                "customer_id": {
+                    "type": "string",
+                    "example": "698c0a0893c94ce530171ccc"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ReturnItemResponse"
+                    }
+                },
+                "order_id": {
+                    "type": "string",
+                    "example": "507f1f77bcf86cd799439011"
+                },
+                "refund_total": {
+                    "type": "number",
+                    "example": 179.91
+                },
+                "rejection_reason": {
+                    "type": "string",
+                    "example": "Produto fora do prazo de devolução"
+                },
+                "resolved_at": {
+                    "type": "string",
+                    "example": "2024-02-20T10:00:00Z"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "requested"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-02-20T10:00:00Z"
+                }
+            }
+        },
+        "dto.ShipmentItemRequest": {
             "type": "object",
             "required": [
                 "product_id",
@@ -356,96 +4814,249 @@ const docTemplate = `{
                 "quantity": {
                     "type": "integer",
                     "minimum": 1,
-                    "example": 6
+                    "example": 2
+                },
+                "variant_id": {
+                    "type": "string",
+                    "example": "507f1f77bcf86cd799439016"
                 }
             }
         },
-        "dto.OrderItemResponse": {
+        "dto.ShipmentItemResponse": {
             "type": "object",
             "properties": {
-                "price": {
-                    "type": "number",
-                    "example": 199.9
-                },
                 "product_id": {
                     "type": "string",
-                    "example": "507f1f77bcf86cd799439011"
-                },
-                "product_name": {
-                    "type": "string",
-                    "example": "Mouse Gamer"
+                    "example": "698c0a0893c94ce530171bbb"
                 },
                 "quantity": {
                     "type": "integer",
                     "example": 2
+                },
+                "variant_id": {
+                    "type": "string",
+                    "example": "507f1f77bcf86cd799439016"
                 }
             }
         },
-        "dto.OrderResponse": {
+        "dto.ShipmentResponse": {
             "type": "object",
             "properties": {
                 "_id": {
                     "type": "string",
-                    "example": "507f1f77bcf86cd799439011"
+                    "example": "507f1f77bcf86cd799439013"
+                },
+                "carrier": {
+                    "type": "string",
+                    "example": "Correios"
                 },
                 "created_at": {
                     "type": "string",
-                    "example": "2024-02-10T12:00:00Z"
+                    "example": "2024-02-11T09:00:00Z"
+                },
+                "delivered_at": {
+                    "type": "string",
+                    "example": "2024-02-13T15:30:00Z"
                 },
                 "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dto.OrderItemResponse"
+                        "$ref": "#/definitions/dto.ShipmentItemResponse"
                     }
                 },
-                "order_number": {
+                "order_id": {
+                    "type": "string",
+                    "example": "507f1f77bcf86cd799439011"
+                },
+                "proof_url": {
+                    "type": "string",
+                    "example": "https://cdn.example.com/pod/123.jpg"
+                },
+                "received_by": {
+                    "type": "string",
+                    "example": "Maria da Silva"
+                },
+                "shipped_at": {
                     "type": "string",
-                    "example": "ORD-A1B2C3D4"
+                    "example": "2024-02-11T09:00:00Z"
                 },
                 "status": {
                     "type": "string",
-                    "example": "criado"
+                    "example": "enviado"
                 },
-                "total": {
-                    "type": "number",
-                    "example": 399.8
+                "tracking_code": {
+                    "type": "string",
+                    "example": "BR123456789BR"
                 },
                 "updated_at": {
                     "type": "string",
-                    "example": "2024-02-10T12:00:00Z"
+                    "example": "2024-02-13T15:30:00Z"
                 }
             }
         },
-        "dto.ProductResponse": {
+        "dto.ShipmentSummaryResponse": {
+            "type": "object",
+            "properties": {
+                "carrier": {
+                    "type": "string",
+                    "example": "Correios"
+                },
+                "delivered_at": {
+                    "type": "string",
+                    "example": "2024-02-13T15:30:00Z"
+                },
+                "shipment_id": {
+                    "type": "string",
+                    "example": "507f1f77bcf86cd799439013"
+                },
+                "shipped_at": {
+                    "type": "string",
+                    "example": "2024-02-11T09:00:00Z"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "enviado"
+                },
+                "tracking_code": {
+                    "type": "string",
+                    "example": "BR123456789BR"
+                }
+            }
+        },
+        "dto.StockAdjustmentRequest": {
+            "type": "object",
+            "required": [
+                "quantity",
+                "reason"
+            ],
+            "properties": {
+                "quantity": {
+                    "type": "integer",
+                    "example": -2
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Avaria no estoque"
+                },
+                "variant_id": {
+                    "type": "string",
+                    "example": "507f1f77bcf86cd799439016"
+                }
+            }
+        },
+        "dto.StockMovementResponse": {
             "type": "object",
             "properties": {
                 "_id": {
                     "type": "string",
-                    "example": "507f1f77bcf86cd799439011"
+                    "example": "507f1f77bcf86cd799439015"
+                },
+                "actor": {
+                    "type": "string",
+                    "example": "joao.estoque"
+                },
+                "balance_after": {
+                    "type": "integer",
+                    "example": 48
                 },
                 "created_at": {
                     "type": "string",
                     "example": "2024-02-10T12:00:00Z"
                 },
-                "description": {
+                "product_id": {
                     "type": "string",
-                    "example": "Mouse Gamer RGB 16000 DPI"
+                    "example": "698c0a0893c94ce530171bbb"
                 },
-                "name": {
+                "quantity": {
+                    "type": "integer",
+                    "example": -2
+                },
+                "reason": {
                     "type": "string",
-                    "example": "Mouse Gamer"
+                    "example": "Avaria no estoque"
                 },
-                "price": {
-                    "type": "number",
-                    "example": 199.9
+                "reference": {
+                    "type": "string",
+                    "example": "ORD-20261016-0001238"
                 },
-                "quantity": {
+                "type": {
+                    "type": "string",
+                    "example": "manual_adjustment"
+                },
+                "variant_id": {
+                    "type": "string",
+                    "example": "507f1f77bcf86cd799439016"
+                }
+            }
+        },
+        "dto.UpdateCouponRequest": {
+            "type": "object",
+            "required": [
+                "type",
+                "value"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "category_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "698c0a0893c94ce530171ccc"
+                    ]
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "BRL"
+                },
+                "max_uses": {
                     "type": "integer",
-                    "example": 50
+                    "minimum": 0,
+                    "example": 100
                 },
-                "updated_at": {
+                "max_uses_per_customer": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 1
+                },
+                "min_order_value": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 100
+                },
+                "product_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "698c0a0893c94ce530171bbb"
+                    ]
+                },
+                "type": {
                     "type": "string",
-                    "example": "2024-02-10T12:00:00Z"
+                    "enum": [
+                        "percentage",
+                        "fixed"
+                    ],
+                    "example": "percentage"
+                },
+                "valid_from": {
+                    "type": "string",
+                    "example": "2024-02-01T00:00:00Z"
+                },
+                "valid_until": {
+                    "type": "string",
+                    "example": "2024-03-01T00:00:00Z"
+                },
+                "value": {
+                    "type": "number",
+                    "example": 10
                 }
             }
         },
@@ -467,20 +5078,63 @@ const docTemplate = `{
                 }
             }
         },
-        "handlers.ErrorResponseDoc": {
+        "handlers.FieldError": {
             "type": "object",
             "properties": {
-                "error": {
+                "field": {
                     "type": "string",
-                    "example": "Detailed error message"
+                    "example": "items[0].quantity"
                 },
                 "message": {
                     "type": "string",
-                    "example": "User-friendly error message"
+                    "example": "quantity deve ser maior do que 0"
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": false
+                "param": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "rule": {
+                    "type": "string",
+                    "example": "gt"
+                }
+            }
+        },
+        "handlers.Problem": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "insufficient_stock"
+                },
+                "detail": {
+                    "type": "string",
+                    "example": "Insufficient stock for product Mouse Gamer"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.FieldError"
+                    }
+                },
+                "instance": {
+                    "type": "string",
+                    "example": "/api/v1/orders"
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "4f9c2d7e8a1b3c5d6e7f8091a2b3c4d5"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 409
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Insufficient stock"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "urn:rank-my-app:problem:insufficient_stock"
                 }
             }
         },
@@ -499,15 +5153,53 @@ const docTemplate = `{
             }
         }
     },
+    "securityDefinitions": {
+        "ApiKeyAuth": {
+            "description": "Chave de API de integrações servidor a servidor, limitada pelos escopos da chave.",
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
+        "BearerAuth": {
+            "description": "JWT no formato \"Bearer {token}\". Exigido quando auth.enabled = true.",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
+    },
     "tags": [
         {
             "description": "Operações relacionadas a produtos",
             "name": "Products"
         },
+        {
+            "description": "Operações relacionadas a categorias de produtos",
+            "name": "Categories"
+        },
         {
             "description": "Operações relacionadas a pedidos",
             "name": "Orders"
         },
+        {
+            "description": "Operações relacionadas a cupons de desconto",
+            "name": "Coupons"
+        },
+        {
+            "description": "Operações relacionadas a clientes",
+            "name": "Customers"
+        },
+        {
+            "description": "Operações relacionadas a envios e entregas de pedidos",
+            "name": "Shipments"
+        },
+        {
+            "description": "Operações relacionadas a devoluções e reembolsos de pedidos",
+            "name": "Returns"
+        },
+        {
+            "description": "Chaves de API de integrações de parceiros",
+            "name": "API Keys"
+        },
         {
             "description": "Health check da aplicação",
             "name": "Health"
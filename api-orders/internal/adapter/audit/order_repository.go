@@ -0,0 +1,93 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// orderRepository records in the audit trail every mutation of the orders it
+// stores through the wrapped repository. Reads pass through. Status and fulfillment
+// updates are version-conditioned, so the order read before them is the one they
+// apply to; the change is applied to it instead of reading the order again, when a
+// later write may already show.
+type orderRepository struct {
+	ports.OrderRepository
+	recorder
+}
+
+func NewOrderRepository(repository ports.OrderRepository, auditor ports.Auditor, logger *zap.Logger) ports.OrderRepository {
+	return &orderRepository{
+		OrderRepository: repository,
+		recorder:        recorder{auditor: auditor, logger: logger},
+	}
+}
+
+func (r *orderRepository) Create(ctx context.Context, order *domain.Order) error {
+	if err := r.OrderRepository.Create(ctx, order); err != nil {
+		return err
+	}
+
+	r.record(ctx, domain.AuditOrderCreated, domain.AuditResourceOrder, order.ID.Hex(), nil, order)
+	return nil
+}
+
+func (r *orderRepository) UpdateStatus(ctx context.Context, id primitive.ObjectID, status string, version int64) error {
+	before := r.current(ctx, id)
+	if err := r.OrderRepository.UpdateStatus(ctx, id, status, version); err != nil {
+		return err
+	}
+
+	after := nextOrder(before, func(order *domain.Order) { order.Status = status })
+	r.record(ctx, domain.AuditOrderStatusUpdated, domain.AuditResourceOrder, id.Hex(), before, after)
+	return nil
+}
+
+func (r *orderRepository) UpdateFulfillment(ctx context.Context, id primitive.ObjectID, shipments []domain.ShipmentSummary, status string, version int64) error {
+	before := r.current(ctx, id)
+	if err := r.OrderRepository.UpdateFulfillment(ctx, id, shipments, status, version); err != nil {
+		return err
+	}
+
+	after := nextOrder(before, func(order *domain.Order) {
+		order.Shipments = shipments
+		order.Status = status
+	})
+	r.record(ctx, domain.AuditOrderFulfillmentUpdated, domain.AuditResourceOrder, id.Hex(), before, after)
+	return nil
+}
+
+func (r *orderRepository) MarkReleased(ctx context.Context, id primitive.ObjectID, at time.Time) error {
+	if err := r.OrderRepository.MarkReleased(ctx, id, at); err != nil {
+		return err
+	}
+
+	r.record(ctx, domain.AuditOrderReleased, domain.AuditResourceOrder, id.Hex(), bson.M{}, bson.M{"released_at": at})
+	return nil
+}
+
+// current returns the stored order, nil when it cannot be read
+func (r *orderRepository) current(ctx context.Context, id primitive.ObjectID) *domain.Order {
+	order, err := r.OrderRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil
+	}
+	return order
+}
+
+// nextOrder returns a copy of the order with the change applied, at the following
+// version; nil when the order could not be read
+func nextOrder(order *domain.Order, change func(order *domain.Order)) *domain.Order {
+	if order == nil {
+		return nil
+	}
+	after := *order
+	change(&after)
+	after.Version++
+	return &after
+}
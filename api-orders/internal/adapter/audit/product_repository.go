@@ -0,0 +1,142 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// productRepository records in the audit trail every mutation of the products it
+// stores through the wrapped repository. Reads pass through.
+//
+// The snapshots are never read after the mutation, when a concurrent write may
+// already show: quantity adjustments derive the previous state from the stored
+// product they return, and version-conditioned updates read the state they apply
+// to, which cannot change before they succeed, and apply the change to it.
+type productRepository struct {
+	ports.ProductRepository
+	recorder
+}
+
+func NewProductRepository(repository ports.ProductRepository, auditor ports.Auditor, logger *zap.Logger) ports.ProductRepository {
+	return &productRepository{
+		ProductRepository: repository,
+		recorder:          recorder{auditor: auditor, logger: logger},
+	}
+}
+
+func (r *productRepository) Create(ctx context.Context, product *domain.Product) error {
+	if err := r.ProductRepository.Create(ctx, product); err != nil {
+		return err
+	}
+
+	r.record(ctx, domain.AuditProductCreated, domain.AuditResourceProduct, product.ID.Hex(), nil, product)
+	return nil
+}
+
+func (r *productRepository) Update(ctx context.Context, product *domain.Product) error {
+	before := r.current(ctx, product.ID)
+	if err := r.ProductRepository.Update(ctx, product); err != nil {
+		return err
+	}
+
+	r.record(ctx, domain.AuditProductUpdated, domain.AuditResourceProduct, product.ID.Hex(), before, product)
+	return nil
+}
+
+func (r *productRepository) AdjustQuantity(ctx context.Context, id primitive.ObjectID, delta int) (*domain.Product, error) {
+	product, err := r.ProductRepository.AdjustQuantity(ctx, id, delta)
+	if err != nil {
+		return nil, err
+	}
+
+	before := previous(product)
+	before.Quantity -= delta
+
+	r.record(ctx, domain.AuditProductQuantityAdjusted, domain.AuditResourceProduct, id.Hex(), before, product)
+	return product, nil
+}
+
+func (r *productRepository) AdjustVariantQuantity(ctx context.Context, id, variantID primitive.ObjectID, delta int) (*domain.Product, error) {
+	product, err := r.ProductRepository.AdjustVariantQuantity(ctx, id, variantID, delta)
+	if err != nil {
+		return nil, err
+	}
+
+	before := previous(product)
+	before.Quantity -= delta
+	if variant := before.Variant(variantID); variant != nil {
+		variant.Quantity -= delta
+	}
+
+	r.record(ctx, domain.AuditProductQuantityAdjusted, domain.AuditResourceProduct, id.Hex(), before, product)
+	return product, nil
+}
+
+// SetQuantity is not version-conditioned: an adjustment between the read and the
+// write shows in the previous quantity, and in its own audit entry
+func (r *productRepository) SetQuantity(ctx context.Context, id primitive.ObjectID, quantity int) error {
+	before := r.current(ctx, id)
+	if err := r.ProductRepository.SetQuantity(ctx, id, quantity); err != nil {
+		return err
+	}
+
+	after := next(before, func(product *domain.Product) { product.Quantity = quantity })
+	r.record(ctx, domain.AuditProductQuantitySet, domain.AuditResourceProduct, id.Hex(), before, after)
+	return nil
+}
+
+func (r *productRepository) SetReorderThreshold(ctx context.Context, id primitive.ObjectID, threshold int, version int64) error {
+	before := r.current(ctx, id)
+	if err := r.ProductRepository.SetReorderThreshold(ctx, id, threshold, version); err != nil {
+		return err
+	}
+
+	after := next(before, func(product *domain.Product) { product.ReorderThreshold = threshold })
+	r.record(ctx, domain.AuditProductReorderThresholdSet, domain.AuditResourceProduct, id.Hex(), before, after)
+	return nil
+}
+
+func (r *productRepository) SetPrice(ctx context.Context, id primitive.ObjectID, price float64, version int64) error {
+	before := r.current(ctx, id)
+	if err := r.ProductRepository.SetPrice(ctx, id, price, version); err != nil {
+		return err
+	}
+
+	after := next(before, func(product *domain.Product) { product.Price = price })
+	r.record(ctx, domain.AuditProductPriceSet, domain.AuditResourceProduct, id.Hex(), before, after)
+	return nil
+}
+
+// current returns the stored product, nil when it cannot be read
+func (r *productRepository) current(ctx context.Context, id primitive.ObjectID) *domain.Product {
+	product, err := r.ProductRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil
+	}
+	return product
+}
+
+// previous returns a copy of the product at the version before its last mutation
+func previous(product *domain.Product) *domain.Product {
+	before := *product
+	before.Variants = append([]domain.ProductVariant(nil), product.Variants...)
+	before.Version--
+	return &before
+}
+
+// next returns a copy of the product with the change applied, at the following
+// version; nil when the product could not be read
+func next(product *domain.Product, change func(product *domain.Product)) *domain.Product {
+	if product == nil {
+		return nil
+	}
+	after := *product
+	after.Variants = append([]domain.ProductVariant(nil), product.Variants...)
+	change(&after)
+	after.Version++
+	return &after
+}
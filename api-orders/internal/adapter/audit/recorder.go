@@ -0,0 +1,59 @@
+package audit
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.uber.org/zap"
+)
+
+// recorder diffs the snapshots of a document taken around a mutation and records
+// the changes. The mutation already happened when it is recorded, so a failure to
+// record is logged instead of failing the request.
+type recorder struct {
+	auditor ports.Auditor
+	logger  *zap.Logger
+}
+
+func (r recorder) record(ctx context.Context, action, resourceType, resourceID string, before, after interface{}) {
+	changes := domain.AuditDiff(snapshot(before), snapshot(after))
+	if err := r.auditor.Record(ctx, action, resourceType, resourceID, changes); err != nil {
+		r.logger.Error("Failed to record audit entry",
+			zap.String("action", action),
+			zap.String("resource_id", resourceID),
+			zap.Error(err),
+		)
+	}
+}
+
+// snapshot returns the fields of a document as they are stored, nil for a missing
+// document
+func snapshot(document interface{}) map[string]interface{} {
+	if document == nil {
+		return nil
+	}
+	if value := reflect.ValueOf(document); value.Kind() == reflect.Ptr && value.IsNil() {
+		return nil
+	}
+
+	raw, err := bson.Marshal(document)
+	if err != nil {
+		return nil
+	}
+
+	decoder, err := bson.NewDecoder(bsonrw.NewBSONDocumentReader(raw))
+	if err != nil {
+		return nil
+	}
+	decoder.DefaultDocumentM()
+
+	var fields map[string]interface{}
+	if err := decoder.Decode(&fields); err != nil {
+		return nil
+	}
+	return fields
+}
@@ -0,0 +1,106 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+)
+
+// Options are the claims checks shared by every verifier. Empty Issuer and
+// Audience are not checked.
+type Options struct {
+	Issuer   string
+	Audience string
+	Leeway   time.Duration
+}
+
+// claims are the token claims read by the API. Roles may come as a list in
+// "roles" or as a single "role". "tenant_id" pins the caller to a tenant.
+type claims struct {
+	Roles      []string `json:"roles"`
+	Role       string   `json:"role"`
+	CustomerID string   `json:"customer_id"`
+	TenantID   string   `json:"tenant_id"`
+	jwt.RegisteredClaims
+}
+
+type jwtVerifier struct {
+	parser  *jwt.Parser
+	keyFunc jwt.Keyfunc
+}
+
+// NewHMACVerifier creates a verifier of HS256 tokens signed with a shared secret
+func NewHMACVerifier(secret string, opts Options) (ports.TokenVerifier, error) {
+	if secret == "" {
+		return nil, errors.New("auth secret is required for HS256")
+	}
+
+	key := []byte(secret)
+	return newJWTVerifier(jwt.SigningMethodHS256.Alg(), opts, func(token *jwt.Token) (any, error) {
+		return key, nil
+	}), nil
+}
+
+// NewJWKSVerifier creates a verifier of RS256 tokens signed by one of the RSA keys
+// of a local JWKS file, selected by the "kid" header of the token
+func NewJWKSVerifier(path string, opts Options) (ports.TokenVerifier, error) {
+	keys, err := loadJWKS(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return newJWTVerifier(jwt.SigningMethodRS256.Alg(), opts, keys.keyFunc), nil
+}
+
+func newJWTVerifier(alg string, opts Options, keyFunc jwt.Keyfunc) *jwtVerifier {
+	parserOpts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{alg}),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(opts.Leeway),
+	}
+	if opts.Issuer != "" {
+		parserOpts = append(parserOpts, jwt.WithIssuer(opts.Issuer))
+	}
+	if opts.Audience != "" {
+		parserOpts = append(parserOpts, jwt.WithAudience(opts.Audience))
+	}
+
+	return &jwtVerifier{
+		parser:  jwt.NewParser(parserOpts...),
+		keyFunc: keyFunc,
+	}
+}
+
+func (v *jwtVerifier) Verify(ctx context.Context, token string) (*domain.Principal, error) {
+	var tokenClaims claims
+	if _, err := v.parser.ParseWithClaims(token, &tokenClaims, v.keyFunc); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidToken, err)
+	}
+
+	if tokenClaims.Subject == "" {
+		return nil, fmt.Errorf("%w: missing subject", domain.ErrInvalidToken)
+	}
+
+	if tokenClaims.TenantID != "" {
+		if err := domain.ValidateTenant(tokenClaims.TenantID); err != nil {
+			return nil, fmt.Errorf("%w: %v", domain.ErrInvalidToken, err)
+		}
+	}
+
+	roles := tokenClaims.Roles
+	if len(roles) == 0 && tokenClaims.Role != "" {
+		roles = []string{tokenClaims.Role}
+	}
+
+	return &domain.Principal{
+		Subject:    tokenClaims.Subject,
+		Roles:      roles,
+		CustomerID: tokenClaims.CustomerID,
+		TenantID:   tokenClaims.TenantID,
+	}, nil
+}
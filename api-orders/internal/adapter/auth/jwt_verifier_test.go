@@ -0,0 +1,134 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/gvillela7/rank-my-app/internal/adapter/auth"
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+)
+
+func signHS256(t *testing.T, secret string, claims jwt.MapClaims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return token
+}
+
+func TestHMACVerifier_Verify_ReadsRolesAndCustomer(t *testing.T) {
+	verifier, err := auth.NewHMACVerifier("segredo", auth.Options{Issuer: "rank-my-app"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	token := signHS256(t, "segredo", jwt.MapClaims{
+		"sub":         "user-1",
+		"iss":         "rank-my-app",
+		"exp":         time.Now().Add(time.Hour).Unix(),
+		"roles":       []string{domain.RoleCustomer},
+		"customer_id": "698c0a0893c94ce530171ccc",
+		"tenant_id":   "store-a",
+	})
+
+	principal, err := verifier.Verify(context.Background(), token)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if principal.Subject != "user-1" || principal.CustomerID != "698c0a0893c94ce530171ccc" || principal.TenantID != "store-a" {
+		t.Errorf("Unexpected principal %+v", principal)
+	}
+	if !principal.HasRole(domain.RoleCustomer) || principal.HasRole(domain.RoleOperator) {
+		t.Errorf("Expected only the customer role, got %v", principal.Roles)
+	}
+}
+
+func TestHMACVerifier_Verify_RejectsInvalidTokens(t *testing.T) {
+	verifier, err := auth.NewHMACVerifier("segredo", auth.Options{Issuer: "rank-my-app"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	valid := jwt.MapClaims{"sub": "user-1", "iss": "rank-my-app", "exp": time.Now().Add(time.Hour).Unix()}
+	expired := jwt.MapClaims{"sub": "user-1", "iss": "rank-my-app", "exp": time.Now().Add(-time.Hour).Unix()}
+	otherIssuer := jwt.MapClaims{"sub": "user-1", "iss": "outro", "exp": time.Now().Add(time.Hour).Unix()}
+	noExpiry := jwt.MapClaims{"sub": "user-1", "iss": "rank-my-app"}
+
+	tokens := map[string]string{
+		"wrong secret": signHS256(t, "outro-segredo", valid),
+		"expired":      signHS256(t, "segredo", expired),
+		"other issuer": signHS256(t, "segredo", otherIssuer),
+		"no expiry":    signHS256(t, "segredo", noExpiry),
+		"malformed":    "not-a-token",
+	}
+
+	for name, token := range tokens {
+		if _, err := verifier.Verify(context.Background(), token); !errors.Is(err, domain.ErrInvalidToken) {
+			t.Errorf("%s: expected ErrInvalidToken, got %v", name, err)
+		}
+	}
+}
+
+func TestJWKSVerifier_Verify_SelectsKeyByKid(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	set := map[string]any{"keys": []map[string]string{{
+		"kty": "RSA",
+		"kid": "2024-01",
+		"use": "sig",
+		"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}}}
+	content, _ := json.Marshal(set)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, content, 0o600); err != nil {
+		t.Fatalf("failed to write JWKS: %v", err)
+	}
+
+	verifier, err := auth.NewJWKSVerifier(path, auth.Options{})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"sub":  "ops-1",
+		"exp":  time.Now().Add(time.Hour).Unix(),
+		"role": domain.RoleOperator,
+	})
+	token.Header["kid"] = "2024-01"
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+
+	principal, err := verifier.Verify(context.Background(), signed)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !principal.HasRole(domain.RoleOperator) {
+		t.Errorf("Expected operator role, got %v", principal.Roles)
+	}
+
+	// HS256 tokens must not be accepted by an RS256 verifier
+	if _, err := verifier.Verify(context.Background(), signHS256(t, "segredo", jwt.MapClaims{
+		"sub": "ops-1",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})); !errors.Is(err, domain.ErrInvalidToken) {
+		t.Errorf("Expected ErrInvalidToken for HS256 token, got %v", err)
+	}
+}
@@ -0,0 +1,143 @@
+package exchangerate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+)
+
+type httpProvider struct {
+	baseURL   string
+	base      string
+	cacheTTL  time.Duration
+	client    *http.Client
+	logger    *zap.Logger
+	refreshes singleflight.Group
+	mu        sync.RWMutex
+	table     *RateTable
+	fetchedAt time.Time
+}
+
+// NewHTTPProvider creates a provider that fetches a rate table from an HTTP endpoint
+// answering GET {baseURL}?base={base} with {"base": "...", "rates": {...}}.
+// The table is cached for cacheTTL.
+func NewHTTPProvider(baseURL, base string, timeout, cacheTTL time.Duration, logger *zap.Logger) ports.ExchangeRateProvider {
+	return &httpProvider{
+		baseURL:  baseURL,
+		base:     strings.ToUpper(base),
+		cacheTTL: cacheTTL,
+		client:   &http.Client{Timeout: timeout},
+		logger:   logger,
+	}
+}
+
+func (p *httpProvider) GetRate(ctx context.Context, from, to string) (*domain.ExchangeRate, error) {
+	table, fetchedAt, err := p.rateTable(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rate, err := crossRate(*table, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ExchangeRate{
+		From:      from,
+		To:        to,
+		Rate:      rate,
+		Source:    "http:" + p.baseURL,
+		FetchedAt: fetchedAt,
+	}, nil
+}
+
+// rateTable returns the cached table, refreshing it once expired. Concurrent
+// callers share a single refresh, and the table is not locked while it runs.
+func (p *httpProvider) rateTable(ctx context.Context) (*RateTable, time.Time, error) {
+	table, fetchedAt := p.cached()
+	if table != nil && time.Since(fetchedAt) < p.cacheTTL {
+		return table, fetchedAt, nil
+	}
+
+	// the refresh outlives a caller that gives up, the client timeout bounds it
+	refresh := p.refreshes.DoChan("rates", func() (interface{}, error) {
+		table, err := p.fetch(context.WithoutCancel(ctx))
+		if err != nil {
+			return nil, err
+		}
+
+		p.mu.Lock()
+		p.table, p.fetchedAt = table, time.Now()
+		p.mu.Unlock()
+		return nil, nil
+	})
+
+	var err error
+	select {
+	case result := <-refresh:
+		err = result.Err
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	table, fetchedAt = p.cached()
+	if err != nil {
+		if table != nil {
+			p.logger.Warn("Failed to refresh exchange rates, using cached table",
+				zap.Time("fetched_at", fetchedAt),
+				zap.Error(err),
+			)
+			return table, fetchedAt, nil
+		}
+		return nil, time.Time{}, err
+	}
+
+	return table, fetchedAt, nil
+}
+
+func (p *httpProvider) cached() (*RateTable, time.Time) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.table, p.fetchedAt
+}
+
+func (p *httpProvider) fetch(ctx context.Context) (*RateTable, error) {
+	endpoint := fmt.Sprintf("%s?base=%s", p.baseURL, url.QueryEscape(p.base))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build exchange rate request: %w", err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch exchange rates: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("exchange rate provider returned status %d", resp.StatusCode)
+	}
+
+	var table RateTable
+	if err := json.NewDecoder(resp.Body).Decode(&table); err != nil {
+		return nil, fmt.Errorf("failed to decode exchange rates: %w", err)
+	}
+
+	if table.Base == "" {
+		table.Base = p.base
+	}
+
+	normalized := newStaticProvider(table, "").table
+	return &normalized, nil
+}
@@ -0,0 +1,99 @@
+package exchangerate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+)
+
+// RateTable holds the value of one unit of Base in every other supported currency
+type RateTable struct {
+	Base  string             `json:"base"`
+	Rates map[string]float64 `json:"rates"`
+}
+
+type staticProvider struct {
+	table     RateTable
+	source    string
+	updatedAt time.Time
+}
+
+// NewStaticProvider creates a provider backed by a fixed rate table
+func NewStaticProvider(base string, rates map[string]float64) ports.ExchangeRateProvider {
+	return newStaticProvider(RateTable{Base: base, Rates: rates}, "static")
+}
+
+// NewFileProvider creates a provider backed by a JSON rate table file, e.g.
+// {"base": "BRL", "rates": {"USD": 0.18, "EUR": 0.17}}
+func NewFileProvider(path string) (ports.ExchangeRateProvider, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read exchange rate file: %w", err)
+	}
+
+	var table RateTable
+	if err := json.Unmarshal(content, &table); err != nil {
+		return nil, fmt.Errorf("failed to parse exchange rate file: %w", err)
+	}
+
+	if table.Base == "" {
+		return nil, fmt.Errorf("exchange rate file %s has no base currency", path)
+	}
+
+	return newStaticProvider(table, "file:"+path), nil
+}
+
+func newStaticProvider(table RateTable, source string) *staticProvider {
+	rates := make(map[string]float64, len(table.Rates)+1)
+	for currency, rate := range table.Rates {
+		rates[strings.ToUpper(currency)] = rate
+	}
+	base := strings.ToUpper(table.Base)
+	rates[base] = 1
+
+	return &staticProvider{
+		table:     RateTable{Base: base, Rates: rates},
+		source:    source,
+		updatedAt: time.Now(),
+	}
+}
+
+func (p *staticProvider) GetRate(ctx context.Context, from, to string) (*domain.ExchangeRate, error) {
+	rate, err := crossRate(p.table, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ExchangeRate{
+		From:      from,
+		To:        to,
+		Rate:      rate,
+		Source:    p.source,
+		FetchedAt: p.updatedAt,
+	}, nil
+}
+
+// crossRate derives the from->to rate through the table's base currency
+func crossRate(table RateTable, from, to string) (float64, error) {
+	if from == to {
+		return 1, nil
+	}
+
+	fromRate, ok := table.Rates[from]
+	if !ok || fromRate <= 0 {
+		return 0, fmt.Errorf("%w: %s", domain.ErrExchangeRateNotFound, from)
+	}
+
+	toRate, ok := table.Rates[to]
+	if !ok || toRate <= 0 {
+		return 0, fmt.Errorf("%w: %s", domain.ErrExchangeRateNotFound, to)
+	}
+
+	return toRate / fromRate, nil
+}
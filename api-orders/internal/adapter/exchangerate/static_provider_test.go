@@ -0,0 +1,41 @@
+package exchangerate_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/gvillela7/rank-my-app/internal/adapter/exchangerate"
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+)
+
+func TestStaticProvider_GetRate_CrossRate(t *testing.T) {
+	provider := exchangerate.NewStaticProvider("BRL", map[string]float64{"USD": 0.2, "EUR": 0.16})
+
+	rate, err := provider.GetRate(context.Background(), "USD", "EUR")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if math.Abs(rate.Rate-0.8) > 1e-9 {
+		t.Errorf("Expected rate 0.8, got %f", rate.Rate)
+	}
+
+	if rate.Source != "static" {
+		t.Errorf("Expected source static, got %s", rate.Source)
+	}
+
+	if converted := rate.Convert(100); converted != 80 {
+		t.Errorf("Expected converted amount 80, got %f", converted)
+	}
+}
+
+func TestStaticProvider_GetRate_UnsupportedCurrency(t *testing.T) {
+	provider := exchangerate.NewStaticProvider("BRL", map[string]float64{"USD": 0.2})
+
+	_, err := provider.GetRate(context.Background(), "BRL", "JPY")
+	if !errors.Is(err, domain.ErrExchangeRateNotFound) {
+		t.Errorf("Expected ErrExchangeRateNotFound, got %v", err)
+	}
+}
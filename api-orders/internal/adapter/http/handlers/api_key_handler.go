@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/gvillela7/rank-my-app/internal/core/dto"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.uber.org/zap"
+)
+
+type APIKeyHandler struct {
+	useCase   ports.APIKeyUseCase
+	validator *validator.Validate
+	logger    *zap.Logger
+}
+
+func NewAPIKeyHandler(useCase ports.APIKeyUseCase, validator *validator.Validate, logger *zap.Logger) *APIKeyHandler {
+	return &APIKeyHandler{
+		useCase:   useCase,
+		validator: validator,
+		logger:    logger,
+	}
+}
+
+// IssueAPIKey godoc
+// @Summary      Issue an API key
+// @Description  Issues an API key for a service-to-service client. The key is returned only in this response; store it safely.
+// @Tags         API Keys
+// @Accept       json
+// @Produce      json
+// @Param        key  body      dto.CreateAPIKeyRequest  true  "API key information"
+// @Success      201  {object}  SuccessResponseDoc{data=dto.IssuedAPIKeyResponse}  "API key issued successfully"
+// @Failure      400  {object}  Problem  "Invalid request body or validation error"
+// @Failure      401  {object}  Problem  "Missing or invalid bearer token"
+// @Failure      403  {object}  Problem  "Role not allowed"
+// @Failure      500  {object}  Problem  "Internal server error"
+// @Security     BearerAuth
+// @Router       /api-keys [post]
+func (h *APIKeyHandler) IssueAPIKey(c *gin.Context) {
+	var req dto.CreateAPIKeyRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind JSON", zap.Error(err))
+		ValidationErrorResponse(c, err)
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		h.logger.Error("Validation failed", zap.Error(err))
+		ValidationErrorResponse(c, err)
+		return
+	}
+
+	key, err := h.useCase.IssueAPIKey(c.Request.Context(), &req)
+	if err != nil {
+		h.logger.Error("Failed to issue API key", zap.Error(err))
+		ErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusCreated, key, "API key issued successfully")
+}
+
+// ListAPIKeys godoc
+// @Summary      List API keys
+// @Description  Lists the API keys, most recent first, without the keys themselves
+// @Tags         API Keys
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  SuccessResponseDoc{data=[]dto.APIKeyResponse}  "API keys retrieved successfully"
+// @Failure      401  {object}  Problem  "Missing or invalid bearer token"
+// @Failure      403  {object}  Problem  "Role not allowed"
+// @Failure      500  {object}  Problem  "Internal server error"
+// @Security     BearerAuth
+// @Router       /api-keys [get]
+func (h *APIKeyHandler) ListAPIKeys(c *gin.Context) {
+	keys, err := h.useCase.ListAPIKeys(c.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to list API keys", zap.Error(err))
+		ErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, keys, "API keys retrieved successfully")
+}
+
+// RotateAPIKey godoc
+// @Summary      Rotate an API key
+// @Description  Replaces the key of an API key, keeping its name, scopes and expiration. The previous key stops working at once.
+// @Tags         API Keys
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "API key ID (MongoDB ObjectID)"
+// @Success      200  {object}  SuccessResponseDoc{data=dto.IssuedAPIKeyResponse}  "API key rotated successfully"
+// @Failure      401  {object}  Problem  "Missing or invalid bearer token"
+// @Failure      403  {object}  Problem  "Role not allowed"
+// @Failure      404  {object}  Problem  "API key not found"
+// @Failure      409  {object}  Problem  "API key revoked"
+// @Failure      500  {object}  Problem  "Internal server error"
+// @Security     BearerAuth
+// @Router       /api-keys/{id}/rotate [post]
+func (h *APIKeyHandler) RotateAPIKey(c *gin.Context) {
+	id := c.Param("id")
+
+	key, err := h.useCase.RotateAPIKey(c.Request.Context(), id)
+	if err != nil {
+		h.logger.Error("Failed to rotate API key", zap.Error(err), zap.String("id", id))
+		ErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, key, "API key rotated successfully")
+}
+
+// RevokeAPIKey godoc
+// @Summary      Revoke an API key
+// @Description  Revokes an API key. Revoking an already revoked key has no effect.
+// @Tags         API Keys
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "API key ID (MongoDB ObjectID)"
+// @Success      200  {object}  SuccessResponseDoc{data=dto.APIKeyResponse}  "API key revoked successfully"
+// @Failure      401  {object}  Problem  "Missing or invalid bearer token"
+// @Failure      403  {object}  Problem  "Role not allowed"
+// @Failure      404  {object}  Problem  "API key not found"
+// @Failure      500  {object}  Problem  "Internal server error"
+// @Security     BearerAuth
+// @Router       /api-keys/{id} [delete]
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	id := c.Param("id")
+
+	key, err := h.useCase.RevokeAPIKey(c.Request.Context(), id)
+	if err != nil {
+		h.logger.Error("Failed to revoke API key", zap.Error(err), zap.String("id", id))
+		ErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, key, "API key revoked successfully")
+}
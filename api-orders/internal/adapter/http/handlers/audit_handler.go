@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/gvillela7/rank-my-app/internal/core/dto"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.uber.org/zap"
+)
+
+type AuditHandler struct {
+	useCase   ports.AuditUseCase
+	validator *validator.Validate
+	logger    *zap.Logger
+}
+
+func NewAuditHandler(useCase ports.AuditUseCase, validator *validator.Validate, logger *zap.Logger) *AuditHandler {
+	return &AuditHandler{
+		useCase:   useCase,
+		validator: validator,
+		logger:    logger,
+	}
+}
+
+// ListAuditEntries godoc
+// @Summary      Query the audit trail
+// @Description  Lists the recorded product and order mutations, latest first: who made them (actor and source service), when, from which request and client IP, and the changed fields with their previous and new values. Entries are append-only.
+// @Tags         Audit
+// @Accept       json
+// @Produce      json
+// @Param        actor          query     string  false  "Subject of the token or API key that made the change"
+// @Param        action         query     string  false  "Action, such as order.status_updated"
+// @Param        resource_type  query     string  false  "Resource type"  Enums(product, order, payment)
+// @Param        resource_id    query     string  false  "Resource ID"
+// @Param        request_id     query     string  false  "X-Request-ID of the request that made the change"
+// @Param        from           query     string  false  "Earliest change (RFC 3339)"
+// @Param        to             query     string  false  "Latest change (RFC 3339)"
+// @Param        offset         query     int     false  "Number of entries to skip"  default(0)
+// @Param        limit          query     int     false  "Page size (1-100)"  default(20)
+// @Success      200            {object}  SuccessResponseDoc{data=dto.AuditPageResponse}  "Audit entries retrieved successfully"
+// @Failure      400            {object}  Problem  "Invalid query parameters"
+// @Failure      401            {object}  Problem  "Missing or invalid bearer token"
+// @Failure      403            {object}  Problem  "Role not allowed"
+// @Failure      500            {object}  Problem  "Internal server error"
+// @Security     BearerAuth
+// @Router       /audit [get]
+func (h *AuditHandler) ListAuditEntries(c *gin.Context) {
+	var req dto.AuditQueryRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error("Failed to bind query", zap.Error(err))
+		ValidationErrorResponse(c, err)
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		h.logger.Error("Validation failed", zap.Error(err))
+		ValidationErrorResponse(c, err)
+		return
+	}
+
+	entries, err := h.useCase.ListAuditEntries(c.Request.Context(), &req)
+	if err != nil {
+		h.logger.Error("Failed to list audit entries", zap.Error(err))
+		ErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, entries, "Audit entries retrieved successfully")
+}
@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/dto"
+)
+
+// canAccessCustomer reports whether the caller may see the data of a customer.
+// Every caller may when authentication is disabled.
+func canAccessCustomer(c *gin.Context, customerID string) bool {
+	principal, ok := domain.PrincipalFromContext(c.Request.Context())
+	return !ok || principal.CanAccessCustomer(customerID)
+}
+
+// canAccessOrder reports whether the caller may see an order. API keys see only
+// the orders they created.
+func canAccessOrder(c *gin.Context, order *dto.OrderResponse) bool {
+	principal, ok := domain.PrincipalFromContext(c.Request.Context())
+	if ok && principal.APIKeyID != "" {
+		return order.APIKeyID == principal.APIKeyID
+	}
+	return canAccessCustomer(c, order.CustomerID)
+}
+
+// orderingCustomer resolves the customer of a new order. Customers always order for
+// themselves: an empty customer_id is filled in and another customer's is rejected.
+// Staff and API keys order for any customer.
+func orderingCustomer(c *gin.Context, customerID string) (string, error) {
+	principal, ok := domain.PrincipalFromContext(c.Request.Context())
+	if !ok || principal.HasRole(domain.RoleOperator) || principal.APIKeyID != "" {
+		return customerID, nil
+	}
+
+	if principal.CustomerID == "" {
+		return "", domain.ErrForbidden.WithDetail("Token is not linked to a customer")
+	}
+	if customerID != "" && customerID != principal.CustomerID {
+		return "", domain.ErrForbidden.WithDetail("Customers can only place their own orders")
+	}
+	return principal.CustomerID, nil
+}
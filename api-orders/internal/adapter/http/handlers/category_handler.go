@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/gvillela7/rank-my-app/internal/core/dto"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.uber.org/zap"
+)
+
+type CategoryHandler struct {
+	useCase   ports.CategoryUseCase
+	validator *validator.Validate
+	logger    *zap.Logger
+}
+
+func NewCategoryHandler(useCase ports.CategoryUseCase, validator *validator.Validate, logger *zap.Logger) *CategoryHandler {
+	return &CategoryHandler{
+		useCase:   useCase,
+		validator: validator,
+		logger:    logger,
+	}
+}
+
+// CreateCategory godoc
+// @Summary      Create a new category
+// @Description  Creates a category, optionally below a parent category. Products classified in it are also found under its ancestors.
+// @Tags         Categories
+// @Accept       json
+// @Produce      json
+// @Param        category  body      dto.CategoryRequest  true  "Category information"
+// @Success      201       {object}  SuccessResponseDoc{data=dto.CategoryResponse}  "Category created successfully"
+// @Failure      400       {object}  Problem  "Invalid request body or parent category not found"
+// @Failure      401       {object}  Problem  "Missing or invalid bearer token"
+// @Failure      403       {object}  Problem  "Role not allowed"
+// @Failure      500       {object}  Problem  "Internal server error"
+// @Security     BearerAuth
+// @Router       /categories [post]
+func (h *CategoryHandler) CreateCategory(c *gin.Context) {
+	var req dto.CategoryRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind JSON", zap.Error(err))
+		ValidationErrorResponse(c, err)
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		h.logger.Error("Validation failed", zap.Error(err))
+		ValidationErrorResponse(c, err)
+		return
+	}
+
+	category, err := h.useCase.CreateCategory(c.Request.Context(), &req)
+	if err != nil {
+		h.logger.Error("Failed to create category", zap.Error(err))
+		ErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusCreated, category, "Category created successfully")
+}
+
+// ListCategories godoc
+// @Summary      List categories
+// @Description  Lists all categories ordered by name. The tree is built from parent_id and path.
+// @Tags         Categories
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  SuccessResponseDoc{data=[]dto.CategoryResponse}  "Categories retrieved successfully"
+// @Failure      401  {object}  Problem  "Missing or invalid bearer token or API key"
+// @Failure      403  {object}  Problem  "API key scope not allowed"
+// @Failure      500  {object}  Problem  "Internal server error"
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /categories [get]
+func (h *CategoryHandler) ListCategories(c *gin.Context) {
+	categories, err := h.useCase.ListCategories(c.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to list categories", zap.Error(err))
+		ErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, categories, "Categories retrieved successfully")
+}
@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/gvillela7/rank-my-app/internal/core/dto"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.uber.org/zap"
+)
+
+type CouponHandler struct {
+	useCase   ports.CouponUseCase
+	validator *validator.Validate
+	logger    *zap.Logger
+}
+
+func NewCouponHandler(useCase ports.CouponUseCase, validator *validator.Validate, logger *zap.Logger) *CouponHandler {
+	return &CouponHandler{
+		useCase:   useCase,
+		validator: validator,
+		logger:    logger,
+	}
+}
+
+// CreateCoupon godoc
+// @Summary      Create a new coupon
+// @Description  Creates a discount coupon (percentage or fixed) with optional restrictions and usage limits
+// @Tags         Coupons
+// @Accept       json
+// @Produce      json
+// @Param        coupon  body      dto.CreateCouponRequest  true  "Coupon information"
+// @Success      201     {object}  SuccessResponseDoc{data=dto.CouponResponse}  "Coupon created successfully"
+// @Failure      400     {object}  Problem  "Invalid request body or validation error"
+// @Failure      401     {object}  Problem  "Missing or invalid bearer token"
+// @Failure      403     {object}  Problem  "Role not allowed"
+// @Failure      409     {object}  Problem  "Coupon code already exists"
+// @Failure      500     {object}  Problem  "Internal server error"
+// @Security     BearerAuth
+// @Router       /coupons [post]
+func (h *CouponHandler) CreateCoupon(c *gin.Context) {
+	var req dto.CreateCouponRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind JSON", zap.Error(err))
+		ValidationErrorResponse(c, err)
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		h.logger.Error("Validation failed", zap.Error(err))
+		ValidationErrorResponse(c, err)
+		return
+	}
+
+	coupon, err := h.useCase.CreateCoupon(c.Request.Context(), &req)
+	if err != nil {
+		h.logger.Error("Failed to create coupon", zap.Error(err))
+		ErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusCreated, coupon, "Coupon created successfully")
+}
+
+// ListCoupons godoc
+// @Summary      List coupons
+// @Description  Lists all coupons, most recent first
+// @Tags         Coupons
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  SuccessResponseDoc{data=[]dto.CouponResponse}  "Coupons retrieved successfully"
+// @Failure      401  {object}  Problem  "Missing or invalid bearer token"
+// @Failure      403  {object}  Problem  "Role not allowed"
+// @Failure      500  {object}  Problem  "Internal server error"
+// @Security     BearerAuth
+// @Router       /coupons [get]
+func (h *CouponHandler) ListCoupons(c *gin.Context) {
+	coupons, err := h.useCase.ListCoupons(c.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to list coupons", zap.Error(err))
+		ErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, coupons, "Coupons retrieved successfully")
+}
+
+// GetCouponByID godoc
+// @Summary      Get coupon by ID
+// @Description  Retrieves a coupon, including its usage count, by its MongoDB ObjectID
+// @Tags         Coupons
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Coupon ID (MongoDB ObjectID)"
+// @Success      200  {object}  SuccessResponseDoc{data=dto.CouponResponse}  "Coupon retrieved successfully"
+// @Failure      401  {object}  Problem  "Missing or invalid bearer token"
+// @Failure      403  {object}  Problem  "Role not allowed"
+// @Failure      404  {object}  Problem  "Coupon not found"
+// @Failure      500  {object}  Problem  "Internal server error"
+// @Security     BearerAuth
+// @Router       /coupons/{id} [get]
+func (h *CouponHandler) GetCouponByID(c *gin.Context) {
+	id := c.Param("id")
+
+	coupon, err := h.useCase.GetCouponByID(c.Request.Context(), id)
+	if err != nil {
+		h.logger.Error("Failed to get coupon", zap.Error(err), zap.String("id", id))
+		ErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, coupon, "Coupon retrieved successfully")
+}
+
+// UpdateCoupon godoc
+// @Summary      Update coupon
+// @Description  Replaces the definition of an existing coupon. The code and usage counters are kept.
+// @Tags         Coupons
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string                   true  "Coupon ID (MongoDB ObjectID)"
+// @Param        coupon  body      dto.UpdateCouponRequest  true  "Coupon information"
+// @Success      200     {object}  SuccessResponseDoc{data=dto.CouponResponse}  "Coupon updated successfully"
+// @Failure      400     {object}  Problem  "Invalid request body or validation error"
+// @Failure      401     {object}  Problem  "Missing or invalid bearer token"
+// @Failure      403     {object}  Problem  "Role not allowed"
+// @Failure      404     {object}  Problem  "Coupon not found"
+// @Failure      500     {object}  Problem  "Internal server error"
+// @Security     BearerAuth
+// @Router       /coupons/{id} [put]
+func (h *CouponHandler) UpdateCoupon(c *gin.Context) {
+	id := c.Param("id")
+	var req dto.UpdateCouponRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind JSON", zap.Error(err))
+		ValidationErrorResponse(c, err)
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		h.logger.Error("Validation failed", zap.Error(err))
+		ValidationErrorResponse(c, err)
+		return
+	}
+
+	coupon, err := h.useCase.UpdateCoupon(c.Request.Context(), id, &req)
+	if err != nil {
+		h.logger.Error("Failed to update coupon", zap.Error(err), zap.String("id", id))
+		ErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, coupon, "Coupon updated successfully")
+}
+
+// DeleteCoupon godoc
+// @Summary      Delete coupon
+// @Description  Deletes a coupon. Orders that already used it keep the applied discount.
+// @Tags         Coupons
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Coupon ID (MongoDB ObjectID)"
+// @Success      200  {object}  SuccessResponseDoc  "Coupon deleted successfully"
+// @Failure      401  {object}  Problem  "Missing or invalid bearer token"
+// @Failure      403  {object}  Problem  "Role not allowed"
+// @Failure      404  {object}  Problem  "Coupon not found"
+// @Failure      500  {object}  Problem  "Internal server error"
+// @Security     BearerAuth
+// @Router       /coupons/{id} [delete]
+func (h *CouponHandler) DeleteCoupon(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := h.useCase.DeleteCoupon(c.Request.Context(), id); err != nil {
+		h.logger.Error("Failed to delete coupon", zap.Error(err), zap.String("id", id))
+		ErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, nil, "Coupon deleted successfully")
+}
@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/dto"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.uber.org/zap"
+)
+
+type CustomerHandler struct {
+	useCase   ports.CustomerUseCase
+	validator *validator.Validate
+	logger    *zap.Logger
+}
+
+func NewCustomerHandler(useCase ports.CustomerUseCase, validator *validator.Validate, logger *zap.Logger) *CustomerHandler {
+	return &CustomerHandler{
+		useCase:   useCase,
+		validator: validator,
+		logger:    logger,
+	}
+}
+
+// CreateCustomer godoc
+// @Summary      Create a new customer
+// @Description  Creates a new customer with name, email, CPF document and addresses
+// @Tags         Customers
+// @Accept       json
+// @Produce      json
+// @Param        customer  body      dto.CustomerRequest  true  "Customer information"
+// @Success      201       {object}  SuccessResponseDoc{data=dto.CustomerResponse}  "Customer created successfully"
+// @Failure      400       {object}  Problem  "Invalid request body or validation error"
+// @Failure      401       {object}  Problem  "Missing or invalid bearer token"
+// @Failure      403       {object}  Problem  "Role not allowed"
+// @Failure      409       {object}  Problem  "Customer email or document already registered"
+// @Failure      500       {object}  Problem  "Internal server error"
+// @Security     BearerAuth
+// @Router       /customers [post]
+func (h *CustomerHandler) CreateCustomer(c *gin.Context) {
+	var req dto.CustomerRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind JSON", zap.Error(err))
+		ValidationErrorResponse(c, err)
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		h.logger.Error("Validation failed", zap.Error(err))
+		ValidationErrorResponse(c, err)
+		return
+	}
+
+	customer, err := h.useCase.CreateCustomer(c.Request.Context(), &req)
+	if err != nil {
+		h.logger.Error("Failed to create customer", zap.Error(err))
+		ErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusCreated, customer, "Customer created successfully")
+}
+
+// ListCustomers godoc
+// @Summary      List customers
+// @Description  Lists all customers ordered by name
+// @Tags         Customers
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  SuccessResponseDoc{data=[]dto.CustomerResponse}  "Customers retrieved successfully"
+// @Failure      401  {object}  Problem  "Missing or invalid bearer token"
+// @Failure      403  {object}  Problem  "Role not allowed"
+// @Failure      500  {object}  Problem  "Internal server error"
+// @Security     BearerAuth
+// @Router       /customers [get]
+func (h *CustomerHandler) ListCustomers(c *gin.Context) {
+	customers, err := h.useCase.ListCustomers(c.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to list customers", zap.Error(err))
+		ErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, customers, "Customers retrieved successfully")
+}
+
+// GetCustomerByID godoc
+// @Summary      Get customer by ID
+// @Description  Retrieves a customer by its MongoDB ObjectID
+// @Tags         Customers
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Customer ID (MongoDB ObjectID)"
+// @Success      200  {object}  SuccessResponseDoc{data=dto.CustomerResponse}  "Customer retrieved successfully"
+// @Failure      401  {object}  Problem  "Missing or invalid bearer token"
+// @Failure      404  {object}  Problem  "Customer not found"
+// @Failure      500  {object}  Problem  "Internal server error"
+// @Security     BearerAuth
+// @Router       /customers/{id} [get]
+func (h *CustomerHandler) GetCustomerByID(c *gin.Context) {
+	id := c.Param("id")
+
+	if !canAccessCustomer(c, id) {
+		ErrorResponse(c, domain.ErrCustomerNotFound)
+		return
+	}
+
+	customer, err := h.useCase.GetCustomerByID(c.Request.Context(), id)
+	if err != nil {
+		h.logger.Error("Failed to get customer", zap.Error(err), zap.String("id", id))
+		ErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, customer, "Customer retrieved successfully")
+}
+
+// UpdateCustomer godoc
+// @Summary      Update customer
+// @Description  Replaces the data and addresses of an existing customer. Orders keep their shipping address snapshot.
+// @Tags         Customers
+// @Accept       json
+// @Produce      json
+// @Param        id        path      string               true  "Customer ID (MongoDB ObjectID)"
+// @Param        customer  body      dto.CustomerRequest  true  "Customer information"
+// @Success      200       {object}  SuccessResponseDoc{data=dto.CustomerResponse}  "Customer updated successfully"
+// @Failure      400       {object}  Problem  "Invalid request body or validation error"
+// @Failure      401       {object}  Problem  "Missing or invalid bearer token"
+// @Failure      404       {object}  Problem  "Customer not found"
+// @Failure      409       {object}  Problem  "Customer email or document already registered"
+// @Failure      500       {object}  Problem  "Internal server error"
+// @Security     BearerAuth
+// @Router       /customers/{id} [put]
+func (h *CustomerHandler) UpdateCustomer(c *gin.Context) {
+	id := c.Param("id")
+
+	if !canAccessCustomer(c, id) {
+		ErrorResponse(c, domain.ErrCustomerNotFound)
+		return
+	}
+
+	var req dto.CustomerRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind JSON", zap.Error(err))
+		ValidationErrorResponse(c, err)
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		h.logger.Error("Validation failed", zap.Error(err))
+		ValidationErrorResponse(c, err)
+		return
+	}
+
+	customer, err := h.useCase.UpdateCustomer(c.Request.Context(), id, &req)
+	if err != nil {
+		h.logger.Error("Failed to update customer", zap.Error(err), zap.String("id", id))
+		ErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, customer, "Customer updated successfully")
+}
+
+// DeleteCustomer godoc
+// @Summary      Delete customer
+// @Description  Deletes a customer. Existing orders keep their customer_id and shipping address snapshot.
+// @Tags         Customers
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Customer ID (MongoDB ObjectID)"
+// @Success      200  {object}  SuccessResponseDoc  "Customer deleted successfully"
+// @Failure      401  {object}  Problem  "Missing or invalid bearer token"
+// @Failure      403  {object}  Problem  "Role not allowed"
+// @Failure      404  {object}  Problem  "Customer not found"
+// @Failure      500  {object}  Problem  "Internal server error"
+// @Security     BearerAuth
+// @Router       /customers/{id} [delete]
+func (h *CustomerHandler) DeleteCustomer(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := h.useCase.DeleteCustomer(c.Request.Context(), id); err != nil {
+		h.logger.Error("Failed to delete customer", zap.Error(err), zap.String("id", id))
+		ErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, nil, "Customer deleted successfully")
+}
+
+// ListCustomerOrders godoc
+// @Summary      List customer orders
+// @Description  Lists the orders placed by a customer, most recent first
+// @Tags         Customers
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Customer ID (MongoDB ObjectID)"
+// @Success      200  {object}  SuccessResponseDoc{data=[]dto.OrderResponse}  "Orders retrieved successfully"
+// @Failure      401  {object}  Problem  "Missing or invalid bearer token"
+// @Failure      404  {object}  Problem  "Customer not found"
+// @Failure      500  {object}  Problem  "Internal server error"
+// @Security     BearerAuth
+// @Router       /customers/{id}/orders [get]
+func (h *CustomerHandler) ListCustomerOrders(c *gin.Context) {
+	id := c.Param("id")
+
+	if !canAccessCustomer(c, id) {
+		ErrorResponse(c, domain.ErrCustomerNotFound)
+		return
+	}
+
+	orders, err := h.useCase.ListCustomerOrders(c.Request.Context(), id)
+	if err != nil {
+		h.logger.Error("Failed to list customer orders", zap.Error(err), zap.String("id", id))
+		ErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, orders, "Orders retrieved successfully")
+}
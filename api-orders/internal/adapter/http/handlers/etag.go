@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+)
+
+// setETag sets the ETag header from the version of the returned resource
+func setETag(c *gin.Context, version int64) {
+	c.Header("ETag", strconv.Quote(strconv.FormatInt(version, 10)))
+}
+
+// ifMatchVersion returns the version required by the If-Match header, or nil when the
+// request has no precondition. ETags that are not one of our versions can never
+// match and fail the precondition; weak ETags are compared by their value.
+func ifMatchVersion(c *gin.Context) (*int64, error) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return nil, nil
+	}
+
+	if strings.Contains(header, ",") {
+		return nil, domain.ErrInvalidRequest.WithDetail("If-Match must carry a single ETag")
+	}
+
+	tag, err := strconv.Unquote(strings.TrimPrefix(header, "W/"))
+	if err != nil {
+		return nil, domain.ErrVersionConflict.WithDetail("If-Match does not match the current version")
+	}
+
+	version, err := strconv.ParseInt(tag, 10, 64)
+	if err != nil {
+		return nil, domain.ErrVersionConflict.WithDetail("If-Match does not match the current version")
+	}
+
+	return &version, nil
+}
@@ -30,9 +30,13 @@ func (h *HealthHandler) HealthCheck(c *gin.Context) {
 
 	apiStatus := "ok"
 
-	rabbitmqStatus := "falha"
-	if h.rabbitConn.IsConnected(ctx) {
-		rabbitmqStatus = "sucesso"
+	// without connection messages go to the in-process broker
+	rabbitmqStatus := "desativado"
+	if h.rabbitConn != nil {
+		rabbitmqStatus = "falha"
+		if h.rabbitConn.IsConnected(ctx) {
+			rabbitmqStatus = "sucesso"
+		}
 	}
 
 	c.JSON(http.StatusOK, gin.H{
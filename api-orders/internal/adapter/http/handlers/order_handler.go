@@ -5,6 +5,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
 	"github.com/gvillela7/rank-my-app/internal/core/dto"
 	"github.com/gvillela7/rank-my-app/internal/core/ports"
 	"go.uber.org/zap"
@@ -32,8 +33,13 @@ func NewOrderHandler(useCase ports.OrderUseCase, validator *validator.Validate,
 // @Produce      json
 // @Param        order  body      dto.CreateOrderRequest  true  "Order information"
 // @Success      201    {object}  SuccessResponseDoc{data=dto.OrderResponse}  "Order created successfully"
-// @Failure      400    {object}  ErrorResponseDoc  "Invalid request body or validation error"
-// @Failure      500    {object}  ErrorResponseDoc  "Internal server error"
+// @Failure      400    {object}  Problem  "Invalid request body or validation error"
+// @Failure      401    {object}  Problem  "Missing or invalid bearer token or API key"
+// @Failure      403    {object}  Problem  "Role not allowed"
+// @Failure      429    {object}  Problem  "Rate limit exceeded; see Retry-After"
+// @Failure      500    {object}  Problem  "Internal server error"
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
 // @Router       /orders [post]
 func (h *OrderHandler) CreateOrder(c *gin.Context) {
 	var req dto.CreateOrderRequest
@@ -50,16 +56,17 @@ func (h *OrderHandler) CreateOrder(c *gin.Context) {
 		return
 	}
 
+	customerID, err := orderingCustomer(c, req.CustomerID)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+	req.CustomerID = customerID
+
 	order, err := h.useCase.CreateOrder(c.Request.Context(), &req)
 	if err != nil {
 		h.logger.Error("Failed to create order", zap.Error(err))
-
-		if httpErr, ok := GetHTTPError(err); ok {
-			ErrorResponse(c, httpErr.Code, httpErr, httpErr.Message)
-			return
-		}
-
-		ErrorResponse(c, http.StatusInternalServerError, err, "Failed to create order")
+		ErrorResponse(c, err)
 		return
 	}
 
@@ -74,8 +81,13 @@ func (h *OrderHandler) CreateOrder(c *gin.Context) {
 // @Produce      json
 // @Param        id   path      string  true  "Order ID (MongoDB ObjectID)"
 // @Success      200  {object}  SuccessResponseDoc{data=dto.OrderResponse}  "Order retrieved successfully"
-// @Failure      404  {object}  ErrorResponseDoc  "Order not found"
-// @Failure      500  {object}  ErrorResponseDoc  "Internal server error"
+// @Header       200  {string}  ETag  "Order version, to be sent back in If-Match"
+// @Failure      401  {object}  Problem  "Missing or invalid bearer token or API key"
+// @Failure      403  {object}  Problem  "API key scope not allowed"
+// @Failure      404  {object}  Problem  "Order not found"
+// @Failure      500  {object}  Problem  "Internal server error"
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
 // @Router       /orders/{id} [get]
 func (h *OrderHandler) GetOrderByID(c *gin.Context) {
 	id := c.Param("id")
@@ -83,16 +95,53 @@ func (h *OrderHandler) GetOrderByID(c *gin.Context) {
 	order, err := h.useCase.GetOrderByID(c.Request.Context(), id)
 	if err != nil {
 		h.logger.Error("Failed to get order", zap.Error(err), zap.String("id", id))
+		ErrorResponse(c, err)
+		return
+	}
+
+	// orders of other callers are reported as missing, not to reveal they exist
+	if !canAccessOrder(c, order) {
+		ErrorResponse(c, domain.ErrOrderNotFound)
+		return
+	}
+
+	setETag(c, order.Version)
+	SuccessResponse(c, http.StatusOK, order, "Order retrieved successfully")
+}
 
-		if httpErr, ok := GetHTTPError(err); ok {
-			ErrorResponse(c, httpErr.Code, httpErr, httpErr.Message)
-			return
-		}
+// GetOrderByNumber godoc
+// @Summary      Get order by number
+// @Description  Retrieves an order by its human-friendly order number, such as ORD-20261016-0001238. Numbers whose check digit does not match are reported as mistyped.
+// @Tags         Orders
+// @Accept       json
+// @Produce      json
+// @Param        number  path      string  true  "Order number"
+// @Success      200     {object}  SuccessResponseDoc{data=dto.OrderResponse}  "Order retrieved successfully"
+// @Header       200     {string}  ETag  "Order version, to be sent back in If-Match"
+// @Failure      401     {object}  Problem  "Missing or invalid bearer token or API key"
+// @Failure      403     {object}  Problem  "API key scope not allowed"
+// @Failure      404     {object}  Problem  "Order not found or mistyped order number"
+// @Failure      500     {object}  Problem  "Internal server error"
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /orders/by-number/{number} [get]
+func (h *OrderHandler) GetOrderByNumber(c *gin.Context) {
+	number := c.Param("number")
+
+	order, err := h.useCase.GetOrderByNumber(c.Request.Context(), number)
+	if err != nil {
+		h.logger.Error("Failed to get order", zap.Error(err), zap.String("number", number))
+		ErrorResponse(c, err)
+		return
+	}
 
-		ErrorResponse(c, http.StatusInternalServerError, err, "Failed to get order")
+	// orders of other callers are reported as missing, not to reveal they exist
+	if !canAccessOrder(c, order) {
+		ErrorResponse(c, domain.ErrOrderNotFound)
 		return
 	}
 
+	setETag(c, order.Version)
 	SuccessResponse(c, http.StatusOK, order, "Order retrieved successfully")
 }
 
@@ -102,12 +151,20 @@ func (h *OrderHandler) GetOrderByID(c *gin.Context) {
 // @Tags         Orders
 // @Accept       json
 // @Produce      json
-// @Param        id      path      string                        true  "Order ID (MongoDB ObjectID)"
-// @Param        status  body      dto.UpdateOrderStatusRequest  true  "New status"
-// @Success      200     {object}  SuccessResponseDoc{data=dto.OrderResponse}  "Order status updated successfully"
-// @Failure      400     {object}  ErrorResponseDoc  "Invalid request body or validation error"
-// @Failure      404     {object}  ErrorResponseDoc  "Order not found"
-// @Failure      500     {object}  ErrorResponseDoc  "Internal server error"
+// @Param        id        path      string                        true   "Order ID (MongoDB ObjectID)"
+// @Param        status    body      dto.UpdateOrderStatusRequest  true   "New status"
+// @Param        If-Match  header    string                        false  "ETag of the order read; required when api.require_if_match is enabled"
+// @Success      200       {object}  SuccessResponseDoc{data=dto.OrderResponse}  "Order status updated successfully"
+// @Header       200       {string}  ETag  "Order version"
+// @Failure      400       {object}  Problem  "Invalid request body or validation error"
+// @Failure      401       {object}  Problem  "Missing or invalid bearer token"
+// @Failure      403       {object}  Problem  "Role not allowed"
+// @Failure      404       {object}  Problem  "Order not found"
+// @Failure      409       {object}  Problem  "Order marked enviado before every item is shipped"
+// @Failure      412       {object}  Problem  "Order changed since the If-Match version"
+// @Failure      428       {object}  Problem  "If-Match header required"
+// @Failure      500       {object}  Problem  "Internal server error"
+// @Security     BearerAuth
 // @Router       /orders/{id}/status [patch]
 func (h *OrderHandler) UpdateOrderStatus(c *gin.Context) {
 	id := c.Param("id")
@@ -125,18 +182,19 @@ func (h *OrderHandler) UpdateOrderStatus(c *gin.Context) {
 		return
 	}
 
-	order, err := h.useCase.UpdateOrderStatus(c.Request.Context(), id, &req)
+	ifMatch, err := ifMatchVersion(c)
 	if err != nil {
-		h.logger.Error("Failed to update order status", zap.Error(err), zap.String("id", id))
-
-		if httpErr, ok := GetHTTPError(err); ok {
-			ErrorResponse(c, httpErr.Code, httpErr, httpErr.Message)
-			return
-		}
+		ErrorResponse(c, err)
+		return
+	}
 
-		ErrorResponse(c, http.StatusInternalServerError, err, "Failed to update order status")
+	order, err := h.useCase.UpdateOrderStatus(c.Request.Context(), id, &req, ifMatch)
+	if err != nil {
+		h.logger.Error("Failed to update order status", zap.Error(err), zap.String("id", id))
+		ErrorResponse(c, err)
 		return
 	}
 
+	setETag(c, order.Version)
 	SuccessResponse(c, http.StatusOK, order, "Order status updated successfully")
 }
@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/gvillela7/rank-my-app/internal/core/dto"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.uber.org/zap"
+)
+
+type PriceHandler struct {
+	useCase   ports.PriceUseCase
+	validator *validator.Validate
+	logger    *zap.Logger
+}
+
+func NewPriceHandler(useCase ports.PriceUseCase, validator *validator.Validate, logger *zap.Logger) *PriceHandler {
+	return &PriceHandler{
+		useCase:   useCase,
+		validator: validator,
+		logger:    logger,
+	}
+}
+
+// ChangePrice godoc
+// @Summary      Change or schedule a product price
+// @Description  Changes the product base price right away, or schedules the change when effective_at is in the future. Scheduled changes are applied by the price scheduler and already used by orders created after effective_at.
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Param        id        path      string                  true   "Product ID (MongoDB ObjectID)"
+// @Param        change    body      dto.PriceChangeRequest  true   "Price change"
+// @Param        If-Match  header    string                  false  "ETag of the product read; required when api.require_if_match is enabled"
+// @Success      201       {object}  SuccessResponseDoc{data=dto.PriceChangeResponse}  "Price change registered successfully"
+// @Failure      400       {object}  Problem  "Invalid request body or validation error"
+// @Failure      401       {object}  Problem  "Missing or invalid bearer token"
+// @Failure      403       {object}  Problem  "Role not allowed"
+// @Failure      404       {object}  Problem  "Product not found"
+// @Failure      412       {object}  Problem  "Product changed since the If-Match version"
+// @Failure      428       {object}  Problem  "If-Match header required"
+// @Failure      500       {object}  Problem  "Internal server error"
+// @Security     BearerAuth
+// @Router       /products/{id}/prices [post]
+func (h *PriceHandler) ChangePrice(c *gin.Context) {
+	productID := c.Param("id")
+	var req dto.PriceChangeRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind JSON", zap.Error(err))
+		ValidationErrorResponse(c, err)
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		h.logger.Error("Validation failed", zap.Error(err))
+		ValidationErrorResponse(c, err)
+		return
+	}
+
+	ifMatch, err := ifMatchVersion(c)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	change, err := h.useCase.ChangePrice(c.Request.Context(), productID, &req, ifMatch)
+	if err != nil {
+		h.logger.Error("Failed to change price", zap.Error(err), zap.String("product_id", productID))
+		ErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusCreated, change, "Price change registered successfully")
+}
+
+// ListPrices godoc
+// @Summary      List product price history
+// @Description  Lists the applied, scheduled and cancelled price changes of a product, latest effective first
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Product ID (MongoDB ObjectID)"
+// @Success      200  {object}  SuccessResponseDoc{data=[]dto.PriceChangeResponse}  "Price history retrieved successfully"
+// @Failure      401  {object}  Problem  "Missing or invalid bearer token or API key"
+// @Failure      403  {object}  Problem  "API key scope not allowed"
+// @Failure      404  {object}  Problem  "Product not found"
+// @Failure      500  {object}  Problem  "Internal server error"
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /products/{id}/prices [get]
+func (h *PriceHandler) ListPrices(c *gin.Context) {
+	productID := c.Param("id")
+
+	changes, err := h.useCase.ListPrices(c.Request.Context(), productID)
+	if err != nil {
+		h.logger.Error("Failed to list prices", zap.Error(err), zap.String("product_id", productID))
+		ErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, changes, "Price history retrieved successfully")
+}
+
+// CancelPriceChange godoc
+// @Summary      Cancel a scheduled price change
+// @Description  Cancels a price change that is still scheduled
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Param        id         path      string  true  "Product ID (MongoDB ObjectID)"
+// @Param        change_id  path      string  true  "Price change ID (MongoDB ObjectID)"
+// @Success      200        {object}  SuccessResponseDoc{data=dto.PriceChangeResponse}  "Price change cancelled successfully"
+// @Failure      401        {object}  Problem  "Missing or invalid bearer token"
+// @Failure      403        {object}  Problem  "Role not allowed"
+// @Failure      404        {object}  Problem  "Price change not found"
+// @Failure      409        {object}  Problem  "Price change already applied or cancelled"
+// @Failure      500        {object}  Problem  "Internal server error"
+// @Security     BearerAuth
+// @Router       /products/{id}/prices/{change_id} [delete]
+func (h *PriceHandler) CancelPriceChange(c *gin.Context) {
+	productID := c.Param("id")
+	changeID := c.Param("change_id")
+
+	change, err := h.useCase.CancelPriceChange(c.Request.Context(), productID, changeID)
+	if err != nil {
+		h.logger.Error("Failed to cancel price change", zap.Error(err), zap.String("product_id", productID), zap.String("change_id", changeID))
+		ErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, change, "Price change cancelled successfully")
+}
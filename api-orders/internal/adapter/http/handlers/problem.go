@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"github.com/gvillela7/rank-my-app/internal/adapter/http/validation"
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+)
+
+// ProblemContentType is the media type of error responses (RFC 7807)
+const ProblemContentType = "application/problem+json"
+
+// problemTypePrefix turns a catalog code into the problem type URI
+const problemTypePrefix = "urn:rank-my-app:problem:"
+
+// Problem is an error response in the RFC 7807 format. Code is the stable code of
+// the error catalog, also carried by Type; Errors lists the invalid fields of a
+// request that failed validation.
+type Problem struct {
+	Type      string       `json:"type" example:"urn:rank-my-app:problem:insufficient_stock"`
+	Title     string       `json:"title" example:"Insufficient stock"`
+	Status    int          `json:"status" example:"409"`
+	Detail    string       `json:"detail,omitempty" example:"Insufficient stock for product Mouse Gamer"`
+	Instance  string       `json:"instance,omitempty" example:"/api/v1/orders"`
+	Code      string       `json:"code" example:"insufficient_stock"`
+	RequestID string       `json:"request_id,omitempty" example:"4f9c2d7e8a1b3c5d6e7f8091a2b3c4d5"`
+	Errors    []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError is a field of the request that broke a validation rule. Field is the
+// path of the field in the JSON body or the name of the query parameter; Message is
+// in the language of the Accept-Language header.
+type FieldError struct {
+	Field   string `json:"field" example:"items[0].quantity"`
+	Rule    string `json:"rule" example:"gt"`
+	Param   string `json:"param,omitempty" example:"0"`
+	Message string `json:"message" example:"quantity deve ser maior do que 0"`
+}
+
+// translatorKey holds in the gin context the translator of the request language
+const translatorKey = "translator"
+
+// SetTranslator sets the language of the validation messages of the request
+func SetTranslator(c *gin.Context, translator ut.Translator) {
+	c.Set(translatorKey, translator)
+}
+
+func translatorOf(c *gin.Context) (ut.Translator, bool) {
+	translator, ok := c.Get(translatorKey)
+	if !ok {
+		return nil, false
+	}
+	t, ok := translator.(ut.Translator)
+	return t, ok
+}
+
+var problemStatus = map[domain.ErrorKind]int{
+	domain.KindInternal:             http.StatusInternalServerError,
+	domain.KindInvalid:              http.StatusBadRequest,
+	domain.KindUnauthenticated:      http.StatusUnauthorized,
+	domain.KindForbidden:            http.StatusForbidden,
+	domain.KindNotFound:             http.StatusNotFound,
+	domain.KindConflict:             http.StatusConflict,
+	domain.KindPreconditionFailed:   http.StatusPreconditionFailed,
+	domain.KindPreconditionRequired: http.StatusPreconditionRequired,
+	domain.KindRateLimited:          http.StatusTooManyRequests,
+	domain.KindUnsupportedMediaType: http.StatusUnsupportedMediaType,
+}
+
+// NewProblem describes err for the client. Errors outside the catalog are reported
+// as internal errors, without their message.
+func NewProblem(c *gin.Context, err error) *Problem {
+	entry, detail, ok := domain.Describe(err)
+	if !ok {
+		entry, detail = domain.ErrInternal, ""
+	}
+	if detail == entry.Title {
+		detail = ""
+	}
+
+	status, ok := problemStatus[entry.Kind]
+	if !ok {
+		status = http.StatusInternalServerError
+	}
+
+	problem := &Problem{
+		Type:     problemTypePrefix + entry.Code,
+		Title:    entry.Title,
+		Status:   status,
+		Detail:   detail,
+		Instance: c.Request.URL.Path,
+		Code:     entry.Code,
+	}
+	if info, ok := domain.RequestInfoFromContext(c.Request.Context()); ok {
+		problem.RequestID = info.ID
+	}
+	return problem
+}
+
+// WriteProblem sends the problem and aborts the remaining handlers
+func WriteProblem(c *gin.Context, problem *Problem) {
+	c.Header("Content-Type", ProblemContentType)
+	c.AbortWithStatusJSON(problem.Status, problem)
+}
+
+// ErrorResponse sends err as a problem. Use cases report failures the client can
+// act upon with the errors of the domain catalog; any other error is internal.
+func ErrorResponse(c *gin.Context, err error) {
+	WriteProblem(c, NewProblem(c, err))
+}
+
+// ValidationErrorResponse sends a request that could not be bound or failed
+// validation as a problem listing its invalid fields. Other binding errors, whose
+// message may describe the server, are sent as the generic invalid request and
+// attached to the context for the request log.
+func ValidationErrorResponse(c *gin.Context, err error) {
+	var (
+		validationErrs validator.ValidationErrors
+		typeErr        *json.UnmarshalTypeError
+		syntaxErr      *json.SyntaxError
+	)
+
+	switch {
+	case errors.As(err, &validationErrs):
+		problem := NewProblem(c, domain.ErrValidationFailed)
+		problem.Errors = fieldErrors(c, validationErrs)
+		WriteProblem(c, problem)
+	case errors.As(err, &typeErr):
+		fieldErr := FieldError{Field: typeErr.Field, Rule: "type", Param: typeErr.Type.String()}
+		if translator, ok := translatorOf(c); ok {
+			fieldErr.Message = validation.TypeMessage(translator, typeErr.Field, fieldErr.Param)
+		}
+
+		problem := NewProblem(c, domain.ErrValidationFailed)
+		problem.Errors = []FieldError{fieldErr}
+		WriteProblem(c, problem)
+	case errors.As(err, &syntaxErr):
+		ErrorResponse(c, domain.ErrInvalidRequest.WithDetail("Request body is not valid JSON"))
+	default:
+		_ = c.Error(err)
+		ErrorResponse(c, domain.ErrInvalidRequest)
+	}
+}
+
+// fieldErrors describes the fields that failed validation, with messages in the
+// language of the request
+func fieldErrors(c *gin.Context, validationErrs validator.ValidationErrors) []FieldError {
+	translator, translate := translatorOf(c)
+
+	fieldErrs := make([]FieldError, len(validationErrs))
+	for i, fieldErr := range validationErrs {
+		fieldErrs[i] = FieldError{
+			Field:   fieldPath(fieldErr.Namespace()),
+			Rule:    fieldErr.Tag(),
+			Param:   fieldErr.Param(),
+			Message: fieldErr.Error(),
+		}
+		if translate {
+			fieldErrs[i].Message = validation.Message(translator, fieldErr)
+		}
+	}
+	return fieldErrs
+}
+
+// validationMessage joins the messages of the fields that failed validation
+func validationMessage(c *gin.Context, err error) string {
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return err.Error()
+	}
+
+	fieldErrs := fieldErrors(c, validationErrs)
+	messages := make([]string, len(fieldErrs))
+	for i, fieldErr := range fieldErrs {
+		messages[i] = fieldErr.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+// fieldPath drops the request struct from a validator namespace, leaving the path
+// of the field within the request
+func fieldPath(namespace string) string {
+	if _, path, ok := strings.Cut(namespace, "."); ok {
+		return path
+	}
+	return namespace
+}
@@ -0,0 +1,31 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gvillela7/rank-my-app/internal/adapter/http/handlers"
+)
+
+func TestValidationErrorResponse_HidesUnknownBindingErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/orders", nil)
+
+	handlers.ValidationErrorResponse(c, errors.New("read tcp 10.0.0.5:8000: i/o timeout"))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", rec.Code)
+	}
+	if body := rec.Body.String(); strings.Contains(body, "10.0.0.5") || !strings.Contains(body, `"code":"invalid_request"`) {
+		t.Errorf("Expected the generic invalid_request problem, got %s", body)
+	}
+	if len(c.Errors) != 1 {
+		t.Errorf("Expected the raw error kept for the request log, got %v", c.Errors)
+	}
+}
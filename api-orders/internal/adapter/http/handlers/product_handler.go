@@ -1,10 +1,15 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
 	"github.com/gvillela7/rank-my-app/internal/core/dto"
 	"github.com/gvillela7/rank-my-app/internal/core/ports"
 	"go.uber.org/zap"
@@ -32,8 +37,11 @@ func NewProductHandler(useCase ports.ProductUseCase, validator *validator.Valida
 // @Produce      json
 // @Param        product  body      dto.CreateProductRequest  true  "Product information"
 // @Success      201      {object}  SuccessResponseDoc{data=dto.ProductResponse}  "Product created successfully"
-// @Failure      400      {object}  ErrorResponseDoc  "Invalid request body or validation error"
-// @Failure      500      {object}  ErrorResponseDoc  "Internal server error"
+// @Failure      400      {object}  Problem  "Invalid request body or validation error"
+// @Failure      401      {object}  Problem  "Missing or invalid bearer token"
+// @Failure      403      {object}  Problem  "Role not allowed"
+// @Failure      500      {object}  Problem  "Internal server error"
+// @Security     BearerAuth
 // @Router       /products [post]
 func (h *ProductHandler) CreateProduct(c *gin.Context) {
 	var req dto.CreateProductRequest
@@ -53,15 +61,273 @@ func (h *ProductHandler) CreateProduct(c *gin.Context) {
 	product, err := h.useCase.CreateProduct(c.Request.Context(), &req)
 	if err != nil {
 		h.logger.Error("Failed to create product", zap.Error(err))
+		ErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusCreated, product, "Product created successfully")
+}
+
+// GetProductByID godoc
+// @Summary      Get product by ID
+// @Description  Retrieves a product by its MongoDB ObjectID
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Product ID (MongoDB ObjectID)"
+// @Success      200  {object}  SuccessResponseDoc{data=dto.ProductResponse}  "Product retrieved successfully"
+// @Header       200  {string}  ETag  "Product version, to be sent back in If-Match"
+// @Failure      401  {object}  Problem  "Missing or invalid bearer token or API key"
+// @Failure      403  {object}  Problem  "API key scope not allowed"
+// @Failure      404  {object}  Problem  "Product not found"
+// @Failure      500  {object}  Problem  "Internal server error"
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /products/{id} [get]
+func (h *ProductHandler) GetProductByID(c *gin.Context) {
+	id := c.Param("id")
+
+	product, err := h.useCase.GetProductByID(c.Request.Context(), id)
+	if err != nil {
+		h.logger.Error("Failed to get product", zap.Error(err), zap.String("id", id))
+		ErrorResponse(c, err)
+		return
+	}
+
+	setETag(c, product.Version)
+	SuccessResponse(c, http.StatusOK, product, "Product retrieved successfully")
+}
+
+// SearchProducts godoc
+// @Summary      Search products
+// @Description  Searches products by text on name and description, filtering by category (including subcategories), tag, base price range and stock. The response carries the category and tag counts of all matching products.
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Param        q          query     string   false  "Text searched in name and description"
+// @Param        category   query     string   false  "Category ID (MongoDB ObjectID)"
+// @Param        tag        query     string   false  "Tag"
+// @Param        min_price  query     number   false  "Minimum price in the product base currency"
+// @Param        max_price  query     number   false  "Maximum price in the product base currency"
+// @Param        in_stock   query     boolean  false  "Only products with quantity greater than zero"
+// @Param        offset     query     int      false  "Number of products to skip"  default(0)
+// @Param        limit      query     int      false  "Page size (1-100)"  default(20)
+// @Success      200        {object}  SuccessResponseDoc{data=dto.ProductSearchResponse}  "Products retrieved successfully"
+// @Failure      400        {object}  Problem  "Invalid query parameters"
+// @Failure      401        {object}  Problem  "Missing or invalid bearer token or API key"
+// @Failure      403        {object}  Problem  "API key scope not allowed"
+// @Failure      500        {object}  Problem  "Internal server error"
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /products [get]
+func (h *ProductHandler) SearchProducts(c *gin.Context) {
+	var req dto.ProductSearchRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error("Failed to bind query", zap.Error(err))
+		ValidationErrorResponse(c, err)
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		h.logger.Error("Validation failed", zap.Error(err))
+		ValidationErrorResponse(c, err)
+		return
+	}
+
+	products, err := h.useCase.SearchProducts(c.Request.Context(), &req)
+	if err != nil {
+		h.logger.Error("Failed to search products", zap.Error(err))
+		ErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, products, "Products retrieved successfully")
+}
+
+// ImportProducts godoc
+// @Summary      Import products
+// @Description  Streams a CSV or NDJSON catalog, upserting every row by SKU. Each row is validated like the product creation; rejected rows are listed in the report and do not stop the import. The format comes from the format query parameter or the Content-Type (text/csv or application/x-ndjson).
+// @Tags         Products
+// @Accept       text/csv
+// @Accept       application/x-ndjson
+// @Produce      json
+// @Param        format  query     string  false  "Input format"  Enums(csv, ndjson)
+// @Param        rows    body      string  true   "CSV with header or one JSON product per line"
+// @Success      200     {object}  SuccessResponseDoc{data=dto.ProductImportReport}  "Products imported"
+// @Failure      400     {object}  Problem  "Invalid format or unreadable input"
+// @Failure      401     {object}  Problem  "Missing or invalid bearer token"
+// @Failure      403     {object}  Problem  "Role not allowed"
+// @Failure      415     {object}  Problem  "Unsupported content type"
+// @Security     BearerAuth
+// @Router       /products/import [post]
+func (h *ProductHandler) ImportProducts(c *gin.Context) {
+	var req dto.ProductTransferRequest
 
-		if httpErr, ok := GetHTTPError(err); ok {
-			ErrorResponse(c, httpErr.Code, httpErr, httpErr.Message)
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error("Failed to bind query", zap.Error(err))
+		ValidationErrorResponse(c, err)
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		h.logger.Error("Validation failed", zap.Error(err))
+		ValidationErrorResponse(c, err)
+		return
+	}
+
+	format := req.Format
+	if format == "" {
+		switch c.ContentType() {
+		case "text/csv":
+			format = dto.ProductFormatCSV
+		case "application/x-ndjson", "application/jsonl":
+			format = dto.ProductFormatNDJSON
+		default:
+			ErrorResponse(c, domain.ErrUnsupportedMediaType.Detailf("Content type %q is not supported; send text/csv or application/x-ndjson", c.ContentType()))
 			return
 		}
+	}
+
+	// a large file takes longer than the server read and write timeouts
+	clearDeadlines(c, h.logger)
 
-		ErrorResponse(c, http.StatusInternalServerError, err, "Failed to create product")
+	reader, err := newProductRowReader(format, c.Request.Body)
+	if err != nil {
+		h.logger.Error("Failed to read import", zap.Error(err))
+		ErrorResponse(c, domain.ErrInvalidRequest.Detailf("Failed to read import: %v", err))
 		return
 	}
 
-	SuccessResponse(c, http.StatusCreated, product, "Product created successfully")
+	report := dto.ProductImportReport{Errors: make([]dto.ProductImportError, 0)}
+	for {
+		row, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			h.logger.Error("Import interrupted", zap.Error(err), zap.Int("processed", report.Processed))
+			ErrorResponse(c, domain.ErrInvalidRequest.Detailf("Import interrupted after %d rows; the rows before it were imported: %v", report.Processed, err))
+			return
+		}
+
+		report.Processed++
+		created, err := h.importRow(c, row)
+		if err != nil {
+			report.Failed++
+			importErr := dto.ProductImportError{Line: row.Line, Error: err.Error()}
+			if entry, detail, ok := domain.Describe(err); ok {
+				importErr.Code, importErr.Error = entry.Code, detail
+			}
+			if row.Request != nil {
+				importErr.SKU = row.Request.SKU
+			}
+			report.Errors = append(report.Errors, importErr)
+			continue
+		}
+
+		if created {
+			report.Created++
+		} else {
+			report.Updated++
+		}
+	}
+
+	SuccessResponse(c, http.StatusOK, report, "Products imported")
+}
+
+// importRow validates and upserts a single import row
+func (h *ProductHandler) importRow(c *gin.Context, row *productRow) (bool, error) {
+	if row.Err != nil {
+		return false, row.Err
+	}
+
+	if err := h.validator.Struct(row.Request); err != nil {
+		return false, errors.New(validationMessage(c, err))
+	}
+
+	created, err := h.useCase.ImportProduct(c.Request.Context(), row.Request)
+	if err != nil {
+		if _, _, ok := domain.Describe(err); ok {
+			return false, err
+		}
+		h.logger.Error("Failed to import product", zap.Error(err), zap.Int("line", row.Line))
+		return false, errors.New("failed to import product")
+	}
+
+	return created, nil
+}
+
+// ExportProducts godoc
+// @Summary      Export products
+// @Description  Streams the whole catalog as CSV or NDJSON, in the same format accepted by the import. CSV has no columns for variants and prices in other currencies; use NDJSON to export them.
+// @Tags         Products
+// @Produce      text/csv
+// @Produce      application/x-ndjson
+// @Param        format  query     string  false  "Output format"  Enums(csv, ndjson)  default(ndjson)
+// @Success      200     {string}  string  "Product rows"
+// @Failure      400     {object}  Problem  "Invalid format"
+// @Failure      401     {object}  Problem  "Missing or invalid bearer token"
+// @Failure      403     {object}  Problem  "Role not allowed"
+// @Failure      500     {object}  Problem  "Internal server error"
+// @Security     BearerAuth
+// @Router       /products/export [get]
+func (h *ProductHandler) ExportProducts(c *gin.Context) {
+	var req dto.ProductTransferRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error("Failed to bind query", zap.Error(err))
+		ValidationErrorResponse(c, err)
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		h.logger.Error("Validation failed", zap.Error(err))
+		ValidationErrorResponse(c, err)
+		return
+	}
+
+	format := req.Format
+	contentType := "application/x-ndjson"
+	if format == dto.ProductFormatCSV {
+		contentType = "text/csv"
+	} else {
+		format = dto.ProductFormatNDJSON
+	}
+
+	// the catalog streams for longer than the server write timeout
+	clearDeadlines(c, h.logger)
+
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=products.%s", format))
+	c.Status(http.StatusOK)
+
+	writer, err := newProductRowWriter(format, c.Writer)
+	if err == nil {
+		err = h.useCase.ExportProducts(c.Request.Context(), writer.Write)
+	}
+	if err == nil {
+		err = writer.Flush()
+	}
+	if err != nil {
+		h.logger.Error("Failed to export products", zap.Error(err))
+
+		// once rows were streamed the status is sent and the export is just cut short
+		if !c.Writer.Written() {
+			c.Header("Content-Disposition", "")
+			ErrorResponse(c, err)
+		}
+	}
+}
+
+// clearDeadlines lifts the read and write deadlines the server set on the
+// connection of the request. Writers without deadlines, as in tests, are left alone.
+func clearDeadlines(c *gin.Context, logger *zap.Logger) {
+	controller := http.NewResponseController(c.Writer)
+	if err := controller.SetReadDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		logger.Warn("Failed to clear read deadline", zap.Error(err))
+	}
+	if err := controller.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		logger.Warn("Failed to clear write deadline", zap.Error(err))
+	}
 }
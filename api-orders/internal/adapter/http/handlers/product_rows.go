@@ -0,0 +1,275 @@
+package handlers
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gvillela7/rank-my-app/internal/core/dto"
+)
+
+// maxNDJSONLine caps the size of a single NDJSON row
+const maxNDJSONLine = 1024 * 1024
+
+// csvProductColumns are the columns of the CSV format, in export order. Tags and
+// attributes are separated by "|" and attributes are written as key=value.
+var csvProductColumns = []string{
+	"sku", "name", "description", "quantity", "price", "currency",
+	"tax_class", "reorder_threshold", "category_id", "tags", "attributes",
+}
+
+// productRow is a decoded row of an import. Err rejects only the row.
+type productRow struct {
+	Line    int
+	Request *dto.CreateProductRequest
+	Err     error
+}
+
+// productRowReader streams the rows of an import. It returns io.EOF at the end
+// of the input and any other error when the input cannot be read any further.
+type productRowReader interface {
+	Next() (*productRow, error)
+}
+
+// productRowWriter streams the rows of an export
+type productRowWriter interface {
+	Write(row *dto.CreateProductRequest) error
+	Flush() error
+}
+
+func newProductRowReader(format string, r io.Reader) (productRowReader, error) {
+	if format == dto.ProductFormatCSV {
+		return newCSVProductReader(r)
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxNDJSONLine)
+	return &ndjsonProductReader{scanner: scanner}, nil
+}
+
+func newProductRowWriter(format string, w io.Writer) (productRowWriter, error) {
+	if format == dto.ProductFormatCSV {
+		writer := csv.NewWriter(w)
+		if err := writer.Write(csvProductColumns); err != nil {
+			return nil, err
+		}
+		return &csvProductWriter{writer: writer}, nil
+	}
+	return &ndjsonProductWriter{writer: bufio.NewWriter(w)}, nil
+}
+
+type ndjsonProductReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func (r *ndjsonProductReader) Next() (*productRow, error) {
+	for r.scanner.Scan() {
+		r.line++
+		text := strings.TrimSpace(r.scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		row := &productRow{Line: r.line, Request: &dto.CreateProductRequest{}}
+		if err := json.Unmarshal([]byte(text), row.Request); err != nil {
+			row.Err = fmt.Errorf("invalid JSON: %w", err)
+		}
+		return row, nil
+	}
+
+	if err := r.scanner.Err(); err != nil {
+		return nil, fmt.Errorf("line %d: %w", r.line+1, err)
+	}
+	return nil, io.EOF
+}
+
+type csvProductReader struct {
+	reader  *csv.Reader
+	columns map[string]int
+}
+
+func newCSVProductReader(r io.Reader) (*csvProductReader, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, errors.New("empty CSV")
+		}
+		return nil, fmt.Errorf("invalid CSV header: %w", err)
+	}
+
+	known := make(map[string]bool, len(csvProductColumns))
+	for _, column := range csvProductColumns {
+		known[column] = true
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(column))
+		if !known[column] {
+			return nil, fmt.Errorf("unknown CSV column: %s", column)
+		}
+		columns[column] = i
+	}
+	for _, column := range []string{"sku", "name", "price"} {
+		if _, ok := columns[column]; !ok {
+			return nil, fmt.Errorf("missing CSV column: %s", column)
+		}
+	}
+
+	return &csvProductReader{reader: reader, columns: columns}, nil
+}
+
+func (r *csvProductReader) Next() (*productRow, error) {
+	record, err := r.reader.Read()
+	if err == io.EOF {
+		return nil, io.EOF
+	}
+
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return &productRow{Line: parseErr.Line, Err: err}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	line, _ := r.reader.FieldPos(0)
+	row := &productRow{Line: line}
+	row.Request, row.Err = r.decode(record)
+	return row, nil
+}
+
+func (r *csvProductReader) decode(record []string) (*dto.CreateProductRequest, error) {
+	field := func(column string) string {
+		if i, ok := r.columns[column]; ok {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	req := &dto.CreateProductRequest{
+		SKU:         field("sku"),
+		Name:        field("name"),
+		Description: field("description"),
+		Currency:    field("currency"),
+		TaxClass:    field("tax_class"),
+		CategoryID:  field("category_id"),
+	}
+
+	var err error
+	if req.Price, err = parseCSVFloat("price", field("price")); err != nil {
+		return nil, err
+	}
+	if req.Quantity, err = parseCSVInt("quantity", field("quantity")); err != nil {
+		return nil, err
+	}
+	if req.ReorderThreshold, err = parseCSVInt("reorder_threshold", field("reorder_threshold")); err != nil {
+		return nil, err
+	}
+
+	if tags := field("tags"); tags != "" {
+		req.Tags = strings.Split(tags, "|")
+	}
+
+	if attributes := field("attributes"); attributes != "" {
+		req.Attributes = make(map[string]string)
+		for _, pair := range strings.Split(attributes, "|") {
+			key, value, ok := strings.Cut(pair, "=")
+			if !ok {
+				return nil, fmt.Errorf("invalid attribute %q, expected key=value", pair)
+			}
+			req.Attributes[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+
+	return req, nil
+}
+
+func parseCSVInt(column, value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q", column, value)
+	}
+	return n, nil
+}
+
+func parseCSVFloat(column, value string) (float64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q", column, value)
+	}
+	return n, nil
+}
+
+// csvProductWriter writes products in the CSV format. Prices in other currencies
+// and variants have no CSV columns; the NDJSON format carries them.
+type csvProductWriter struct {
+	writer *csv.Writer
+}
+
+func (w *csvProductWriter) Write(row *dto.CreateProductRequest) error {
+	keys := make([]string, 0, len(row.Attributes))
+	for key := range row.Attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	attributes := make([]string, len(keys))
+	for i, key := range keys {
+		attributes[i] = key + "=" + row.Attributes[key]
+	}
+
+	return w.writer.Write([]string{
+		row.SKU,
+		row.Name,
+		row.Description,
+		strconv.Itoa(row.Quantity),
+		strconv.FormatFloat(row.Price, 'f', -1, 64),
+		row.Currency,
+		row.TaxClass,
+		strconv.Itoa(row.ReorderThreshold),
+		row.CategoryID,
+		strings.Join(row.Tags, "|"),
+		strings.Join(attributes, "|"),
+	})
+}
+
+func (w *csvProductWriter) Flush() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+type ndjsonProductWriter struct {
+	writer *bufio.Writer
+}
+
+func (w *ndjsonProductWriter) Write(row *dto.CreateProductRequest) error {
+	data, err := json.Marshal(row)
+	if err != nil {
+		return err
+	}
+	if _, err := w.writer.Write(data); err != nil {
+		return err
+	}
+	return w.writer.WriteByte('\n')
+}
+
+func (w *ndjsonProductWriter) Flush() error {
+	return w.writer.Flush()
+}
@@ -1,8 +1,6 @@
 package handlers
 
 import (
-	"net/http"
-
 	"github.com/gin-gonic/gin"
 )
 
@@ -10,7 +8,6 @@ type APIResponse struct {
 	Success bool        `json:"success"`
 	Data    interface{} `json:"data,omitempty"`
 	Message string      `json:"message,omitempty"`
-	Error   string      `json:"error,omitempty"`
 }
 
 // SuccessResponseDoc represents a successful API response for Swagger documentation
@@ -20,13 +17,6 @@ type SuccessResponseDoc struct {
 	Message string      `json:"message" example:"Operation successful"`
 }
 
-// ErrorResponseDoc represents an error API response for Swagger documentation
-type ErrorResponseDoc struct {
-	Success bool   `json:"success" example:"false"`
-	Error   string `json:"error" example:"Detailed error message"`
-	Message string `json:"message" example:"User-friendly error message"`
-}
-
 func SuccessResponse(c *gin.Context, statusCode int, data interface{}, message string) {
 	c.JSON(statusCode, APIResponse{
 		Success: true,
@@ -34,19 +24,3 @@ func SuccessResponse(c *gin.Context, statusCode int, data interface{}, message s
 		Message: message,
 	})
 }
-
-func ErrorResponse(c *gin.Context, statusCode int, err error, message string) {
-	c.JSON(statusCode, APIResponse{
-		Success: false,
-		Error:   err.Error(),
-		Message: message,
-	})
-}
-
-func ValidationErrorResponse(c *gin.Context, err error) {
-	c.JSON(http.StatusBadRequest, APIResponse{
-		Success: false,
-		Error:   err.Error(),
-		Message: "Validation failed",
-	})
-}
@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/gvillela7/rank-my-app/internal/core/dto"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.uber.org/zap"
+)
+
+type ReturnHandler struct {
+	useCase   ports.ReturnUseCase
+	validator *validator.Validate
+	logger    *zap.Logger
+}
+
+func NewReturnHandler(useCase ports.ReturnUseCase, validator *validator.Validate, logger *zap.Logger) *ReturnHandler {
+	return &ReturnHandler{
+		useCase:   useCase,
+		validator: validator,
+		logger:    logger,
+	}
+}
+
+// RequestReturn godoc
+// @Summary      Request a return
+// @Description  Opens a return (RMA) for lines of a delivered order with reason and quantity. Refund amounts are computed from the prices captured on the order, including exclusive taxes and prorated discounts.
+// @Tags         Returns
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string                   true  "Order ID (MongoDB ObjectID)"
+// @Param        return  body      dto.CreateReturnRequest  true  "Returned items"
+// @Success      201     {object}  SuccessResponseDoc{data=dto.ReturnResponse}  "Return requested successfully"
+// @Failure      400     {object}  Problem  "Invalid request body, order status or quantities"
+// @Failure      401     {object}  Problem  "Missing or invalid bearer token"
+// @Failure      403     {object}  Problem  "Role not allowed"
+// @Failure      404     {object}  Problem  "Order not found"
+// @Failure      500     {object}  Problem  "Internal server error"
+// @Security     BearerAuth
+// @Router       /orders/{id}/returns [post]
+func (h *ReturnHandler) RequestReturn(c *gin.Context) {
+	orderID := c.Param("id")
+	var req dto.CreateReturnRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind JSON", zap.Error(err))
+		ValidationErrorResponse(c, err)
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		h.logger.Error("Validation failed", zap.Error(err))
+		ValidationErrorResponse(c, err)
+		return
+	}
+
+	ret, err := h.useCase.RequestReturn(c.Request.Context(), orderID, &req)
+	if err != nil {
+		h.logger.Error("Failed to request return", zap.Error(err), zap.String("order_id", orderID))
+		ErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusCreated, ret, "Return requested successfully")
+}
+
+// ListReturns godoc
+// @Summary      List order returns
+// @Description  Lists the returns of an order
+// @Tags         Returns
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Order ID (MongoDB ObjectID)"
+// @Success      200  {object}  SuccessResponseDoc{data=[]dto.ReturnResponse}  "Returns retrieved successfully"
+// @Failure      401  {object}  Problem  "Missing or invalid bearer token"
+// @Failure      403  {object}  Problem  "Role not allowed"
+// @Failure      404  {object}  Problem  "Order not found"
+// @Failure      500  {object}  Problem  "Internal server error"
+// @Security     BearerAuth
+// @Router       /orders/{id}/returns [get]
+func (h *ReturnHandler) ListReturns(c *gin.Context) {
+	orderID := c.Param("id")
+
+	returns, err := h.useCase.ListReturns(c.Request.Context(), orderID)
+	if err != nil {
+		h.logger.Error("Failed to list returns", zap.Error(err), zap.String("order_id", orderID))
+		ErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, returns, "Returns retrieved successfully")
+}
+
+// ApproveReturn godoc
+// @Summary      Approve a return
+// @Description  Approves a pending return, restocks the returned items and publishes the event that triggers the refund
+// @Tags         Returns
+// @Accept       json
+// @Produce      json
+// @Param        id        path      string  true  "Order ID (MongoDB ObjectID)"
+// @Param        returnId  path      string  true  "Return ID (MongoDB ObjectID)"
+// @Success      200       {object}  SuccessResponseDoc{data=dto.ReturnResponse}  "Return approved successfully"
+// @Failure      401       {object}  Problem  "Missing or invalid bearer token"
+// @Failure      403       {object}  Problem  "Role not allowed"
+// @Failure      404       {object}  Problem  "Order or return not found"
+// @Failure      409       {object}  Problem  "Return already resolved"
+// @Failure      500       {object}  Problem  "Internal server error"
+// @Security     BearerAuth
+// @Router       /orders/{id}/returns/{returnId}/approve [post]
+func (h *ReturnHandler) ApproveReturn(c *gin.Context) {
+	orderID := c.Param("id")
+	returnID := c.Param("returnId")
+
+	ret, err := h.useCase.ApproveReturn(c.Request.Context(), orderID, returnID)
+	if err != nil {
+		h.logger.Error("Failed to approve return", zap.Error(err), zap.String("order_id", orderID), zap.String("return_id", returnID))
+		ErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, ret, "Return approved successfully")
+}
+
+// RejectReturn godoc
+// @Summary      Reject a return
+// @Description  Rejects a pending return with a reason
+// @Tags         Returns
+// @Accept       json
+// @Produce      json
+// @Param        id         path      string                   true  "Order ID (MongoDB ObjectID)"
+// @Param        returnId   path      string                   true  "Return ID (MongoDB ObjectID)"
+// @Param        rejection  body      dto.RejectReturnRequest  true  "Rejection reason"
+// @Success      200        {object}  SuccessResponseDoc{data=dto.ReturnResponse}  "Return rejected successfully"
+// @Failure      400        {object}  Problem  "Invalid request body or validation error"
+// @Failure      401        {object}  Problem  "Missing or invalid bearer token"
+// @Failure      403        {object}  Problem  "Role not allowed"
+// @Failure      404        {object}  Problem  "Order or return not found"
+// @Failure      409        {object}  Problem  "Return already resolved"
+// @Failure      500        {object}  Problem  "Internal server error"
+// @Security     BearerAuth
+// @Router       /orders/{id}/returns/{returnId}/reject [post]
+func (h *ReturnHandler) RejectReturn(c *gin.Context) {
+	orderID := c.Param("id")
+	returnID := c.Param("returnId")
+	var req dto.RejectReturnRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind JSON", zap.Error(err))
+		ValidationErrorResponse(c, err)
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		h.logger.Error("Validation failed", zap.Error(err))
+		ValidationErrorResponse(c, err)
+		return
+	}
+
+	ret, err := h.useCase.RejectReturn(c.Request.Context(), orderID, returnID, &req)
+	if err != nil {
+		h.logger.Error("Failed to reject return", zap.Error(err), zap.String("order_id", orderID), zap.String("return_id", returnID))
+		ErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, ret, "Return rejected successfully")
+}
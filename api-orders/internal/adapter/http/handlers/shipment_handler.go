@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/gvillela7/rank-my-app/internal/core/dto"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.uber.org/zap"
+)
+
+type ShipmentHandler struct {
+	useCase   ports.ShipmentUseCase
+	validator *validator.Validate
+	logger    *zap.Logger
+}
+
+func NewShipmentHandler(useCase ports.ShipmentUseCase, validator *validator.Validate, logger *zap.Logger) *ShipmentHandler {
+	return &ShipmentHandler{
+		useCase:   useCase,
+		validator: validator,
+		logger:    logger,
+	}
+}
+
+// CreateShipment godoc
+// @Summary      Ship order items
+// @Description  Registers a shipment with carrier, tracking code and shipped items, moving the order to "enviado". Partial shipments are supported; when items is empty every remaining unit is shipped. The order must be "em_processamento" or "enviado".
+// @Tags         Shipments
+// @Accept       json
+// @Produce      json
+// @Param        id        path      string                     true  "Order ID (MongoDB ObjectID)"
+// @Param        shipment  body      dto.CreateShipmentRequest  true  "Shipment information"
+// @Success      201       {object}  SuccessResponseDoc{data=dto.ShipmentResponse}  "Shipment created successfully"
+// @Failure      400       {object}  Problem  "Invalid request body, order status or quantities"
+// @Failure      401       {object}  Problem  "Missing or invalid bearer token"
+// @Failure      403       {object}  Problem  "Role not allowed"
+// @Failure      404       {object}  Problem  "Order not found"
+// @Failure      500       {object}  Problem  "Internal server error"
+// @Security     BearerAuth
+// @Router       /orders/{id}/shipments [post]
+func (h *ShipmentHandler) CreateShipment(c *gin.Context) {
+	orderID := c.Param("id")
+	var req dto.CreateShipmentRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind JSON", zap.Error(err))
+		ValidationErrorResponse(c, err)
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		h.logger.Error("Validation failed", zap.Error(err))
+		ValidationErrorResponse(c, err)
+		return
+	}
+
+	shipment, err := h.useCase.CreateShipment(c.Request.Context(), orderID, &req)
+	if err != nil {
+		h.logger.Error("Failed to create shipment", zap.Error(err), zap.String("order_id", orderID))
+		ErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusCreated, shipment, "Shipment created successfully")
+}
+
+// ListShipments godoc
+// @Summary      List order shipments
+// @Description  Lists the shipments of an order in the order they were shipped
+// @Tags         Shipments
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Order ID (MongoDB ObjectID)"
+// @Success      200  {object}  SuccessResponseDoc{data=[]dto.ShipmentResponse}  "Shipments retrieved successfully"
+// @Failure      401  {object}  Problem  "Missing or invalid bearer token"
+// @Failure      403  {object}  Problem  "Role not allowed"
+// @Failure      404  {object}  Problem  "Order not found"
+// @Failure      500  {object}  Problem  "Internal server error"
+// @Security     BearerAuth
+// @Router       /orders/{id}/shipments [get]
+func (h *ShipmentHandler) ListShipments(c *gin.Context) {
+	orderID := c.Param("id")
+
+	shipments, err := h.useCase.ListShipments(c.Request.Context(), orderID)
+	if err != nil {
+		h.logger.Error("Failed to list shipments", zap.Error(err), zap.String("order_id", orderID))
+		ErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, shipments, "Shipments retrieved successfully")
+}
+
+// MarkDelivered godoc
+// @Summary      Confirm shipment delivery
+// @Description  Records the delivery of a shipment with receiver and optional proof. The order moves to "entregue" once every item was shipped and every shipment delivered.
+// @Tags         Shipments
+// @Accept       json
+// @Produce      json
+// @Param        id          path      string                      true  "Order ID (MongoDB ObjectID)"
+// @Param        shipmentId  path      string                      true  "Shipment ID (MongoDB ObjectID)"
+// @Param        delivery    body      dto.DeliverShipmentRequest  true  "Delivery information"
+// @Success      200         {object}  SuccessResponseDoc{data=dto.ShipmentResponse}  "Shipment delivered successfully"
+// @Failure      400         {object}  Problem  "Invalid request body or validation error"
+// @Failure      401         {object}  Problem  "Missing or invalid bearer token"
+// @Failure      403         {object}  Problem  "Role not allowed"
+// @Failure      404         {object}  Problem  "Order or shipment not found"
+// @Failure      409         {object}  Problem  "Shipment already delivered"
+// @Failure      500         {object}  Problem  "Internal server error"
+// @Security     BearerAuth
+// @Router       /orders/{id}/shipments/{shipmentId}/delivery [patch]
+func (h *ShipmentHandler) MarkDelivered(c *gin.Context) {
+	orderID := c.Param("id")
+	shipmentID := c.Param("shipmentId")
+	var req dto.DeliverShipmentRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind JSON", zap.Error(err))
+		ValidationErrorResponse(c, err)
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		h.logger.Error("Validation failed", zap.Error(err))
+		ValidationErrorResponse(c, err)
+		return
+	}
+
+	shipment, err := h.useCase.MarkDelivered(c.Request.Context(), orderID, shipmentID, &req)
+	if err != nil {
+		h.logger.Error("Failed to deliver shipment", zap.Error(err), zap.String("order_id", orderID), zap.String("shipment_id", shipmentID))
+		ErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, shipment, "Shipment delivered successfully")
+}
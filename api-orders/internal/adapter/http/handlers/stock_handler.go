@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/gvillela7/rank-my-app/internal/core/dto"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.uber.org/zap"
+)
+
+type StockHandler struct {
+	useCase   ports.StockUseCase
+	validator *validator.Validate
+	logger    *zap.Logger
+}
+
+func NewStockHandler(useCase ports.StockUseCase, validator *validator.Validate, logger *zap.Logger) *StockHandler {
+	return &StockHandler{
+		useCase:   useCase,
+		validator: validator,
+		logger:    logger,
+	}
+}
+
+// AdjustStock godoc
+// @Summary      Adjust product stock
+// @Description  Adds (positive quantity) or removes (negative quantity) units of a product, recording a manual adjustment in the stock ledger with the reason and the authenticated caller as actor
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Param        id          path      string                      true  "Product ID (MongoDB ObjectID)"
+// @Param        adjustment  body      dto.StockAdjustmentRequest  true  "Stock adjustment"
+// @Success      201         {object}  SuccessResponseDoc{data=dto.StockMovementResponse}  "Stock adjusted successfully"
+// @Failure      400         {object}  Problem  "Invalid request body or stock would become negative"
+// @Failure      401         {object}  Problem  "Missing or invalid bearer token"
+// @Failure      403         {object}  Problem  "Role not allowed"
+// @Failure      404         {object}  Problem  "Product not found"
+// @Failure      500         {object}  Problem  "Internal server error"
+// @Security     BearerAuth
+// @Router       /products/{id}/stock-adjustments [post]
+func (h *StockHandler) AdjustStock(c *gin.Context) {
+	productID := c.Param("id")
+	var req dto.StockAdjustmentRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind JSON", zap.Error(err))
+		ValidationErrorResponse(c, err)
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		h.logger.Error("Validation failed", zap.Error(err))
+		ValidationErrorResponse(c, err)
+		return
+	}
+
+	movement, err := h.useCase.AdjustStock(c.Request.Context(), productID, &req)
+	if err != nil {
+		h.logger.Error("Failed to adjust stock", zap.Error(err), zap.String("product_id", productID))
+		ErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusCreated, movement, "Stock adjusted successfully")
+}
+
+// SetReorderThreshold godoc
+// @Summary      Set product reorder threshold
+// @Description  Sets the quantity at or below which a product.low_stock event is published. Zero disables the low stock alert; product.out_of_stock is always published.
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Param        id         path      string                       true   "Product ID (MongoDB ObjectID)"
+// @Param        threshold  body      dto.ReorderThresholdRequest  true   "Reorder threshold"
+// @Param        If-Match   header    string                       false  "ETag of the product read; required when api.require_if_match is enabled"
+// @Success      200        {object}  SuccessResponseDoc{data=dto.ProductResponse}  "Reorder threshold updated successfully"
+// @Header       200        {string}  ETag  "Product version"
+// @Failure      400        {object}  Problem  "Invalid request body or validation error"
+// @Failure      401        {object}  Problem  "Missing or invalid bearer token"
+// @Failure      403        {object}  Problem  "Role not allowed"
+// @Failure      404        {object}  Problem  "Product not found"
+// @Failure      412        {object}  Problem  "Product changed since the If-Match version"
+// @Failure      428        {object}  Problem  "If-Match header required"
+// @Failure      500        {object}  Problem  "Internal server error"
+// @Security     BearerAuth
+// @Router       /products/{id}/reorder-threshold [put]
+func (h *StockHandler) SetReorderThreshold(c *gin.Context) {
+	productID := c.Param("id")
+	var req dto.ReorderThresholdRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind JSON", zap.Error(err))
+		ValidationErrorResponse(c, err)
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		h.logger.Error("Validation failed", zap.Error(err))
+		ValidationErrorResponse(c, err)
+		return
+	}
+
+	ifMatch, err := ifMatchVersion(c)
+	if err != nil {
+		ErrorResponse(c, err)
+		return
+	}
+
+	product, err := h.useCase.SetReorderThreshold(c.Request.Context(), productID, &req, ifMatch)
+	if err != nil {
+		h.logger.Error("Failed to set reorder threshold", zap.Error(err), zap.String("product_id", productID))
+		ErrorResponse(c, err)
+		return
+	}
+
+	setETag(c, product.Version)
+	SuccessResponse(c, http.StatusOK, product, "Reorder threshold updated successfully")
+}
+
+// ListMovements godoc
+// @Summary      List stock movements
+// @Description  Lists the stock ledger of a product, most recent first
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Product ID (MongoDB ObjectID)"
+// @Success      200  {object}  SuccessResponseDoc{data=[]dto.StockMovementResponse}  "Stock movements retrieved successfully"
+// @Failure      401  {object}  Problem  "Missing or invalid bearer token"
+// @Failure      403  {object}  Problem  "Role not allowed"
+// @Failure      404  {object}  Problem  "Product not found"
+// @Failure      500  {object}  Problem  "Internal server error"
+// @Security     BearerAuth
+// @Router       /products/{id}/stock-movements [get]
+func (h *StockHandler) ListMovements(c *gin.Context) {
+	productID := c.Param("id")
+
+	movements, err := h.useCase.ListMovements(c.Request.Context(), productID)
+	if err != nil {
+		h.logger.Error("Failed to list stock movements", zap.Error(err), zap.String("product_id", productID))
+		ErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, movements, "Stock movements retrieved successfully")
+}
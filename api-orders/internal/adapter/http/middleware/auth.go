@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gvillela7/rank-my-app/internal/adapter/http/handlers"
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.uber.org/zap"
+)
+
+// Authenticate requires a valid bearer token, or an X-API-Key header when keys is
+// not nil, and stores the principal in the request context, where handlers and use
+// cases read it with domain.PrincipalFromContext
+func Authenticate(verifier ports.TokenVerifier, keys ports.APIKeyAuthenticator, logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if key := c.GetHeader("X-API-Key"); key != "" && keys != nil {
+			principal, err := keys.Authenticate(c.Request.Context(), key)
+			if err != nil {
+				logger.Warn("Rejected API key", zap.Error(err), zap.String("path", c.FullPath()))
+				unauthorized(c, domain.ErrInvalidAPIKey)
+				return
+			}
+
+			c.Request = c.Request.WithContext(domain.ContextWithPrincipal(c.Request.Context(), principal))
+			c.Next()
+			return
+		}
+
+		scheme, token, ok := strings.Cut(c.GetHeader("Authorization"), " ")
+		if !ok || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+			unauthorized(c, errMissingCredentials)
+			return
+		}
+
+		principal, err := verifier.Verify(c.Request.Context(), strings.TrimSpace(token))
+		if err != nil {
+			logger.Warn("Rejected bearer token", zap.Error(err), zap.String("path", c.FullPath()))
+			unauthorized(c, domain.ErrInvalidToken)
+			return
+		}
+
+		c.Request = c.Request.WithContext(domain.ContextWithPrincipal(c.Request.Context(), principal))
+		c.Next()
+	}
+}
+
+// RequireRole rejects with 403 Forbidden the principals without one of the roles.
+// Admins pass every role check.
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := domain.PrincipalFromContext(c.Request.Context())
+		if !ok {
+			unauthorized(c, errMissingCredentials)
+			return
+		}
+
+		if !principal.HasRole(roles...) {
+			handlers.ErrorResponse(c, domain.ErrForbidden.WithDetail("Requires one of the roles: "+strings.Join(roles, ", ")))
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequireScope rejects with 403 Forbidden the API keys without the scope. Other
+// principals are restricted by their roles only.
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := domain.PrincipalFromContext(c.Request.Context())
+		if !ok {
+			unauthorized(c, errMissingCredentials)
+			return
+		}
+
+		if !principal.HasScope(scope) {
+			handlers.ErrorResponse(c, domain.ErrForbidden.WithDetail("Requires the API key scope "+scope))
+			return
+		}
+
+		c.Next()
+	}
+}
+
+var errMissingCredentials = domain.ErrUnauthenticated.WithDetail("Send a valid token in the Authorization: Bearer header or an API key in X-API-Key")
+
+func unauthorized(c *gin.Context, err error) {
+	c.Header("WWW-Authenticate", `Bearer realm="api-orders"`)
+	handlers.ErrorResponse(c, err)
+}
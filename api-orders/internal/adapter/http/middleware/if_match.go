@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gvillela7/rank-my-app/internal/adapter/http/handlers"
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+)
+
+// RequireIfMatch rejects requests without an If-Match header with 428 Precondition
+// Required, so that clients cannot overwrite changes they have not seen
+func RequireIfMatch() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader("If-Match") == "" {
+			handlers.ErrorResponse(c, domain.ErrPreconditionRequired.WithDetail("Send the ETag of the resource in the If-Match header"))
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,16 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gvillela7/rank-my-app/internal/adapter/http/handlers"
+	"github.com/gvillela7/rank-my-app/internal/adapter/http/validation"
+)
+
+// Language selects from the Accept-Language header the language of the validation
+// messages: pt-BR, the default, or en
+func Language(translations *validation.Translations) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		handlers.SetTranslator(c, translations.For(c.GetHeader("Accept-Language")))
+		c.Next()
+	}
+}
@@ -18,7 +18,7 @@ func Logger(logger *zap.Logger) gin.HandlerFunc {
 		duration := time.Since(start)
 		statusCode := c.Writer.Status()
 
-		logger.Info("HTTP Request",
+		fields := []zap.Field{
 			zap.String("method", c.Request.Method),
 			zap.String("path", path),
 			zap.String("query", query),
@@ -26,6 +26,13 @@ func Logger(logger *zap.Logger) gin.HandlerFunc {
 			zap.Duration("duration", duration),
 			zap.String("ip", c.ClientIP()),
 			zap.String("user_agent", c.Request.UserAgent()),
-		)
+			zap.String("request_id", c.Writer.Header().Get(RequestIDHeader)),
+		}
+		// errors the handlers kept from the client
+		if len(c.Errors) > 0 {
+			fields = append(fields, zap.Strings("errors", c.Errors.Errors()))
+		}
+
+		logger.Info("HTTP Request", fields...)
 	}
 }
@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gvillela7/rank-my-app/internal/adapter/http/handlers"
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.uber.org/zap"
+)
+
+// RateLimitPolicy is the limit of every route, with overrides keyed by method and
+// route path, e.g. "POST /api/v1/orders". Routes with an override have their own
+// bucket; the others share the default one. IP is the limit of each IP address
+// before authentication, which also holds back callers with failing credentials.
+type RateLimitPolicy struct {
+	Default domain.RateLimit
+	Routes  map[string]domain.RateLimit
+	IP      domain.RateLimit
+}
+
+func (p RateLimitPolicy) limitFor(method, path string) (string, domain.RateLimit) {
+	route := method + " " + path
+	if limit, ok := p.Routes[route]; ok {
+		return route, limit
+	}
+	return "default", p.Default
+}
+
+// RateLimit limits the requests of each client, identified by API key, token subject
+// or IP address, in this order. It must run after Authenticate. Requests are let
+// through when the limiter fails, so that its backend is not a point of failure.
+func RateLimit(limiter ports.RateLimiter, policy RateLimitPolicy, logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		bucket, limit := policy.limitFor(c.Request.Method, c.FullPath())
+		if !limit.Enabled() {
+			c.Next()
+			return
+		}
+
+		if !take(c, limiter, bucket, clientIdentity(c), limit, logger) {
+			return
+		}
+		c.Next()
+	}
+}
+
+// RateLimitByIP limits the requests of each IP address to the IP limit of the
+// policy. It must run before Authenticate, so that requests are limited whether or
+// not their credentials are valid.
+func RateLimitByIP(limiter ports.RateLimiter, policy RateLimitPolicy, logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !policy.IP.Enabled() {
+			c.Next()
+			return
+		}
+
+		if !take(c, limiter, "ip", "ip:"+c.ClientIP(), policy.IP, logger) {
+			return
+		}
+		c.Next()
+	}
+}
+
+// take takes a token from the bucket of the client, aborting the request when it
+// is limited
+func take(c *gin.Context, limiter ports.RateLimiter, bucket, identity string, limit domain.RateLimit, logger *zap.Logger) bool {
+	decision, err := limiter.Take(c.Request.Context(), bucket+"|"+identity, limit)
+	if err != nil {
+		logger.Error("Rate limiter failed", zap.Error(err), zap.String("bucket", bucket))
+		return true
+	}
+
+	c.Header("RateLimit-Limit", strconv.Itoa(decision.Limit))
+	c.Header("RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+	c.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(decision.Reset)))
+
+	if !decision.Allowed {
+		retryAfter := ceilSeconds(decision.RetryAfter)
+		c.Header("Retry-After", strconv.Itoa(retryAfter))
+		handlers.ErrorResponse(c, domain.ErrRateLimited.Detailf("Too many requests, retry in %d seconds", retryAfter))
+		return false
+	}
+	return true
+}
+
+func clientIdentity(c *gin.Context) string {
+	if principal, ok := domain.PrincipalFromContext(c.Request.Context()); ok {
+		if principal.APIKeyID != "" {
+			return "key:" + principal.APIKeyID
+		}
+		return "sub:" + principal.Subject
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// ceilSeconds rounds up to whole seconds, as the headers carry, with at least one
+func ceilSeconds(d time.Duration) int {
+	return int(math.Max(1, math.Ceil(d.Seconds())))
+}
@@ -1,9 +1,9 @@
 package middleware
 
 import (
-	"net/http"
-
 	"github.com/gin-gonic/gin"
+	"github.com/gvillela7/rank-my-app/internal/adapter/http/handlers"
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
 	"go.uber.org/zap"
 )
 
@@ -17,12 +17,7 @@ func Recovery(logger *zap.Logger) gin.HandlerFunc {
 					zap.String("method", c.Request.Method),
 				)
 
-				c.JSON(http.StatusInternalServerError, gin.H{
-					"success": false,
-					"message": "Internal server error",
-					"error":   "An unexpected error occurred",
-				})
-				c.Abort()
+				handlers.ErrorResponse(c, domain.ErrInternal)
 			}
 		}()
 		c.Next()
@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+)
+
+const (
+	RequestIDHeader = "X-Request-ID"
+
+	// maxRequestIDLen bounds the request IDs accepted from clients
+	maxRequestIDLen = 128
+)
+
+// RequestID identifies every request by the X-Request-ID header sent by the client,
+// or a random ID when it sends none, echoes it in the response and stores it with
+// the client IP in the request context, where the audit trail reads them
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if id == "" || len(id) > maxRequestIDLen {
+			id = newRequestID()
+		}
+
+		c.Header(RequestIDHeader, id)
+		info := domain.RequestInfo{ID: id, ClientIP: c.ClientIP()}
+		c.Request = c.Request.WithContext(domain.ContextWithRequestInfo(c.Request.Context(), info))
+		c.Next()
+	}
+}
+
+func newRequestID() string {
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(random)
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gvillela7/rank-my-app/internal/adapter/http/handlers"
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"go.uber.org/zap"
+)
+
+// Tenant scopes the request context to a tenant: the one the principal is bound to,
+// else the one named by the header, else fallback. A header naming another tenant
+// than the principal's is rejected with 403 Forbidden; a missing or malformed
+// tenant with 400 Bad Request.
+func Tenant(header, fallback string, logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requested := c.GetHeader(header)
+
+		tenantID := requested
+		if principal, ok := domain.PrincipalFromContext(c.Request.Context()); ok && principal.TenantID != "" {
+			if requested != "" && requested != principal.TenantID {
+				logger.Warn("Rejected tenant outside the principal scope",
+					zap.String("subject", principal.Subject),
+					zap.String("tenant", requested),
+				)
+				handlers.ErrorResponse(c, domain.ErrForbidden.WithDetail("The credentials are bound to another tenant"))
+				return
+			}
+			tenantID = principal.TenantID
+		}
+		if tenantID == "" {
+			tenantID = fallback
+		}
+
+		if tenantID == "" {
+			handlers.ErrorResponse(c, domain.ErrMissingTenant.WithDetail("Send the tenant in the "+header+" header"))
+			return
+		}
+		if err := domain.ValidateTenant(tenantID); err != nil {
+			handlers.ErrorResponse(c, domain.ErrInvalidTenant.WithDetail("Tenants are lowercase letters, digits, '-' and '_', up to 64 characters"))
+			return
+		}
+
+		c.Request = c.Request.WithContext(domain.ContextWithTenant(c.Request.Context(), tenantID))
+		c.Next()
+	}
+}
@@ -4,18 +4,44 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/gvillela7/rank-my-app/internal/adapter/http/handlers"
 	"github.com/gvillela7/rank-my-app/internal/adapter/http/middleware"
+	"github.com/gvillela7/rank-my-app/internal/adapter/http/validation"
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	"go.uber.org/zap"
 )
 
 type RouterConfig struct {
-	ProductHandler *handlers.ProductHandler
-	OrderHandler   *handlers.OrderHandler
-	HealthHandler  *handlers.HealthHandler
-	Logger         *zap.Logger
-	AllowOrigin    string
-	Environment    string
+	ProductHandler  *handlers.ProductHandler
+	StockHandler    *handlers.StockHandler
+	PriceHandler    *handlers.PriceHandler
+	CategoryHandler *handlers.CategoryHandler
+	OrderHandler    *handlers.OrderHandler
+	CouponHandler   *handlers.CouponHandler
+	CustomerHandler *handlers.CustomerHandler
+	ShipmentHandler *handlers.ShipmentHandler
+	ReturnHandler   *handlers.ReturnHandler
+	APIKeyHandler   *handlers.APIKeyHandler
+	AuditHandler    *handlers.AuditHandler
+	HealthHandler   *handlers.HealthHandler
+	Logger          *zap.Logger
+	AllowOrigin     string
+	Environment     string
+	RequireIfMatch  bool
+	// TokenVerifier authenticates the API routes; nil disables authentication
+	TokenVerifier ports.TokenVerifier
+	// APIKeys authenticates the X-API-Key header next to bearer tokens
+	APIKeys ports.APIKeyAuthenticator
+	// RateLimiter enforces RateLimits on the API routes; nil disables rate limiting
+	RateLimiter ports.RateLimiter
+	RateLimits  middleware.RateLimitPolicy
+	// TenantHeader selects the tenant of the API requests, DefaultTenant serves
+	// the requests naming none
+	TenantHeader  string
+	DefaultTenant string
+	// Translations localizes the validation messages; nil leaves them in English
+	Translations *validation.Translations
 }
 
 func SetupRouter(config *RouterConfig) *gin.Engine {
@@ -26,22 +52,122 @@ func SetupRouter(config *RouterConfig) *gin.Engine {
 	router := gin.New()
 
 	router.Use(middleware.Recovery(config.Logger))
+	router.Use(middleware.RequestID())
 	router.Use(middleware.Logger(config.Logger))
 	router.Use(middleware.CORS(config.AllowOrigin))
+	if config.Translations != nil {
+		router.Use(middleware.Language(config.Translations))
+	}
+
+	// pass stands in for the guards that are disabled by configuration
+	pass := func(c *gin.Context) { c.Next() }
+
+	// conditional guards the mutations checked against the If-Match version,
+	// which clients must send when RequireIfMatch is enabled
+	conditional := gin.HandlerFunc(pass)
+	if config.RequireIfMatch {
+		conditional = middleware.RequireIfMatch()
+	}
+
+	// role restricts a route to the roles, admins included. Routes without it are
+	// open to every authenticated caller; handlers limit customers to their own data.
+	role := func(roles ...string) gin.HandlerFunc {
+		if config.TokenVerifier == nil {
+			return pass
+		}
+		return middleware.RequireRole(roles...)
+	}
+	staff := role(domain.RoleOperator)
+	admin := role(domain.RoleAdmin)
+	people := role(domain.RoleCustomer, domain.RoleOperator)
+
+	// scope restricts the API keys allowed on a route; users pass on their roles
+	scope := func(name string) gin.HandlerFunc {
+		if config.TokenVerifier == nil {
+			return pass
+		}
+		return middleware.RequireScope(name)
+	}
+	productsRead := scope(domain.ScopeProductsRead)
+
+	var apiMiddleware []gin.HandlerFunc
+	if config.RateLimiter != nil {
+		apiMiddleware = append(apiMiddleware, middleware.RateLimitByIP(config.RateLimiter, config.RateLimits, config.Logger))
+	}
+	if config.TokenVerifier != nil {
+		apiMiddleware = append(apiMiddleware, middleware.Authenticate(config.TokenVerifier, config.APIKeys, config.Logger))
+	}
+	apiMiddleware = append(apiMiddleware, middleware.Tenant(config.TenantHeader, config.DefaultTenant, config.Logger))
+	if config.RateLimiter != nil {
+		apiMiddleware = append(apiMiddleware, middleware.RateLimit(config.RateLimiter, config.RateLimits, config.Logger))
+	}
 
-	api := router.Group("/api/v1")
+	api := router.Group("/api/v1", apiMiddleware...)
 	{
 		products := api.Group("/products")
 		{
-			products.POST("", config.ProductHandler.CreateProduct)
+			products.POST("", staff, config.ProductHandler.CreateProduct)
+			products.GET("", productsRead, config.ProductHandler.SearchProducts)
+			products.POST("/import", staff, config.ProductHandler.ImportProducts)
+			products.GET("/export", staff, config.ProductHandler.ExportProducts)
+			products.GET("/:id", productsRead, config.ProductHandler.GetProductByID)
+			products.POST("/:id/stock-adjustments", staff, config.StockHandler.AdjustStock)
+			products.GET("/:id/stock-movements", staff, config.StockHandler.ListMovements)
+			products.PUT("/:id/reorder-threshold", staff, conditional, config.StockHandler.SetReorderThreshold)
+			products.POST("/:id/prices", staff, conditional, config.PriceHandler.ChangePrice)
+			products.GET("/:id/prices", productsRead, config.PriceHandler.ListPrices)
+			products.DELETE("/:id/prices/:change_id", staff, config.PriceHandler.CancelPriceChange)
+		}
+
+		categories := api.Group("/categories")
+		{
+			categories.POST("", staff, config.CategoryHandler.CreateCategory)
+			categories.GET("", productsRead, config.CategoryHandler.ListCategories)
 		}
 
 		orders := api.Group("/orders")
 		{
-			orders.POST("", config.OrderHandler.CreateOrder)
-			orders.GET("/:id", config.OrderHandler.GetOrderByID)
-			orders.PATCH("/:id/status", config.OrderHandler.UpdateOrderStatus)
+			orders.POST("", scope(domain.ScopeOrdersWrite), config.OrderHandler.CreateOrder)
+			orders.GET("/:id", scope(domain.ScopeOrdersRead), config.OrderHandler.GetOrderByID)
+			orders.GET("/by-number/:number", scope(domain.ScopeOrdersRead), config.OrderHandler.GetOrderByNumber)
+			orders.PATCH("/:id/status", staff, conditional, config.OrderHandler.UpdateOrderStatus)
+			orders.POST("/:id/shipments", staff, config.ShipmentHandler.CreateShipment)
+			orders.GET("/:id/shipments", staff, config.ShipmentHandler.ListShipments)
+			orders.PATCH("/:id/shipments/:shipmentId/delivery", staff, config.ShipmentHandler.MarkDelivered)
+			orders.POST("/:id/returns", staff, config.ReturnHandler.RequestReturn)
+			orders.GET("/:id/returns", staff, config.ReturnHandler.ListReturns)
+			orders.POST("/:id/returns/:returnId/approve", staff, config.ReturnHandler.ApproveReturn)
+			orders.POST("/:id/returns/:returnId/reject", staff, config.ReturnHandler.RejectReturn)
 		}
+
+		coupons := api.Group("/coupons")
+		{
+			coupons.POST("", admin, config.CouponHandler.CreateCoupon)
+			coupons.GET("", staff, config.CouponHandler.ListCoupons)
+			coupons.GET("/:id", staff, config.CouponHandler.GetCouponByID)
+			coupons.PUT("/:id", admin, config.CouponHandler.UpdateCoupon)
+			coupons.DELETE("/:id", admin, config.CouponHandler.DeleteCoupon)
+		}
+
+		customers := api.Group("/customers")
+		{
+			customers.POST("", staff, config.CustomerHandler.CreateCustomer)
+			customers.GET("", staff, config.CustomerHandler.ListCustomers)
+			customers.GET("/:id", people, config.CustomerHandler.GetCustomerByID)
+			customers.PUT("/:id", people, config.CustomerHandler.UpdateCustomer)
+			customers.DELETE("/:id", admin, config.CustomerHandler.DeleteCustomer)
+			customers.GET("/:id/orders", people, config.CustomerHandler.ListCustomerOrders)
+		}
+
+		apiKeys := api.Group("/api-keys", admin)
+		{
+			apiKeys.POST("", config.APIKeyHandler.IssueAPIKey)
+			apiKeys.GET("", config.APIKeyHandler.ListAPIKeys)
+			apiKeys.POST("/:id/rotate", config.APIKeyHandler.RotateAPIKey)
+			apiKeys.DELETE("/:id", config.APIKeyHandler.RevokeAPIKey)
+		}
+
+		api.GET("/audit", admin, config.AuditHandler.ListAuditEntries)
 	}
 
 	// Health check endpoint
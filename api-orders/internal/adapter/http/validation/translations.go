@@ -0,0 +1,162 @@
+package validation
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/pt_BR"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	enTranslations "github.com/go-playground/validator/v10/translations/en"
+	ptBRTranslations "github.com/go-playground/validator/v10/translations/pt_BR"
+)
+
+// ruleMessages are the messages of the custom tags and of the rules the validator
+// has no translation for
+var ruleMessages = map[string]map[string]string{
+	"pt_BR": {
+		"cpf":                  "{0} deve ser um CPF válido",
+		"mongodb":              "{0} deve ser um ID válido",
+		"iso4217":              "{0} deve ser um código de moeda ISO 4217",
+		"iso3166_1_alpha2":     "{0} deve ser um código de país ISO 3166-1 alfa-2",
+		"required_without":     "{0} é um campo obrigatório",
+		"required_without_all": "{0} é um campo obrigatório",
+	},
+	"en": {
+		"cpf":              "{0} must be a valid CPF",
+		"mongodb":          "{0} must be a valid ID",
+		"iso4217":          "{0} must be an ISO 4217 currency code",
+		"iso3166_1_alpha2": "{0} must be an ISO 3166-1 alpha-2 country code",
+	},
+}
+
+// otherMessages are the messages of JSON values of the wrong type and of the rules
+// without a message
+var otherMessages = map[string]map[string]string{
+	"pt_BR": {
+		"type":    "{0} deve ser do tipo {1}",
+		"invalid": "{0} é inválido",
+	},
+	"en": {
+		"type":    "{0} must be of type {1}",
+		"invalid": "{0} is invalid",
+	},
+}
+
+// Translations holds the validation messages in the languages the API speaks:
+// Brazilian Portuguese, the default, and English
+type Translations struct {
+	universal *ut.UniversalTranslator
+}
+
+// NewTranslations registers on validate the messages of every supported language
+func NewTranslations(validate *validator.Validate) (*Translations, error) {
+	universal := ut.New(pt_BR.New(), pt_BR.New(), en.New())
+
+	defaults := map[string]func(*validator.Validate, ut.Translator) error{
+		"pt_BR": ptBRTranslations.RegisterDefaultTranslations,
+		"en":    enTranslations.RegisterDefaultTranslations,
+	}
+	for locale, registerDefaults := range defaults {
+		translator, _ := universal.GetTranslator(locale)
+		if err := registerDefaults(validate, translator); err != nil {
+			return nil, fmt.Errorf("failed to register %s validation messages: %w", locale, err)
+		}
+
+		for tag, message := range ruleMessages[locale] {
+			if err := validate.RegisterTranslation(tag, translator, addMessage(tag, message), translateWithParam); err != nil {
+				return nil, fmt.Errorf("failed to register %s message of %s: %w", locale, tag, err)
+			}
+		}
+		for key, message := range otherMessages[locale] {
+			if err := translator.Add(key, message, false); err != nil {
+				return nil, fmt.Errorf("failed to register %s message of %s: %w", locale, key, err)
+			}
+		}
+	}
+
+	return &Translations{universal: universal}, nil
+}
+
+func addMessage(tag, message string) validator.RegisterTranslationsFunc {
+	return func(translator ut.Translator) error {
+		return translator.Add(tag, message, false)
+	}
+}
+
+func translateWithParam(translator ut.Translator, fieldErr validator.FieldError) string {
+	message, err := translator.T(fieldErr.Tag(), fieldErr.Field(), fieldErr.Param())
+	if err != nil {
+		return fieldErr.Error()
+	}
+	return message
+}
+
+// For returns the translator of the supported language the Accept-Language header
+// prefers, Brazilian Portuguese when it accepts none
+func (t *Translations) For(acceptLanguage string) ut.Translator {
+	for _, language := range preferredLanguages(acceptLanguage) {
+		switch {
+		case language == "pt" || strings.HasPrefix(language, "pt-"):
+			translator, _ := t.universal.GetTranslator("pt_BR")
+			return translator
+		case language == "en" || strings.HasPrefix(language, "en-"):
+			translator, _ := t.universal.GetTranslator("en")
+			return translator
+		}
+	}
+	return t.universal.GetFallback()
+}
+
+// preferredLanguages returns the lowercase language ranges of an Accept-Language
+// header by decreasing quality, leaving out the ones refused with q=0
+func preferredLanguages(acceptLanguage string) []string {
+	type weighted struct {
+		language string
+		quality  float64
+	}
+
+	var ranges []weighted
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		language, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if language == "" {
+			continue
+		}
+
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+				quality = parsed
+			}
+		}
+		if quality > 0 {
+			ranges = append(ranges, weighted{language: strings.ToLower(strings.TrimSpace(language)), quality: quality})
+		}
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].quality > ranges[j].quality })
+
+	languages := make([]string, len(ranges))
+	for i, r := range ranges {
+		languages[i] = r.language
+	}
+	return languages
+}
+
+// Message returns the message of a field error in the language of translator
+func Message(translator ut.Translator, fieldErr validator.FieldError) string {
+	message := fieldErr.Translate(translator)
+	if message == fieldErr.Error() {
+		message, _ = translator.T("invalid", fieldErr.Field())
+	}
+	return message
+}
+
+// TypeMessage returns the message of a field holding a JSON value of another type
+func TypeMessage(translator ut.Translator, field, typeName string) string {
+	message, _ := translator.T("type", field, typeName)
+	return message
+}
@@ -0,0 +1,69 @@
+package validation_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gvillela7/rank-my-app/internal/adapter/http/validation"
+)
+
+type testItem struct {
+	Quantity int `json:"quantity" validate:"gt=0"`
+}
+
+type testRequest struct {
+	Document string     `json:"document" validate:"required,cpf"`
+	Items    []testItem `json:"items" validate:"dive"`
+}
+
+func validationErrors(t *testing.T, validate *validator.Validate) validator.ValidationErrors {
+	t.Helper()
+
+	err := validate.Struct(&testRequest{Document: "111.111.111-11", Items: []testItem{{Quantity: 0}}})
+
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) || len(validationErrs) != 2 {
+		t.Fatalf("Expected 2 validation errors, got %v", err)
+	}
+	return validationErrs
+}
+
+func TestTranslations_NamesFieldsByJSONPath(t *testing.T) {
+	validationErrs := validationErrors(t, validation.New())
+
+	if got := validationErrs[1].Namespace(); got != "testRequest.items[0].quantity" {
+		t.Errorf("Expected the JSON path of the field, got %s", got)
+	}
+}
+
+func TestTranslations_For(t *testing.T) {
+	validate := validation.New()
+	translations, err := validation.NewTranslations(validate)
+	if err != nil {
+		t.Fatalf("Failed to create translations: %v", err)
+	}
+	validationErrs := validationErrors(t, validate)
+
+	cases := []struct {
+		acceptLanguage string
+		document       string
+		quantity       string
+	}{
+		{"", "document deve ser um CPF válido", "quantity deve ser maior do que 0"},
+		{"en-US,en;q=0.9", "document must be a valid CPF", "quantity must be greater than 0"},
+		{"fr-FR, en;q=0.5, pt-BR;q=0.8", "document deve ser um CPF válido", "quantity deve ser maior do que 0"},
+		{"de", "document deve ser um CPF válido", "quantity deve ser maior do que 0"},
+	}
+
+	for _, tc := range cases {
+		translator := translations.For(tc.acceptLanguage)
+
+		if got := validation.Message(translator, validationErrs[0]); got != tc.document {
+			t.Errorf("%q: expected %q, got %q", tc.acceptLanguage, tc.document, got)
+		}
+		if got := validation.Message(translator, validationErrs[1]); got != tc.quantity {
+			t.Errorf("%q: expected %q, got %q", tc.acceptLanguage, tc.quantity, got)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package validation
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+)
+
+// New creates a validator with the custom tags used by the request DTOs. Fields are
+// named as clients send them: by their JSON name, or query parameter name.
+func New() *validator.Validate {
+	validate := validator.New()
+
+	validate.RegisterTagNameFunc(fieldName)
+
+	_ = validate.RegisterValidation("cpf", func(fl validator.FieldLevel) bool {
+		return domain.IsValidCPF(fl.Field().String())
+	})
+
+	return validate
+}
+
+// fieldName returns the name of a field in the JSON body, else in the query string.
+// Fields in neither keep their Go name.
+func fieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form"} {
+		if name, _, _ := strings.Cut(field.Tag.Get(tag), ","); name != "" && name != "-" {
+			return name
+		}
+	}
+	return ""
+}
@@ -5,10 +5,12 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/gvillela7/rank-my-app/inprocess"
 	"github.com/gvillela7/rank-my-app/internal/core/domain"
 	"github.com/gvillela7/rank-my-app/internal/core/ports"
 	"github.com/gvillela7/rank-my-app/internal/infra/rabbitmq"
 	amqp "github.com/rabbitmq/amqp091-go"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.uber.org/zap"
 )
 
@@ -19,10 +21,39 @@ const (
 	routingKey      = "order-status"
 	dlxExchangeName = "orders.dlx"
 	dlqName         = "order-status.dlq"
+
+	shipmentQueueName  = "order-shipment"
+	shipmentRoutingKey = "order-shipment"
+	shipmentDLXName    = "orders.shipment.dlx"
+	shipmentDLQName    = "order-shipment.dlq"
+
+	returnQueueName  = "order-return"
+	returnRoutingKey = "order-return"
+	returnDLXName    = "orders.return.dlx"
+	returnDLQName    = "order-return.dlq"
+
+	stockQueueName  = "product-stock"
+	stockRoutingKey = "product-stock"
+	stockDLXName    = "orders.stock.dlx"
+	stockDLQName    = "product-stock.dlq"
 )
 
+type StockAlertMessage struct {
+	Event            string  `json:"event"`
+	ProductID        string  `json:"product_id"`
+	VariantID        string  `json:"variant_id,omitempty"`
+	SKU              string  `json:"sku,omitempty"`
+	ProductName      string  `json:"product_name"`
+	Quantity         int     `json:"quantity"`
+	ReorderThreshold int     `json:"reorder_threshold"`
+	MovementID       string  `json:"movement_id"`
+	Timestamp        float64 `json:"ts"`
+}
+
+// orderProducer publishes to RabbitMQ, or to the in-process broker when it has one
 type orderProducer struct {
 	rabbitConn          *rabbitmq.RabbitMQConnection
+	broker              *inprocess.Broker
 	publishedOrderRepo  ports.PublishedOrderRepository
 	logger              *zap.Logger
 	exchangeInitialized bool
@@ -35,6 +66,40 @@ type OrderStatusMessage struct {
 	Status    string  `json:"status"`
 }
 
+type ShipmentItemMessage struct {
+	ProductID string `json:"product_id"`
+	Quantity  int    `json:"quantity"`
+}
+
+type ShipmentEventMessage struct {
+	Event        string                `json:"event"`
+	ShipmentID   string                `json:"shipment_id"`
+	OrderID      string                `json:"order_id"`
+	OrderStatus  string                `json:"order_status"`
+	Carrier      string                `json:"carrier"`
+	TrackingCode string                `json:"tracking_code"`
+	Items        []ShipmentItemMessage `json:"items"`
+	Timestamp    float64               `json:"ts"`
+}
+
+type ReturnItemMessage struct {
+	ProductID    string  `json:"product_id"`
+	Quantity     int     `json:"quantity"`
+	Reason       string  `json:"reason"`
+	RefundAmount float64 `json:"refund_amount"`
+}
+
+type ReturnEventMessage struct {
+	Event       string              `json:"event"`
+	ReturnID    string              `json:"return_id"`
+	OrderID     string              `json:"order_id"`
+	Items       []ReturnItemMessage `json:"items"`
+	RefundTotal float64             `json:"refund_total"`
+	Currency    string              `json:"currency"`
+	Reason      string              `json:"reason,omitempty"`
+	Timestamp   float64             `json:"ts"`
+}
+
 func NewOrderProducer(
 	rabbitConn *rabbitmq.RabbitMQConnection,
 	publishedOrderRepo ports.PublishedOrderRepository,
@@ -53,6 +118,20 @@ func NewOrderProducer(
 	return producer, nil
 }
 
+// NewInProcessProducer creates a producer publishing to the in-process broker,
+// whose queues need no declaration
+func NewInProcessProducer(
+	broker *inprocess.Broker,
+	publishedOrderRepo ports.PublishedOrderRepository,
+	logger *zap.Logger,
+) ports.MessageProducer {
+	return &orderProducer{
+		broker:             broker,
+		publishedOrderRepo: publishedOrderRepo,
+		logger:             logger,
+	}
+}
+
 func (p *orderProducer) setupInfrastructure() error {
 	channel, err := p.rabbitConn.GetChannel()
 	if err != nil {
@@ -73,8 +152,32 @@ func (p *orderProducer) setupInfrastructure() error {
 	}
 	p.logger.Info("Exchange declared successfully", zap.String("exchange", exchangeName))
 
-	err = channel.ExchangeDeclare(
-		dlxExchangeName,
+	if err := p.declareQueue(channel, queueName, routingKey, dlxExchangeName, dlqName); err != nil {
+		return err
+	}
+
+	if err := p.declareQueue(channel, shipmentQueueName, shipmentRoutingKey, shipmentDLXName, shipmentDLQName); err != nil {
+		return err
+	}
+
+	if err := p.declareQueue(channel, returnQueueName, returnRoutingKey, returnDLXName, returnDLQName); err != nil {
+		return err
+	}
+
+	if err := p.declareQueue(channel, stockQueueName, stockRoutingKey, stockDLXName, stockDLQName); err != nil {
+		return err
+	}
+	p.queueInitialized = true
+	p.exchangeInitialized = true
+
+	return nil
+}
+
+// declareQueue declares a durable queue bound to the orders exchange with its own
+// dead letter exchange and queue
+func (p *orderProducer) declareQueue(channel *amqp.Channel, name, key, dlx, dlq string) error {
+	err := channel.ExchangeDeclare(
+		dlx,
 		"fanout",
 		true,
 		false,
@@ -85,10 +188,10 @@ func (p *orderProducer) setupInfrastructure() error {
 	if err != nil {
 		return fmt.Errorf("failed to declare DLX: %w", err)
 	}
-	p.logger.Info("DLX declared successfully", zap.String("dlx", dlxExchangeName))
+	p.logger.Info("DLX declared successfully", zap.String("dlx", dlx))
 
-	dlq, err := channel.QueueDeclare(
-		dlqName,
+	deadLetterQueue, err := channel.QueueDeclare(
+		dlq,
 		true,
 		false,
 		false,
@@ -98,25 +201,25 @@ func (p *orderProducer) setupInfrastructure() error {
 	if err != nil {
 		return fmt.Errorf("failed to declare DLQ: %w", err)
 	}
-	p.logger.Info("DLQ declared successfully", zap.String("dlq", dlqName))
+	p.logger.Info("DLQ declared successfully", zap.String("dlq", dlq))
 
 	err = channel.QueueBind(
-		dlq.Name,
+		deadLetterQueue.Name,
 		"",
-		dlxExchangeName,
+		dlx,
 		false,
 		nil,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to bind DLQ to DLX: %w", err)
 	}
-	p.logger.Info("DLQ bound to DLX successfully")
+	p.logger.Info("DLQ bound to DLX successfully", zap.String("dlq", dlq))
 
 	queueArgs := amqp.Table{
-		"x-dead-letter-exchange": dlxExchangeName,
+		"x-dead-letter-exchange": dlx,
 	}
 	queue, err := channel.QueueDeclare(
-		queueName,
+		name,
 		true,
 		false,
 		false,
@@ -126,15 +229,14 @@ func (p *orderProducer) setupInfrastructure() error {
 	if err != nil {
 		return fmt.Errorf("failed to declare queue: %w", err)
 	}
-	p.queueInitialized = true
 	p.logger.Info("Queue declared successfully with DLX",
-		zap.String("queue", queueName),
-		zap.String("dlx", dlxExchangeName),
+		zap.String("queue", name),
+		zap.String("dlx", dlx),
 	)
 
 	err = channel.QueueBind(
 		queue.Name,
-		routingKey,
+		key,
 		exchangeName,
 		false,
 		nil,
@@ -143,13 +245,11 @@ func (p *orderProducer) setupInfrastructure() error {
 		return fmt.Errorf("failed to bind queue: %w", err)
 	}
 	p.logger.Info("Queue bound to exchange successfully",
-		zap.String("queue", queueName),
+		zap.String("queue", name),
 		zap.String("exchange", exchangeName),
-		zap.String("routing_key", routingKey),
+		zap.String("routing_key", key),
 	)
 
-	p.exchangeInitialized = true
-
 	return nil
 }
 
@@ -177,12 +277,12 @@ func (p *orderProducer) PublishOrderStatus(ctx context.Context, orderID, status
 		return fmt.Errorf("failed to marshal message: %w", err)
 	}
 
-	published := p.publishToRabbitMQ(ctx, messageBody)
+	published := p.publish(ctx, routingKey, messageBody)
 
 	p.savePublicationRecord(ctx, orderID, status, published, timestamp)
 
 	if !published {
-		return fmt.Errorf("failed to publish message to RabbitMQ")
+		return fmt.Errorf("failed to publish message")
 	}
 
 	p.logger.Info("Order status published successfully",
@@ -194,46 +294,208 @@ func (p *orderProducer) PublishOrderStatus(ctx context.Context, orderID, status
 	return nil
 }
 
-func (p *orderProducer) publishToRabbitMQ(ctx context.Context, messageBody []byte) bool {
-	if !p.exchangeInitialized || !p.queueInitialized {
-		p.logger.Warn("RabbitMQ infrastructure not initialized, attempting setup")
-		if err := p.setupInfrastructure(); err != nil {
-			p.logger.Error("Failed to setup infrastructure", zap.Error(err))
-			return false
+func (p *orderProducer) PublishShipmentEvent(ctx context.Context, event *domain.ShipmentEvent) error {
+	p.logger.Info("Publishing shipment event",
+		zap.String("event", event.Event),
+		zap.String("shipment_id", event.ShipmentID),
+		zap.String("order_id", event.OrderID),
+	)
+
+	items := make([]ShipmentItemMessage, len(event.Items))
+	for i, item := range event.Items {
+		items[i] = ShipmentItemMessage{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
 		}
 	}
 
-	channel, err := p.rabbitConn.GetChannel()
+	message := ShipmentEventMessage{
+		Event:        event.Event,
+		ShipmentID:   event.ShipmentID,
+		OrderID:      event.OrderID,
+		OrderStatus:  event.OrderStatus,
+		Carrier:      event.Carrier,
+		TrackingCode: event.TrackingCode,
+		Items:        items,
+		Timestamp:    float64(event.OccurredAt.UnixNano()) / 1e9,
+	}
+
+	messageBody, err := json.Marshal(message)
 	if err != nil {
-		p.logger.Error("Failed to get RabbitMQ channel", zap.Error(err))
-		return false
+		p.logger.Error("Failed to marshal message",
+			zap.String("shipment_id", event.ShipmentID),
+			zap.Error(err),
+		)
+		return fmt.Errorf("failed to marshal message: %w", err)
 	}
 
-	err = channel.PublishWithContext(
-		ctx,
-		exchangeName,
-		routingKey,
-		false,
-		false,
-		amqp.Publishing{
-			ContentType:  "application/json",
-			DeliveryMode: amqp.Persistent,
-			Body:         messageBody,
-		},
+	if !p.publish(ctx, shipmentRoutingKey, messageBody) {
+		return fmt.Errorf("failed to publish message")
+	}
+
+	p.logger.Info("Shipment event published successfully",
+		zap.String("event", event.Event),
+		zap.String("shipment_id", event.ShipmentID),
+		zap.String("order_id", event.OrderID),
 	)
 
+	return nil
+}
+
+func (p *orderProducer) PublishReturnEvent(ctx context.Context, event *domain.ReturnEvent) error {
+	p.logger.Info("Publishing return event",
+		zap.String("event", event.Event),
+		zap.String("return_id", event.ReturnID),
+		zap.String("order_id", event.OrderID),
+	)
+
+	items := make([]ReturnItemMessage, len(event.Items))
+	for i, item := range event.Items {
+		items[i] = ReturnItemMessage{
+			ProductID:    item.ProductID,
+			Quantity:     item.Quantity,
+			Reason:       item.Reason,
+			RefundAmount: item.RefundAmount,
+		}
+	}
+
+	message := ReturnEventMessage{
+		Event:       event.Event,
+		ReturnID:    event.ReturnID,
+		OrderID:     event.OrderID,
+		Items:       items,
+		RefundTotal: event.RefundTotal,
+		Currency:    event.Currency,
+		Reason:      event.Reason,
+		Timestamp:   float64(event.OccurredAt.UnixNano()) / 1e9,
+	}
+
+	messageBody, err := json.Marshal(message)
+	if err != nil {
+		p.logger.Error("Failed to marshal message",
+			zap.String("return_id", event.ReturnID),
+			zap.Error(err),
+		)
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	if !p.publish(ctx, returnRoutingKey, messageBody) {
+		return fmt.Errorf("failed to publish message")
+	}
+
+	p.logger.Info("Return event published successfully",
+		zap.String("event", event.Event),
+		zap.String("return_id", event.ReturnID),
+		zap.String("order_id", event.OrderID),
+	)
+
+	return nil
+}
+
+func (p *orderProducer) PublishStockAlert(ctx context.Context, event *domain.StockAlertEvent) error {
+	p.logger.Info("Publishing stock alert",
+		zap.String("event", event.Event),
+		zap.String("product_id", event.ProductID),
+		zap.String("variant_id", event.VariantID),
+		zap.Int("quantity", event.Quantity),
+	)
+
+	message := StockAlertMessage{
+		Event:            event.Event,
+		ProductID:        event.ProductID,
+		VariantID:        event.VariantID,
+		SKU:              event.SKU,
+		ProductName:      event.ProductName,
+		Quantity:         event.Quantity,
+		ReorderThreshold: event.ReorderThreshold,
+		MovementID:       event.MovementID,
+		Timestamp:        float64(event.OccurredAt.UnixNano()) / 1e9,
+	}
+
+	messageBody, err := json.Marshal(message)
+	if err != nil {
+		p.logger.Error("Failed to marshal message",
+			zap.String("product_id", event.ProductID),
+			zap.Error(err),
+		)
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	if !p.publish(ctx, stockRoutingKey, messageBody) {
+		return fmt.Errorf("failed to publish message")
+	}
+
+	p.logger.Info("Stock alert published successfully",
+		zap.String("event", event.Event),
+		zap.String("product_id", event.ProductID),
+	)
+
+	return nil
+}
+
+// publish publishes the message with the tenant of ctx in the tenant_id header,
+// which consumers use to scope the documents they update, and the ID of the request
+// that triggered it as correlation ID, which links their audit entries to it
+func (p *orderProducer) publish(ctx context.Context, key string, messageBody []byte) bool {
+	tenantID, ok := domain.TenantFromContext(ctx)
+	if !ok {
+		p.logger.Error("Refusing to publish a message without tenant", zap.String("routing_key", key))
+		return false
+	}
+
+	requestInfo, _ := domain.RequestInfoFromContext(ctx)
+
+	message := amqp.Publishing{
+		ContentType:   "application/json",
+		DeliveryMode:  amqp.Persistent,
+		CorrelationId: requestInfo.ID,
+		Headers:       amqp.Table{domain.TenantHeader: tenantID},
+		Body:          messageBody,
+	}
+
+	var err error
+	if p.broker != nil {
+		err = p.broker.Publish(ctx, exchangeName, key, message)
+	} else {
+		err = p.publishToRabbitMQ(ctx, key, message)
+	}
 	if err != nil {
-		p.logger.Error("Failed to publish message to RabbitMQ", zap.Error(err))
+		p.logger.Error("Failed to publish message", zap.String("routing_key", key), zap.Error(err))
 		return false
 	}
 
 	return true
 }
 
+func (p *orderProducer) publishToRabbitMQ(ctx context.Context, key string, message amqp.Publishing) error {
+	if !p.exchangeInitialized || !p.queueInitialized {
+		p.logger.Warn("RabbitMQ infrastructure not initialized, attempting setup")
+		if err := p.setupInfrastructure(); err != nil {
+			return fmt.Errorf("failed to setup infrastructure: %w", err)
+		}
+	}
+
+	channel, err := p.rabbitConn.GetChannel()
+	if err != nil {
+		return fmt.Errorf("failed to get RabbitMQ channel: %w", err)
+	}
+
+	return channel.PublishWithContext(ctx, exchangeName, key, false, false, message)
+}
+
 func (p *orderProducer) savePublicationRecord(ctx context.Context, orderID, status string, published bool, timestamp float64) {
-	publishedOrder := domain.NewPublishedOrder(orderID, status, published, timestamp)
+	objectID, err := primitive.ObjectIDFromHex(orderID)
+	if err != nil {
+		p.logger.Error("Failed to save publication record: invalid order ID",
+			zap.String("order_id", orderID),
+			zap.Error(err),
+		)
+		return
+	}
+
+	publishedOrder := domain.NewPublishedOrder(objectID, status, published, timestamp)
 
-	err := p.publishedOrderRepo.Create(ctx, publishedOrder)
+	err = p.publishedOrderRepo.Create(ctx, publishedOrder)
 	if err != nil {
 		p.logger.Error("Failed to save publication record to database",
 			zap.String("order_id", orderID),
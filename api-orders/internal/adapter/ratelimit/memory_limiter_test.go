@@ -0,0 +1,62 @@
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/adapter/ratelimit"
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+)
+
+func TestMemoryLimiter_Take_RefusesWhenBucketIsEmpty(t *testing.T) {
+	limiter := ratelimit.NewMemoryLimiter()
+	limit := domain.RateLimit{Requests: 60, Period: time.Hour, Burst: 2}
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		decision, err := limiter.Take(ctx, "orders|ip:10.0.0.1", limit)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if !decision.Allowed || decision.Remaining != 1-i {
+			t.Fatalf("Request %d: expected allowed with %d remaining, got %+v", i+1, 1-i, decision)
+		}
+	}
+
+	decision, err := limiter.Take(ctx, "orders|ip:10.0.0.1", limit)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if decision.Allowed || decision.Limit != 2 {
+		t.Fatalf("Expected third request refused with limit 2, got %+v", decision)
+	}
+	// one token per minute
+	if decision.RetryAfter <= 0 || decision.RetryAfter > time.Minute {
+		t.Errorf("Expected retry within a minute, got %s", decision.RetryAfter)
+	}
+
+	other, _ := limiter.Take(ctx, "orders|ip:10.0.0.2", limit)
+	if !other.Allowed {
+		t.Error("Expected another client to have its own bucket")
+	}
+}
+
+func TestMemoryLimiter_Take_RefillsOverTime(t *testing.T) {
+	limiter := ratelimit.NewMemoryLimiter()
+	limit := domain.RateLimit{Requests: 1, Period: 50 * time.Millisecond}
+	ctx := context.Background()
+
+	if decision, _ := limiter.Take(ctx, "key", limit); !decision.Allowed {
+		t.Fatal("Expected first request allowed")
+	}
+	if decision, _ := limiter.Take(ctx, "key", limit); decision.Allowed {
+		t.Fatal("Expected second request refused")
+	}
+
+	time.Sleep(60 * time.Millisecond)
+
+	if decision, _ := limiter.Take(ctx, "key", limit); !decision.Allowed {
+		t.Error("Expected request allowed after the bucket refilled")
+	}
+}
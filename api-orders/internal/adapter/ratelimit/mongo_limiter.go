@@ -0,0 +1,74 @@
+package ratelimit
+
+import (
+	"context"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoLimiter struct {
+	collection *mongo.Collection
+}
+
+// NewMongoLimiter creates a limiter that keeps the buckets in the rate_limits
+// collection, shared by every replica. Buckets expire through expires_at once
+// they are full again.
+func NewMongoLimiter(db *mongo.Database) ports.RateLimiter {
+	return &mongoLimiter{
+		collection: db.Collection("rate_limits"),
+	}
+}
+
+type bucketDocument struct {
+	Tokens  float64 `bson:"tokens"`
+	Allowed bool    `bson:"allowed"`
+}
+
+// Take refills and takes from the bucket in a single update pipeline, using the
+// server clock so that replicas with clock skew share the same buckets
+func (l *mongoLimiter) Take(ctx context.Context, key string, limit domain.RateLimit) (domain.RateLimitDecision, error) {
+	capacity := float64(limit.Capacity())
+	msPerToken := 1000 / limit.RefillRate()
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"tokens": bson.M{"$min": bson.A{capacity, bson.M{"$add": bson.A{
+				bson.M{"$ifNull": bson.A{"$tokens", capacity}},
+				bson.M{"$divide": bson.A{
+					bson.M{"$subtract": bson.A{"$$NOW", bson.M{"$ifNull": bson.A{"$updated_at", "$$NOW"}}}},
+					msPerToken,
+				}},
+			}}}},
+		}}},
+		{{Key: "$set", Value: bson.M{
+			"allowed": bson.M{"$gte": bson.A{"$tokens", 1}},
+		}}},
+		{{Key: "$set", Value: bson.M{
+			"tokens":     bson.M{"$cond": bson.A{"$allowed", bson.M{"$subtract": bson.A{"$tokens", 1}}, "$tokens"}},
+			"updated_at": "$$NOW",
+		}}},
+		{{Key: "$set", Value: bson.M{
+			"expires_at": bson.M{"$add": bson.A{
+				"$$NOW",
+				bson.M{"$ceil": bson.M{"$multiply": bson.A{bson.M{"$subtract": bson.A{capacity, "$tokens"}}, msPerToken}}},
+			}},
+		}}},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var doc bucketDocument
+	err := l.collection.FindOneAndUpdate(ctx, bson.M{"_id": key}, pipeline, opts).Decode(&doc)
+	if mongo.IsDuplicateKeyError(err) {
+		// another replica created the bucket at the same time
+		err = l.collection.FindOneAndUpdate(ctx, bson.M{"_id": key}, pipeline, opts).Decode(&doc)
+	}
+	if err != nil {
+		return domain.RateLimitDecision{}, err
+	}
+
+	return limit.Decide(doc.Allowed, doc.Tokens), nil
+}
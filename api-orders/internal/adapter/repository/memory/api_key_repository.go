@@ -0,0 +1,97 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type apiKeyRepository struct {
+	table *table[domain.APIKey]
+}
+
+func NewAPIKeyRepository() ports.APIKeyRepository {
+	return &apiKeyRepository{
+		table: newTenantTable[domain.APIKey](),
+	}
+}
+
+func (r *apiKeyRepository) Create(ctx context.Context, key *domain.APIKey) error {
+	tenantID, err := r.table.tenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	key.ID = primitive.NewObjectID()
+	key.TenantID = tenantID
+	key.CreatedAt = time.Now()
+
+	return r.table.insert(ctx, key, func(existing, key *domain.APIKey) bool {
+		return existing.Hash == key.Hash
+	})
+}
+
+func (r *apiKeyRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*domain.APIKey, error) {
+	return r.table.find(ctx, byAPIKeyID(id))
+}
+
+// FindByHash looks the key up in every tenant, as requests are authenticated
+// before their tenant is resolved
+func (r *apiKeyRepository) FindByHash(ctx context.Context, hash string) (*domain.APIKey, error) {
+	return r.table.findAcrossTenants(func(key *domain.APIKey) bool { return key.Hash == hash })
+}
+
+func (r *apiKeyRepository) List(ctx context.Context) ([]domain.APIKey, error) {
+	keys, err := r.table.filter(ctx, all[domain.APIKey])
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(keys, func(i, j int) bool { return keys[i].CreatedAt.After(keys[j].CreatedAt) })
+	return keys, nil
+}
+
+func (r *apiKeyRepository) Rotate(ctx context.Context, id primitive.ObjectID, prefix, hash string) error {
+	_, err := r.table.update(ctx, activeAPIKey(id), func(key *domain.APIKey) error {
+		now := time.Now()
+		key.Prefix = prefix
+		key.Hash = hash
+		key.RotatedAt = &now
+		return nil
+	})
+	return err
+}
+
+func (r *apiKeyRepository) Revoke(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.table.update(ctx, activeAPIKey(id), func(key *domain.APIKey) error {
+		now := time.Now()
+		key.RevokedAt = &now
+		return nil
+	})
+	return err
+}
+
+func (r *apiKeyRepository) TouchLastUsed(ctx context.Context, id primitive.ObjectID, at time.Time) error {
+	_, err := r.table.update(ctx, byAPIKeyID(id), func(key *domain.APIKey) error {
+		key.LastUsedAt = &at
+		return nil
+	})
+	if err == mongo.ErrNoDocuments {
+		return nil
+	}
+	return err
+}
+
+func byAPIKeyID(id primitive.ObjectID) func(key *domain.APIKey) bool {
+	return func(key *domain.APIKey) bool { return key.ID == id }
+}
+
+// activeAPIKey matches the key until it is revoked
+func activeAPIKey(id primitive.ObjectID) func(key *domain.APIKey) bool {
+	return func(key *domain.APIKey) bool { return key.ID == id && key.RevokedAt == nil }
+}
@@ -0,0 +1,57 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// auditRepository only inserts and reads: the audit trail is append-only
+type auditRepository struct {
+	table *table[domain.AuditEntry]
+}
+
+func NewAuditRepository() ports.AuditRepository {
+	return &auditRepository{
+		table: newTenantTable[domain.AuditEntry](),
+	}
+}
+
+func (r *auditRepository) Append(ctx context.Context, entry *domain.AuditEntry) error {
+	tenantID, err := r.table.tenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	entry.ID = primitive.NewObjectID()
+	entry.TenantID = tenantID
+
+	return r.table.insert(ctx, entry, nil)
+}
+
+func (r *auditRepository) Find(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, int, error) {
+	entries, err := r.table.filter(ctx, func(entry *domain.AuditEntry) bool {
+		return (filter.Actor == "" || entry.Actor == filter.Actor) &&
+			(filter.Action == "" || entry.Action == filter.Action) &&
+			(filter.ResourceType == "" || entry.ResourceType == filter.ResourceType) &&
+			(filter.ResourceID == "" || entry.ResourceID == filter.ResourceID) &&
+			(filter.RequestID == "" || entry.RequestID == filter.RequestID) &&
+			(filter.From == nil || !entry.OccurredAt.Before(*filter.From)) &&
+			(filter.To == nil || !entry.OccurredAt.After(*filter.To))
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if !entries[i].OccurredAt.Equal(entries[j].OccurredAt) {
+			return entries[i].OccurredAt.After(entries[j].OccurredAt)
+		}
+		return entries[i].ID.Hex() > entries[j].ID.Hex()
+	})
+
+	return page(entries, filter.Offset, filter.Limit), len(entries), nil
+}
@@ -0,0 +1,49 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type categoryRepository struct {
+	table *table[domain.Category]
+}
+
+func NewCategoryRepository() ports.CategoryRepository {
+	return &categoryRepository{
+		table: newTenantTable[domain.Category](),
+	}
+}
+
+func (r *categoryRepository) Create(ctx context.Context, category *domain.Category) error {
+	tenantID, err := r.table.tenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	category.ID = primitive.NewObjectID()
+	category.TenantID = tenantID
+	category.CreatedAt = time.Now()
+	category.UpdatedAt = time.Now()
+
+	return r.table.insert(ctx, category, nil)
+}
+
+func (r *categoryRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*domain.Category, error) {
+	return r.table.find(ctx, func(category *domain.Category) bool { return category.ID == id })
+}
+
+func (r *categoryRepository) List(ctx context.Context) ([]domain.Category, error) {
+	categories, err := r.table.filter(ctx, all[domain.Category])
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(categories, func(i, j int) bool { return categories[i].Name < categories[j].Name })
+	return categories, nil
+}
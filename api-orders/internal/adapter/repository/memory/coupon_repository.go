@@ -0,0 +1,132 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type couponRepository struct {
+	table *table[domain.Coupon]
+}
+
+func NewCouponRepository() ports.CouponRepository {
+	return &couponRepository{
+		table: newTenantTable[domain.Coupon](),
+	}
+}
+
+func (r *couponRepository) Create(ctx context.Context, coupon *domain.Coupon) error {
+	tenantID, err := r.table.tenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	coupon.ID = primitive.NewObjectID()
+	coupon.TenantID = tenantID
+	coupon.CreatedAt = time.Now()
+	coupon.UpdatedAt = time.Now()
+
+	return r.table.insert(ctx, coupon, func(existing, coupon *domain.Coupon) bool {
+		return existing.Code == coupon.Code
+	})
+}
+
+func (r *couponRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*domain.Coupon, error) {
+	return r.table.find(ctx, byCouponID(id))
+}
+
+func (r *couponRepository) FindByCode(ctx context.Context, code string) (*domain.Coupon, error) {
+	return r.table.find(ctx, func(coupon *domain.Coupon) bool { return coupon.Code == code })
+}
+
+func (r *couponRepository) List(ctx context.Context) ([]domain.Coupon, error) {
+	coupons, err := r.table.filter(ctx, all[domain.Coupon])
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(coupons, func(i, j int) bool { return coupons[i].CreatedAt.After(coupons[j].CreatedAt) })
+	return coupons, nil
+}
+
+// Update replaces the coupon definition, leaving usage counters untouched
+func (r *couponRepository) Update(ctx context.Context, coupon *domain.Coupon) error {
+	coupon.UpdatedAt = time.Now()
+
+	_, err := r.table.update(ctx, byCouponID(coupon.ID), func(stored *domain.Coupon) error {
+		stored.Type = coupon.Type
+		stored.Value = coupon.Value
+		stored.Currency = coupon.Currency
+		stored.MinOrderValue = coupon.MinOrderValue
+		stored.ProductIDs = coupon.ProductIDs
+		stored.CategoryIDs = coupon.CategoryIDs
+		stored.ValidFrom = coupon.ValidFrom
+		stored.ValidUntil = coupon.ValidUntil
+		stored.MaxUses = coupon.MaxUses
+		stored.MaxUsesPerCustomer = coupon.MaxUsesPerCustomer
+		stored.Active = coupon.Active
+		stored.UpdatedAt = coupon.UpdatedAt
+		return nil
+	})
+	return err
+}
+
+func (r *couponRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	return r.table.delete(ctx, byCouponID(id))
+}
+
+// Redeem checks the limits and increments the counters under the table lock, so
+// concurrent orders cannot exceed the configured limits
+func (r *couponRepository) Redeem(ctx context.Context, id primitive.ObjectID, customerID string) error {
+	_, err := r.table.update(ctx, func(coupon *domain.Coupon) bool {
+		return coupon.ID == id && coupon.Active
+	}, func(coupon *domain.Coupon) error {
+		if coupon.MaxUses > 0 && coupon.UsedCount >= coupon.MaxUses {
+			return domain.ErrCouponUsageLimitReached
+		}
+		if customerID != "" && coupon.MaxUsesPerCustomer > 0 && coupon.CustomerUsage[customerID] >= coupon.MaxUsesPerCustomer {
+			return domain.ErrCouponUsageLimitReached
+		}
+
+		coupon.UsedCount++
+		if customerID != "" {
+			if coupon.CustomerUsage == nil {
+				coupon.CustomerUsage = make(map[string]int)
+			}
+			coupon.CustomerUsage[customerID]++
+		}
+		coupon.UpdatedAt = time.Now()
+		return nil
+	})
+	if err == mongo.ErrNoDocuments {
+		return domain.ErrCouponUsageLimitReached
+	}
+	return err
+}
+
+func (r *couponRepository) Release(ctx context.Context, id primitive.ObjectID, customerID string) error {
+	_, err := r.table.update(ctx, byCouponID(id), func(coupon *domain.Coupon) error {
+		coupon.UsedCount--
+		if customerID != "" {
+			if coupon.CustomerUsage == nil {
+				coupon.CustomerUsage = make(map[string]int)
+			}
+			coupon.CustomerUsage[customerID]--
+		}
+		return nil
+	})
+	if err == mongo.ErrNoDocuments {
+		return nil
+	}
+	return err
+}
+
+func byCouponID(id primitive.ObjectID) func(coupon *domain.Coupon) bool {
+	return func(coupon *domain.Coupon) bool { return coupon.ID == id }
+}
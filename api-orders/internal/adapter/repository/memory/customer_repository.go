@@ -0,0 +1,93 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type customerRepository struct {
+	table *table[domain.Customer]
+}
+
+func NewCustomerRepository() ports.CustomerRepository {
+	return &customerRepository{
+		table: newTenantTable[domain.Customer](),
+	}
+}
+
+func (r *customerRepository) Create(ctx context.Context, customer *domain.Customer) error {
+	tenantID, err := r.table.tenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	customer.ID = primitive.NewObjectID()
+	customer.TenantID = tenantID
+	customer.CreatedAt = time.Now()
+	customer.UpdatedAt = time.Now()
+
+	return r.table.insert(ctx, customer, sameEmailOrDocument)
+}
+
+// sameEmailOrDocument mirrors the unique indexes on email and on non-empty documents
+func sameEmailOrDocument(existing, customer *domain.Customer) bool {
+	return existing.ID != customer.ID &&
+		(existing.Email == customer.Email || (customer.Document != "" && existing.Document == customer.Document))
+}
+
+func (r *customerRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*domain.Customer, error) {
+	return r.table.find(ctx, byCustomerID(id))
+}
+
+func (r *customerRepository) FindByEmailOrDocument(ctx context.Context, email, document string) (*domain.Customer, error) {
+	return r.table.find(ctx, func(customer *domain.Customer) bool {
+		return customer.Email == email || customer.Document == document
+	})
+}
+
+func (r *customerRepository) List(ctx context.Context) ([]domain.Customer, error) {
+	customers, err := r.table.filter(ctx, all[domain.Customer])
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(customers, func(i, j int) bool { return customers[i].Name < customers[j].Name })
+	return customers, nil
+}
+
+func (r *customerRepository) Update(ctx context.Context, customer *domain.Customer) error {
+	customer.UpdatedAt = time.Now()
+
+	others, err := r.table.filter(ctx, func(existing *domain.Customer) bool {
+		return sameEmailOrDocument(existing, customer)
+	})
+	if err != nil {
+		return err
+	}
+	if len(others) > 0 {
+		return errDuplicateKey
+	}
+
+	_, err = r.table.update(ctx, byCustomerID(customer.ID), func(stored *domain.Customer) error {
+		stored.Name = customer.Name
+		stored.Email = customer.Email
+		stored.Document = customer.Document
+		stored.Addresses = customer.Addresses
+		stored.UpdatedAt = customer.UpdatedAt
+		return nil
+	})
+	return err
+}
+
+func (r *customerRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	return r.table.delete(ctx, byCustomerID(id))
+}
+
+func byCustomerID(id primitive.ObjectID) func(customer *domain.Customer) bool {
+	return func(customer *domain.Customer) bool { return customer.ID == id }
+}
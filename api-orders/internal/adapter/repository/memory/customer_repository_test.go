@@ -0,0 +1,61 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gvillela7/rank-my-app/internal/adapter/repository/memory"
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestCustomerRepository_ScopesToTenant(t *testing.T) {
+	repo := memory.NewCustomerRepository()
+	acme := domain.ContextWithTenant(context.Background(), "acme")
+	globex := domain.ContextWithTenant(context.Background(), "globex")
+
+	customer := &domain.Customer{Name: "Maria", Email: "maria@example.com"}
+	if err := repo.Create(acme, customer); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if customer.TenantID != "acme" {
+		t.Errorf("Expected the customer stamped with tenant acme, got %q", customer.TenantID)
+	}
+
+	if err := repo.Create(acme, &domain.Customer{Name: "Outra", Email: "maria@example.com"}); !mongo.IsDuplicateKeyError(err) {
+		t.Errorf("Expected a duplicate key error, got: %v", err)
+	}
+	if err := repo.Create(globex, &domain.Customer{Name: "Maria", Email: "maria@example.com"}); err != nil {
+		t.Errorf("Expected the e-mail to be free in another tenant, got: %v", err)
+	}
+
+	customers, err := repo.List(globex)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(customers) != 1 || customers[0].ID == customer.ID {
+		t.Errorf("Expected only the customer of globex listed, got %+v", customers)
+	}
+	if err := repo.Delete(globex, customer.ID); err != mongo.ErrNoDocuments {
+		t.Errorf("Expected mongo.ErrNoDocuments in another tenant, got: %v", err)
+	}
+}
+
+func TestAPIKeyRepository_FindsHashAcrossTenants(t *testing.T) {
+	repo := memory.NewAPIKeyRepository()
+	acme := domain.ContextWithTenant(context.Background(), "acme")
+
+	key := &domain.APIKey{Name: "ERP", Hash: "hash-1"}
+	if err := repo.Create(acme, key); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	// authentication runs before the tenant of the request is known
+	found, err := repo.FindByHash(context.Background(), "hash-1")
+	if err != nil || found.TenantID != "acme" {
+		t.Errorf("Expected the key of acme, got %+v and %v", found, err)
+	}
+	if keys, _ := repo.List(domain.ContextWithTenant(context.Background(), "globex")); len(keys) != 0 {
+		t.Errorf("Expected no keys listed in globex, got %d", len(keys))
+	}
+}
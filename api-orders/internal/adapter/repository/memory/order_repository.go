@@ -0,0 +1,114 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type orderRepository struct {
+	table *table[domain.Order]
+}
+
+func NewOrderRepository() ports.OrderRepository {
+	return &orderRepository{
+		table: newTenantTable[domain.Order](),
+	}
+}
+
+func (r *orderRepository) Create(ctx context.Context, order *domain.Order) error {
+	tenantID, err := r.table.tenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	order.ID = primitive.NewObjectID()
+	order.TenantID = tenantID
+	order.Version = 1
+	order.CreatedAt = time.Now()
+	order.UpdatedAt = time.Now()
+
+	return r.table.insert(ctx, order, func(existing, order *domain.Order) bool {
+		return existing.OrderNumber == order.OrderNumber
+	})
+}
+
+func (r *orderRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*domain.Order, error) {
+	return r.table.find(ctx, byOrderID(id))
+}
+
+func (r *orderRepository) FindByOrderNumber(ctx context.Context, orderNumber string) (*domain.Order, error) {
+	return r.table.find(ctx, func(order *domain.Order) bool {
+		return order.OrderNumber == orderNumber
+	})
+}
+
+func (r *orderRepository) UpdateStatus(ctx context.Context, id primitive.ObjectID, status string, version int64) error {
+	_, err := r.table.update(ctx, byOrderID(id), func(order *domain.Order) error {
+		if err := checkVersion(order.Version, version); err != nil {
+			return err
+		}
+		order.Status = status
+		order.Version++
+		order.UpdatedAt = time.Now()
+		return nil
+	})
+	return err
+}
+
+func (r *orderRepository) FindByCustomerID(ctx context.Context, customerID string) ([]domain.Order, error) {
+	orders, err := r.table.filter(ctx, func(order *domain.Order) bool {
+		return order.CustomerID == customerID
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(orders, func(i, j int) bool { return orders[i].CreatedAt.After(orders[j].CreatedAt) })
+	return orders, nil
+}
+
+func (r *orderRepository) UpdateFulfillment(ctx context.Context, id primitive.ObjectID, shipments []domain.ShipmentSummary, status string, version int64) error {
+	_, err := r.table.update(ctx, byOrderID(id), func(order *domain.Order) error {
+		if err := checkVersion(order.Version, version); err != nil {
+			return err
+		}
+		order.Shipments = shipments
+		order.Status = status
+		order.Version++
+		order.UpdatedAt = time.Now()
+		return nil
+	})
+	return err
+}
+
+func (r *orderRepository) FindUnreleasedDeclined(ctx context.Context, limit int) ([]domain.Order, error) {
+	orders, err := r.table.filterAcrossTenants(func(order *domain.Order) bool {
+		return order.Status == domain.OrderStatusPaymentDeclined && order.ReleasedAt == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(orders, func(i, j int) bool { return orders[i].CreatedAt.Before(orders[j].CreatedAt) })
+	return page(orders, 0, limit), nil
+}
+
+func (r *orderRepository) MarkReleased(ctx context.Context, id primitive.ObjectID, at time.Time) error {
+	_, err := r.table.update(ctx, func(order *domain.Order) bool {
+		return order.ID == id && order.ReleasedAt == nil
+	}, func(order *domain.Order) error {
+		order.ReleasedAt = &at
+		order.UpdatedAt = time.Now()
+		return nil
+	})
+	return err
+}
+
+func byOrderID(id primitive.ObjectID) func(order *domain.Order) bool {
+	return func(order *domain.Order) bool { return order.ID == id }
+}
@@ -0,0 +1,115 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type priceChangeRepository struct {
+	table *table[domain.PriceChange]
+}
+
+func NewPriceChangeRepository() ports.PriceChangeRepository {
+	return &priceChangeRepository{
+		table: newTenantTable[domain.PriceChange](),
+	}
+}
+
+func (r *priceChangeRepository) Create(ctx context.Context, change *domain.PriceChange) error {
+	tenantID, err := r.table.tenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	change.ID = primitive.NewObjectID()
+	change.TenantID = tenantID
+	change.CreatedAt = time.Now()
+
+	return r.table.insert(ctx, change, nil)
+}
+
+func (r *priceChangeRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*domain.PriceChange, error) {
+	return r.table.find(ctx, func(change *domain.PriceChange) bool { return change.ID == id })
+}
+
+func (r *priceChangeRepository) FindByProductID(ctx context.Context, productID primitive.ObjectID) ([]domain.PriceChange, error) {
+	changes, err := r.table.filter(ctx, func(change *domain.PriceChange) bool {
+		return change.ProductID == productID
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sortLatestFirst(changes)
+	return changes, nil
+}
+
+func (r *priceChangeRepository) FindEffective(ctx context.Context, productID primitive.ObjectID, at time.Time) (*domain.PriceChange, error) {
+	changes, err := r.table.filter(ctx, func(change *domain.PriceChange) bool {
+		return change.ProductID == productID &&
+			change.Status != domain.PriceChangeCancelled &&
+			!change.EffectiveAt.After(at)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return nil, mongo.ErrNoDocuments
+	}
+
+	sortLatestFirst(changes)
+	return &changes[0], nil
+}
+
+func (r *priceChangeRepository) FindDue(ctx context.Context, at time.Time, limit int) ([]domain.PriceChange, error) {
+	changes, err := r.table.filterAcrossTenants(func(change *domain.PriceChange) bool {
+		return change.Status == domain.PriceChangeScheduled && !change.EffectiveAt.After(at)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool { return changes[i].EffectiveAt.Before(changes[j].EffectiveAt) })
+	return page(changes, 0, limit), nil
+}
+
+func (r *priceChangeRepository) MarkApplied(ctx context.Context, change *domain.PriceChange) error {
+	return r.resolve(ctx, change.ID, func(stored *domain.PriceChange) {
+		stored.Status = domain.PriceChangeApplied
+		stored.PreviousPrice = change.PreviousPrice
+		stored.AppliedAt = change.AppliedAt
+	})
+}
+
+func (r *priceChangeRepository) Cancel(ctx context.Context, id primitive.ObjectID) error {
+	return r.resolve(ctx, id, func(stored *domain.PriceChange) {
+		stored.Status = domain.PriceChangeCancelled
+	})
+}
+
+// resolve updates a change that is still scheduled, returning mongo.ErrNoDocuments otherwise
+func (r *priceChangeRepository) resolve(ctx context.Context, id primitive.ObjectID, set func(change *domain.PriceChange)) error {
+	_, err := r.table.update(ctx, func(change *domain.PriceChange) bool {
+		return change.ID == id && change.Status == domain.PriceChangeScheduled
+	}, func(change *domain.PriceChange) error {
+		set(change)
+		return nil
+	})
+	return err
+}
+
+// sortLatestFirst orders changes by effective date, then creation, newest first
+func sortLatestFirst(changes []domain.PriceChange) {
+	sort.SliceStable(changes, func(i, j int) bool {
+		if !changes[i].EffectiveAt.Equal(changes[j].EffectiveAt) {
+			return changes[i].EffectiveAt.After(changes[j].EffectiveAt)
+		}
+		return changes[i].ID.Hex() > changes[j].ID.Hex()
+	})
+}
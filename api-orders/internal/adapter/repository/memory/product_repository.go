@@ -0,0 +1,300 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// maxTagFacets caps how many tags are counted in a search
+const maxTagFacets = 50
+
+type productRepository struct {
+	table *table[domain.Product]
+}
+
+func NewProductRepository() ports.ProductRepository {
+	return &productRepository{
+		table: newTenantTable[domain.Product](),
+	}
+}
+
+func (r *productRepository) Create(ctx context.Context, product *domain.Product) error {
+	tenantID, err := r.table.tenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	product.ID = primitive.NewObjectID()
+	product.TenantID = tenantID
+	product.Version = 1
+	product.CreatedAt = time.Now()
+	product.UpdatedAt = time.Now()
+
+	return r.table.insert(ctx, product, sameSKU)
+}
+
+// sameSKU mirrors the unique indexes on sku and on variants.sku
+func sameSKU(existing, product *domain.Product) bool {
+	if product.SKU != "" && existing.SKU == product.SKU {
+		return true
+	}
+	for _, variant := range product.Variants {
+		if existing.VariantBySKU(variant.SKU) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *productRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*domain.Product, error) {
+	return r.table.find(ctx, byProductID(id))
+}
+
+func (r *productRepository) FindBySKU(ctx context.Context, sku string) (*domain.Product, error) {
+	return r.table.find(ctx, func(product *domain.Product) bool {
+		return product.SKU == sku || product.VariantBySKU(sku) != nil
+	})
+}
+
+func (r *productRepository) FindByVariantID(ctx context.Context, variantID primitive.ObjectID) (*domain.Product, error) {
+	return r.table.find(ctx, func(product *domain.Product) bool {
+		return product.Variant(variantID) != nil
+	})
+}
+
+func (r *productRepository) List(ctx context.Context) ([]domain.Product, error) {
+	return r.table.filter(ctx, all[domain.Product])
+}
+
+func (r *productRepository) Each(ctx context.Context, fn func(product *domain.Product) error) error {
+	products, err := r.table.filter(ctx, all[domain.Product])
+	if err != nil {
+		return err
+	}
+	sort.Slice(products, func(i, j int) bool { return products[i].ID.Hex() < products[j].ID.Hex() })
+
+	for i := range products {
+		if err := fn(&products[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *productRepository) Update(ctx context.Context, product *domain.Product) error {
+	product.UpdatedAt = time.Now()
+
+	_, err := r.table.update(ctx, byProductID(product.ID), func(stored *domain.Product) error {
+		if err := checkVersion(stored.Version, product.Version); err != nil {
+			return err
+		}
+
+		stored.Name = product.Name
+		stored.Description = product.Description
+		stored.Price = product.Price
+		stored.Currency = product.Currency
+		stored.Prices = product.Prices
+		stored.TaxClass = product.TaxClass
+		stored.ReorderThreshold = product.ReorderThreshold
+		stored.CategoryID = product.CategoryID
+		stored.CategoryPath = product.CategoryPath
+		stored.Tags = product.Tags
+		stored.Attributes = product.Attributes
+		stored.UpdatedAt = product.UpdatedAt
+
+		// variant quantities are moved only through the stock ledger
+		for _, variant := range product.Variants {
+			if storedVariant := stored.Variant(variant.ID); storedVariant != nil {
+				storedVariant.Price = variant.Price
+				storedVariant.Attributes = variant.Attributes
+			}
+		}
+
+		stored.Version++
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	product.Version++
+	return nil
+}
+
+// Search approximates the text search of MongoDB: products whose name or
+// description contains any of the words match, those containing more words first
+func (r *productRepository) Search(ctx context.Context, filter domain.ProductFilter) (*domain.ProductSearchResult, error) {
+	words := strings.Fields(strings.ToLower(filter.Text))
+	scores := make(map[primitive.ObjectID]int)
+
+	products, err := r.table.filter(ctx, func(product *domain.Product) bool {
+		if len(words) > 0 {
+			text := strings.ToLower(product.Name + " " + product.Description)
+			score := 0
+			for _, word := range words {
+				if strings.Contains(text, word) {
+					score++
+				}
+			}
+			if score == 0 {
+				return false
+			}
+			scores[product.ID] = score
+		}
+		if filter.CategoryID != nil && !containsID(product.CategoryPath, *filter.CategoryID) {
+			return false
+		}
+		if filter.Tag != "" && !containsString(product.Tags, filter.Tag) {
+			return false
+		}
+		if filter.MinPrice != nil && product.Price < *filter.MinPrice {
+			return false
+		}
+		if filter.MaxPrice != nil && product.Price > *filter.MaxPrice {
+			return false
+		}
+		return !filter.InStock || product.Quantity > 0
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(products, func(i, j int) bool {
+		if scores[products[i].ID] != scores[products[j].ID] {
+			return scores[products[i].ID] > scores[products[j].ID]
+		}
+		return products[i].Name < products[j].Name
+	})
+
+	categories := make(map[string]int)
+	tags := make(map[string]int)
+	for _, product := range products {
+		for _, id := range product.CategoryPath {
+			categories[id.Hex()]++
+		}
+		for _, tag := range product.Tags {
+			tags[tag]++
+		}
+	}
+
+	result := &domain.ProductSearchResult{
+		Products:       page(products, filter.Offset, filter.Limit),
+		Total:          len(products),
+		CategoryFacets: facets(categories, 0),
+		TagFacets:      facets(tags, maxTagFacets),
+	}
+	return result, nil
+}
+
+func (r *productRepository) AdjustQuantity(ctx context.Context, id primitive.ObjectID, delta int) (*domain.Product, error) {
+	return r.table.update(ctx, byProductID(id), func(product *domain.Product) error {
+		if product.Quantity+delta < 0 {
+			return domain.ErrInsufficientStock
+		}
+		product.Quantity += delta
+		product.Version++
+		product.UpdatedAt = time.Now()
+		return nil
+	})
+}
+
+func (r *productRepository) AdjustVariantQuantity(ctx context.Context, id, variantID primitive.ObjectID, delta int) (*domain.Product, error) {
+	return r.table.update(ctx, byProductID(id), func(product *domain.Product) error {
+		variant := product.Variant(variantID)
+		if variant == nil {
+			return mongo.ErrNoDocuments
+		}
+		if variant.Quantity+delta < 0 {
+			return domain.ErrInsufficientStock
+		}
+		variant.Quantity += delta
+		product.Quantity += delta
+		product.Version++
+		product.UpdatedAt = time.Now()
+		return nil
+	})
+}
+
+func (r *productRepository) SetQuantity(ctx context.Context, id primitive.ObjectID, quantity int) error {
+	_, err := r.table.update(ctx, byProductID(id), func(product *domain.Product) error {
+		product.Quantity = quantity
+		product.Version++
+		product.UpdatedAt = time.Now()
+		return nil
+	})
+	return err
+}
+
+func (r *productRepository) SetPrice(ctx context.Context, id primitive.ObjectID, price float64, version int64) error {
+	_, err := r.table.update(ctx, byProductID(id), func(product *domain.Product) error {
+		if err := checkVersion(product.Version, version); err != nil {
+			return err
+		}
+		product.Price = price
+		product.Version++
+		product.UpdatedAt = time.Now()
+		return nil
+	})
+	return err
+}
+
+func (r *productRepository) SetReorderThreshold(ctx context.Context, id primitive.ObjectID, threshold int, version int64) error {
+	_, err := r.table.update(ctx, byProductID(id), func(product *domain.Product) error {
+		if err := checkVersion(product.Version, version); err != nil {
+			return err
+		}
+		product.ReorderThreshold = threshold
+		product.Version++
+		product.UpdatedAt = time.Now()
+		return nil
+	})
+	return err
+}
+
+func byProductID(id primitive.ObjectID) func(product *domain.Product) bool {
+	return func(product *domain.Product) bool { return product.ID == id }
+}
+
+func containsID(ids []primitive.ObjectID, id primitive.ObjectID) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
+
+// facets sorts the counts by count, then value, keeping the first limit ones
+// when limit is positive
+func facets(counts map[string]int, limit int) []domain.FacetCount {
+	result := make([]domain.FacetCount, 0, len(counts))
+	for value, count := range counts {
+		result = append(result, domain.FacetCount{Value: value, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Value < result[j].Value
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
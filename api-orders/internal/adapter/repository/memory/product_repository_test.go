@@ -0,0 +1,43 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gvillela7/rank-my-app/internal/adapter/repository/memory"
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestProductRepository_ScopesToTenant(t *testing.T) {
+	repo := memory.NewProductRepository()
+	acme := domain.ContextWithTenant(context.Background(), "acme")
+	globex := domain.ContextWithTenant(context.Background(), "globex")
+
+	product := &domain.Product{Name: "Teclado", SKU: "TEC-1", Quantity: 1}
+	if err := repo.Create(acme, product); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if err := repo.Create(acme, &domain.Product{Name: "Outro", SKU: "TEC-1"}); !mongo.IsDuplicateKeyError(err) {
+		t.Errorf("Expected a duplicate key error, got: %v", err)
+	}
+	if err := repo.Create(globex, &domain.Product{Name: "Teclado", SKU: "TEC-1"}); err != nil {
+		t.Errorf("Expected the SKU to be free in another tenant, got: %v", err)
+	}
+
+	if _, err := repo.FindByID(globex, product.ID); err != mongo.ErrNoDocuments {
+		t.Errorf("Expected mongo.ErrNoDocuments in another tenant, got: %v", err)
+	}
+	if _, err := repo.FindByID(context.Background(), product.ID); !errors.Is(err, domain.ErrMissingTenant) {
+		t.Errorf("Expected ErrMissingTenant without tenant, got: %v", err)
+	}
+
+	if _, err := repo.AdjustQuantity(acme, product.ID, -2); !errors.Is(err, domain.ErrInsufficientStock) {
+		t.Errorf("Expected ErrInsufficientStock, got: %v", err)
+	}
+	if err := repo.SetPrice(acme, product.ID, 10, product.Version+1); !errors.Is(err, domain.ErrVersionConflict) {
+		t.Errorf("Expected ErrVersionConflict, got: %v", err)
+	}
+}
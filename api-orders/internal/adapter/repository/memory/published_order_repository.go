@@ -0,0 +1,33 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+)
+
+type publishedOrderRepository struct {
+	table *table[domain.PublishedOrder]
+}
+
+func NewPublishedOrderRepository() ports.PublishedOrderRepository {
+	return &publishedOrderRepository{
+		table: newTenantTable[domain.PublishedOrder](),
+	}
+}
+
+func (r *publishedOrderRepository) Create(ctx context.Context, publishedOrder *domain.PublishedOrder) error {
+	tenantID, err := r.table.tenant(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create published order record: %w", err)
+	}
+
+	publishedOrder.TenantID = tenantID
+
+	if err := r.table.insert(ctx, publishedOrder, nil); err != nil {
+		return fmt.Errorf("failed to create published order record: %w", err)
+	}
+	return nil
+}
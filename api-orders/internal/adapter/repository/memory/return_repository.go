@@ -0,0 +1,66 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type returnRepository struct {
+	table *table[domain.ReturnRequest]
+}
+
+func NewReturnRepository() ports.ReturnRepository {
+	return &returnRepository{
+		table: newTenantTable[domain.ReturnRequest](),
+	}
+}
+
+func (r *returnRepository) Create(ctx context.Context, ret *domain.ReturnRequest) error {
+	tenantID, err := r.table.tenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	ret.ID = primitive.NewObjectID()
+	ret.TenantID = tenantID
+	ret.CreatedAt = time.Now()
+	ret.UpdatedAt = time.Now()
+
+	return r.table.insert(ctx, ret, nil)
+}
+
+func (r *returnRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*domain.ReturnRequest, error) {
+	return r.table.find(ctx, func(ret *domain.ReturnRequest) bool { return ret.ID == id })
+}
+
+func (r *returnRepository) FindByOrderID(ctx context.Context, orderID string) ([]domain.ReturnRequest, error) {
+	returns, err := r.table.filter(ctx, func(ret *domain.ReturnRequest) bool {
+		return ret.OrderID == orderID
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(returns, func(i, j int) bool { return returns[i].CreatedAt.Before(returns[j].CreatedAt) })
+	return returns, nil
+}
+
+func (r *returnRepository) Resolve(ctx context.Context, ret *domain.ReturnRequest) error {
+	ret.UpdatedAt = time.Now()
+
+	_, err := r.table.update(ctx, func(stored *domain.ReturnRequest) bool {
+		return stored.ID == ret.ID && stored.Status == domain.ReturnStatusRequested
+	}, func(stored *domain.ReturnRequest) error {
+		stored.Status = ret.Status
+		stored.RejectionReason = ret.RejectionReason
+		stored.ResolvedAt = ret.ResolvedAt
+		stored.UpdatedAt = ret.UpdatedAt
+		return nil
+	})
+	return err
+}
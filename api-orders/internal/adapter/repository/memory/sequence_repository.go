@@ -0,0 +1,34 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+)
+
+type sequenceRepository struct {
+	mu       sync.Mutex
+	counters map[string]int64
+}
+
+func NewSequenceRepository() ports.SequenceRepository {
+	return &sequenceRepository{
+		counters: make(map[string]int64),
+	}
+}
+
+func (r *sequenceRepository) Next(ctx context.Context, name string) (int64, error) {
+	tenantID, ok := domain.TenantFromContext(ctx)
+	if !ok {
+		return 0, domain.ErrMissingTenant
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := tenantID + ":" + name
+	r.counters[key]++
+	return r.counters[key], nil
+}
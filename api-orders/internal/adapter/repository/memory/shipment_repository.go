@@ -0,0 +1,71 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type shipmentRepository struct {
+	table *table[domain.Shipment]
+}
+
+func NewShipmentRepository() ports.ShipmentRepository {
+	return &shipmentRepository{
+		table: newTenantTable[domain.Shipment](),
+	}
+}
+
+func (r *shipmentRepository) Create(ctx context.Context, shipment *domain.Shipment) error {
+	tenantID, err := r.table.tenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	shipment.ID = primitive.NewObjectID()
+	shipment.TenantID = tenantID
+	shipment.CreatedAt = time.Now()
+	shipment.UpdatedAt = time.Now()
+
+	return r.table.insert(ctx, shipment, nil)
+}
+
+func (r *shipmentRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*domain.Shipment, error) {
+	return r.table.find(ctx, func(shipment *domain.Shipment) bool { return shipment.ID == id })
+}
+
+func (r *shipmentRepository) FindByOrderID(ctx context.Context, orderID string) ([]domain.Shipment, error) {
+	shipments, err := r.table.filter(ctx, func(shipment *domain.Shipment) bool {
+		return shipment.OrderID == orderID
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(shipments, func(i, j int) bool { return shipments[i].ShippedAt.Before(shipments[j].ShippedAt) })
+	return shipments, nil
+}
+
+func (r *shipmentRepository) MarkDelivered(ctx context.Context, shipment *domain.Shipment) error {
+	shipment.UpdatedAt = time.Now()
+
+	_, err := r.table.update(ctx, func(stored *domain.Shipment) bool {
+		return stored.ID == shipment.ID && stored.Status != domain.ShipmentStatusDelivered
+	}, func(stored *domain.Shipment) error {
+		stored.Status = shipment.Status
+		stored.DeliveredAt = shipment.DeliveredAt
+		stored.ReceivedBy = shipment.ReceivedBy
+		stored.ProofURL = shipment.ProofURL
+		stored.UpdatedAt = shipment.UpdatedAt
+		return nil
+	})
+	return err
+}
+
+func (r *shipmentRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	return r.table.delete(ctx, func(shipment *domain.Shipment) bool { return shipment.ID == id })
+}
@@ -0,0 +1,83 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type stockMovementRepository struct {
+	table *table[domain.StockMovement]
+}
+
+func NewStockMovementRepository() ports.StockMovementRepository {
+	return &stockMovementRepository{
+		table: newTenantTable[domain.StockMovement](),
+	}
+}
+
+func (r *stockMovementRepository) Create(ctx context.Context, movement *domain.StockMovement) error {
+	tenantID, err := r.table.tenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	movement.ID = primitive.NewObjectID()
+	movement.TenantID = tenantID
+	movement.CreatedAt = time.Now()
+
+	return r.table.insert(ctx, movement, sameLine)
+}
+
+// sameLine mirrors the unique indexes restocking each line of a return, and
+// cancelling each line of an order, once
+func sameLine(existing, movement *domain.StockMovement) bool {
+	if movement.Type != domain.StockMovementReturn && movement.Type != domain.StockMovementCancellation {
+		return false
+	}
+	return existing.Type == movement.Type &&
+		existing.Reference == movement.Reference && existing.ProductID == movement.ProductID &&
+		sameVariant(existing.VariantID, movement.VariantID)
+}
+
+func sameVariant(a, b *primitive.ObjectID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func (r *stockMovementRepository) FindByProductID(ctx context.Context, productID primitive.ObjectID) ([]domain.StockMovement, error) {
+	movements, err := r.table.filter(ctx, func(movement *domain.StockMovement) bool {
+		return movement.ProductID == productID
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(movements, func(i, j int) bool { return movements[i].CreatedAt.After(movements[j].CreatedAt) })
+	return movements, nil
+}
+
+func (r *stockMovementRepository) FindByReference(ctx context.Context, movementType, reference string) ([]domain.StockMovement, error) {
+	return r.table.filter(ctx, func(movement *domain.StockMovement) bool {
+		return movement.Type == movementType && movement.Reference == reference
+	})
+}
+
+func (r *stockMovementRepository) SumByProduct(ctx context.Context) (map[primitive.ObjectID]int, error) {
+	movements, err := r.table.filter(ctx, all[domain.StockMovement])
+	if err != nil {
+		return nil, err
+	}
+
+	balances := make(map[primitive.ObjectID]int)
+	for _, movement := range movements {
+		balances[movement.ProductID] += movement.Quantity
+	}
+	return balances, nil
+}
@@ -0,0 +1,241 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// codeDuplicateKey is the server error code of a unique index violation, so that
+// mongo.IsDuplicateKeyError recognizes errDuplicateKey
+const codeDuplicateKey = 11000
+
+var errDuplicateKey = mongo.WriteException{
+	WriteErrors: mongo.WriteErrors{{Code: codeDuplicateKey, Message: "duplicate key"}},
+}
+
+// table holds the documents of a collection in insertion order. Documents are
+// copied through BSON on the way in and out, so callers never share memory with
+// the table and read documents back as they would from MongoDB. The documents of
+// a tenant-owned table are restricted to the tenant of the context, like
+// tenantCollection does, and its operations fail with domain.ErrMissingTenant
+// when the context carries no tenant. Not found is mongo.ErrNoDocuments, as the
+// use cases expect from any repository.
+type table[T any] struct {
+	mu      sync.RWMutex
+	tenants bool
+	rows    []row[T]
+}
+
+type row[T any] struct {
+	tenant string
+	doc    *T
+}
+
+func newTable[T any]() *table[T] {
+	return &table[T]{}
+}
+
+func newTenantTable[T any]() *table[T] {
+	return &table[T]{tenants: true}
+}
+
+// tenant returns the tenant of ctx, which repositories stamp on the documents
+// they insert. Tables that are not tenant-owned have no tenant.
+func (t *table[T]) tenant(ctx context.Context) (string, error) {
+	if !t.tenants {
+		return "", nil
+	}
+	tenantID, ok := domain.TenantFromContext(ctx)
+	if !ok {
+		return "", domain.ErrMissingTenant
+	}
+	return tenantID, nil
+}
+
+// insert appends doc, failing with a duplicate key error when unique reports that
+// it collides with a document of the table
+func (t *table[T]) insert(ctx context.Context, doc *T, unique func(existing, doc *T) bool) error {
+	tenantID, err := t.tenant(ctx)
+	if err != nil {
+		return err
+	}
+	stored, err := clone(doc)
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if unique != nil {
+		for _, r := range t.rows {
+			if r.tenant == tenantID && unique(r.doc, doc) {
+				return errDuplicateKey
+			}
+		}
+	}
+
+	t.rows = append(t.rows, row[T]{tenant: tenantID, doc: stored})
+	return nil
+}
+
+// find returns the first document of the tenant that matches
+func (t *table[T]) find(ctx context.Context, match func(doc *T) bool) (*T, error) {
+	tenantID, err := t.tenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	for _, r := range t.rows {
+		if r.tenant == tenantID && match(r.doc) {
+			return clone(r.doc)
+		}
+	}
+	return nil, mongo.ErrNoDocuments
+}
+
+// findAcrossTenants returns the first document of any tenant that matches, for the
+// callers resolving the tenant from the document itself
+func (t *table[T]) findAcrossTenants(match func(doc *T) bool) (*T, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	for _, r := range t.rows {
+		if match(r.doc) {
+			return clone(r.doc)
+		}
+	}
+	return nil, mongo.ErrNoDocuments
+}
+
+// filter returns the documents of the tenant that match, in insertion order
+func (t *table[T]) filter(ctx context.Context, match func(doc *T) bool) ([]T, error) {
+	tenantID, err := t.tenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return t.collect(func(r row[T]) bool { return r.tenant == tenantID && match(r.doc) })
+}
+
+// filterAcrossTenants returns the matching documents of every tenant, for the
+// background jobs serving them all
+func (t *table[T]) filterAcrossTenants(match func(doc *T) bool) ([]T, error) {
+	return t.collect(func(r row[T]) bool { return match(r.doc) })
+}
+
+func (t *table[T]) collect(keep func(r row[T]) bool) ([]T, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	docs := make([]T, 0)
+	for _, r := range t.rows {
+		if !keep(r) {
+			continue
+		}
+		doc, err := clone(r.doc)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, *doc)
+	}
+	return docs, nil
+}
+
+// update applies change to the first document of the tenant that matches and
+// returns it as stored. The document is left as it was when change fails.
+func (t *table[T]) update(ctx context.Context, match func(doc *T) bool, change func(doc *T) error) (*T, error) {
+	tenantID, err := t.tenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for i, r := range t.rows {
+		if r.tenant != tenantID || !match(r.doc) {
+			continue
+		}
+
+		updated, err := clone(r.doc)
+		if err != nil {
+			return nil, err
+		}
+		if err := change(updated); err != nil {
+			return nil, err
+		}
+		stored, err := clone(updated)
+		if err != nil {
+			return nil, err
+		}
+		t.rows[i].doc = stored
+		return updated, nil
+	}
+	return nil, mongo.ErrNoDocuments
+}
+
+// delete removes the first document of the tenant that matches
+func (t *table[T]) delete(ctx context.Context, match func(doc *T) bool) error {
+	tenantID, err := t.tenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for i, r := range t.rows {
+		if r.tenant == tenantID && match(r.doc) {
+			t.rows = append(t.rows[:i], t.rows[i+1:]...)
+			return nil
+		}
+	}
+	return mongo.ErrNoDocuments
+}
+
+// clone copies doc through BSON. Embedded documents of interface fields decode as
+// maps, as the audit repository configures for MongoDB.
+func clone[T any](doc *T) (*T, error) {
+	data, err := bson.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder, err := bson.NewDecoder(bsonrw.NewBSONDocumentReader(data))
+	if err != nil {
+		return nil, err
+	}
+	decoder.DefaultDocumentM()
+
+	var copied T
+	if err := decoder.Decode(&copied); err != nil {
+		return nil, err
+	}
+	return &copied, nil
+}
+
+// all matches every document
+func all[T any](*T) bool {
+	return true
+}
+
+// page returns the documents from offset on, at most limit of them when limit is
+// positive
+func page[T any](docs []T, offset, limit int) []T {
+	if offset >= len(docs) {
+		return make([]T, 0)
+	}
+	docs = docs[offset:]
+	if limit > 0 && len(docs) > limit {
+		docs = docs[:limit]
+	}
+	return docs
+}
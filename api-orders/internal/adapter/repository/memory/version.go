@@ -0,0 +1,12 @@
+package memory
+
+import "github.com/gvillela7/rank-my-app/internal/core/domain"
+
+// checkVersion conditions an update on the version of the stored document, like
+// withVersion does for MongoDB
+func checkVersion(stored, expected int64) error {
+	if stored != expected {
+		return domain.ErrVersionConflict
+	}
+	return nil
+}
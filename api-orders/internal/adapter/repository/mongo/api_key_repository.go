@@ -0,0 +1,130 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type apiKeyRepository struct {
+	collection tenantCollection
+}
+
+func NewAPIKeyRepository(db *mongo.Database) ports.APIKeyRepository {
+	return &apiKeyRepository{
+		collection: newTenantCollection(db, "api_keys"),
+	}
+}
+
+func (r *apiKeyRepository) Create(ctx context.Context, key *domain.APIKey) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tenantID, err := r.collection.tenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	key.ID = primitive.NewObjectID()
+	key.TenantID = tenantID
+	key.CreatedAt = time.Now()
+
+	return r.collection.InsertOne(ctx, key)
+}
+
+func (r *apiKeyRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*domain.APIKey, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	var key domain.APIKey
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&key)
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// FindByHash looks the key up in every tenant: requests are authenticated before
+// their tenant is resolved, and the key tells which tenant it is bound to
+func (r *apiKeyRepository) FindByHash(ctx context.Context, hash string) (*domain.APIKey, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	var key domain.APIKey
+	err := r.collection.FindOneAcrossTenants(ctx, bson.M{"hash": hash}).Decode(&key)
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *apiKeyRepository) List(ctx context.Context) ([]domain.APIKey, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	cursor, err := r.collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]domain.APIKey, 0)
+	if err := cursor.All(ctx, &keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (r *apiKeyRepository) Rotate(ctx context.Context, id primitive.ObjectID, prefix, hash string) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	filter := bson.M{"_id": id, "revoked_at": bson.M{"$exists": false}}
+	update := bson.M{"$set": bson.M{
+		"prefix":     prefix,
+		"hash":       hash,
+		"rotated_at": time.Now(),
+	}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (r *apiKeyRepository) Revoke(ctx context.Context, id primitive.ObjectID) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	filter := bson.M{"_id": id, "revoked_at": bson.M{"$exists": false}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"revoked_at": time.Now()}})
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (r *apiKeyRepository) TouchLastUsed(ctx context.Context, id primitive.ObjectID, at time.Time) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"last_used_at": at}})
+	return err
+}
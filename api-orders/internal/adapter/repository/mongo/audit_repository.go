@@ -0,0 +1,95 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// auditRepository only inserts and reads: the audit trail is append-only
+type auditRepository struct {
+	collection tenantCollection
+}
+
+func NewAuditRepository(db *mongo.Database) ports.AuditRepository {
+	// changed values are free-form; embedded documents decode as maps so that they
+	// render as JSON objects
+	bsonOpts := options.Collection().SetBSONOptions(&options.BSONOptions{DefaultDocumentM: true})
+
+	return &auditRepository{
+		collection: newTenantCollection(db, "audit_log", bsonOpts),
+	}
+}
+
+func (r *auditRepository) Append(ctx context.Context, entry *domain.AuditEntry) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tenantID, err := r.collection.tenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	entry.ID = primitive.NewObjectID()
+	entry.TenantID = tenantID
+
+	return r.collection.InsertOne(ctx, entry)
+}
+
+func (r *auditRepository) Find(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, int, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	query := bson.M{}
+	if filter.Actor != "" {
+		query["actor"] = filter.Actor
+	}
+	if filter.Action != "" {
+		query["action"] = filter.Action
+	}
+	if filter.ResourceType != "" {
+		query["resource_type"] = filter.ResourceType
+	}
+	if filter.ResourceID != "" {
+		query["resource_id"] = filter.ResourceID
+	}
+	if filter.RequestID != "" {
+		query["request_id"] = filter.RequestID
+	}
+	if filter.From != nil || filter.To != nil {
+		occurredAt := bson.M{}
+		if filter.From != nil {
+			occurredAt["$gte"] = *filter.From
+		}
+		if filter.To != nil {
+			occurredAt["$lte"] = *filter.To
+		}
+		query["occurred_at"] = occurredAt
+	}
+
+	total, err := r.collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "occurred_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64(filter.Offset)).
+		SetLimit(int64(filter.Limit))
+
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	entries := make([]domain.AuditEntry, 0)
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, 0, err
+	}
+	return entries, int(total), nil
+}
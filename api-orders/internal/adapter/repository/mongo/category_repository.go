@@ -0,0 +1,69 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type categoryRepository struct {
+	collection tenantCollection
+}
+
+func NewCategoryRepository(db *mongo.Database) ports.CategoryRepository {
+	return &categoryRepository{
+		collection: newTenantCollection(db, "categories"),
+	}
+}
+
+func (r *categoryRepository) Create(ctx context.Context, category *domain.Category) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tenantID, err := r.collection.tenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	category.ID = primitive.NewObjectID()
+	category.TenantID = tenantID
+	category.CreatedAt = time.Now()
+	category.UpdatedAt = time.Now()
+
+	return r.collection.InsertOne(ctx, category)
+}
+
+func (r *categoryRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*domain.Category, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	var category domain.Category
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&category)
+	if err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+func (r *categoryRepository) List(ctx context.Context) ([]domain.Category, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	categories := make([]domain.Category, 0)
+	if err := cursor.All(ctx, &categories); err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
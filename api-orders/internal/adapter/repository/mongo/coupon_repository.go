@@ -0,0 +1,190 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type couponRepository struct {
+	collection tenantCollection
+}
+
+func NewCouponRepository(db *mongo.Database) ports.CouponRepository {
+	return &couponRepository{
+		collection: newTenantCollection(db, "coupons"),
+	}
+}
+
+func (r *couponRepository) Create(ctx context.Context, coupon *domain.Coupon) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tenantID, err := r.collection.tenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	coupon.ID = primitive.NewObjectID()
+	coupon.TenantID = tenantID
+	coupon.CreatedAt = time.Now()
+	coupon.UpdatedAt = time.Now()
+
+	return r.collection.InsertOne(ctx, coupon)
+}
+
+func (r *couponRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*domain.Coupon, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	var coupon domain.Coupon
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&coupon)
+	if err != nil {
+		return nil, err
+	}
+	return &coupon, nil
+}
+
+func (r *couponRepository) FindByCode(ctx context.Context, code string) (*domain.Coupon, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	var coupon domain.Coupon
+	err := r.collection.FindOne(ctx, bson.M{"code": code}).Decode(&coupon)
+	if err != nil {
+		return nil, err
+	}
+	return &coupon, nil
+}
+
+func (r *couponRepository) List(ctx context.Context) ([]domain.Coupon, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	cursor, err := r.collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	coupons := make([]domain.Coupon, 0)
+	if err := cursor.All(ctx, &coupons); err != nil {
+		return nil, err
+	}
+	return coupons, nil
+}
+
+// Update replaces the coupon definition, leaving usage counters untouched
+func (r *couponRepository) Update(ctx context.Context, coupon *domain.Coupon) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	coupon.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"type":                  coupon.Type,
+			"value":                 coupon.Value,
+			"currency":              coupon.Currency,
+			"min_order_value":       coupon.MinOrderValue,
+			"product_ids":           coupon.ProductIDs,
+			"category_ids":          coupon.CategoryIDs,
+			"valid_from":            coupon.ValidFrom,
+			"valid_until":           coupon.ValidUntil,
+			"max_uses":              coupon.MaxUses,
+			"max_uses_per_customer": coupon.MaxUsesPerCustomer,
+			"active":                coupon.Active,
+			"updated_at":            coupon.UpdatedAt,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": coupon.ID}, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (r *couponRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	return nil
+}
+
+// Redeem increments the global and per-customer counters in a single conditional
+// update so concurrent orders cannot exceed the configured limits
+func (r *couponRepository) Redeem(ctx context.Context, id primitive.ObjectID, customerID string) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	conditions := bson.A{
+		bson.M{"$or": bson.A{
+			bson.M{"$eq": bson.A{"$max_uses", 0}},
+			bson.M{"$lt": bson.A{"$used_count", "$max_uses"}},
+		}},
+	}
+	increment := bson.M{"used_count": 1}
+
+	if customerID != "" {
+		usageField := "customer_usage." + customerID
+		conditions = append(conditions, bson.M{"$or": bson.A{
+			bson.M{"$eq": bson.A{"$max_uses_per_customer", 0}},
+			bson.M{"$lt": bson.A{bson.M{"$ifNull": bson.A{"$" + usageField, 0}}, "$max_uses_per_customer"}},
+		}})
+		increment[usageField] = 1
+	}
+
+	filter := bson.M{
+		"_id":    id,
+		"active": true,
+		"$expr":  bson.M{"$and": conditions},
+	}
+	update := bson.M{
+		"$inc": increment,
+		"$set": bson.M{"updated_at": time.Now()},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrCouponUsageLimitReached
+	}
+
+	return nil
+}
+
+func (r *couponRepository) Release(ctx context.Context, id primitive.ObjectID, customerID string) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	decrement := bson.M{"used_count": -1}
+	if customerID != "" {
+		decrement["customer_usage."+customerID] = -1
+	}
+
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$inc": decrement})
+	return err
+}
@@ -0,0 +1,131 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type customerRepository struct {
+	collection tenantCollection
+}
+
+func NewCustomerRepository(db *mongo.Database) ports.CustomerRepository {
+	return &customerRepository{
+		collection: newTenantCollection(db, "customers"),
+	}
+}
+
+func (r *customerRepository) Create(ctx context.Context, customer *domain.Customer) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tenantID, err := r.collection.tenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	customer.ID = primitive.NewObjectID()
+	customer.TenantID = tenantID
+	customer.CreatedAt = time.Now()
+	customer.UpdatedAt = time.Now()
+
+	return r.collection.InsertOne(ctx, customer)
+}
+
+func (r *customerRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*domain.Customer, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	var customer domain.Customer
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&customer)
+	if err != nil {
+		return nil, err
+	}
+	return &customer, nil
+}
+
+func (r *customerRepository) FindByEmailOrDocument(ctx context.Context, email, document string) (*domain.Customer, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	filter := bson.M{
+		"$or": bson.A{
+			bson.M{"email": email},
+			bson.M{"document": document},
+		},
+	}
+
+	var customer domain.Customer
+	err := r.collection.FindOne(ctx, filter).Decode(&customer)
+	if err != nil {
+		return nil, err
+	}
+	return &customer, nil
+}
+
+func (r *customerRepository) List(ctx context.Context) ([]domain.Customer, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	cursor, err := r.collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "name", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	customers := make([]domain.Customer, 0)
+	if err := cursor.All(ctx, &customers); err != nil {
+		return nil, err
+	}
+	return customers, nil
+}
+
+func (r *customerRepository) Update(ctx context.Context, customer *domain.Customer) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	customer.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"name":       customer.Name,
+			"email":      customer.Email,
+			"document":   customer.Document,
+			"addresses":  customer.Addresses,
+			"updated_at": customer.UpdatedAt,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": customer.ID}, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (r *customerRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	return nil
+}
@@ -9,28 +9,46 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
 )
 
+// orderWrites are acknowledged once a majority of the replica set has them, so a
+// failover cannot roll back an order the client was told about
+var orderWrites = options.Collection().SetWriteConcern(writeconcern.Majority())
+
 type orderRepository struct {
-	collection *mongo.Collection
+	collection tenantCollection
 }
 
 func NewOrderRepository(db *mongo.Database) ports.OrderRepository {
 	return &orderRepository{
-		collection: db.Collection("orders"),
+		collection: newTenantCollection(db, "orders", orderWrites),
 	}
 }
 
 func (r *orderRepository) Create(ctx context.Context, order *domain.Order) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tenantID, err := r.collection.tenant(ctx)
+	if err != nil {
+		return err
+	}
+
 	order.ID = primitive.NewObjectID()
+	order.TenantID = tenantID
+	order.Version = 1
 	order.CreatedAt = time.Now()
 	order.UpdatedAt = time.Now()
 
-	_, err := r.collection.InsertOne(ctx, order)
-	return err
+	return r.collection.InsertOne(ctx, order)
 }
 
 func (r *orderRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*domain.Order, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
 	var order domain.Order
 	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&order)
 	if err != nil {
@@ -39,15 +57,125 @@ func (r *orderRepository) FindByID(ctx context.Context, id primitive.ObjectID) (
 	return &order, nil
 }
 
-func (r *orderRepository) UpdateStatus(ctx context.Context, id primitive.ObjectID, status string) error {
+func (r *orderRepository) FindByOrderNumber(ctx context.Context, orderNumber string) (*domain.Order, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	var order domain.Order
+	err := r.collection.FindOne(ctx, bson.M{"order_number": orderNumber}).Decode(&order)
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+func (r *orderRepository) UpdateStatus(ctx context.Context, id primitive.ObjectID, status string, version int64) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	update := bson.M{
+		"$set": bson.M{
+			"status":     status,
+			"updated_at": time.Now(),
+		},
+		"$inc": bson.M{"version": 1},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, withVersion(bson.M{"_id": id}, version), update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return missedVersion(ctx, r.collection, id)
+	}
+
+	return nil
+}
+
+func (r *orderRepository) FindByCustomerID(ctx context.Context, customerID string) ([]domain.Order, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"customer_id": customerID}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	orders := make([]domain.Order, 0)
+	if err := cursor.All(ctx, &orders); err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+func (r *orderRepository) UpdateFulfillment(ctx context.Context, id primitive.ObjectID, shipments []domain.ShipmentSummary, status string, version int64) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
 	update := bson.M{
 		"$set": bson.M{
+			"shipments":  shipments,
 			"status":     status,
 			"updated_at": time.Now(),
 		},
+		"$inc": bson.M{"version": 1},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, withVersion(bson.M{"_id": id}, version), update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return missedVersion(ctx, r.collection, id)
+	}
+
+	return nil
+}
+
+func (r *orderRepository) FindUnreleasedDeclined(ctx context.Context, limit int) ([]domain.Order, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	filter := bson.M{
+		"status":      domain.OrderStatusPaymentDeclined,
+		"released_at": bson.M{"$exists": false},
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.collection.FindAcrossTenants(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	orders := make([]domain.Order, 0)
+	if err := cursor.All(ctx, &orders); err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+func (r *orderRepository) MarkReleased(ctx context.Context, id primitive.ObjectID, at time.Time) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	filter := bson.M{
+		"_id":         id,
+		"released_at": bson.M{"$exists": false},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"released_at": at,
+			"updated_at":  time.Now(),
+		},
 	}
 
-	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	result, err := r.collection.UpdateOne(ctx, filter, update)
 	if err != nil {
 		return err
 	}
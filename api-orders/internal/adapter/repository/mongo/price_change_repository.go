@@ -0,0 +1,149 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type priceChangeRepository struct {
+	collection tenantCollection
+}
+
+func NewPriceChangeRepository(db *mongo.Database) ports.PriceChangeRepository {
+	return &priceChangeRepository{
+		collection: newTenantCollection(db, "price_history"),
+	}
+}
+
+func (r *priceChangeRepository) Create(ctx context.Context, change *domain.PriceChange) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tenantID, err := r.collection.tenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	change.ID = primitive.NewObjectID()
+	change.TenantID = tenantID
+	change.CreatedAt = time.Now()
+
+	return r.collection.InsertOne(ctx, change)
+}
+
+func (r *priceChangeRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*domain.PriceChange, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	var change domain.PriceChange
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&change)
+	if err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+func (r *priceChangeRepository) FindByProductID(ctx context.Context, productID primitive.ObjectID) ([]domain.PriceChange, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "effective_at", Value: -1}, {Key: "_id", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"product_id": productID}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]domain.PriceChange, 0)
+	if err := cursor.All(ctx, &changes); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+func (r *priceChangeRepository) FindEffective(ctx context.Context, productID primitive.ObjectID, at time.Time) (*domain.PriceChange, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	filter := bson.M{
+		"product_id":   productID,
+		"status":       bson.M{"$ne": domain.PriceChangeCancelled},
+		"effective_at": bson.M{"$lte": at},
+	}
+	opts := options.FindOne().SetSort(bson.D{{Key: "effective_at", Value: -1}, {Key: "_id", Value: -1}})
+
+	var change domain.PriceChange
+	err := r.collection.FindOne(ctx, filter, opts).Decode(&change)
+	if err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+func (r *priceChangeRepository) FindDue(ctx context.Context, at time.Time, limit int) ([]domain.PriceChange, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	filter := bson.M{
+		"status":       domain.PriceChangeScheduled,
+		"effective_at": bson.M{"$lte": at},
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "effective_at", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.collection.FindAcrossTenants(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]domain.PriceChange, 0)
+	if err := cursor.All(ctx, &changes); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+func (r *priceChangeRepository) MarkApplied(ctx context.Context, change *domain.PriceChange) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	return r.resolve(ctx, change.ID, bson.M{
+		"status":         domain.PriceChangeApplied,
+		"previous_price": change.PreviousPrice,
+		"applied_at":     change.AppliedAt,
+	})
+}
+
+func (r *priceChangeRepository) Cancel(ctx context.Context, id primitive.ObjectID) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	return r.resolve(ctx, id, bson.M{"status": domain.PriceChangeCancelled})
+}
+
+// resolve updates a change that is still scheduled, returning mongo.ErrNoDocuments otherwise
+func (r *priceChangeRepository) resolve(ctx context.Context, id primitive.ObjectID, set bson.M) error {
+	filter := bson.M{
+		"_id":    id,
+		"status": domain.PriceChangeScheduled,
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, bson.M{"$set": set})
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package mongo
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/gvillela7/rank-my-app/internal/core/domain"
@@ -9,28 +10,41 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type productRepository struct {
-	collection *mongo.Collection
+	collection tenantCollection
 }
 
 func NewProductRepository(db *mongo.Database) ports.ProductRepository {
 	return &productRepository{
-		collection: db.Collection("products"),
+		collection: newTenantCollection(db, "products"),
 	}
 }
 
 func (r *productRepository) Create(ctx context.Context, product *domain.Product) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tenantID, err := r.collection.tenant(ctx)
+	if err != nil {
+		return err
+	}
+
 	product.ID = primitive.NewObjectID()
+	product.TenantID = tenantID
+	product.Version = 1
 	product.CreatedAt = time.Now()
 	product.UpdatedAt = time.Now()
 
-	_, err := r.collection.InsertOne(ctx, product)
-	return err
+	return r.collection.InsertOne(ctx, product)
 }
 
 func (r *productRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*domain.Product, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
 	var product domain.Product
 	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&product)
 	if err != nil {
@@ -38,3 +52,382 @@ func (r *productRepository) FindByID(ctx context.Context, id primitive.ObjectID)
 	}
 	return &product, nil
 }
+
+func (r *productRepository) FindBySKU(ctx context.Context, sku string) (*domain.Product, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	filter := bson.M{"$or": bson.A{
+		bson.M{"sku": sku},
+		bson.M{"variants.sku": sku},
+	}}
+
+	var product domain.Product
+	err := r.collection.FindOne(ctx, filter).Decode(&product)
+	if err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+func (r *productRepository) FindByVariantID(ctx context.Context, variantID primitive.ObjectID) (*domain.Product, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	var product domain.Product
+	err := r.collection.FindOne(ctx, bson.M{"variants._id": variantID}).Decode(&product)
+	if err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+func (r *productRepository) List(ctx context.Context) ([]domain.Product, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	cursor, err := r.collection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+
+	products := make([]domain.Product, 0)
+	if err := cursor.All(ctx, &products); err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+// Each streams the products to fn. The operation timeout bounds the query and the
+// fetch of each batch, not the whole iteration, which lasts as long as fn takes.
+func (r *productRepository) Each(ctx context.Context, fn func(product *domain.Product) error) error {
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+	findCtx, cancel := withTimeout(ctx)
+	cursor, err := r.collection.Find(findCtx, bson.M{}, opts)
+	cancel()
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for {
+		batchCtx, cancel := withTimeout(ctx)
+		ok := cursor.Next(batchCtx)
+		cancel()
+		if !ok {
+			break
+		}
+
+		var product domain.Product
+		if err := cursor.Decode(&product); err != nil {
+			return err
+		}
+		if err := fn(&product); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+func (r *productRepository) Update(ctx context.Context, product *domain.Product) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	product.UpdatedAt = time.Now()
+
+	set := bson.M{
+		"name":              product.Name,
+		"description":       product.Description,
+		"price":             product.Price,
+		"currency":          product.Currency,
+		"prices":            product.Prices,
+		"tax_class":         product.TaxClass,
+		"reorder_threshold": product.ReorderThreshold,
+		"category_id":       product.CategoryID,
+		"category_path":     product.CategoryPath,
+		"tags":              product.Tags,
+		"attributes":        product.Attributes,
+		"updated_at":        product.UpdatedAt,
+	}
+
+	// variants are addressed by id so that their quantities, moved only through
+	// the stock ledger, are never overwritten
+	arrayFilters := make([]interface{}, 0, len(product.Variants))
+	for i, variant := range product.Variants {
+		identifier := fmt.Sprintf("v%d", i)
+		set["variants.$["+identifier+"].price"] = variant.Price
+		set["variants.$["+identifier+"].attributes"] = variant.Attributes
+		arrayFilters = append(arrayFilters, bson.M{identifier + "._id": variant.ID})
+	}
+
+	opts := options.Update()
+	if len(arrayFilters) > 0 {
+		opts.SetArrayFilters(options.ArrayFilters{Filters: arrayFilters})
+	}
+
+	filter := withVersion(bson.M{"_id": product.ID}, product.Version)
+	update := bson.M{"$set": set, "$inc": bson.M{"version": 1}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update, opts)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return missedVersion(ctx, r.collection, product.ID)
+	}
+
+	product.Version++
+	return nil
+}
+
+// maxTagFacets caps how many tags are counted in a search
+const maxTagFacets = 50
+
+// Search runs the filter in a single aggregation, returning the requested page and
+// the category and tag counts of every matching product. Text search requires the
+// text index on tenant_id, name and description created by the migrations.
+func (r *productRepository) Search(ctx context.Context, filter domain.ProductFilter) (*domain.ProductSearchResult, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	match := bson.M{}
+	sort := bson.D{{Key: "name", Value: 1}}
+
+	if filter.Text != "" {
+		match["$text"] = bson.M{"$search": filter.Text}
+		sort = bson.D{{Key: "score", Value: bson.M{"$meta": "textScore"}}, {Key: "name", Value: 1}}
+	}
+	if filter.CategoryID != nil {
+		match["category_path"] = *filter.CategoryID
+	}
+	if filter.Tag != "" {
+		match["tags"] = filter.Tag
+	}
+	if filter.MinPrice != nil || filter.MaxPrice != nil {
+		price := bson.M{}
+		if filter.MinPrice != nil {
+			price["$gte"] = *filter.MinPrice
+		}
+		if filter.MaxPrice != nil {
+			price["$lte"] = *filter.MaxPrice
+		}
+		match["price"] = price
+	}
+	if filter.InStock {
+		match["quantity"] = bson.M{"$gt": 0}
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$facet", Value: bson.M{
+			"items": bson.A{
+				bson.M{"$sort": sort},
+				bson.M{"$skip": filter.Offset},
+				bson.M{"$limit": filter.Limit},
+			},
+			"total": bson.A{
+				bson.M{"$count": "count"},
+			},
+			"categories": bson.A{
+				bson.M{"$unwind": "$category_path"},
+				bson.M{"$group": bson.M{"_id": "$category_path", "count": bson.M{"$sum": 1}}},
+				bson.M{"$sort": bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}},
+			},
+			"tags": bson.A{
+				bson.M{"$unwind": "$tags"},
+				bson.M{"$group": bson.M{"_id": "$tags", "count": bson.M{"$sum": 1}}},
+				bson.M{"$sort": bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}},
+				bson.M{"$limit": maxTagFacets},
+			},
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var facets []struct {
+		Items []domain.Product `bson:"items"`
+		Total []struct {
+			Count int `bson:"count"`
+		} `bson:"total"`
+		Categories []struct {
+			ID    primitive.ObjectID `bson:"_id"`
+			Count int                `bson:"count"`
+		} `bson:"categories"`
+		Tags []domain.FacetCount `bson:"tags"`
+	}
+	if err := cursor.All(ctx, &facets); err != nil {
+		return nil, err
+	}
+
+	result := &domain.ProductSearchResult{
+		Products:       make([]domain.Product, 0),
+		CategoryFacets: make([]domain.FacetCount, 0),
+		TagFacets:      make([]domain.FacetCount, 0),
+	}
+	if len(facets) == 0 {
+		return result, nil
+	}
+
+	page := facets[0]
+	if page.Items != nil {
+		result.Products = page.Items
+	}
+	if len(page.Total) > 0 {
+		result.Total = page.Total[0].Count
+	}
+	for _, category := range page.Categories {
+		result.CategoryFacets = append(result.CategoryFacets, domain.FacetCount{Value: category.ID.Hex(), Count: category.Count})
+	}
+	if page.Tags != nil {
+		result.TagFacets = page.Tags
+	}
+
+	return result, nil
+}
+
+func (r *productRepository) AdjustQuantity(ctx context.Context, id primitive.ObjectID, delta int) (*domain.Product, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	filter := bson.M{"_id": id}
+	if delta < 0 {
+		filter["quantity"] = bson.M{"$gte": -delta}
+	}
+
+	update := bson.M{
+		"$inc": bson.M{"quantity": delta, "version": 1},
+		"$set": bson.M{"updated_at": time.Now()},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var product domain.Product
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&product)
+	if err == mongo.ErrNoDocuments && delta < 0 {
+		if _, findErr := r.FindByID(ctx, id); findErr != nil {
+			return nil, findErr
+		}
+		return nil, domain.ErrInsufficientStock
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+func (r *productRepository) AdjustVariantQuantity(ctx context.Context, id, variantID primitive.ObjectID, delta int) (*domain.Product, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	variant := bson.M{"_id": variantID}
+	if delta < 0 {
+		variant["quantity"] = bson.M{"$gte": -delta}
+	}
+	filter := bson.M{
+		"_id":      id,
+		"variants": bson.M{"$elemMatch": variant},
+	}
+
+	update := bson.M{
+		"$inc": bson.M{
+			"quantity":            delta,
+			"variants.$.quantity": delta,
+			"version":             1,
+		},
+		"$set": bson.M{"updated_at": time.Now()},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var product domain.Product
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&product)
+	if err == mongo.ErrNoDocuments {
+		current, findErr := r.FindByID(ctx, id)
+		if findErr != nil {
+			return nil, findErr
+		}
+		if current.Variant(variantID) == nil || delta >= 0 {
+			return nil, mongo.ErrNoDocuments
+		}
+		return nil, domain.ErrInsufficientStock
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+func (r *productRepository) SetQuantity(ctx context.Context, id primitive.ObjectID, quantity int) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	update := bson.M{
+		"$set": bson.M{
+			"quantity":   quantity,
+			"updated_at": time.Now(),
+		},
+		"$inc": bson.M{"version": 1},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (r *productRepository) SetPrice(ctx context.Context, id primitive.ObjectID, price float64, version int64) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	update := bson.M{
+		"$set": bson.M{
+			"price":      price,
+			"updated_at": time.Now(),
+		},
+		"$inc": bson.M{"version": 1},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, withVersion(bson.M{"_id": id}, version), update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return missedVersion(ctx, r.collection, id)
+	}
+
+	return nil
+}
+
+func (r *productRepository) SetReorderThreshold(ctx context.Context, id primitive.ObjectID, threshold int, version int64) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	update := bson.M{
+		"$set": bson.M{
+			"reorder_threshold": threshold,
+			"updated_at":        time.Now(),
+		},
+		"$inc": bson.M{"version": 1},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, withVersion(bson.M{"_id": id}, version), update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return missedVersion(ctx, r.collection, id)
+	}
+
+	return nil
+}
@@ -11,35 +11,43 @@ import (
 )
 
 type publishedOrderRepository struct {
-	collection *mongo.Collection
+	collection tenantCollection
 	logger     *zap.Logger
 }
 
 func NewPublishedOrderRepository(db *mongo.Database, logger *zap.Logger) ports.PublishedOrderRepository {
 	return &publishedOrderRepository{
-		collection: db.Collection("published_orders"),
+		collection: newTenantCollection(db, "published_orders"),
 		logger:     logger,
 	}
 }
 
 func (r *publishedOrderRepository) Create(ctx context.Context, publishedOrder *domain.PublishedOrder) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
 	r.logger.Info("Creating published order record",
-		zap.String("order_id", publishedOrder.OrderID),
+		zap.String("order_id", publishedOrder.OrderID.Hex()),
 		zap.Bool("published", publishedOrder.Published),
 		zap.String("order_status", publishedOrder.OrderStatus),
 	)
 
-	_, err := r.collection.InsertOne(ctx, publishedOrder)
+	tenantID, err := r.collection.tenant(ctx)
 	if err != nil {
+		return fmt.Errorf("failed to create published order record: %w", err)
+	}
+	publishedOrder.TenantID = tenantID
+
+	if err := r.collection.InsertOne(ctx, publishedOrder); err != nil {
 		r.logger.Error("Failed to create published order record",
-			zap.String("order_id", publishedOrder.OrderID),
+			zap.String("order_id", publishedOrder.OrderID.Hex()),
 			zap.Error(err),
 		)
 		return fmt.Errorf("failed to create published order record: %w", err)
 	}
 
 	r.logger.Info("Published order record created successfully",
-		zap.String("order_id", publishedOrder.OrderID),
+		zap.String("order_id", publishedOrder.OrderID.Hex()),
 		zap.String("record_id", publishedOrder.ID.Hex()),
 	)
 
@@ -0,0 +1,98 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type returnRepository struct {
+	collection tenantCollection
+}
+
+func NewReturnRepository(db *mongo.Database) ports.ReturnRepository {
+	return &returnRepository{
+		collection: newTenantCollection(db, "returns"),
+	}
+}
+
+func (r *returnRepository) Create(ctx context.Context, ret *domain.ReturnRequest) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tenantID, err := r.collection.tenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	ret.ID = primitive.NewObjectID()
+	ret.TenantID = tenantID
+	ret.CreatedAt = time.Now()
+	ret.UpdatedAt = time.Now()
+
+	return r.collection.InsertOne(ctx, ret)
+}
+
+func (r *returnRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*domain.ReturnRequest, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	var ret domain.ReturnRequest
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&ret)
+	if err != nil {
+		return nil, err
+	}
+	return &ret, nil
+}
+
+func (r *returnRepository) FindByOrderID(ctx context.Context, orderID string) ([]domain.ReturnRequest, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"order_id": orderID}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	returns := make([]domain.ReturnRequest, 0)
+	if err := cursor.All(ctx, &returns); err != nil {
+		return nil, err
+	}
+	return returns, nil
+}
+
+func (r *returnRepository) Resolve(ctx context.Context, ret *domain.ReturnRequest) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	ret.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"status":           ret.Status,
+			"rejection_reason": ret.RejectionReason,
+			"resolved_at":      ret.ResolvedAt,
+			"updated_at":       ret.UpdatedAt,
+		},
+	}
+
+	filter := bson.M{"_id": ret.ID, "status": domain.ReturnStatusRequested}
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	return nil
+}
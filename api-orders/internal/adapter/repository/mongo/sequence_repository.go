@@ -0,0 +1,55 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// sequenceRepository draws order numbers, so its counters are written like the orders
+type sequenceRepository struct {
+	collection tenantCollection
+}
+
+// counterDocument is a counter of a tenant. Its _id joins the tenant and the
+// counter name, so concurrent upserts of a new counter cannot create two documents.
+type counterDocument struct {
+	ID       string `bson:"_id"`
+	TenantID string `bson:"tenant_id"`
+	Value    int64  `bson:"value"`
+}
+
+func NewSequenceRepository(db *mongo.Database) ports.SequenceRepository {
+	return &sequenceRepository{
+		collection: newTenantCollection(db, "counters", orderWrites),
+	}
+}
+
+func (r *sequenceRepository) Next(ctx context.Context, name string) (int64, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tenantID, err := r.collection.tenant(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	filter := bson.M{"_id": tenantID + ":" + name}
+	update := bson.M{"$inc": bson.M{"value": 1}}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var counter counterDocument
+	err = r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&counter)
+	if mongo.IsDuplicateKeyError(err) {
+		// another request created the counter at the same time
+		err = r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&counter)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return counter.Value, nil
+}
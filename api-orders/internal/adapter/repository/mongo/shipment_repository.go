@@ -0,0 +1,115 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type shipmentRepository struct {
+	collection tenantCollection
+}
+
+func NewShipmentRepository(db *mongo.Database) ports.ShipmentRepository {
+	return &shipmentRepository{
+		collection: newTenantCollection(db, "shipments"),
+	}
+}
+
+func (r *shipmentRepository) Create(ctx context.Context, shipment *domain.Shipment) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tenantID, err := r.collection.tenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	shipment.ID = primitive.NewObjectID()
+	shipment.TenantID = tenantID
+	shipment.CreatedAt = time.Now()
+	shipment.UpdatedAt = time.Now()
+
+	return r.collection.InsertOne(ctx, shipment)
+}
+
+func (r *shipmentRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*domain.Shipment, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	var shipment domain.Shipment
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&shipment)
+	if err != nil {
+		return nil, err
+	}
+	return &shipment, nil
+}
+
+func (r *shipmentRepository) FindByOrderID(ctx context.Context, orderID string) ([]domain.Shipment, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "shipped_at", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"order_id": orderID}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	shipments := make([]domain.Shipment, 0)
+	if err := cursor.All(ctx, &shipments); err != nil {
+		return nil, err
+	}
+	return shipments, nil
+}
+
+func (r *shipmentRepository) MarkDelivered(ctx context.Context, shipment *domain.Shipment) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	shipment.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"status":       shipment.Status,
+			"delivered_at": shipment.DeliveredAt,
+			"received_by":  shipment.ReceivedBy,
+			"proof_url":    shipment.ProofURL,
+			"updated_at":   shipment.UpdatedAt,
+		},
+	}
+
+	filter := bson.M{"_id": shipment.ID, "status": bson.M{"$ne": domain.ShipmentStatusDelivered}}
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (r *shipmentRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type stockMovementRepository struct {
+	collection tenantCollection
+}
+
+func NewStockMovementRepository(db *mongo.Database) ports.StockMovementRepository {
+	return &stockMovementRepository{
+		collection: newTenantCollection(db, "stock_movements"),
+	}
+}
+
+func (r *stockMovementRepository) Create(ctx context.Context, movement *domain.StockMovement) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tenantID, err := r.collection.tenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	movement.ID = primitive.NewObjectID()
+	movement.TenantID = tenantID
+	movement.CreatedAt = time.Now()
+
+	return r.collection.InsertOne(ctx, movement)
+}
+
+func (r *stockMovementRepository) FindByProductID(ctx context.Context, productID primitive.ObjectID) ([]domain.StockMovement, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"product_id": productID}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	movements := make([]domain.StockMovement, 0)
+	if err := cursor.All(ctx, &movements); err != nil {
+		return nil, err
+	}
+	return movements, nil
+}
+
+func (r *stockMovementRepository) FindByReference(ctx context.Context, movementType, reference string) ([]domain.StockMovement, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	cursor, err := r.collection.Find(ctx, bson.M{"type": movementType, "reference": reference})
+	if err != nil {
+		return nil, err
+	}
+
+	movements := make([]domain.StockMovement, 0)
+	if err := cursor.All(ctx, &movements); err != nil {
+		return nil, err
+	}
+	return movements, nil
+}
+
+func (r *stockMovementRepository) SumByProduct(ctx context.Context) (map[primitive.ObjectID]int, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$product_id"},
+			{Key: "quantity", Value: bson.D{{Key: "$sum", Value: "$quantity"}}},
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []struct {
+		ProductID primitive.ObjectID `bson:"_id"`
+		Quantity  int                `bson:"quantity"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	balances := make(map[primitive.ObjectID]int, len(results))
+	for _, result := range results {
+		balances[result.ProductID] = result.Quantity
+	}
+	return balances, nil
+}
@@ -0,0 +1,137 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// tenantCollection is the collection of a tenant-owned document type. Every filter
+// and pipeline it runs is restricted to the tenant of the context, so repositories
+// built on it cannot read or write the documents of another tenant. Operations
+// fail with domain.ErrMissingTenant when the context carries no tenant.
+type tenantCollection struct {
+	collection *mongo.Collection
+}
+
+func newTenantCollection(db *mongo.Database, name string, opts ...*options.CollectionOptions) tenantCollection {
+	return tenantCollection{collection: db.Collection(name, opts...)}
+}
+
+// tenant returns the tenant of ctx, which repositories stamp on the documents they insert
+func (c tenantCollection) tenant(ctx context.Context) (string, error) {
+	tenantID, ok := domain.TenantFromContext(ctx)
+	if !ok {
+		return "", domain.ErrMissingTenant
+	}
+	return tenantID, nil
+}
+
+// scope returns a copy of filter restricted to the tenant of ctx
+func (c tenantCollection) scope(ctx context.Context, filter bson.M) (bson.M, error) {
+	tenantID, err := c.tenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	scoped := make(bson.M, len(filter)+1)
+	for key, value := range filter {
+		scoped[key] = value
+	}
+	scoped["tenant_id"] = tenantID
+	return scoped, nil
+}
+
+// InsertOne inserts a document whose tenant_id the caller set with tenant
+func (c tenantCollection) InsertOne(ctx context.Context, document interface{}) error {
+	if _, err := c.tenant(ctx); err != nil {
+		return err
+	}
+	_, err := c.collection.InsertOne(ctx, document)
+	return err
+}
+
+func (c tenantCollection) FindOne(ctx context.Context, filter bson.M, opts ...*options.FindOneOptions) *mongo.SingleResult {
+	scoped, err := c.scope(ctx, filter)
+	if err != nil {
+		return mongo.NewSingleResultFromDocument(bson.D{}, err, nil)
+	}
+	return c.collection.FindOne(ctx, scoped, opts...)
+}
+
+func (c tenantCollection) Find(ctx context.Context, filter bson.M, opts ...*options.FindOptions) (*mongo.Cursor, error) {
+	scoped, err := c.scope(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return c.collection.Find(ctx, scoped, opts...)
+}
+
+func (c tenantCollection) CountDocuments(ctx context.Context, filter bson.M) (int64, error) {
+	scoped, err := c.scope(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	return c.collection.CountDocuments(ctx, scoped)
+}
+
+func (c tenantCollection) UpdateOne(ctx context.Context, filter bson.M, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	scoped, err := c.scope(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return c.collection.UpdateOne(ctx, scoped, update, opts...)
+}
+
+func (c tenantCollection) FindOneAndUpdate(ctx context.Context, filter bson.M, update interface{}, opts ...*options.FindOneAndUpdateOptions) *mongo.SingleResult {
+	scoped, err := c.scope(ctx, filter)
+	if err != nil {
+		return mongo.NewSingleResultFromDocument(bson.D{}, err, nil)
+	}
+	return c.collection.FindOneAndUpdate(ctx, scoped, update, opts...)
+}
+
+func (c tenantCollection) DeleteOne(ctx context.Context, filter bson.M) (*mongo.DeleteResult, error) {
+	scoped, err := c.scope(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return c.collection.DeleteOne(ctx, scoped)
+}
+
+// FindOneAcrossTenants runs an unscoped lookup for the callers that resolve the
+// tenant from the document itself, as authentication does with API keys
+func (c tenantCollection) FindOneAcrossTenants(ctx context.Context, filter bson.M) *mongo.SingleResult {
+	return c.collection.FindOne(ctx, filter)
+}
+
+// FindAcrossTenants runs an unscoped query for the background jobs serving every
+// tenant, which must scope the work on each document to its tenant_id
+func (c tenantCollection) FindAcrossTenants(ctx context.Context, filter bson.M, opts ...*options.FindOptions) (*mongo.Cursor, error) {
+	return c.collection.Find(ctx, filter, opts...)
+}
+
+// Aggregate runs the pipeline over the documents of the tenant. The tenant is merged
+// into a leading $match stage, which must stay first when it holds a $text search.
+func (c tenantCollection) Aggregate(ctx context.Context, pipeline mongo.Pipeline) (*mongo.Cursor, error) {
+	if len(pipeline) > 0 && len(pipeline[0]) == 1 && pipeline[0][0].Key == "$match" {
+		if match, ok := pipeline[0][0].Value.(bson.M); ok {
+			scoped, err := c.scope(ctx, match)
+			if err != nil {
+				return nil, err
+			}
+			stages := append(mongo.Pipeline{{{Key: "$match", Value: scoped}}}, pipeline[1:]...)
+			return c.collection.Aggregate(ctx, stages)
+		}
+	}
+
+	scoped, err := c.scope(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	stages := append(mongo.Pipeline{{{Key: "$match", Value: scoped}}}, pipeline...)
+	return c.collection.Aggregate(ctx, stages)
+}
@@ -0,0 +1,34 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// withVersion conditions filter on the document version. Documents created before
+// versioning have no version field and match version 0.
+func withVersion(filter bson.M, version int64) bson.M {
+	if version == 0 {
+		filter["version"] = bson.M{"$in": bson.A{0, nil}}
+	} else {
+		filter["version"] = version
+	}
+	return filter
+}
+
+// missedVersion explains a version-conditioned update that matched nothing:
+// domain.ErrVersionConflict when the document exists, mongo.ErrNoDocuments otherwise
+func missedVersion(ctx context.Context, collection tenantCollection, id primitive.ObjectID) error {
+	count, err := collection.CountDocuments(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return domain.ErrVersionConflict
+}
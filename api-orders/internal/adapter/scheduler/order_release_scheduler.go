@@ -0,0 +1,74 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.uber.org/zap"
+)
+
+// OrderReleaseScheduler periodically gives back the stock and coupon of the orders
+// whose payment was declined
+type OrderReleaseScheduler struct {
+	useCase  ports.OrderUseCase
+	interval time.Duration
+	logger   *zap.Logger
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+func NewOrderReleaseScheduler(useCase ports.OrderUseCase, interval time.Duration, logger *zap.Logger) *OrderReleaseScheduler {
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+	return &OrderReleaseScheduler{
+		useCase:  useCase,
+		interval: interval,
+		logger:   logger,
+	}
+}
+
+// Start runs the scheduler in the background until Stop is called
+func (s *OrderReleaseScheduler) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(ctx)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		s.logger.Info("Order release scheduler started", zap.Duration("interval", s.interval))
+		for {
+			s.run(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (s *OrderReleaseScheduler) run(ctx context.Context) {
+	released, err := s.useCase.ReleaseDeclinedOrders(ctx)
+	if err != nil && ctx.Err() == nil {
+		s.logger.Error("Failed to release declined orders", zap.Error(err), zap.Int("released", released))
+		return
+	}
+	if released > 0 {
+		s.logger.Info("Declined orders released", zap.Int("released", released))
+	}
+}
+
+// Stop stops the scheduler, waiting for the run in progress
+func (s *OrderReleaseScheduler) Stop() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+}
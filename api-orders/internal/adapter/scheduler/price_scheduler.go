@@ -0,0 +1,76 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.uber.org/zap"
+)
+
+// defaultInterval is used when no positive interval is configured
+const defaultInterval = time.Minute
+
+// PriceScheduler periodically applies the scheduled price changes that became effective
+type PriceScheduler struct {
+	useCase  ports.PriceUseCase
+	interval time.Duration
+	logger   *zap.Logger
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+func NewPriceScheduler(useCase ports.PriceUseCase, interval time.Duration, logger *zap.Logger) *PriceScheduler {
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+	return &PriceScheduler{
+		useCase:  useCase,
+		interval: interval,
+		logger:   logger,
+	}
+}
+
+// Start runs the scheduler in the background until Stop is called
+func (s *PriceScheduler) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(ctx)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		s.logger.Info("Price scheduler started", zap.Duration("interval", s.interval))
+		for {
+			s.run(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (s *PriceScheduler) run(ctx context.Context) {
+	applied, err := s.useCase.ApplyDuePrices(ctx)
+	if err != nil && ctx.Err() == nil {
+		s.logger.Error("Failed to apply scheduled prices", zap.Error(err), zap.Int("applied", applied))
+		return
+	}
+	if applied > 0 {
+		s.logger.Info("Scheduled prices applied", zap.Int("applied", applied))
+	}
+}
+
+// Stop stops the scheduler, waiting for the run in progress
+func (s *PriceScheduler) Stop() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+}
@@ -0,0 +1,99 @@
+package tax
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+)
+
+// Wildcard matches any tax class or region in a Rule
+const Wildcard = "*"
+
+// Rule is the tax rate applied to a tax class shipped to a region.
+// Inclusive rules treat item prices as already containing the tax.
+type Rule struct {
+	TaxClass  string
+	Region    string
+	Rate      float64
+	Inclusive bool
+}
+
+type ruleTableCalculator struct {
+	defaultRegion string
+	rules         map[ruleKey]Rule
+}
+
+type ruleKey struct {
+	taxClass string
+	region   string
+}
+
+// NewRuleTableCalculator creates a calculator that looks rates up in a rule table.
+// The most specific rule wins: class+region, class+*, *+region, then *+*.
+// Items without a matching rule are not taxed.
+func NewRuleTableCalculator(defaultRegion string, rules []Rule) ports.TaxCalculator {
+	table := make(map[ruleKey]Rule, len(rules))
+	for _, rule := range rules {
+		key := ruleKey{
+			taxClass: strings.ToLower(rule.TaxClass),
+			region:   strings.ToUpper(rule.Region),
+		}
+		table[key] = rule
+	}
+
+	return &ruleTableCalculator{
+		defaultRegion: strings.ToUpper(defaultRegion),
+		rules:         table,
+	}
+}
+
+func (c *ruleTableCalculator) Calculate(ctx context.Context, region string, items []domain.OrderItem) (*domain.TaxResult, error) {
+	region = strings.ToUpper(region)
+	if region == "" {
+		region = c.defaultRegion
+	}
+
+	lines := make([]domain.ItemTax, len(items))
+	for i, item := range items {
+		rule, ok := c.match(strings.ToLower(item.TaxClass), region)
+		if !ok {
+			continue
+		}
+
+		// taxes apply to what is charged, after the discount
+		line := item.TaxableTotal()
+		amount := line * rule.Rate
+		if rule.Inclusive {
+			amount = line - line/(1+rule.Rate)
+		}
+
+		lines[i] = domain.ItemTax{
+			Rate:      rule.Rate,
+			Amount:    domain.RoundMoney(amount),
+			Inclusive: rule.Inclusive,
+		}
+	}
+
+	return &domain.TaxResult{
+		Region: region,
+		Lines:  lines,
+	}, nil
+}
+
+func (c *ruleTableCalculator) match(taxClass, region string) (Rule, bool) {
+	candidates := []ruleKey{
+		{taxClass: taxClass, region: region},
+		{taxClass: taxClass, region: Wildcard},
+		{taxClass: Wildcard, region: region},
+		{taxClass: Wildcard, region: Wildcard},
+	}
+
+	for _, key := range candidates {
+		if rule, ok := c.rules[key]; ok {
+			return rule, true
+		}
+	}
+	return Rule{}, false
+}
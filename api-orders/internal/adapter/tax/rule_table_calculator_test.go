@@ -0,0 +1,55 @@
+package tax_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gvillela7/rank-my-app/internal/adapter/tax"
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+)
+
+func TestRuleTableCalculator_Calculate(t *testing.T) {
+	calculator := tax.NewRuleTableCalculator("SP", []tax.Rule{
+		{TaxClass: "*", Region: "*", Rate: 0.18, Inclusive: true},
+		{TaxClass: "books", Region: "*", Rate: 0},
+		{TaxClass: "electronics", Region: "RJ", Rate: 0.2},
+	})
+
+	items := []domain.OrderItem{
+		{ProductID: "1", Price: 118, Quantity: 1, TaxClass: "standard"},
+		{ProductID: "2", Price: 50, Quantity: 2, TaxClass: "books"},
+		{ProductID: "3", Price: 100, Quantity: 1, TaxClass: "electronics"},
+	}
+
+	result, err := calculator.Calculate(context.Background(), "rj", items)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if result.Region != "RJ" {
+		t.Errorf("Expected region RJ, got %s", result.Region)
+	}
+
+	expected := []domain.ItemTax{
+		{Rate: 0.18, Amount: 18, Inclusive: true},
+		{Rate: 0, Amount: 0},
+		{Rate: 0.2, Amount: 20},
+	}
+	for i, line := range result.Lines {
+		if line != expected[i] {
+			t.Errorf("Line %d: expected %+v, got %+v", i, expected[i], line)
+		}
+	}
+
+	order := &domain.Order{Items: items}
+	order.ApplyTaxes(result)
+
+	if order.TaxTotal != 38 {
+		t.Errorf("Expected tax total 38, got %f", order.TaxTotal)
+	}
+
+	// inclusive tax is already in the prices, only the exclusive 20 is added
+	if order.Total != 338 {
+		t.Errorf("Expected total 338, got %f", order.Total)
+	}
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var ErrInvalidAPIKey = NewError(KindUnauthenticated, "invalid_api_key", "Invalid API key")
+
+const (
+	ScopeOrdersWrite  = "orders:write"
+	ScopeOrdersRead   = "orders:read"
+	ScopeProductsRead = "products:read"
+
+	APIKeyStatusActive  = "active"
+	APIKeyStatusExpired = "expired"
+	APIKeyStatusRevoked = "revoked"
+)
+
+// APIKey authenticates a service-to-service client. Only the SHA-256 hash of the
+// key is stored; Prefix keeps its first characters so that it can be recognized.
+type APIKey struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty"`
+	TenantID   string             `bson:"tenant_id"`
+	Name       string             `bson:"name"`
+	Prefix     string             `bson:"prefix"`
+	Hash       string             `bson:"hash"`
+	Scopes     []string           `bson:"scopes"`
+	ExpiresAt  *time.Time         `bson:"expires_at,omitempty"`
+	LastUsedAt *time.Time         `bson:"last_used_at,omitempty"`
+	RotatedAt  *time.Time         `bson:"rotated_at,omitempty"`
+	RevokedAt  *time.Time         `bson:"revoked_at,omitempty"`
+	CreatedAt  time.Time          `bson:"created_at"`
+}
+
+// StatusAt reports whether the key is active, expired or revoked at t
+func (k *APIKey) StatusAt(t time.Time) string {
+	if k.RevokedAt != nil {
+		return APIKeyStatusRevoked
+	}
+	if k.ExpiresAt != nil && !t.Before(*k.ExpiresAt) {
+		return APIKeyStatusExpired
+	}
+	return APIKeyStatusActive
+}
+
+// Principal returns the principal of requests authenticated with the key
+func (k *APIKey) Principal() *Principal {
+	return &Principal{
+		Subject:  "api-key:" + k.ID.Hex(),
+		APIKeyID: k.ID.Hex(),
+		TenantID: k.TenantID,
+		Scopes:   k.Scopes,
+	}
+}
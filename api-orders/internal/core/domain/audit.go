@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"reflect"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	AuditResourceProduct = "product"
+	AuditResourceOrder   = "order"
+
+	AuditProductCreated             = "product.created"
+	AuditProductUpdated             = "product.updated"
+	AuditProductQuantityAdjusted    = "product.quantity_adjusted"
+	AuditProductQuantitySet         = "product.quantity_set"
+	AuditProductReorderThresholdSet = "product.reorder_threshold_set"
+	AuditProductPriceSet            = "product.price_set"
+	AuditOrderCreated               = "order.created"
+	AuditOrderStatusUpdated         = "order.status_updated"
+	AuditOrderFulfillmentUpdated    = "order.fulfillment_updated"
+	AuditOrderReleased              = "order.released"
+
+	AuditActorSystem    = "system"
+	AuditActorAnonymous = "anonymous"
+	AuditSourceAPI      = "api-orders"
+)
+
+// AuditChange is the value of a document field before and after a mutation. Before
+// is missing for created documents.
+type AuditChange struct {
+	Field  string      `bson:"field"`
+	Before interface{} `bson:"before,omitempty"`
+	After  interface{} `bson:"after,omitempty"`
+}
+
+// AuditEntry records who changed a resource, when and how. Entries are append-only:
+// they are never updated nor deleted. Source names the service that made the change;
+// Actor is the authenticated subject, "anonymous" for unauthenticated requests and
+// "system" for background jobs.
+type AuditEntry struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty"`
+	TenantID     string             `bson:"tenant_id"`
+	Actor        string             `bson:"actor"`
+	Source       string             `bson:"source"`
+	Action       string             `bson:"action"`
+	ResourceType string             `bson:"resource_type"`
+	ResourceID   string             `bson:"resource_id"`
+	Changes      []AuditChange      `bson:"changes"`
+	RequestID    string             `bson:"request_id,omitempty"`
+	ClientIP     string             `bson:"client_ip,omitempty"`
+	OccurredAt   time.Time          `bson:"occurred_at"`
+}
+
+// AuditFilter selects audit entries, latest first. Zero values disable a criterion.
+type AuditFilter struct {
+	Actor        string
+	Action       string
+	ResourceType string
+	ResourceID   string
+	RequestID    string
+	From         *time.Time
+	To           *time.Time
+	Offset       int
+	Limit        int
+}
+
+// AuditDiff returns the fields whose values differ between two snapshots of a
+// document, sorted by name. A nil snapshot stands for a document that does not
+// exist. The update timestamp is left out, every mutation changes it.
+func AuditDiff(before, after map[string]interface{}) []AuditChange {
+	fields := make(map[string]struct{}, len(before)+len(after))
+	for field := range before {
+		fields[field] = struct{}{}
+	}
+	for field := range after {
+		fields[field] = struct{}{}
+	}
+	delete(fields, "updated_at")
+
+	changes := make([]AuditChange, 0)
+	for field := range fields {
+		previous, current := before[field], after[field]
+		if reflect.DeepEqual(previous, current) {
+			continue
+		}
+		changes = append(changes, AuditChange{Field: field, Before: previous, After: current})
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes
+}
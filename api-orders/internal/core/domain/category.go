@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Category groups products in a tree. Path holds the ancestors from the root,
+// so a product classified in a category is also found under every ancestor.
+type Category struct {
+	ID        primitive.ObjectID   `bson:"_id,omitempty"`
+	TenantID  string               `bson:"tenant_id"`
+	Name      string               `bson:"name"`
+	ParentID  *primitive.ObjectID  `bson:"parent_id,omitempty"`
+	Path      []primitive.ObjectID `bson:"path"`
+	CreatedAt time.Time            `bson:"created_at"`
+	UpdatedAt time.Time            `bson:"updated_at"`
+}
+
+// Lineage returns the category ancestors followed by the category itself
+func (c *Category) Lineage() []primitive.ObjectID {
+	lineage := make([]primitive.ObjectID, 0, len(c.Path)+1)
+	lineage = append(lineage, c.Path...)
+	return append(lineage, c.ID)
+}
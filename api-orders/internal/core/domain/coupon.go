@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	CouponTypePercentage = "percentage"
+	CouponTypeFixed      = "fixed"
+)
+
+var ErrCouponUsageLimitReached = NewError(KindInvalid, "coupon_usage_limit_reached", "Coupon usage limit reached")
+
+// Coupon is a discount code applied during order creation.
+// Value and MinOrderValue are expressed in Currency; percentage coupons ignore it for Value.
+// A coupon restricted to products or categories discounts only the items of those
+// products or of those categories, their subcategories included.
+type Coupon struct {
+	ID                 primitive.ObjectID `bson:"_id,omitempty"`
+	TenantID           string             `bson:"tenant_id"`
+	Code               string             `bson:"code"`
+	Type               string             `bson:"type"`
+	Value              float64            `bson:"value"`
+	Currency           string             `bson:"currency"`
+	MinOrderValue      float64            `bson:"min_order_value"`
+	ProductIDs         []string           `bson:"product_ids,omitempty"`
+	CategoryIDs        []string           `bson:"category_ids,omitempty"`
+	ValidFrom          *time.Time         `bson:"valid_from,omitempty"`
+	ValidUntil         *time.Time         `bson:"valid_until,omitempty"`
+	MaxUses            int                `bson:"max_uses"`
+	MaxUsesPerCustomer int                `bson:"max_uses_per_customer"`
+	UsedCount          int                `bson:"used_count"`
+	CustomerUsage      map[string]int     `bson:"customer_usage,omitempty"`
+	Active             bool               `bson:"active"`
+	CreatedAt          time.Time          `bson:"created_at"`
+	UpdatedAt          time.Time          `bson:"updated_at"`
+}
+
+// IsValidAt reports whether the coupon is active and inside its validity window at t
+func (c *Coupon) IsValidAt(t time.Time) bool {
+	if !c.Active {
+		return false
+	}
+	if c.ValidFrom != nil && t.Before(*c.ValidFrom) {
+		return false
+	}
+	if c.ValidUntil != nil && t.After(*c.ValidUntil) {
+		return false
+	}
+	return true
+}
+
+// AppliesTo reports whether the coupon can discount the given item
+func (c *Coupon) AppliesTo(item *OrderItem) bool {
+	if len(c.ProductIDs) == 0 && len(c.CategoryIDs) == 0 {
+		return true
+	}
+	for _, id := range c.ProductIDs {
+		if id == item.ProductID {
+			return true
+		}
+	}
+	for _, id := range c.CategoryIDs {
+		for _, categoryID := range item.CategoryPath {
+			if id == categoryID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// EligibleSubtotal sums the items the coupon applies to
+func (c *Coupon) EligibleSubtotal(items []OrderItem) float64 {
+	subtotal := 0.0
+	for i := range items {
+		if c.AppliesTo(&items[i]) {
+			subtotal += items[i].LineTotal()
+		}
+	}
+	return RoundMoney(subtotal)
+}
+
+// Discount computes the discount for the given items. rate converts the coupon
+// currency into the order currency and is only used for fixed coupons.
+func (c *Coupon) Discount(items []OrderItem, rate float64) float64 {
+	eligible := c.EligibleSubtotal(items)
+
+	var discount float64
+	switch c.Type {
+	case CouponTypePercentage:
+		discount = eligible * c.Value / 100
+	case CouponTypeFixed:
+		discount = c.Value * rate
+	}
+
+	if discount > eligible {
+		discount = eligible
+	}
+	return RoundMoney(discount)
+}
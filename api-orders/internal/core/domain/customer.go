@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Address struct {
+	ID         primitive.ObjectID `bson:"_id"`
+	Label      string             `bson:"label,omitempty"`
+	Street     string             `bson:"street"`
+	Number     string             `bson:"number"`
+	Complement string             `bson:"complement,omitempty"`
+	District   string             `bson:"district,omitempty"`
+	City       string             `bson:"city"`
+	State      string             `bson:"state"`
+	ZipCode    string             `bson:"zip_code"`
+	Country    string             `bson:"country"`
+	Default    bool               `bson:"default"`
+}
+
+type Customer struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	TenantID  string             `bson:"tenant_id"`
+	Name      string             `bson:"name"`
+	Email     string             `bson:"email"`
+	Document  string             `bson:"document"`
+	Addresses []Address          `bson:"addresses"`
+	CreatedAt time.Time          `bson:"created_at"`
+	UpdatedAt time.Time          `bson:"updated_at"`
+}
+
+// ShippingAddress returns the address with the given ID or, when id is zero,
+// the default address (the first one if none is flagged as default)
+func (c *Customer) ShippingAddress(id primitive.ObjectID) (*Address, bool) {
+	for i := range c.Addresses {
+		if !id.IsZero() && c.Addresses[i].ID == id {
+			return &c.Addresses[i], true
+		}
+		if id.IsZero() && c.Addresses[i].Default {
+			return &c.Addresses[i], true
+		}
+	}
+
+	if id.IsZero() && len(c.Addresses) > 0 {
+		return &c.Addresses[0], true
+	}
+	return nil, false
+}
+
+// NormalizeDocument strips CPF punctuation, keeping only digits
+func NormalizeDocument(document string) string {
+	var digits strings.Builder
+	for _, r := range document {
+		if r >= '0' && r <= '9' {
+			digits.WriteRune(r)
+		}
+	}
+	return digits.String()
+}
+
+// IsValidCPF validates a Brazilian CPF, formatted or not, by its check digits
+func IsValidCPF(document string) bool {
+	cpf := NormalizeDocument(document)
+	if len(cpf) != 11 {
+		return false
+	}
+
+	allEqual := true
+	for i := 1; i < len(cpf); i++ {
+		if cpf[i] != cpf[0] {
+			allEqual = false
+			break
+		}
+	}
+	if allEqual {
+		return false
+	}
+
+	for _, length := range []int{9, 10} {
+		sum := 0
+		for i := 0; i < length; i++ {
+			sum += int(cpf[i]-'0') * (length + 1 - i)
+		}
+		digit := sum * 10 % 11
+		if digit == 10 {
+			digit = 0
+		}
+		if digit != int(cpf[length]-'0') {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,23 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+)
+
+func TestIsValidCPF(t *testing.T) {
+	cases := map[string]bool{
+		"529.982.247-25": true,
+		"52998224725":    true,
+		"529.982.247-24": false,
+		"111.111.111-11": false,
+		"1234567890":     false,
+	}
+
+	for cpf, expected := range cases {
+		if got := domain.IsValidCPF(cpf); got != expected {
+			t.Errorf("IsValidCPF(%s): expected %v, got %v", cpf, expected, got)
+		}
+	}
+}
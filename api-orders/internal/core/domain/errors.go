@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrorKind classifies a failure by what the caller can do about it. Adapters map
+// each kind to their own status codes.
+type ErrorKind int
+
+const (
+	KindInternal ErrorKind = iota
+	KindInvalid
+	KindUnauthenticated
+	KindForbidden
+	KindNotFound
+	KindConflict
+	KindPreconditionFailed
+	KindPreconditionRequired
+	KindRateLimited
+	KindUnsupportedMediaType
+)
+
+// Error is an entry of the error catalog: a failure with a stable machine-readable
+// code, which clients can rely on, and a short title for people. Entries are
+// compared by identity, so use cases and repositories return them, or occurrences
+// of them, and callers check with errors.Is.
+type Error struct {
+	Kind  ErrorKind
+	Code  string
+	Title string
+}
+
+func NewError(kind ErrorKind, code, title string) *Error {
+	return &Error{Kind: kind, Code: code, Title: title}
+}
+
+func (e *Error) Error() string {
+	return e.Title
+}
+
+// WithDetail returns an occurrence of e described by detail, which is shown to the
+// client
+func (e *Error) WithDetail(detail string) error {
+	return &Failure{Err: e, Detail: detail}
+}
+
+// Detailf is WithDetail with a formatted detail
+func (e *Error) Detailf(format string, args ...interface{}) error {
+	return e.WithDetail(fmt.Sprintf(format, args...))
+}
+
+// Wrap returns an occurrence of e described by detail and caused by cause. The
+// cause is logged but never shown to the client.
+func (e *Error) Wrap(detail string, cause error) error {
+	return &Failure{Err: e, Detail: detail, Cause: cause}
+}
+
+// Failure is an occurrence of a catalog error
+type Failure struct {
+	Err    *Error
+	Detail string
+	Cause  error
+}
+
+func (f *Failure) Error() string {
+	if f.Cause != nil {
+		return fmt.Sprintf("%s: %v", f.Detail, f.Cause)
+	}
+	return f.Detail
+}
+
+// Unwrap exposes both the catalog entry and the cause to errors.Is and errors.As
+func (f *Failure) Unwrap() []error {
+	if f.Cause != nil {
+		return []error{f.Err, f.Cause}
+	}
+	return []error{f.Err}
+}
+
+// Describe returns the catalog entry of err and the detail to show for it. Errors
+// outside the catalog are internal: ok is false and they must not be shown.
+func Describe(err error) (entry *Error, detail string, ok bool) {
+	var failure *Failure
+	if errors.As(err, &failure) {
+		return failure.Err, failure.Detail, true
+	}
+	if errors.As(err, &entry) {
+		return entry, entry.Title, true
+	}
+	return nil, "", false
+}
+
+// Generic errors, for failures that no client needs to tell apart
+var (
+	ErrInternal             = NewError(KindInternal, "internal_error", "Internal server error")
+	ErrInvalidRequest       = NewError(KindInvalid, "invalid_request", "Invalid request")
+	ErrValidationFailed     = NewError(KindInvalid, "validation_failed", "Validation failed")
+	ErrUnauthenticated      = NewError(KindUnauthenticated, "unauthenticated", "Authentication required")
+	ErrForbidden            = NewError(KindForbidden, "forbidden", "Not allowed")
+	ErrConflict             = NewError(KindConflict, "conflict", "Conflict with the current state of the resource")
+	ErrPreconditionRequired = NewError(KindPreconditionRequired, "precondition_required", "If-Match header required")
+	ErrRateLimited          = NewError(KindRateLimited, "rate_limited", "Rate limit exceeded")
+	ErrUnsupportedMediaType = NewError(KindUnsupportedMediaType, "unsupported_media_type", "Unsupported content type")
+)
+
+// Resources that do not exist, or that the caller may not see
+var (
+	ErrProductNotFound     = NewError(KindNotFound, "product_not_found", "Product not found")
+	ErrVariantNotFound     = NewError(KindNotFound, "variant_not_found", "Variant not found")
+	ErrOrderNotFound       = NewError(KindNotFound, "order_not_found", "Order not found")
+	ErrCustomerNotFound    = NewError(KindNotFound, "customer_not_found", "Customer not found")
+	ErrAddressNotFound     = NewError(KindNotFound, "address_not_found", "Shipping address not found")
+	ErrCouponNotFound      = NewError(KindNotFound, "coupon_not_found", "Coupon not found")
+	ErrShipmentNotFound    = NewError(KindNotFound, "shipment_not_found", "Shipment not found")
+	ErrReturnNotFound      = NewError(KindNotFound, "return_not_found", "Return not found")
+	ErrPriceChangeNotFound = NewError(KindNotFound, "price_change_not_found", "Price change not found")
+	ErrAPIKeyNotFound      = NewError(KindNotFound, "api_key_not_found", "API key not found")
+)
+
+// Business rules
+var (
+	// ErrInvalidTransition is a change the current status of a resource does not
+	// allow, such as shipping an order that was not paid
+	ErrInvalidTransition   = NewError(KindConflict, "invalid_transition", "Status does not allow the change")
+	ErrUnknownCategory     = NewError(KindInvalid, "unknown_category", "Category not found")
+	ErrDuplicateSKU        = NewError(KindConflict, "duplicate_sku", "SKU already registered")
+	ErrDuplicateCoupon     = NewError(KindConflict, "duplicate_coupon_code", "Coupon code already exists")
+	ErrDuplicateCustomer   = NewError(KindConflict, "duplicate_customer", "Customer email or document already registered")
+	ErrCouponNotApplicable = NewError(KindInvalid, "coupon_not_applicable", "Coupon does not apply to the order")
+)
@@ -0,0 +1,31 @@
+package domain_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+)
+
+func TestDescribe(t *testing.T) {
+	cause := errors.New("stock would go negative")
+	wrapped := fmt.Errorf("failed to reserve stock: %w", domain.ErrInsufficientStock.Wrap("Insufficient stock for product Mouse", cause))
+
+	entry, detail, ok := domain.Describe(wrapped)
+	if !ok || entry != domain.ErrInsufficientStock || detail != "Insufficient stock for product Mouse" {
+		t.Errorf("Expected the insufficient stock entry with its detail, got %v %q %v", entry, detail, ok)
+	}
+	if !errors.Is(wrapped, domain.ErrInsufficientStock) || !errors.Is(wrapped, cause) {
+		t.Error("Expected the failure to match both its catalog entry and its cause")
+	}
+
+	entry, detail, ok = domain.Describe(fmt.Errorf("update: %w", domain.ErrVersionConflict))
+	if !ok || entry != domain.ErrVersionConflict || detail != domain.ErrVersionConflict.Title {
+		t.Errorf("Expected a bare entry to be described by its title, got %v %q %v", entry, detail, ok)
+	}
+
+	if _, _, ok := domain.Describe(errors.New("connection refused")); ok {
+		t.Error("Expected errors outside the catalog not to be described")
+	}
+}
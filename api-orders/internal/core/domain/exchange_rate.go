@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// DefaultCurrency is the currency assumed for products and orders that do not declare one
+const DefaultCurrency = "BRL"
+
+var ErrExchangeRateNotFound = NewError(KindInvalid, "unsupported_currency", "Unsupported currency conversion")
+
+// ExchangeRate is the conversion rate from one currency to another as reported by a provider.
+// It is snapshotted on orders so totals can be reproduced later.
+type ExchangeRate struct {
+	From      string    `bson:"from"`
+	To        string    `bson:"to"`
+	Rate      float64   `bson:"rate"`
+	Source    string    `bson:"source"`
+	FetchedAt time.Time `bson:"fetched_at"`
+}
+
+// Convert applies the rate to amount, rounded to two decimal places
+func (r *ExchangeRate) Convert(amount float64) float64 {
+	return RoundMoney(amount * r.Rate)
+}
+
+// RoundMoney rounds a monetary amount to two decimal places
+func RoundMoney(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -6,27 +6,181 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+const (
+	OrderStatusCreated         = "criado"
+	OrderStatusProcessing      = "em_processamento"
+	OrderStatusShipped         = "enviado"
+	OrderStatusDelivered       = "entregue"
+	OrderStatusPaymentDeclined = "pagamento_recusado"
+)
+
 type OrderItem struct {
-	ProductID   string  `bson:"product_id"`
-	ProductName string  `bson:"product_name"`
-	Price       float64 `bson:"price"`
-	Quantity    int     `bson:"quantity"`
+	ProductID    string  `bson:"product_id"`
+	VariantID    string  `bson:"variant_id,omitempty"`
+	SKU          string  `bson:"sku,omitempty"`
+	ProductName  string  `bson:"product_name"`
+	Price        float64 `bson:"price"`
+	Quantity     int     `bson:"quantity"`
+	BasePrice    float64 `bson:"base_price"`
+	BaseCurrency string  `bson:"base_currency"`
+	TaxClass     string  `bson:"tax_class"`
+	TaxRate      float64 `bson:"tax_rate"`
+	TaxAmount    float64 `bson:"tax_amount"`
+	TaxInclusive bool    `bson:"tax_inclusive"`
+	// Discount is the share of the order discount taken off the line before taxes
+	Discount float64 `bson:"discount,omitempty"`
+	// CategoryPath snapshots the category of the product and its ancestors
+	CategoryPath []string `bson:"category_path,omitempty"`
+}
+
+// Key identifies the line among the order items
+func (i *OrderItem) Key() string {
+	return ItemKey(i.ProductID, i.VariantID)
+}
+
+// LineTotal returns the item price multiplied by its quantity
+func (i *OrderItem) LineTotal() float64 {
+	return i.Price * float64(i.Quantity)
+}
+
+// TaxableTotal returns the line total after its share of the order discount
+func (i *OrderItem) TaxableTotal() float64 {
+	return i.LineTotal() - i.Discount
 }
 
 type Order struct {
-	ID          primitive.ObjectID `bson:"_id,omitempty"`
-	OrderNumber string             `bson:"order_number"`
-	Items       []OrderItem        `bson:"items"`
-	Total       float64            `bson:"total"`
-	Status      string             `bson:"status"`
-	CreatedAt   time.Time          `bson:"created_at"`
-	UpdatedAt   time.Time          `bson:"updated_at"`
+	ID              primitive.ObjectID `bson:"_id,omitempty"`
+	TenantID        string             `bson:"tenant_id"`
+	OrderNumber     string             `bson:"order_number"`
+	CustomerID      string             `bson:"customer_id,omitempty"`
+	APIKeyID        string             `bson:"api_key_id,omitempty"`
+	ShippingAddress *Address           `bson:"shipping_address,omitempty"`
+	Items           []OrderItem        `bson:"items"`
+	CouponCode      string             `bson:"coupon_code,omitempty"`
+	Subtotal        float64            `bson:"subtotal"`
+	DiscountTotal   float64            `bson:"discount_total"`
+	TaxRegion       string             `bson:"tax_region,omitempty"`
+	TaxTotal        float64            `bson:"tax_total"`
+	Total           float64            `bson:"total"`
+	Currency        string             `bson:"currency"`
+	ExchangeRates   []ExchangeRate     `bson:"exchange_rates,omitempty"`
+	Shipments       []ShipmentSummary  `bson:"shipments,omitempty"`
+	Status          string             `bson:"status"`
+	// ReleasedAt is when the stock and coupon of an order whose payment was
+	// declined were given back
+	ReleasedAt *time.Time `bson:"released_at,omitempty"`
+	Version    int64      `bson:"version"`
+	CreatedAt  time.Time  `bson:"created_at"`
+	UpdatedAt  time.Time  `bson:"updated_at"`
 }
 
+// CalculateTotal recomputes subtotal, tax total and total from the items.
+// Inclusive taxes are already part of the item prices and are not added again.
 func (o *Order) CalculateTotal() {
-	total := 0.0
+	subtotal := 0.0
+	taxTotal := 0.0
+	exclusiveTax := 0.0
+	for _, item := range o.Items {
+		subtotal += item.LineTotal()
+		taxTotal += item.TaxAmount
+		if !item.TaxInclusive {
+			exclusiveTax += item.TaxAmount
+		}
+	}
+	o.Subtotal = RoundMoney(subtotal)
+	o.TaxTotal = RoundMoney(taxTotal)
+
+	total := o.Subtotal - o.DiscountTotal
+	if total < 0 {
+		total = 0
+	}
+	o.Total = RoundMoney(total + exclusiveTax)
+}
+
+// ApplyTaxes stores the per-line taxes of result on the items and recalculates the total
+func (o *Order) ApplyTaxes(result *TaxResult) {
+	o.TaxRegion = result.Region
+	for i := range o.Items {
+		if i >= len(result.Lines) {
+			break
+		}
+		o.Items[i].TaxRate = result.Lines[i].Rate
+		o.Items[i].TaxAmount = RoundMoney(result.Lines[i].Amount)
+		o.Items[i].TaxInclusive = result.Lines[i].Inclusive
+	}
+	o.CalculateTotal()
+}
+
+// ApplyDiscount sets the order discount, prorated by line total onto the eligible
+// lines, every line when eligible is nil, and recalculates the total. The last
+// eligible line takes the rounding remainder.
+func (o *Order) ApplyDiscount(discount float64, eligible func(item *OrderItem) bool) {
+	o.DiscountTotal = RoundMoney(discount)
+
+	lines := make([]int, 0, len(o.Items))
+	base := 0.0
+	for i := range o.Items {
+		o.Items[i].Discount = 0
+		if eligible == nil || eligible(&o.Items[i]) {
+			lines = append(lines, i)
+			base += o.Items[i].LineTotal()
+		}
+	}
+
+	remaining := o.DiscountTotal
+	for n, i := range lines {
+		if base <= 0 {
+			break
+		}
+		share := RoundMoney(o.DiscountTotal * o.Items[i].LineTotal() / base)
+		if n == len(lines)-1 || share > remaining {
+			share = remaining
+		}
+		o.Items[i].Discount = share
+		remaining = RoundMoney(remaining - share)
+	}
+
+	o.CalculateTotal()
+}
+
+// OrderedQuantities returns the ordered units of each line, keyed by ItemKey
+func (o *Order) OrderedQuantities() map[string]int {
+	ordered := make(map[string]int)
 	for _, item := range o.Items {
-		total += item.Price * float64(item.Quantity)
+		ordered[item.Key()] += item.Quantity
+	}
+	return ordered
+}
+
+// UnitRefund returns what was paid for one unit of the line identified by key: the
+// line price plus exclusive tax, minus the share of the order discount of the line
+func (o *Order) UnitRefund(key string) (float64, bool) {
+	prorated := false
+	for _, item := range o.Items {
+		if item.Discount > 0 {
+			prorated = true
+		}
+	}
+
+	for _, item := range o.Items {
+		if item.Key() != key {
+			continue
+		}
+
+		unit := item.Price
+		if !item.TaxInclusive && item.Quantity > 0 {
+			unit += item.TaxAmount / float64(item.Quantity)
+		}
+		if prorated && item.Quantity > 0 {
+			unit -= item.Discount / float64(item.Quantity)
+		} else if !prorated && o.DiscountTotal > 0 && o.Subtotal > 0 {
+			// orders placed before the discount was taken off the lines
+			unit -= o.DiscountTotal * item.Price / o.Subtotal
+		}
+		if unit < 0 {
+			unit = 0
+		}
+		return RoundMoney(unit), true
 	}
-	o.Total = total
+	return 0, false
 }
@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// OrderNumberSequence names the counters order numbers are drawn from
+const OrderNumberSequence = "order_number"
+
+// OrderNumberFormat describes the human-friendly order numbers, such as
+// ORD-20261016-0001238: a prefix, the day the order was placed and its sequence
+// within that day, followed by a check digit that catches mistyped numbers.
+// Without a date layout the sequence never restarts. Sequences wider than Digits
+// are not truncated.
+type OrderNumberFormat struct {
+	Prefix     string
+	DateLayout string
+	Digits     int
+	CheckDigit bool
+	Location   *time.Location
+}
+
+// Sequence returns the counter the number of an order placed at date is drawn
+// from, one per day when the format has a date
+func (f OrderNumberFormat) Sequence(date time.Time) string {
+	if f.DateLayout == "" {
+		return OrderNumberSequence
+	}
+	return OrderNumberSequence + ":" + f.date(date)
+}
+
+// Format returns the number of the seq-th order of the sequence of date
+func (f OrderNumberFormat) Format(date time.Time, seq int64) string {
+	parts := make([]string, 0, 3)
+	if f.Prefix != "" {
+		parts = append(parts, f.Prefix)
+	}
+	if f.DateLayout != "" {
+		parts = append(parts, f.date(date))
+	}
+
+	sequence := fmt.Sprintf("%0*d", f.Digits, seq)
+	if f.CheckDigit {
+		sequence += string(luhnDigit(digitsOf(strings.Join(parts, "") + sequence)))
+	}
+	parts = append(parts, sequence)
+
+	return strings.Join(parts, "-")
+}
+
+// Verify reports whether the check digit of number matches. Numbers are always
+// valid when the format has no check digit.
+func (f OrderNumberFormat) Verify(number string) bool {
+	if !f.CheckDigit {
+		return true
+	}
+
+	rest := strings.TrimPrefix(number, f.Prefix)
+	for _, r := range rest {
+		if r != '-' && (r < '0' || r > '9') {
+			return false
+		}
+	}
+
+	digits := digitsOf(f.Prefix + rest)
+	if len(digits) < 2 {
+		return false
+	}
+	return luhnDigit(digits[:len(digits)-1]) == digits[len(digits)-1]
+}
+
+func (f OrderNumberFormat) date(date time.Time) string {
+	if f.Location != nil {
+		date = date.In(f.Location)
+	}
+	return date.Format(f.DateLayout)
+}
+
+// digitsOf returns the decimal digits of s, in order
+func digitsOf(s string) string {
+	var digits strings.Builder
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			digits.WriteRune(r)
+		}
+	}
+	return digits.String()
+}
+
+// luhnDigit returns the Luhn check digit of digits
+func luhnDigit(digits string) byte {
+	sum := 0
+	double := true
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return byte('0' + (10-sum%10)%10)
+}
@@ -0,0 +1,39 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+)
+
+func TestOrderNumberFormat(t *testing.T) {
+	saoPaulo := time.FixedZone("BRT", -3*60*60)
+	format := domain.OrderNumberFormat{Prefix: "ORD", DateLayout: "20060102", Digits: 6, CheckDigit: true, Location: saoPaulo}
+
+	// still October 16th in São Paulo
+	placedAt := time.Date(2026, 10, 17, 1, 30, 0, 0, time.UTC)
+
+	if sequence := format.Sequence(placedAt); sequence != "order_number:20261016" {
+		t.Errorf("Expected the sequence of the local day, got %q", sequence)
+	}
+
+	number := format.Format(placedAt, 123)
+	if number != "ORD-20261016-0001238" {
+		t.Fatalf("Expected ORD-20261016-0001238, got %q", number)
+	}
+	if !format.Verify(number) {
+		t.Errorf("Expected %q to be valid", number)
+	}
+
+	for _, mistyped := range []string{"ORD-20261016-0001239", "ORD-20261016-0001328", "ORD-a1b2c3d4"} {
+		if format.Verify(mistyped) {
+			t.Errorf("Expected %q to be invalid", mistyped)
+		}
+	}
+
+	plain := domain.OrderNumberFormat{Prefix: "ORD", Digits: 4}
+	if number := plain.Format(placedAt, 12345); number != "ORD-12345" || plain.Sequence(placedAt) != "order_number" {
+		t.Errorf("Expected an unbounded sequence without date, got %q", number)
+	}
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	PriceChangeScheduled = "scheduled"
+	PriceChangeApplied   = "applied"
+	PriceChangeCancelled = "cancelled"
+)
+
+// PriceChange is an entry of the price history of a product. Changes with a future
+// EffectiveAt stay scheduled until the price scheduler applies them. Price is in
+// Currency, the product base currency when the change was made; explicit prices in
+// other currencies are not tracked.
+type PriceChange struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty"`
+	TenantID      string             `bson:"tenant_id"`
+	ProductID     primitive.ObjectID `bson:"product_id"`
+	Price         float64            `bson:"price"`
+	PreviousPrice *float64           `bson:"previous_price,omitempty"`
+	Currency      string             `bson:"currency"`
+	Status        string             `bson:"status"`
+	Reason        string             `bson:"reason"`
+	Actor         string             `bson:"actor"`
+	EffectiveAt   time.Time          `bson:"effective_at"`
+	AppliedAt     *time.Time         `bson:"applied_at,omitempty"`
+	CreatedAt     time.Time          `bson:"created_at"`
+}
+
+// NewAppliedPriceChange records a price the product takes right away
+func NewAppliedPriceChange(product *Product, previousPrice *float64, reason, actor string) *PriceChange {
+	now := time.Now()
+	return &PriceChange{
+		ProductID:     product.ID,
+		Price:         product.Price,
+		PreviousPrice: previousPrice,
+		Currency:      product.BaseCurrency(),
+		Status:        PriceChangeApplied,
+		Reason:        reason,
+		Actor:         actor,
+		EffectiveAt:   now,
+		AppliedAt:     &now,
+	}
+}
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"context"
+)
+
+var ErrInvalidToken = NewError(KindUnauthenticated, "invalid_token", "Invalid bearer token")
+
+const (
+	RoleAdmin    = "admin"
+	RoleOperator = "operator"
+	RoleCustomer = "customer"
+)
+
+// Principal is the authenticated caller of a request. CustomerID links callers
+// with the customer role to the customer record they may act on. Callers
+// authenticated by an API key carry its ID and scopes instead of roles. TenantID
+// pins the caller to a tenant; callers without one choose it per request.
+type Principal struct {
+	Subject    string
+	Roles      []string
+	CustomerID string
+	TenantID   string
+	APIKeyID   string
+	Scopes     []string
+}
+
+// HasRole reports whether the principal has one of the roles. Admins have every role.
+func (p *Principal) HasRole(roles ...string) bool {
+	for _, held := range p.Roles {
+		if held == RoleAdmin {
+			return true
+		}
+		for _, role := range roles {
+			if held == role {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// HasScope reports whether the principal may use the scope. Scopes restrict API
+// keys only; users are restricted by their roles.
+func (p *Principal) HasScope(scope string) bool {
+	if p.APIKeyID == "" {
+		return true
+	}
+	for _, held := range p.Scopes {
+		if held == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// CanAccessCustomer reports whether the principal may see the data of a customer:
+// staff may see every customer, customers only themselves
+func (p *Principal) CanAccessCustomer(customerID string) bool {
+	if p.HasRole(RoleOperator) {
+		return true
+	}
+	return p.CustomerID != "" && p.CustomerID == customerID
+}
+
+type principalKey struct{}
+
+// ContextWithPrincipal returns a copy of ctx carrying the authenticated principal
+func ContextWithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the authenticated principal of ctx, if any. There is
+// none when authentication is disabled.
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok && principal != nil
+}
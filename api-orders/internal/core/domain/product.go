@@ -6,12 +6,60 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// ProductPrice is an explicit price for a product in a currency other than its base currency
+type ProductPrice struct {
+	Currency string  `bson:"currency"`
+	Amount   float64 `bson:"amount"`
+}
+
 type Product struct {
-	ID          primitive.ObjectID `bson:"_id,omitempty"`
-	Name        string             `bson:"name"`
-	Description string             `bson:"description"`
-	Quantity    int                `bson:"quantity"`
-	Price       float64            `bson:"price"`
-	CreatedAt   time.Time          `bson:"created_at"`
-	UpdatedAt   time.Time          `bson:"updated_at"`
+	ID               primitive.ObjectID   `bson:"_id,omitempty"`
+	TenantID         string               `bson:"tenant_id"`
+	Name             string               `bson:"name"`
+	SKU              string               `bson:"sku,omitempty"`
+	Description      string               `bson:"description"`
+	Quantity         int                  `bson:"quantity"`
+	ReorderThreshold int                  `bson:"reorder_threshold"`
+	Price            float64              `bson:"price"`
+	Currency         string               `bson:"currency"`
+	Prices           []ProductPrice       `bson:"prices,omitempty"`
+	TaxClass         string               `bson:"tax_class"`
+	CategoryID       *primitive.ObjectID  `bson:"category_id,omitempty"`
+	CategoryPath     []primitive.ObjectID `bson:"category_path,omitempty"`
+	Tags             []string             `bson:"tags,omitempty"`
+	Attributes       map[string]string    `bson:"attributes,omitempty"`
+	Variants         []ProductVariant     `bson:"variants,omitempty"`
+	Version          int64                `bson:"version"`
+	CreatedAt        time.Time            `bson:"created_at"`
+	UpdatedAt        time.Time            `bson:"updated_at"`
+}
+
+// BaseCurrency returns the currency of Price, falling back to DefaultCurrency
+// for products created before currencies were introduced
+func (p *Product) BaseCurrency() string {
+	if p.Currency == "" {
+		return DefaultCurrency
+	}
+	return p.Currency
+}
+
+// EffectiveTaxClass returns TaxClass, falling back to DefaultTaxClass
+func (p *Product) EffectiveTaxClass() string {
+	if p.TaxClass == "" {
+		return DefaultTaxClass
+	}
+	return p.TaxClass
+}
+
+// PriceIn returns the explicit price of the product in the given currency, if any
+func (p *Product) PriceIn(currency string) (float64, bool) {
+	if currency == p.BaseCurrency() {
+		return p.Price, true
+	}
+	for _, price := range p.Prices {
+		if price.Currency == currency {
+			return price.Amount, true
+		}
+	}
+	return 0, false
 }
@@ -0,0 +1,30 @@
+package domain
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// ProductFilter holds the criteria of a product search. Zero values disable a criterion.
+type ProductFilter struct {
+	Text       string
+	CategoryID *primitive.ObjectID
+	Tag        string
+	MinPrice   *float64
+	MaxPrice   *float64
+	InStock    bool
+	Offset     int
+	Limit      int
+}
+
+// FacetCount is the number of matching products sharing a facet value
+type FacetCount struct {
+	Value string `bson:"_id"`
+	Count int    `bson:"count"`
+}
+
+// ProductSearchResult is a page of matching products with the facet counts of
+// the whole result set
+type ProductSearchResult struct {
+	Products       []Product
+	Total          int
+	CategoryFacets []FacetCount
+	TagFacets      []FacetCount
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ProductVariant is a sellable version of a product (size, color...) with its own
+// SKU and stock. Price overrides the product base price when set.
+type ProductVariant struct {
+	ID         primitive.ObjectID `bson:"_id"`
+	SKU        string             `bson:"sku"`
+	Attributes map[string]string  `bson:"attributes,omitempty"`
+	Price      *float64           `bson:"price,omitempty"`
+	Quantity   int                `bson:"quantity"`
+}
+
+// HasVariants reports whether the product is sold through its variants, in which
+// case Quantity is the sum of the variant quantities
+func (p *Product) HasVariants() bool {
+	return len(p.Variants) > 0
+}
+
+// Variant returns the variant with the given ID, or nil
+func (p *Product) Variant(id primitive.ObjectID) *ProductVariant {
+	for i := range p.Variants {
+		if p.Variants[i].ID == id {
+			return &p.Variants[i]
+		}
+	}
+	return nil
+}
+
+// VariantBySKU returns the variant with the given SKU, or nil
+func (p *Product) VariantBySKU(sku string) *ProductVariant {
+	for i := range p.Variants {
+		if p.Variants[i].SKU == sku {
+			return &p.Variants[i]
+		}
+	}
+	return nil
+}
+
+// VariantPrice returns the variant price in the product base currency
+func (p *Product) VariantPrice(variant *ProductVariant) float64 {
+	if variant.Price != nil {
+		return *variant.Price
+	}
+	return p.Price
+}
+
+// SKUs returns the product SKU, if any, followed by the variant SKUs
+func (p *Product) SKUs() []string {
+	skus := make([]string, 0, len(p.Variants)+1)
+	if p.SKU != "" {
+		skus = append(skus, p.SKU)
+	}
+	for _, variant := range p.Variants {
+		skus = append(skus, variant.SKU)
+	}
+	return skus
+}
+
+// ItemKey identifies an order line: the product, or the product variant when there is one
+func ItemKey(productID, variantID string) string {
+	if variantID == "" {
+		return productID
+	}
+	return productID + ":" + variantID
+}
@@ -6,16 +6,19 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// PublishedOrder records the publication of an order status. OrderID is stored as
+// an ObjectID, as manager-status reads and writes it.
 type PublishedOrder struct {
 	ID          primitive.ObjectID `bson:"_id,omitempty"`
-	OrderID     string             `bson:"order_id"`
+	TenantID    string             `bson:"tenant_id"`
+	OrderID     primitive.ObjectID `bson:"order_id"`
 	Published   bool               `bson:"published"`
 	OrderStatus string             `bson:"order_status"`
 	Timestamp   float64            `bson:"ts"`
 	PublishedAt time.Time          `bson:"published_at"`
 }
 
-func NewPublishedOrder(orderID, orderStatus string, published bool, timestamp float64) *PublishedOrder {
+func NewPublishedOrder(orderID primitive.ObjectID, orderStatus string, published bool, timestamp float64) *PublishedOrder {
 	return &PublishedOrder{
 		ID:          primitive.NewObjectID(),
 		OrderID:     orderID,
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// RateLimit is a token bucket: up to Burst requests at once, refilled at Requests
+// per Period. Burst defaults to Requests.
+type RateLimit struct {
+	Requests int
+	Period   time.Duration
+	Burst    int
+}
+
+// Capacity is the size of the bucket
+func (l RateLimit) Capacity() int {
+	if l.Burst > 0 {
+		return l.Burst
+	}
+	return l.Requests
+}
+
+// RefillRate is the number of tokens added to the bucket per second
+func (l RateLimit) RefillRate() float64 {
+	return float64(l.Requests) / l.Period.Seconds()
+}
+
+// Enabled reports whether the limit restricts anything
+func (l RateLimit) Enabled() bool {
+	return l.Requests > 0 && l.Period > 0
+}
+
+// RateLimitDecision is the outcome of taking a token from a bucket
+type RateLimitDecision struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is the time until the bucket is full again
+	Reset time.Duration
+	// RetryAfter is the time until the next token, when the request was refused
+	RetryAfter time.Duration
+}
+
+// Decide builds the decision for a bucket left with tokens after the request
+func (l RateLimit) Decide(allowed bool, tokens float64) RateLimitDecision {
+	rate := l.RefillRate()
+	decision := RateLimitDecision{
+		Allowed:   allowed,
+		Limit:     l.Capacity(),
+		Remaining: int(math.Max(0, math.Floor(tokens))),
+		Reset:     time.Duration((float64(l.Capacity()) - tokens) / rate * float64(time.Second)),
+	}
+	if !allowed {
+		decision.RetryAfter = time.Duration((1 - tokens) / rate * float64(time.Second))
+	}
+	return decision
+}
@@ -0,0 +1,23 @@
+package domain
+
+import "context"
+
+// RequestInfo identifies the API request an operation runs for
+type RequestInfo struct {
+	ID       string
+	ClientIP string
+}
+
+type requestInfoKey struct{}
+
+// ContextWithRequestInfo returns a copy of ctx carrying the request info
+func ContextWithRequestInfo(ctx context.Context, info RequestInfo) context.Context {
+	return context.WithValue(ctx, requestInfoKey{}, info)
+}
+
+// RequestInfoFromContext returns the request info of ctx. There is none outside
+// API requests, such as in background jobs.
+func RequestInfoFromContext(ctx context.Context) (RequestInfo, bool) {
+	info, ok := ctx.Value(requestInfoKey{}).(RequestInfo)
+	return info, ok
+}
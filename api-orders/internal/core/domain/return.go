@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	ReturnStatusRequested = "requested"
+	ReturnStatusApproved  = "approved"
+	ReturnStatusRejected  = "rejected"
+
+	ReturnEventRequested = "return.requested"
+	ReturnEventApproved  = "return.approved"
+	ReturnEventRejected  = "return.rejected"
+)
+
+type ReturnItem struct {
+	ProductID    string  `bson:"product_id"`
+	VariantID    string  `bson:"variant_id,omitempty"`
+	Quantity     int     `bson:"quantity"`
+	Reason       string  `bson:"reason"`
+	UnitRefund   float64 `bson:"unit_refund"`
+	RefundAmount float64 `bson:"refund_amount"`
+}
+
+// Key identifies the order line the item belongs to
+func (i *ReturnItem) Key() string {
+	return ItemKey(i.ProductID, i.VariantID)
+}
+
+// ReturnRequest is a return merchandise authorization (RMA) for lines of a delivered order
+type ReturnRequest struct {
+	ID              primitive.ObjectID `bson:"_id,omitempty"`
+	TenantID        string             `bson:"tenant_id"`
+	OrderID         string             `bson:"order_id"`
+	CustomerID      string             `bson:"customer_id,omitempty"`
+	Items           []ReturnItem       `bson:"items"`
+	Status          string             `bson:"status"`
+	RefundTotal     float64            `bson:"refund_total"`
+	Currency        string             `bson:"currency"`
+	RejectionReason string             `bson:"rejection_reason,omitempty"`
+	ResolvedAt      *time.Time         `bson:"resolved_at,omitempty"`
+	CreatedAt       time.Time          `bson:"created_at"`
+	UpdatedAt       time.Time          `bson:"updated_at"`
+}
+
+// ReturnedQuantities returns how many units of each order line are covered by returns
+// that were not rejected
+func ReturnedQuantities(returns []ReturnRequest) map[string]int {
+	returned := make(map[string]int)
+	for _, ret := range returns {
+		if ret.Status == ReturnStatusRejected {
+			continue
+		}
+		for _, item := range ret.Items {
+			returned[item.Key()] += item.Quantity
+		}
+	}
+	return returned
+}
+
+// ReturnEvent is published on the message bus at each step of a return
+type ReturnEvent struct {
+	Event       string
+	ReturnID    string
+	OrderID     string
+	Items       []ReturnItem
+	RefundTotal float64
+	Currency    string
+	Reason      string
+	OccurredAt  time.Time
+}
+
+func NewReturnEvent(event string, ret *ReturnRequest) *ReturnEvent {
+	return &ReturnEvent{
+		Event:       event,
+		ReturnID:    ret.ID.Hex(),
+		OrderID:     ret.OrderID,
+		Items:       ret.Items,
+		RefundTotal: ret.RefundTotal,
+		Currency:    ret.Currency,
+		Reason:      ret.RejectionReason,
+		OccurredAt:  time.Now(),
+	}
+}
@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	ShipmentStatusShipped   = "enviado"
+	ShipmentStatusDelivered = "entregue"
+
+	ShipmentEventCreated   = "shipment.created"
+	ShipmentEventDelivered = "shipment.delivered"
+)
+
+type ShipmentItem struct {
+	ProductID string `bson:"product_id"`
+	VariantID string `bson:"variant_id,omitempty"`
+	Quantity  int    `bson:"quantity"`
+}
+
+// Key identifies the order line the item belongs to
+func (i *ShipmentItem) Key() string {
+	return ItemKey(i.ProductID, i.VariantID)
+}
+
+// Shipment is a package sent for an order. An order may be fulfilled by several
+// partial shipments.
+type Shipment struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty"`
+	TenantID     string             `bson:"tenant_id"`
+	OrderID      string             `bson:"order_id"`
+	Carrier      string             `bson:"carrier"`
+	TrackingCode string             `bson:"tracking_code"`
+	Items        []ShipmentItem     `bson:"items"`
+	Status       string             `bson:"status"`
+	ShippedAt    time.Time          `bson:"shipped_at"`
+	DeliveredAt  *time.Time         `bson:"delivered_at,omitempty"`
+	ReceivedBy   string             `bson:"received_by,omitempty"`
+	ProofURL     string             `bson:"proof_url,omitempty"`
+	CreatedAt    time.Time          `bson:"created_at"`
+	UpdatedAt    time.Time          `bson:"updated_at"`
+}
+
+// ShipmentSummary is the copy of a shipment kept on the order document
+type ShipmentSummary struct {
+	ShipmentID   string     `bson:"shipment_id"`
+	Carrier      string     `bson:"carrier"`
+	TrackingCode string     `bson:"tracking_code"`
+	Status       string     `bson:"status"`
+	ShippedAt    time.Time  `bson:"shipped_at"`
+	DeliveredAt  *time.Time `bson:"delivered_at,omitempty"`
+}
+
+func (s *Shipment) Summary() ShipmentSummary {
+	return ShipmentSummary{
+		ShipmentID:   s.ID.Hex(),
+		Carrier:      s.Carrier,
+		TrackingCode: s.TrackingCode,
+		Status:       s.Status,
+		ShippedAt:    s.ShippedAt,
+		DeliveredAt:  s.DeliveredAt,
+	}
+}
+
+// ShippedQuantities returns how many units of each order line are covered by shipments
+func ShippedQuantities(shipments []Shipment) map[string]int {
+	shipped := make(map[string]int)
+	for _, shipment := range shipments {
+		for _, item := range shipment.Items {
+			shipped[item.Key()] += item.Quantity
+		}
+	}
+	return shipped
+}
+
+// ShipmentEvent is published on the message bus when a shipment changes
+type ShipmentEvent struct {
+	Event        string
+	ShipmentID   string
+	OrderID      string
+	OrderStatus  string
+	Carrier      string
+	TrackingCode string
+	Items        []ShipmentItem
+	OccurredAt   time.Time
+}
+
+func NewShipmentEvent(event string, shipment *Shipment, orderStatus string) *ShipmentEvent {
+	return &ShipmentEvent{
+		Event:        event,
+		ShipmentID:   shipment.ID.Hex(),
+		OrderID:      shipment.OrderID,
+		OrderStatus:  orderStatus,
+		Carrier:      shipment.Carrier,
+		TrackingCode: shipment.TrackingCode,
+		Items:        shipment.Items,
+		OccurredAt:   time.Now(),
+	}
+}
@@ -0,0 +1,69 @@
+package domain
+
+import "time"
+
+const (
+	StockEventLowStock   = "product.low_stock"
+	StockEventOutOfStock = "product.out_of_stock"
+)
+
+// StockAlertEvent is published on the message bus when a stock movement takes a
+// product to its reorder threshold or out of stock. For products with variants it
+// is the variant moved, identified by VariantID and SKU, that crossed the threshold.
+type StockAlertEvent struct {
+	Event            string
+	ProductID        string
+	VariantID        string
+	SKU              string
+	ProductName      string
+	Quantity         int
+	ReorderThreshold int
+	MovementID       string
+	OccurredAt       time.Time
+}
+
+// StockAlert returns the event raised when the movement took the stock it moved,
+// the variant one for variant movements, across a threshold, or an empty string.
+// The reorder threshold of the product applies to each of its variants.
+func (p *Product) StockAlert(movement *StockMovement) string {
+	quantity, _ := p.movedStock(movement)
+	previous := quantity - movement.Quantity
+
+	switch {
+	case quantity <= 0 && previous > 0:
+		return StockEventOutOfStock
+	case p.ReorderThreshold > 0 && quantity <= p.ReorderThreshold && previous > p.ReorderThreshold:
+		return StockEventLowStock
+	}
+	return ""
+}
+
+// movedStock returns the quantity the movement changed, with the variant it moved
+func (p *Product) movedStock(movement *StockMovement) (int, *ProductVariant) {
+	if movement.VariantID != nil {
+		if variant := p.Variant(*movement.VariantID); variant != nil {
+			return variant.Quantity, variant
+		}
+	}
+	return p.Quantity, nil
+}
+
+func NewStockAlertEvent(event string, product *Product, movement *StockMovement) *StockAlertEvent {
+	quantity, variant := product.movedStock(movement)
+
+	alert := &StockAlertEvent{
+		Event:            event,
+		ProductID:        product.ID.Hex(),
+		SKU:              product.SKU,
+		ProductName:      product.Name,
+		Quantity:         quantity,
+		ReorderThreshold: product.ReorderThreshold,
+		MovementID:       movement.ID.Hex(),
+		OccurredAt:       time.Now(),
+	}
+	if variant != nil {
+		alert.VariantID = variant.ID.Hex()
+		alert.SKU = variant.SKU
+	}
+	return alert
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var ErrInsufficientStock = NewError(KindConflict, "insufficient_stock", "Insufficient stock")
+
+const (
+	StockMovementInitial      = "initial_stock"
+	StockMovementReservation  = "order_reservation"
+	StockMovementCancellation = "cancellation_restock"
+	StockMovementAdjustment   = "manual_adjustment"
+	StockMovementReturn       = "return"
+	StockMovementImport       = "import"
+
+	// StockActorSystem is the actor of movements made by the application itself
+	StockActorSystem = "system"
+)
+
+// StockMovement is an append-only entry of the stock ledger. The sum of the
+// movements of a product must match its quantity. Movements of a variant carry
+// VariantID and their BalanceAfter is the variant balance.
+type StockMovement struct {
+	ID           primitive.ObjectID  `bson:"_id,omitempty"`
+	TenantID     string              `bson:"tenant_id"`
+	ProductID    primitive.ObjectID  `bson:"product_id"`
+	VariantID    *primitive.ObjectID `bson:"variant_id,omitempty"`
+	Type         string              `bson:"type"`
+	Quantity     int                 `bson:"quantity"`
+	BalanceAfter int                 `bson:"balance_after"`
+	Reason       string              `bson:"reason"`
+	Actor        string              `bson:"actor"`
+	Reference    string              `bson:"reference,omitempty"`
+	CreatedAt    time.Time           `bson:"created_at"`
+}
+
+// StockDrift reports a product whose quantity differs from its ledger. Untracked
+// products have no movements at all, as products created before the ledger existed.
+type StockDrift struct {
+	ProductID      primitive.ObjectID
+	ProductName    string
+	Quantity       int
+	LedgerQuantity int
+	Untracked      bool
+}
+
+// Drift returns how many units the product quantity is above the ledger
+func (d *StockDrift) Drift() int {
+	return d.Quantity - d.LedgerQuantity
+}
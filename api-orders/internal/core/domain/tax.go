@@ -0,0 +1,18 @@
+package domain
+
+// DefaultTaxClass is the tax class assumed for products that do not declare one
+const DefaultTaxClass = "standard"
+
+// ItemTax is the tax computed for one order line
+type ItemTax struct {
+	Rate      float64
+	Amount    float64
+	Inclusive bool
+}
+
+// TaxResult is the outcome of a tax calculation for an order.
+// Lines follow the order of the items passed to the calculator.
+type TaxResult struct {
+	Region string
+	Lines  []ItemTax
+}
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"context"
+	"regexp"
+)
+
+var (
+	ErrMissingTenant = NewError(KindInvalid, "missing_tenant", "Missing tenant")
+	ErrInvalidTenant = NewError(KindInvalid, "invalid_tenant", "Invalid tenant")
+)
+
+// TenantHeader is the AMQP message header carrying the tenant of an event
+const TenantHeader = "tenant_id"
+
+var tenantPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// ValidateTenant checks that a tenant identifier is a lowercase slug of at most 64
+// characters
+func ValidateTenant(tenantID string) error {
+	if !tenantPattern.MatchString(tenantID) {
+		return ErrInvalidTenant
+	}
+	return nil
+}
+
+type tenantKey struct{}
+
+// ContextWithTenant returns a copy of ctx scoped to the tenant. Repositories of
+// tenant-owned documents only read and write the documents of this tenant.
+func ContextWithTenant(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenantID)
+}
+
+// TenantFromContext returns the tenant ctx is scoped to, if any
+func TenantFromContext(ctx context.Context) (string, bool) {
+	tenantID, ok := ctx.Value(tenantKey{}).(string)
+	return tenantID, ok && tenantID != ""
+}
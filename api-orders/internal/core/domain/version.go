@@ -0,0 +1,6 @@
+package domain
+
+// ErrVersionConflict is returned by version-conditioned updates when the document
+// changed since the version the caller read. Orders and products start at version 1
+// and every update increments it; documents created before versioning are at 0.
+var ErrVersionConflict = NewError(KindPreconditionFailed, "version_conflict", "Resource was modified by another request")
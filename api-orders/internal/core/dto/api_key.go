@@ -0,0 +1,51 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+)
+
+// CreateAPIKeyRequest represents the request body for issuing an API key
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name" validate:"required,max=100" example:"Parceiro Marketplace"`
+	Scopes    []string   `json:"scopes" validate:"required,min=1,dive,oneof=orders:write orders:read products:read" example:"orders:write,orders:read"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty" example:"2025-01-01T00:00:00Z"`
+}
+
+// APIKeyResponse represents an API key. The key itself is never returned after issue.
+type APIKeyResponse struct {
+	ID         string     `json:"_id" example:"507f1f77bcf86cd799439011"`
+	Name       string     `json:"name" example:"Parceiro Marketplace"`
+	Prefix     string     `json:"prefix" example:"rma_3fK9x2"`
+	Scopes     []string   `json:"scopes" example:"orders:write,orders:read"`
+	Status     string     `json:"status" example:"active"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty" example:"2025-01-01T00:00:00Z"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" example:"2024-02-10T12:00:00Z"`
+	RotatedAt  *time.Time `json:"rotated_at,omitempty" example:"2024-02-10T12:00:00Z"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" example:"2024-02-10T12:00:00Z"`
+	CreatedAt  time.Time  `json:"created_at" example:"2024-02-10T12:00:00Z"`
+}
+
+// IssuedAPIKeyResponse carries the key in clear text. It is shown only once, when
+// the key is issued or rotated.
+type IssuedAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key" example:"rma_3fK9x2Lq8Vn0Zp4Tb7Yc1Wd6Ue5Rf3Gh2Ji9Kk0Ll"`
+}
+
+// ToAPIKeyResponse converts a domain APIKey to APIKeyResponse
+func ToAPIKeyResponse(key *domain.APIKey) *APIKeyResponse {
+	return &APIKeyResponse{
+		ID:         key.ID.Hex(),
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     key.Scopes,
+		Status:     key.StatusAt(time.Now()),
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+		RotatedAt:  key.RotatedAt,
+		RevokedAt:  key.RevokedAt,
+		CreatedAt:  key.CreatedAt,
+	}
+}
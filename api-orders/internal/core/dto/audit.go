@@ -0,0 +1,74 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+)
+
+// AuditQueryRequest represents the filters of the audit trail. Dates are RFC 3339.
+type AuditQueryRequest struct {
+	Actor        string     `form:"actor" validate:"omitempty,max=200" example:"user-1"`
+	Action       string     `form:"action" validate:"omitempty,max=64" example:"order.status_updated"`
+	ResourceType string     `form:"resource_type" validate:"omitempty,oneof=product order payment" example:"order"`
+	ResourceID   string     `form:"resource_id" validate:"omitempty,max=64" example:"698c0a0893c94ce530171aaa"`
+	RequestID    string     `form:"request_id" validate:"omitempty,max=128" example:"4f9c2d7e8a1b3c5d6e7f8091a2b3c4d5"`
+	From         *time.Time `form:"from" example:"2024-02-01T00:00:00Z"`
+	To           *time.Time `form:"to" example:"2024-02-29T23:59:59Z"`
+	Offset       int        `form:"offset" validate:"gte=0" example:"0"`
+	Limit        int        `form:"limit" validate:"omitempty,gte=1,lte=100" example:"20"`
+}
+
+// AuditChangeResponse is the value of a field before and after a mutation
+type AuditChangeResponse struct {
+	Field  string      `json:"field" example:"status"`
+	Before interface{} `json:"before,omitempty" swaggertype:"string" example:"enviado"`
+	After  interface{} `json:"after,omitempty" swaggertype:"string" example:"entregue"`
+}
+
+// AuditEntryResponse represents a mutation recorded in the audit trail
+type AuditEntryResponse struct {
+	ID           string                `json:"_id" example:"698c0a0893c94ce530171fff"`
+	Actor        string                `json:"actor" example:"user-1"`
+	Source       string                `json:"source" example:"api-orders"`
+	Action       string                `json:"action" example:"order.status_updated"`
+	ResourceType string                `json:"resource_type" example:"order"`
+	ResourceID   string                `json:"resource_id" example:"698c0a0893c94ce530171aaa"`
+	Changes      []AuditChangeResponse `json:"changes"`
+	RequestID    string                `json:"request_id,omitempty" example:"4f9c2d7e8a1b3c5d6e7f8091a2b3c4d5"`
+	ClientIP     string                `json:"client_ip,omitempty" example:"203.0.113.10"`
+	OccurredAt   time.Time             `json:"occurred_at" example:"2024-02-10T12:00:00Z"`
+}
+
+// AuditPageResponse is a page of the audit trail, latest entries first
+type AuditPageResponse struct {
+	Items  []AuditEntryResponse `json:"items"`
+	Total  int                  `json:"total" example:"42"`
+	Offset int                  `json:"offset" example:"0"`
+	Limit  int                  `json:"limit" example:"20"`
+}
+
+// ToAuditEntryResponse converts a domain AuditEntry to AuditEntryResponse
+func ToAuditEntryResponse(entry *domain.AuditEntry) *AuditEntryResponse {
+	changes := make([]AuditChangeResponse, len(entry.Changes))
+	for i, change := range entry.Changes {
+		changes[i] = AuditChangeResponse{
+			Field:  change.Field,
+			Before: change.Before,
+			After:  change.After,
+		}
+	}
+
+	return &AuditEntryResponse{
+		ID:           entry.ID.Hex(),
+		Actor:        entry.Actor,
+		Source:       entry.Source,
+		Action:       entry.Action,
+		ResourceType: entry.ResourceType,
+		ResourceID:   entry.ResourceID,
+		Changes:      changes,
+		RequestID:    entry.RequestID,
+		ClientIP:     entry.ClientIP,
+		OccurredAt:   entry.OccurredAt,
+	}
+}
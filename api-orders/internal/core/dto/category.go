@@ -0,0 +1,45 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+)
+
+// CategoryRequest represents the request body for creating a category
+type CategoryRequest struct {
+	Name     string `json:"name" validate:"required,min=2,max=100" example:"Periféricos"`
+	ParentID string `json:"parent_id,omitempty" validate:"omitempty,mongodb" example:"698c0a0893c94ce530171ddd"`
+}
+
+// CategoryResponse represents a category of the catalog tree
+type CategoryResponse struct {
+	ID        string    `json:"_id" example:"698c0a0893c94ce530171eee"`
+	Name      string    `json:"name" example:"Periféricos"`
+	ParentID  string    `json:"parent_id,omitempty" example:"698c0a0893c94ce530171ddd"`
+	Path      []string  `json:"path" example:"698c0a0893c94ce530171ddd"`
+	CreatedAt time.Time `json:"created_at" example:"2024-02-10T12:00:00Z"`
+	UpdatedAt time.Time `json:"updated_at" example:"2024-02-10T12:00:00Z"`
+}
+
+// ToCategoryResponse converts a domain Category to CategoryResponse
+func ToCategoryResponse(category *domain.Category) *CategoryResponse {
+	path := make([]string, len(category.Path))
+	for i, id := range category.Path {
+		path[i] = id.Hex()
+	}
+
+	var parentID string
+	if category.ParentID != nil {
+		parentID = category.ParentID.Hex()
+	}
+
+	return &CategoryResponse{
+		ID:        category.ID.Hex(),
+		Name:      category.Name,
+		ParentID:  parentID,
+		Path:      path,
+		CreatedAt: category.CreatedAt,
+		UpdatedAt: category.UpdatedAt,
+	}
+}
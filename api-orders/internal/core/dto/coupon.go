@@ -0,0 +1,70 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+)
+
+// CreateCouponRequest represents the request body for creating a coupon
+type CreateCouponRequest struct {
+	Code string `json:"code" validate:"required,alphanum,min=3,max=32" example:"BEMVINDO10"`
+	UpdateCouponRequest
+}
+
+// UpdateCouponRequest represents the request body for updating a coupon
+type UpdateCouponRequest struct {
+	Type               string     `json:"type" validate:"required,oneof=percentage fixed" example:"percentage"`
+	Value              float64    `json:"value" validate:"required,gt=0" example:"10"`
+	Currency           string     `json:"currency,omitempty" validate:"omitempty,iso4217" example:"BRL"`
+	MinOrderValue      float64    `json:"min_order_value" validate:"gte=0" example:"100"`
+	ProductIDs         []string   `json:"product_ids,omitempty" validate:"omitempty,dive,mongodb" example:"698c0a0893c94ce530171bbb"`
+	CategoryIDs        []string   `json:"category_ids,omitempty" validate:"omitempty,dive,mongodb" example:"698c0a0893c94ce530171ccc"`
+	ValidFrom          *time.Time `json:"valid_from,omitempty" example:"2024-02-01T00:00:00Z"`
+	ValidUntil         *time.Time `json:"valid_until,omitempty" example:"2024-03-01T00:00:00Z"`
+	MaxUses            int        `json:"max_uses" validate:"gte=0" example:"100"`
+	MaxUsesPerCustomer int        `json:"max_uses_per_customer" validate:"gte=0" example:"1"`
+	Active             *bool      `json:"active,omitempty" example:"true"`
+}
+
+// CouponResponse represents the response body for coupon operations
+type CouponResponse struct {
+	ID                 string     `json:"_id" example:"507f1f77bcf86cd799439011"`
+	Code               string     `json:"code" example:"BEMVINDO10"`
+	Type               string     `json:"type" example:"percentage"`
+	Value              float64    `json:"value" example:"10"`
+	Currency           string     `json:"currency" example:"BRL"`
+	MinOrderValue      float64    `json:"min_order_value" example:"100"`
+	ProductIDs         []string   `json:"product_ids,omitempty"`
+	CategoryIDs        []string   `json:"category_ids,omitempty"`
+	ValidFrom          *time.Time `json:"valid_from,omitempty" example:"2024-02-01T00:00:00Z"`
+	ValidUntil         *time.Time `json:"valid_until,omitempty" example:"2024-03-01T00:00:00Z"`
+	MaxUses            int        `json:"max_uses" example:"100"`
+	MaxUsesPerCustomer int        `json:"max_uses_per_customer" example:"1"`
+	UsedCount          int        `json:"used_count" example:"12"`
+	Active             bool       `json:"active" example:"true"`
+	CreatedAt          time.Time  `json:"created_at" example:"2024-02-10T12:00:00Z"`
+	UpdatedAt          time.Time  `json:"updated_at" example:"2024-02-10T12:00:00Z"`
+}
+
+// ToCouponResponse converts a domain Coupon to CouponResponse
+func ToCouponResponse(coupon *domain.Coupon) *CouponResponse {
+	return &CouponResponse{
+		ID:                 coupon.ID.Hex(),
+		Code:               coupon.Code,
+		Type:               coupon.Type,
+		Value:              coupon.Value,
+		Currency:           coupon.Currency,
+		MinOrderValue:      coupon.MinOrderValue,
+		ProductIDs:         coupon.ProductIDs,
+		CategoryIDs:        coupon.CategoryIDs,
+		ValidFrom:          coupon.ValidFrom,
+		ValidUntil:         coupon.ValidUntil,
+		MaxUses:            coupon.MaxUses,
+		MaxUsesPerCustomer: coupon.MaxUsesPerCustomer,
+		UsedCount:          coupon.UsedCount,
+		Active:             coupon.Active,
+		CreatedAt:          coupon.CreatedAt,
+		UpdatedAt:          coupon.UpdatedAt,
+	}
+}
@@ -0,0 +1,90 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+)
+
+// AddressRequest represents a customer address in create/update requests
+type AddressRequest struct {
+	Label      string `json:"label,omitempty" validate:"omitempty,max=32" example:"Casa"`
+	Street     string `json:"street" validate:"required" example:"Av. Paulista"`
+	Number     string `json:"number" validate:"required" example:"1000"`
+	Complement string `json:"complement,omitempty" example:"Apto 12"`
+	District   string `json:"district,omitempty" example:"Bela Vista"`
+	City       string `json:"city" validate:"required" example:"São Paulo"`
+	State      string `json:"state" validate:"required,len=2,alpha" example:"SP"`
+	ZipCode    string `json:"zip_code" validate:"required,numeric,len=8" example:"01310100"`
+	Country    string `json:"country,omitempty" validate:"omitempty,iso3166_1_alpha2" example:"BR"`
+	Default    bool   `json:"default" example:"true"`
+}
+
+// CustomerRequest represents the request body for creating or replacing a customer
+type CustomerRequest struct {
+	Name      string           `json:"name" validate:"required,min=3" example:"Maria da Silva"`
+	Email     string           `json:"email" validate:"required,email" example:"maria@example.com"`
+	Document  string           `json:"document" validate:"required,cpf" example:"529.982.247-25"`
+	Addresses []AddressRequest `json:"addresses,omitempty" validate:"omitempty,dive"`
+}
+
+// AddressResponse represents a customer or order shipping address
+type AddressResponse struct {
+	ID         string `json:"_id" example:"507f1f77bcf86cd799439012"`
+	Label      string `json:"label,omitempty" example:"Casa"`
+	Street     string `json:"street" example:"Av. Paulista"`
+	Number     string `json:"number" example:"1000"`
+	Complement string `json:"complement,omitempty" example:"Apto 12"`
+	District   string `json:"district,omitempty" example:"Bela Vista"`
+	City       string `json:"city" example:"São Paulo"`
+	State      string `json:"state" example:"SP"`
+	ZipCode    string `json:"zip_code" example:"01310100"`
+	Country    string `json:"country" example:"BR"`
+	Default    bool   `json:"default" example:"true"`
+}
+
+// CustomerResponse represents the response body for customer operations
+type CustomerResponse struct {
+	ID        string            `json:"_id" example:"507f1f77bcf86cd799439011"`
+	Name      string            `json:"name" example:"Maria da Silva"`
+	Email     string            `json:"email" example:"maria@example.com"`
+	Document  string            `json:"document" example:"52998224725"`
+	Addresses []AddressResponse `json:"addresses"`
+	CreatedAt time.Time         `json:"created_at" example:"2024-02-10T12:00:00Z"`
+	UpdatedAt time.Time         `json:"updated_at" example:"2024-02-10T12:00:00Z"`
+}
+
+// ToAddressResponse converts a domain Address to AddressResponse
+func ToAddressResponse(address *domain.Address) *AddressResponse {
+	return &AddressResponse{
+		ID:         address.ID.Hex(),
+		Label:      address.Label,
+		Street:     address.Street,
+		Number:     address.Number,
+		Complement: address.Complement,
+		District:   address.District,
+		City:       address.City,
+		State:      address.State,
+		ZipCode:    address.ZipCode,
+		Country:    address.Country,
+		Default:    address.Default,
+	}
+}
+
+// ToCustomerResponse converts a domain Customer to CustomerResponse
+func ToCustomerResponse(customer *domain.Customer) *CustomerResponse {
+	addresses := make([]AddressResponse, len(customer.Addresses))
+	for i := range customer.Addresses {
+		addresses[i] = *ToAddressResponse(&customer.Addresses[i])
+	}
+
+	return &CustomerResponse{
+		ID:        customer.ID.Hex(),
+		Name:      customer.Name,
+		Email:     customer.Email,
+		Document:  customer.Document,
+		Addresses: addresses,
+		CreatedAt: customer.CreatedAt,
+		UpdatedAt: customer.UpdatedAt,
+	}
+}
@@ -6,15 +6,23 @@ import (
 	"github.com/gvillela7/rank-my-app/internal/core/domain"
 )
 
-// OrderItemRequest represents an item in the order creation request
+// OrderItemRequest represents an item in the order creation request. The item is
+// identified by product_id, sku or variant_id; products with variants need sku or variant_id.
 type OrderItemRequest struct {
-	ProductID string `json:"product_id" validate:"required" example:"698c0a0893c94ce530171bbb"`
+	ProductID string `json:"product_id,omitempty" validate:"required_without_all=SKU VariantID" example:"698c0a0893c94ce530171bbb"`
+	SKU       string `json:"sku,omitempty" validate:"omitempty,max=64" example:"MOUSE-RGB-PRETO"`
+	VariantID string `json:"variant_id,omitempty" validate:"omitempty,mongodb" example:"507f1f77bcf86cd799439016"`
 	Quantity  int    `json:"quantity" validate:"required,gte=1" example:"6"`
 }
 
 // CreateOrderRequest represents the request body for creating an order
 type CreateOrderRequest struct {
-	Items []OrderItemRequest `json:"items" validate:"required,min=1,dive"`
+	CustomerID        string             `json:"customer_id,omitempty" validate:"omitempty,mongodb" example:"698c0a0893c94ce530171ccc"`
+	Items             []OrderItemRequest `json:"items" validate:"required,min=1,dive"`
+	Currency          string             `json:"currency,omitempty" validate:"omitempty,iso4217" example:"USD"`
+	CouponCode        string             `json:"coupon_code,omitempty" validate:"omitempty,alphanum,max=32" example:"BEMVINDO10"`
+	DestinationRegion string             `json:"destination_region,omitempty" validate:"omitempty,alphanum,max=8" example:"SP"`
+	ShippingAddressID string             `json:"shipping_address_id,omitempty" validate:"omitempty,mongodb" example:"507f1f77bcf86cd799439012"`
 }
 
 // UpdateOrderStatusRequest represents the request body for updating order status
@@ -24,21 +32,51 @@ type UpdateOrderStatusRequest struct {
 
 // OrderItemResponse represents an item in the order response
 type OrderItemResponse struct {
-	ProductID   string  `json:"product_id" example:"507f1f77bcf86cd799439011"`
-	ProductName string  `json:"product_name" example:"Mouse Gamer"`
-	Price       float64 `json:"price" example:"199.90"`
-	Quantity    int     `json:"quantity" example:"2"`
+	ProductID    string  `json:"product_id" example:"507f1f77bcf86cd799439011"`
+	VariantID    string  `json:"variant_id,omitempty" example:"507f1f77bcf86cd799439016"`
+	SKU          string  `json:"sku,omitempty" example:"MOUSE-RGB-PRETO"`
+	ProductName  string  `json:"product_name" example:"Mouse Gamer"`
+	Price        float64 `json:"price" example:"199.90"`
+	Quantity     int     `json:"quantity" example:"2"`
+	BasePrice    float64 `json:"base_price" example:"199.90"`
+	BaseCurrency string  `json:"base_currency" example:"BRL"`
+	TaxClass     string  `json:"tax_class" example:"standard"`
+	TaxRate      float64 `json:"tax_rate" example:"0.18"`
+	TaxAmount    float64 `json:"tax_amount" example:"60.99"`
+	TaxInclusive bool    `json:"tax_inclusive" example:"true"`
+	Discount     float64 `json:"discount,omitempty" example:"19.99"`
+}
+
+// ExchangeRateResponse represents the exchange rate applied when the order was created
+type ExchangeRateResponse struct {
+	From      string    `json:"from" example:"BRL"`
+	To        string    `json:"to" example:"USD"`
+	Rate      float64   `json:"rate" example:"0.18"`
+	Source    string    `json:"source" example:"static"`
+	FetchedAt time.Time `json:"fetched_at" example:"2024-02-10T12:00:00Z"`
 }
 
 // OrderResponse represents the response body for order operations
 type OrderResponse struct {
-	ID          string              `json:"_id" example:"507f1f77bcf86cd799439011"`
-	OrderNumber string              `json:"order_number" example:"ORD-A1B2C3D4"`
-	Items       []OrderItemResponse `json:"items"`
-	Total       float64             `json:"total" example:"399.80"`
-	Status      string              `json:"status" example:"criado"`
-	CreatedAt   time.Time           `json:"created_at" example:"2024-02-10T12:00:00Z"`
-	UpdatedAt   time.Time           `json:"updated_at" example:"2024-02-10T12:00:00Z"`
+	ID              string                    `json:"_id" example:"507f1f77bcf86cd799439011"`
+	OrderNumber     string                    `json:"order_number" example:"ORD-20261016-0001238"`
+	CustomerID      string                    `json:"customer_id,omitempty" example:"698c0a0893c94ce530171ccc"`
+	APIKeyID        string                    `json:"api_key_id,omitempty" example:"507f1f77bcf86cd799439013"`
+	ShippingAddress *AddressResponse          `json:"shipping_address,omitempty"`
+	Items           []OrderItemResponse       `json:"items"`
+	CouponCode      string                    `json:"coupon_code,omitempty" example:"BEMVINDO10"`
+	Subtotal        float64                   `json:"subtotal" example:"399.80"`
+	DiscountTotal   float64                   `json:"discount_total" example:"39.98"`
+	TaxRegion       string                    `json:"tax_region,omitempty" example:"SP"`
+	TaxTotal        float64                   `json:"tax_total" example:"54.89"`
+	Total           float64                   `json:"total" example:"359.82"`
+	Currency        string                    `json:"currency" example:"BRL"`
+	ExchangeRates   []ExchangeRateResponse    `json:"exchange_rates,omitempty"`
+	Shipments       []ShipmentSummaryResponse `json:"shipments,omitempty"`
+	Status          string                    `json:"status" example:"criado"`
+	Version         int64                     `json:"version" example:"3"`
+	CreatedAt       time.Time                 `json:"created_at" example:"2024-02-10T12:00:00Z"`
+	UpdatedAt       time.Time                 `json:"updated_at" example:"2024-02-10T12:00:00Z"`
 }
 
 // ToOrderResponse converts a domain Order to OrderResponse
@@ -46,20 +84,69 @@ func ToOrderResponse(order *domain.Order) *OrderResponse {
 	items := make([]OrderItemResponse, len(order.Items))
 	for i, item := range order.Items {
 		items[i] = OrderItemResponse{
-			ProductID:   item.ProductID,
-			ProductName: item.ProductName,
-			Price:       item.Price,
-			Quantity:    item.Quantity,
+			ProductID:    item.ProductID,
+			VariantID:    item.VariantID,
+			SKU:          item.SKU,
+			ProductName:  item.ProductName,
+			Price:        item.Price,
+			Quantity:     item.Quantity,
+			BasePrice:    item.BasePrice,
+			BaseCurrency: item.BaseCurrency,
+			TaxClass:     item.TaxClass,
+			TaxRate:      item.TaxRate,
+			TaxAmount:    item.TaxAmount,
+			TaxInclusive: item.TaxInclusive,
+			Discount:     item.Discount,
 		}
 	}
 
+	var rates []ExchangeRateResponse
+	for _, rate := range order.ExchangeRates {
+		rates = append(rates, ExchangeRateResponse{
+			From:      rate.From,
+			To:        rate.To,
+			Rate:      rate.Rate,
+			Source:    rate.Source,
+			FetchedAt: rate.FetchedAt,
+		})
+	}
+
+	var shipments []ShipmentSummaryResponse
+	for _, shipment := range order.Shipments {
+		shipments = append(shipments, ShipmentSummaryResponse{
+			ShipmentID:   shipment.ShipmentID,
+			Carrier:      shipment.Carrier,
+			TrackingCode: shipment.TrackingCode,
+			Status:       shipment.Status,
+			ShippedAt:    shipment.ShippedAt,
+			DeliveredAt:  shipment.DeliveredAt,
+		})
+	}
+
+	var shippingAddress *AddressResponse
+	if order.ShippingAddress != nil {
+		shippingAddress = ToAddressResponse(order.ShippingAddress)
+	}
+
 	return &OrderResponse{
-		ID:          order.ID.Hex(),
-		OrderNumber: order.OrderNumber,
-		Items:       items,
-		Total:       order.Total,
-		Status:      order.Status,
-		CreatedAt:   order.CreatedAt,
-		UpdatedAt:   order.UpdatedAt,
+		ID:              order.ID.Hex(),
+		OrderNumber:     order.OrderNumber,
+		CustomerID:      order.CustomerID,
+		APIKeyID:        order.APIKeyID,
+		ShippingAddress: shippingAddress,
+		Items:           items,
+		CouponCode:      order.CouponCode,
+		Subtotal:        order.Subtotal,
+		DiscountTotal:   order.DiscountTotal,
+		TaxRegion:       order.TaxRegion,
+		TaxTotal:        order.TaxTotal,
+		Total:           order.Total,
+		Currency:        order.Currency,
+		ExchangeRates:   rates,
+		Shipments:       shipments,
+		Status:          order.Status,
+		Version:         order.Version,
+		CreatedAt:       order.CreatedAt,
+		UpdatedAt:       order.UpdatedAt,
 	}
 }
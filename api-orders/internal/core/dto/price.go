@@ -0,0 +1,48 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+)
+
+// PriceChangeRequest represents a change of the product base price. Without
+// effective_at, or with a past one, the price changes right away.
+type PriceChangeRequest struct {
+	Price       float64    `json:"price" validate:"required,gt=0" example:"179.90"`
+	EffectiveAt *time.Time `json:"effective_at,omitempty" example:"2024-03-01T00:00:00Z"`
+	Reason      string     `json:"reason" validate:"required,max=500" example:"Promoção de março"`
+	Actor       string     `json:"actor" validate:"required,max=100" example:"maria.comercial"`
+}
+
+// PriceChangeResponse represents an entry of the price history
+type PriceChangeResponse struct {
+	ID            string     `json:"_id" example:"507f1f77bcf86cd799439017"`
+	ProductID     string     `json:"product_id" example:"698c0a0893c94ce530171bbb"`
+	Price         float64    `json:"price" example:"179.90"`
+	PreviousPrice *float64   `json:"previous_price,omitempty" example:"199.90"`
+	Currency      string     `json:"currency" example:"BRL"`
+	Status        string     `json:"status" example:"scheduled"`
+	Reason        string     `json:"reason" example:"Promoção de março"`
+	Actor         string     `json:"actor" example:"maria.comercial"`
+	EffectiveAt   time.Time  `json:"effective_at" example:"2024-03-01T00:00:00Z"`
+	AppliedAt     *time.Time `json:"applied_at,omitempty" example:"2024-03-01T00:00:30Z"`
+	CreatedAt     time.Time  `json:"created_at" example:"2024-02-10T12:00:00Z"`
+}
+
+// ToPriceChangeResponse converts a domain PriceChange to PriceChangeResponse
+func ToPriceChangeResponse(change *domain.PriceChange) *PriceChangeResponse {
+	return &PriceChangeResponse{
+		ID:            change.ID.Hex(),
+		ProductID:     change.ProductID.Hex(),
+		Price:         change.Price,
+		PreviousPrice: change.PreviousPrice,
+		Currency:      change.Currency,
+		Status:        change.Status,
+		Reason:        change.Reason,
+		Actor:         change.Actor,
+		EffectiveAt:   change.EffectiveAt,
+		AppliedAt:     change.AppliedAt,
+		CreatedAt:     change.CreatedAt,
+	}
+}
@@ -3,34 +3,162 @@ package dto
 import (
 	"time"
 
-	"go.mongodb.org/mongo-driver/bson/primitive"
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
 )
 
+type ProductPriceRequest struct {
+	Currency string  `json:"currency" validate:"required,iso4217" example:"USD"`
+	Amount   float64 `json:"amount" validate:"required,gt=0" example:"39.90"`
+}
+
+// ProductVariantRequest represents a variant in the product creation request.
+// Price overrides the product price, in the product base currency.
+type ProductVariantRequest struct {
+	SKU        string            `json:"sku" validate:"required,max=64" example:"MOUSE-RGB-PRETO"`
+	Attributes map[string]string `json:"attributes,omitempty" validate:"omitempty,max=20,dive,keys,required,max=64,endkeys,max=256"`
+	Price      *float64          `json:"price,omitempty" validate:"omitempty,gt=0" example:"209.90"`
+	Quantity   int               `json:"quantity" validate:"gte=0" example:"20"`
+}
+
 type CreateProductRequest struct {
-	Name        string  `json:"name" validate:"required,min=3" example:"Mouse Gamer"`
-	Description string  `json:"description" validate:"required" example:"Mouse Gamer RGB 16000 DPI"`
-	Quantity    int     `json:"quantity" validate:"required,gte=0" example:"50"`
-	Price       float64 `json:"price" validate:"required,gt=0" example:"199.90"`
+	Name             string                  `json:"name" validate:"required,min=3" example:"Mouse Gamer"`
+	SKU              string                  `json:"sku,omitempty" validate:"omitempty,max=64" example:"MOUSE-RGB"`
+	Description      string                  `json:"description" validate:"required" example:"Mouse Gamer RGB 16000 DPI"`
+	Quantity         int                     `json:"quantity" validate:"required_without=Variants,gte=0" example:"50"`
+	Price            float64                 `json:"price" validate:"required,gt=0" example:"199.90"`
+	Currency         string                  `json:"currency,omitempty" validate:"omitempty,iso4217" example:"BRL"`
+	Prices           []ProductPriceRequest   `json:"prices,omitempty" validate:"omitempty,dive"`
+	TaxClass         string                  `json:"tax_class,omitempty" validate:"omitempty,max=32" example:"standard"`
+	ReorderThreshold int                     `json:"reorder_threshold,omitempty" validate:"omitempty,gte=0" example:"5"`
+	CategoryID       string                  `json:"category_id,omitempty" validate:"omitempty,mongodb" example:"698c0a0893c94ce530171eee"`
+	Tags             []string                `json:"tags,omitempty" validate:"omitempty,max=20,dive,required,max=32" example:"gamer,rgb"`
+	Attributes       map[string]string       `json:"attributes,omitempty" validate:"omitempty,max=50,dive,keys,required,max=64,endkeys,max=256"`
+	Variants         []ProductVariantRequest `json:"variants,omitempty" validate:"omitempty,max=100,dive"`
+}
+
+// ProductSearchRequest represents the query string of the product search
+type ProductSearchRequest struct {
+	Query    string   `form:"q" validate:"omitempty,max=100" example:"mouse"`
+	Category string   `form:"category" validate:"omitempty,mongodb" example:"698c0a0893c94ce530171eee"`
+	Tag      string   `form:"tag" validate:"omitempty,max=32" example:"gamer"`
+	MinPrice *float64 `form:"min_price" validate:"omitempty,gte=0" example:"100"`
+	MaxPrice *float64 `form:"max_price" validate:"omitempty,gte=0" example:"500"`
+	InStock  bool     `form:"in_stock" example:"true"`
+	Offset   int      `form:"offset" validate:"gte=0" example:"0"`
+	Limit    int      `form:"limit" validate:"omitempty,gte=1,lte=100" example:"20"`
+}
+
+// ReorderThresholdRequest represents the request body for changing the reorder threshold of a product
+type ReorderThresholdRequest struct {
+	ReorderThreshold *int `json:"reorder_threshold" validate:"required,gte=0" example:"5"`
+}
+
+type ProductPriceResponse struct {
+	Currency string  `json:"currency" example:"USD"`
+	Amount   float64 `json:"amount" example:"39.90"`
+}
+
+// ProductVariantResponse represents a variant with its effective price in the product base currency
+type ProductVariantResponse struct {
+	ID         string            `json:"_id" example:"507f1f77bcf86cd799439016"`
+	SKU        string            `json:"sku" example:"MOUSE-RGB-PRETO"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+	Price      float64           `json:"price" example:"209.90"`
+	Quantity   int               `json:"quantity" example:"20"`
 }
 
 type ProductResponse struct {
-	ID          string    `json:"_id" example:"507f1f77bcf86cd799439011"`
-	Name        string    `json:"name" example:"Mouse Gamer"`
-	Description string    `json:"description" example:"Mouse Gamer RGB 16000 DPI"`
-	Quantity    int       `json:"quantity" example:"50"`
-	Price       float64   `json:"price" example:"199.90"`
-	CreatedAt   time.Time `json:"created_at" example:"2024-02-10T12:00:00Z"`
-	UpdatedAt   time.Time `json:"updated_at" example:"2024-02-10T12:00:00Z"`
+	ID               string                   `json:"_id" example:"507f1f77bcf86cd799439011"`
+	Name             string                   `json:"name" example:"Mouse Gamer"`
+	SKU              string                   `json:"sku,omitempty" example:"MOUSE-RGB"`
+	Description      string                   `json:"description" example:"Mouse Gamer RGB 16000 DPI"`
+	Quantity         int                      `json:"quantity" example:"50"`
+	Price            float64                  `json:"price" example:"199.90"`
+	Currency         string                   `json:"currency" example:"BRL"`
+	Prices           []ProductPriceResponse   `json:"prices,omitempty"`
+	TaxClass         string                   `json:"tax_class" example:"standard"`
+	ReorderThreshold int                      `json:"reorder_threshold" example:"5"`
+	CategoryID       string                   `json:"category_id,omitempty" example:"698c0a0893c94ce530171eee"`
+	Tags             []string                 `json:"tags,omitempty" example:"gamer,rgb"`
+	Attributes       map[string]string        `json:"attributes,omitempty"`
+	Variants         []ProductVariantResponse `json:"variants,omitempty"`
+	Version          int64                    `json:"version" example:"3"`
+	CreatedAt        time.Time                `json:"created_at" example:"2024-02-10T12:00:00Z"`
+	UpdatedAt        time.Time                `json:"updated_at" example:"2024-02-10T12:00:00Z"`
+}
+
+// FacetCountResponse is the number of matching products with a tag
+type FacetCountResponse struct {
+	Value string `json:"value" example:"gamer"`
+	Count int    `json:"count" example:"12"`
+}
+
+// CategoryFacetResponse is the number of matching products in a category or its subcategories
+type CategoryFacetResponse struct {
+	ID    string `json:"_id" example:"698c0a0893c94ce530171eee"`
+	Name  string `json:"name" example:"Periféricos"`
+	Count int    `json:"count" example:"8"`
+}
+
+// ProductFacetsResponse groups the facet counts of a product search
+type ProductFacetsResponse struct {
+	Categories []CategoryFacetResponse `json:"categories"`
+	Tags       []FacetCountResponse    `json:"tags"`
+}
+
+// ProductSearchResponse represents a page of the product search
+type ProductSearchResponse struct {
+	Items  []ProductResponse     `json:"items"`
+	Total  int                   `json:"total" example:"42"`
+	Offset int                   `json:"offset" example:"0"`
+	Limit  int                   `json:"limit" example:"20"`
+	Facets ProductFacetsResponse `json:"facets"`
 }
 
-func ToProductResponse(id primitive.ObjectID, name, description string, quantity int, price float64, createdAt, updatedAt time.Time) *ProductResponse {
+// ToProductResponse converts a domain Product to ProductResponse
+func ToProductResponse(product *domain.Product) *ProductResponse {
+	var prices []ProductPriceResponse
+	for _, price := range product.Prices {
+		prices = append(prices, ProductPriceResponse{
+			Currency: price.Currency,
+			Amount:   price.Amount,
+		})
+	}
+
+	var variants []ProductVariantResponse
+	for i := range product.Variants {
+		variant := &product.Variants[i]
+		variants = append(variants, ProductVariantResponse{
+			ID:         variant.ID.Hex(),
+			SKU:        variant.SKU,
+			Attributes: variant.Attributes,
+			Price:      product.VariantPrice(variant),
+			Quantity:   variant.Quantity,
+		})
+	}
+
+	var categoryID string
+	if product.CategoryID != nil {
+		categoryID = product.CategoryID.Hex()
+	}
+
 	return &ProductResponse{
-		ID:          id.Hex(),
-		Name:        name,
-		Description: description,
-		Quantity:    quantity,
-		Price:       price,
-		CreatedAt:   createdAt,
-		UpdatedAt:   updatedAt,
+		ID:               product.ID.Hex(),
+		Name:             product.Name,
+		SKU:              product.SKU,
+		Description:      product.Description,
+		Quantity:         product.Quantity,
+		Price:            product.Price,
+		Currency:         product.BaseCurrency(),
+		Prices:           prices,
+		TaxClass:         product.EffectiveTaxClass(),
+		ReorderThreshold: product.ReorderThreshold,
+		CategoryID:       categoryID,
+		Tags:             product.Tags,
+		Attributes:       product.Attributes,
+		Variants:         variants,
+		Version:          product.Version,
+		CreatedAt:        product.CreatedAt,
+		UpdatedAt:        product.UpdatedAt,
 	}
 }
@@ -0,0 +1,75 @@
+package dto
+
+import (
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+)
+
+const (
+	ProductFormatCSV    = "csv"
+	ProductFormatNDJSON = "ndjson"
+)
+
+// ProductTransferRequest represents the query string of the product import and export
+type ProductTransferRequest struct {
+	Format string `form:"format" validate:"omitempty,oneof=csv ndjson" example:"csv"`
+}
+
+// ProductImportError reports why a row of an import was rejected
+type ProductImportError struct {
+	Line  int    `json:"line" example:"3"`
+	SKU   string `json:"sku,omitempty" example:"MOUSE-RGB"`
+	Code  string `json:"code,omitempty" example:"duplicate_sku"`
+	Error string `json:"error" example:"Price must be greater than 0"`
+}
+
+// ProductImportReport summarizes a product import
+type ProductImportReport struct {
+	Processed int                  `json:"processed" example:"120"`
+	Created   int                  `json:"created" example:"100"`
+	Updated   int                  `json:"updated" example:"18"`
+	Failed    int                  `json:"failed" example:"2"`
+	Errors    []ProductImportError `json:"errors"`
+}
+
+// ToProductImportRow converts a domain Product to the row format of the import,
+// so that an export can be imported back
+func ToProductImportRow(product *domain.Product) *CreateProductRequest {
+	var prices []ProductPriceRequest
+	for _, price := range product.Prices {
+		prices = append(prices, ProductPriceRequest{
+			Currency: price.Currency,
+			Amount:   price.Amount,
+		})
+	}
+
+	var variants []ProductVariantRequest
+	for _, variant := range product.Variants {
+		variants = append(variants, ProductVariantRequest{
+			SKU:        variant.SKU,
+			Attributes: variant.Attributes,
+			Price:      variant.Price,
+			Quantity:   variant.Quantity,
+		})
+	}
+
+	var categoryID string
+	if product.CategoryID != nil {
+		categoryID = product.CategoryID.Hex()
+	}
+
+	return &CreateProductRequest{
+		Name:             product.Name,
+		SKU:              product.SKU,
+		Description:      product.Description,
+		Quantity:         product.Quantity,
+		Price:            product.Price,
+		Currency:         product.BaseCurrency(),
+		Prices:           prices,
+		TaxClass:         product.EffectiveTaxClass(),
+		ReorderThreshold: product.ReorderThreshold,
+		CategoryID:       categoryID,
+		Tags:             product.Tags,
+		Attributes:       product.Attributes,
+		Variants:         variants,
+	}
+}
@@ -0,0 +1,78 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+)
+
+// ReturnItemRequest represents an order line being returned
+type ReturnItemRequest struct {
+	ProductID string `json:"product_id" validate:"required,mongodb" example:"698c0a0893c94ce530171bbb"`
+	VariantID string `json:"variant_id,omitempty" validate:"omitempty,mongodb" example:"507f1f77bcf86cd799439016"`
+	Quantity  int    `json:"quantity" validate:"required,gte=1" example:"1"`
+	Reason    string `json:"reason" validate:"required,max=500" example:"Produto com defeito"`
+}
+
+// CreateReturnRequest represents the request body for opening a return
+type CreateReturnRequest struct {
+	Items []ReturnItemRequest `json:"items" validate:"required,min=1,dive"`
+}
+
+// RejectReturnRequest represents the request body for rejecting a return
+type RejectReturnRequest struct {
+	Reason string `json:"reason" validate:"required,max=500" example:"Produto fora do prazo de devolução"`
+}
+
+type ReturnItemResponse struct {
+	ProductID    string  `json:"product_id" example:"698c0a0893c94ce530171bbb"`
+	VariantID    string  `json:"variant_id,omitempty" example:"507f1f77bcf86cd799439016"`
+	Quantity     int     `json:"quantity" example:"1"`
+	Reason       string  `json:"reason" example:"Produto com defeito"`
+	UnitRefund   float64 `json:"unit_refund" example:"179.91"`
+	RefundAmount float64 `json:"refund_amount" example:"179.91"`
+}
+
+// ReturnResponse represents the response body for return operations
+type ReturnResponse struct {
+	ID              string               `json:"_id" example:"507f1f77bcf86cd799439014"`
+	OrderID         string               `json:"order_id" example:"507f1f77bcf86cd799439011"`
+	CustomerID      string               `json:"customer_id,omitempty" example:"698c0a0893c94ce530171ccc"`
+	Items           []ReturnItemResponse `json:"items"`
+	Status          string               `json:"status" example:"requested"`
+	RefundTotal     float64              `json:"refund_total" example:"179.91"`
+	Currency        string               `json:"currency" example:"BRL"`
+	RejectionReason string               `json:"rejection_reason,omitempty" example:"Produto fora do prazo de devolução"`
+	ResolvedAt      *time.Time           `json:"resolved_at,omitempty" example:"2024-02-20T10:00:00Z"`
+	CreatedAt       time.Time            `json:"created_at" example:"2024-02-18T10:00:00Z"`
+	UpdatedAt       time.Time            `json:"updated_at" example:"2024-02-20T10:00:00Z"`
+}
+
+// ToReturnResponse converts a domain ReturnRequest to ReturnResponse
+func ToReturnResponse(ret *domain.ReturnRequest) *ReturnResponse {
+	items := make([]ReturnItemResponse, len(ret.Items))
+	for i, item := range ret.Items {
+		items[i] = ReturnItemResponse{
+			ProductID:    item.ProductID,
+			VariantID:    item.VariantID,
+			Quantity:     item.Quantity,
+			Reason:       item.Reason,
+			UnitRefund:   item.UnitRefund,
+			RefundAmount: item.RefundAmount,
+		}
+	}
+
+	return &ReturnResponse{
+		ID:              ret.ID.Hex(),
+		OrderID:         ret.OrderID,
+		CustomerID:      ret.CustomerID,
+		Items:           items,
+		Status:          ret.Status,
+		RefundTotal:     ret.RefundTotal,
+		Currency:        ret.Currency,
+		RejectionReason: ret.RejectionReason,
+		ResolvedAt:      ret.ResolvedAt,
+		CreatedAt:       ret.CreatedAt,
+		UpdatedAt:       ret.UpdatedAt,
+	}
+}
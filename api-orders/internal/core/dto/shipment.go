@@ -0,0 +1,89 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+)
+
+// ShipmentItemRequest represents an order item included in a shipment
+type ShipmentItemRequest struct {
+	ProductID string `json:"product_id" validate:"required,mongodb" example:"698c0a0893c94ce530171bbb"`
+	VariantID string `json:"variant_id,omitempty" validate:"omitempty,mongodb" example:"507f1f77bcf86cd799439016"`
+	Quantity  int    `json:"quantity" validate:"required,gte=1" example:"2"`
+}
+
+// CreateShipmentRequest represents the request body for shipping an order.
+// When items is empty every item not yet shipped is included.
+type CreateShipmentRequest struct {
+	Carrier      string                `json:"carrier" validate:"required" example:"Correios"`
+	TrackingCode string                `json:"tracking_code" validate:"required" example:"BR123456789BR"`
+	Items        []ShipmentItemRequest `json:"items,omitempty" validate:"omitempty,dive"`
+	ShippedAt    *time.Time            `json:"shipped_at,omitempty" example:"2024-02-11T09:00:00Z"`
+}
+
+// DeliverShipmentRequest represents the request body for confirming a delivery
+type DeliverShipmentRequest struct {
+	ReceivedBy  string     `json:"received_by" validate:"required" example:"Maria da Silva"`
+	ProofURL    string     `json:"proof_url,omitempty" validate:"omitempty,url" example:"https://cdn.example.com/pod/123.jpg"`
+	DeliveredAt *time.Time `json:"delivered_at,omitempty" example:"2024-02-13T15:30:00Z"`
+}
+
+type ShipmentItemResponse struct {
+	ProductID string `json:"product_id" example:"698c0a0893c94ce530171bbb"`
+	VariantID string `json:"variant_id,omitempty" example:"507f1f77bcf86cd799439016"`
+	Quantity  int    `json:"quantity" example:"2"`
+}
+
+// ShipmentResponse represents the response body for shipment operations
+type ShipmentResponse struct {
+	ID           string                 `json:"_id" example:"507f1f77bcf86cd799439013"`
+	OrderID      string                 `json:"order_id" example:"507f1f77bcf86cd799439011"`
+	Carrier      string                 `json:"carrier" example:"Correios"`
+	TrackingCode string                 `json:"tracking_code" example:"BR123456789BR"`
+	Items        []ShipmentItemResponse `json:"items"`
+	Status       string                 `json:"status" example:"enviado"`
+	ShippedAt    time.Time              `json:"shipped_at" example:"2024-02-11T09:00:00Z"`
+	DeliveredAt  *time.Time             `json:"delivered_at,omitempty" example:"2024-02-13T15:30:00Z"`
+	ReceivedBy   string                 `json:"received_by,omitempty" example:"Maria da Silva"`
+	ProofURL     string                 `json:"proof_url,omitempty" example:"https://cdn.example.com/pod/123.jpg"`
+	CreatedAt    time.Time              `json:"created_at" example:"2024-02-11T09:00:00Z"`
+	UpdatedAt    time.Time              `json:"updated_at" example:"2024-02-13T15:30:00Z"`
+}
+
+// ShipmentSummaryResponse represents a shipment in the order response
+type ShipmentSummaryResponse struct {
+	ShipmentID   string     `json:"shipment_id" example:"507f1f77bcf86cd799439013"`
+	Carrier      string     `json:"carrier" example:"Correios"`
+	TrackingCode string     `json:"tracking_code" example:"BR123456789BR"`
+	Status       string     `json:"status" example:"enviado"`
+	ShippedAt    time.Time  `json:"shipped_at" example:"2024-02-11T09:00:00Z"`
+	DeliveredAt  *time.Time `json:"delivered_at,omitempty" example:"2024-02-13T15:30:00Z"`
+}
+
+// ToShipmentResponse converts a domain Shipment to ShipmentResponse
+func ToShipmentResponse(shipment *domain.Shipment) *ShipmentResponse {
+	items := make([]ShipmentItemResponse, len(shipment.Items))
+	for i, item := range shipment.Items {
+		items[i] = ShipmentItemResponse{
+			ProductID: item.ProductID,
+			VariantID: item.VariantID,
+			Quantity:  item.Quantity,
+		}
+	}
+
+	return &ShipmentResponse{
+		ID:           shipment.ID.Hex(),
+		OrderID:      shipment.OrderID,
+		Carrier:      shipment.Carrier,
+		TrackingCode: shipment.TrackingCode,
+		Items:        items,
+		Status:       shipment.Status,
+		ShippedAt:    shipment.ShippedAt,
+		DeliveredAt:  shipment.DeliveredAt,
+		ReceivedBy:   shipment.ReceivedBy,
+		ProofURL:     shipment.ProofURL,
+		CreatedAt:    shipment.CreatedAt,
+		UpdatedAt:    shipment.UpdatedAt,
+	}
+}
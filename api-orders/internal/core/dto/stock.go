@@ -0,0 +1,73 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+)
+
+// StockAdjustmentRequest represents a manual change of a product stock.
+// Positive quantities add units, negative ones remove them. Products with
+// variants are adjusted one variant at a time. The actor is the authenticated caller.
+type StockAdjustmentRequest struct {
+	VariantID string `json:"variant_id,omitempty" validate:"omitempty,mongodb" example:"507f1f77bcf86cd799439016"`
+	Quantity  int    `json:"quantity" validate:"required" example:"-2"`
+	Reason    string `json:"reason" validate:"required,max=500" example:"Avaria no estoque"`
+}
+
+// StockMovementResponse represents an entry of the stock ledger
+type StockMovementResponse struct {
+	ID           string    `json:"_id" example:"507f1f77bcf86cd799439015"`
+	ProductID    string    `json:"product_id" example:"698c0a0893c94ce530171bbb"`
+	VariantID    string    `json:"variant_id,omitempty" example:"507f1f77bcf86cd799439016"`
+	Type         string    `json:"type" example:"manual_adjustment"`
+	Quantity     int       `json:"quantity" example:"-2"`
+	BalanceAfter int       `json:"balance_after" example:"48"`
+	Reason       string    `json:"reason" example:"Avaria no estoque"`
+	Actor        string    `json:"actor" example:"joao.estoque"`
+	Reference    string    `json:"reference,omitempty" example:"ORD-20261016-0001238"`
+	CreatedAt    time.Time `json:"created_at" example:"2024-02-10T12:00:00Z"`
+}
+
+// StockDriftResponse represents a product whose quantity differs from its ledger
+type StockDriftResponse struct {
+	ProductID      string `json:"product_id" example:"698c0a0893c94ce530171bbb"`
+	ProductName    string `json:"product_name" example:"Mouse Gamer"`
+	Quantity       int    `json:"quantity" example:"50"`
+	LedgerQuantity int    `json:"ledger_quantity" example:"48"`
+	Drift          int    `json:"drift" example:"2"`
+	Untracked      bool   `json:"untracked,omitempty" example:"false"`
+}
+
+// ToStockMovementResponse converts a domain StockMovement to StockMovementResponse
+func ToStockMovementResponse(movement *domain.StockMovement) *StockMovementResponse {
+	var variantID string
+	if movement.VariantID != nil {
+		variantID = movement.VariantID.Hex()
+	}
+
+	return &StockMovementResponse{
+		ID:           movement.ID.Hex(),
+		ProductID:    movement.ProductID.Hex(),
+		VariantID:    variantID,
+		Type:         movement.Type,
+		Quantity:     movement.Quantity,
+		BalanceAfter: movement.BalanceAfter,
+		Reason:       movement.Reason,
+		Actor:        movement.Actor,
+		Reference:    movement.Reference,
+		CreatedAt:    movement.CreatedAt,
+	}
+}
+
+// ToStockDriftResponse converts a domain StockDrift to StockDriftResponse
+func ToStockDriftResponse(drift *domain.StockDrift) *StockDriftResponse {
+	return &StockDriftResponse{
+		ProductID:      drift.ProductID.Hex(),
+		ProductName:    drift.ProductName,
+		Quantity:       drift.Quantity,
+		LedgerQuantity: drift.LedgerQuantity,
+		Drift:          drift.Drift(),
+		Untracked:      drift.Untracked,
+	}
+}
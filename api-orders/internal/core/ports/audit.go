@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+)
+
+// AuditRepository stores the audit trail of the tenant of the context. It is
+// append-only: entries are never updated nor deleted.
+type AuditRepository interface {
+	Append(ctx context.Context, entry *domain.AuditEntry) error
+	// Find returns a page of the entries matching filter, latest first, and the
+	// number of matching entries
+	Find(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, int, error)
+}
+
+// Auditor records a mutation of a resource in the audit trail, attributing it to
+// the principal and request of ctx. Mutations without changes are not recorded.
+type Auditor interface {
+	Record(ctx context.Context, action, resourceType, resourceID string, changes []domain.AuditChange) error
+}
@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+)
+
+// TokenVerifier validates bearer tokens and returns the principal they identify.
+// Tokens that are malformed, expired or not signed by a trusted key fail with
+// domain.ErrInvalidToken.
+type TokenVerifier interface {
+	Verify(ctx context.Context, token string) (*domain.Principal, error)
+}
+
+// APIKeyAuthenticator resolves the principal of an API key. Unknown, expired and
+// revoked keys fail with domain.ErrInvalidAPIKey.
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, key string) (*domain.Principal, error)
+}
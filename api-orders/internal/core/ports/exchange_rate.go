@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+)
+
+// ExchangeRateProvider resolves the rate used to convert prices between currencies.
+// Implementations return domain.ErrExchangeRateNotFound when a currency pair is unsupported.
+type ExchangeRateProvider interface {
+	GetRate(ctx context.Context, from, to string) (*domain.ExchangeRate, error)
+}
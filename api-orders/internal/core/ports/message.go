@@ -1,7 +1,14 @@
 package ports
 
-import "context"
+import (
+	"context"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+)
 
 type MessageProducer interface {
 	PublishOrderStatus(ctx context.Context, orderID, status string, timestamp float64) error
+	PublishShipmentEvent(ctx context.Context, event *domain.ShipmentEvent) error
+	PublishReturnEvent(ctx context.Context, event *domain.ReturnEvent) error
+	PublishStockAlert(ctx context.Context, event *domain.StockAlertEvent) error
 }
@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+)
+
+// RateLimiter takes one token from the bucket of key, refilled according to limit
+type RateLimiter interface {
+	Take(ctx context.Context, key string, limit domain.RateLimit) (domain.RateLimitDecision, error)
+}
@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/gvillela7/rank-my-app/internal/core/domain"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -10,12 +11,132 @@ import (
 type ProductRepository interface {
 	Create(ctx context.Context, product *domain.Product) error
 	FindByID(ctx context.Context, id primitive.ObjectID) (*domain.Product, error)
+	// FindBySKU returns the product whose own SKU or one of whose variant SKUs matches sku
+	FindBySKU(ctx context.Context, sku string) (*domain.Product, error)
+	FindByVariantID(ctx context.Context, variantID primitive.ObjectID) (*domain.Product, error)
+	List(ctx context.Context) ([]domain.Product, error)
+	// Each calls fn for every product, in creation order, without loading them all at once
+	Each(ctx context.Context, fn func(product *domain.Product) error) error
+	// Update replaces the catalog fields of the product and the price and attributes
+	// of its variants, leaving every quantity untouched. It fails with
+	// domain.ErrVersionConflict when the product is no longer at product.Version.
+	Update(ctx context.Context, product *domain.Product) error
+	Search(ctx context.Context, filter domain.ProductFilter) (*domain.ProductSearchResult, error)
+	// AdjustQuantity atomically adds delta to the product quantity and returns the
+	// updated product, failing with domain.ErrInsufficientStock when it would go negative
+	AdjustQuantity(ctx context.Context, id primitive.ObjectID, delta int) (*domain.Product, error)
+	// AdjustVariantQuantity adds delta to the variant and to the product quantity in a
+	// single update, failing with domain.ErrInsufficientStock when the variant would go negative
+	AdjustVariantQuantity(ctx context.Context, id, variantID primitive.ObjectID, delta int) (*domain.Product, error)
+	SetQuantity(ctx context.Context, id primitive.ObjectID, quantity int) error
+	// SetReorderThreshold and SetPrice only update the product still at version,
+	// failing with domain.ErrVersionConflict otherwise
+	SetReorderThreshold(ctx context.Context, id primitive.ObjectID, threshold int, version int64) error
+	SetPrice(ctx context.Context, id primitive.ObjectID, price float64, version int64) error
+}
+
+type PriceChangeRepository interface {
+	Create(ctx context.Context, change *domain.PriceChange) error
+	FindByID(ctx context.Context, id primitive.ObjectID) (*domain.PriceChange, error)
+	// FindByProductID returns the price history of the product, latest effective first
+	FindByProductID(ctx context.Context, productID primitive.ObjectID) ([]domain.PriceChange, error)
+	// FindEffective returns the latest change not cancelled that is effective at the
+	// given time, whether or not the scheduler already applied it
+	FindEffective(ctx context.Context, productID primitive.ObjectID, at time.Time) (*domain.PriceChange, error)
+	// FindDue returns scheduled changes of every tenant effective at the given time,
+	// oldest first
+	FindDue(ctx context.Context, at time.Time, limit int) ([]domain.PriceChange, error)
+	// MarkApplied and Cancel resolve a scheduled change, returning
+	// mongo.ErrNoDocuments when it is no longer scheduled
+	MarkApplied(ctx context.Context, change *domain.PriceChange) error
+	Cancel(ctx context.Context, id primitive.ObjectID) error
+}
+
+type CategoryRepository interface {
+	Create(ctx context.Context, category *domain.Category) error
+	FindByID(ctx context.Context, id primitive.ObjectID) (*domain.Category, error)
+	List(ctx context.Context) ([]domain.Category, error)
+}
+
+type StockMovementRepository interface {
+	Create(ctx context.Context, movement *domain.StockMovement) error
+	FindByProductID(ctx context.Context, productID primitive.ObjectID) ([]domain.StockMovement, error)
+	FindByReference(ctx context.Context, movementType, reference string) ([]domain.StockMovement, error)
+	// SumByProduct returns the ledger balance of every product with movements
+	SumByProduct(ctx context.Context) (map[primitive.ObjectID]int, error)
 }
 
 type OrderRepository interface {
 	Create(ctx context.Context, order *domain.Order) error
 	FindByID(ctx context.Context, id primitive.ObjectID) (*domain.Order, error)
-	UpdateStatus(ctx context.Context, id primitive.ObjectID, status string) error
+	FindByOrderNumber(ctx context.Context, orderNumber string) (*domain.Order, error)
+	// UpdateStatus fails with domain.ErrVersionConflict when the order is no longer at version
+	UpdateStatus(ctx context.Context, id primitive.ObjectID, status string, version int64) error
+	FindByCustomerID(ctx context.Context, customerID string) ([]domain.Order, error)
+	UpdateFulfillment(ctx context.Context, id primitive.ObjectID, shipments []domain.ShipmentSummary, status string, version int64) error
+	// FindUnreleasedDeclined returns orders of every tenant whose payment was declined
+	// and whose stock and coupon were not given back yet, oldest first
+	FindUnreleasedDeclined(ctx context.Context, limit int) ([]domain.Order, error)
+	// MarkReleased returns mongo.ErrNoDocuments when the order was already released
+	MarkReleased(ctx context.Context, id primitive.ObjectID, at time.Time) error
+}
+
+// SequenceRepository keeps the counters of the tenant
+type SequenceRepository interface {
+	// Next atomically increments the named counter and returns its new value; new
+	// counters start at 1
+	Next(ctx context.Context, name string) (int64, error)
+}
+
+type ShipmentRepository interface {
+	Create(ctx context.Context, shipment *domain.Shipment) error
+	FindByID(ctx context.Context, id primitive.ObjectID) (*domain.Shipment, error)
+	FindByOrderID(ctx context.Context, orderID string) ([]domain.Shipment, error)
+	MarkDelivered(ctx context.Context, shipment *domain.Shipment) error
+	Delete(ctx context.Context, id primitive.ObjectID) error
+}
+
+type ReturnRepository interface {
+	Create(ctx context.Context, ret *domain.ReturnRequest) error
+	FindByID(ctx context.Context, id primitive.ObjectID) (*domain.ReturnRequest, error)
+	FindByOrderID(ctx context.Context, orderID string) ([]domain.ReturnRequest, error)
+	// Resolve moves a requested return to its new status, returning
+	// mongo.ErrNoDocuments when it is no longer pending
+	Resolve(ctx context.Context, ret *domain.ReturnRequest) error
+}
+
+type CustomerRepository interface {
+	Create(ctx context.Context, customer *domain.Customer) error
+	FindByID(ctx context.Context, id primitive.ObjectID) (*domain.Customer, error)
+	FindByEmailOrDocument(ctx context.Context, email, document string) (*domain.Customer, error)
+	List(ctx context.Context) ([]domain.Customer, error)
+	Update(ctx context.Context, customer *domain.Customer) error
+	Delete(ctx context.Context, id primitive.ObjectID) error
+}
+
+type CouponRepository interface {
+	Create(ctx context.Context, coupon *domain.Coupon) error
+	FindByID(ctx context.Context, id primitive.ObjectID) (*domain.Coupon, error)
+	FindByCode(ctx context.Context, code string) (*domain.Coupon, error)
+	List(ctx context.Context) ([]domain.Coupon, error)
+	Update(ctx context.Context, coupon *domain.Coupon) error
+	Delete(ctx context.Context, id primitive.ObjectID) error
+	// Redeem atomically increments the usage counters, returning
+	// domain.ErrCouponUsageLimitReached when a limit would be exceeded
+	Redeem(ctx context.Context, id primitive.ObjectID, customerID string) error
+	// Release reverts a previous Redeem
+	Release(ctx context.Context, id primitive.ObjectID, customerID string) error
+}
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *domain.APIKey) error
+	FindByID(ctx context.Context, id primitive.ObjectID) (*domain.APIKey, error)
+	FindByHash(ctx context.Context, hash string) (*domain.APIKey, error)
+	List(ctx context.Context) ([]domain.APIKey, error)
+	// Rotate replaces the hash and prefix of a key that is not revoked
+	Rotate(ctx context.Context, id primitive.ObjectID, prefix, hash string) error
+	Revoke(ctx context.Context, id primitive.ObjectID) error
+	TouchLastUsed(ctx context.Context, id primitive.ObjectID, at time.Time) error
 }
 
 type PublishedOrderRepository interface {
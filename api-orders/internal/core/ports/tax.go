@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+)
+
+// TaxCalculator computes the taxes of order items shipped to a destination region.
+// An empty region means the calculator's default region.
+type TaxCalculator interface {
+	Calculate(ctx context.Context, region string, items []domain.OrderItem) (*domain.TaxResult, error)
+}
@@ -8,10 +8,89 @@ import (
 
 type ProductUseCase interface {
 	CreateProduct(ctx context.Context, req *dto.CreateProductRequest) (*dto.ProductResponse, error)
+	GetProductByID(ctx context.Context, id string) (*dto.ProductResponse, error)
+	SearchProducts(ctx context.Context, req *dto.ProductSearchRequest) (*dto.ProductSearchResponse, error)
+	// ImportProduct upserts the product of an import row by SKU, reporting whether it was created
+	ImportProduct(ctx context.Context, req *dto.CreateProductRequest) (bool, error)
+	ExportProducts(ctx context.Context, fn func(row *dto.CreateProductRequest) error) error
+}
+
+type PriceUseCase interface {
+	// ChangePrice changes the product base price right away, or schedules the
+	// change when it is effective in the future
+	ChangePrice(ctx context.Context, productID string, req *dto.PriceChangeRequest, ifMatch *int64) (*dto.PriceChangeResponse, error)
+	ListPrices(ctx context.Context, productID string) ([]dto.PriceChangeResponse, error)
+	CancelPriceChange(ctx context.Context, productID, changeID string) (*dto.PriceChangeResponse, error)
+	// ApplyDuePrices applies the scheduled changes already effective, returning how many were applied
+	ApplyDuePrices(ctx context.Context) (int, error)
+}
+
+type CategoryUseCase interface {
+	CreateCategory(ctx context.Context, req *dto.CategoryRequest) (*dto.CategoryResponse, error)
+	ListCategories(ctx context.Context) ([]dto.CategoryResponse, error)
+}
+
+type StockUseCase interface {
+	AdjustStock(ctx context.Context, productID string, req *dto.StockAdjustmentRequest) (*dto.StockMovementResponse, error)
+	SetReorderThreshold(ctx context.Context, productID string, req *dto.ReorderThresholdRequest, ifMatch *int64) (*dto.ProductResponse, error)
+	ListMovements(ctx context.Context, productID string) ([]dto.StockMovementResponse, error)
+	// Reconcile recomputes every product quantity from the ledger and reports the drifts,
+	// overwriting the quantities with the ledger balance when apply is true
+	Reconcile(ctx context.Context, apply bool) ([]dto.StockDriftResponse, error)
 }
 
 type OrderUseCase interface {
 	CreateOrder(ctx context.Context, req *dto.CreateOrderRequest) (*dto.OrderResponse, error)
 	GetOrderByID(ctx context.Context, id string) (*dto.OrderResponse, error)
-	UpdateOrderStatus(ctx context.Context, id string, req *dto.UpdateOrderStatusRequest) (*dto.OrderResponse, error)
+	GetOrderByNumber(ctx context.Context, orderNumber string) (*dto.OrderResponse, error)
+	// UpdateOrderStatus changes the status of the order; a non-nil ifMatch must be
+	// the current order version
+	UpdateOrderStatus(ctx context.Context, id string, req *dto.UpdateOrderStatusRequest, ifMatch *int64) (*dto.OrderResponse, error)
+	// ReleaseDeclinedOrders gives back the stock and coupon of the orders whose payment
+	// was declined, returning how many were released
+	ReleaseDeclinedOrders(ctx context.Context) (int, error)
+}
+
+type CouponUseCase interface {
+	CreateCoupon(ctx context.Context, req *dto.CreateCouponRequest) (*dto.CouponResponse, error)
+	GetCouponByID(ctx context.Context, id string) (*dto.CouponResponse, error)
+	ListCoupons(ctx context.Context) ([]dto.CouponResponse, error)
+	UpdateCoupon(ctx context.Context, id string, req *dto.UpdateCouponRequest) (*dto.CouponResponse, error)
+	DeleteCoupon(ctx context.Context, id string) error
+}
+
+type APIKeyUseCase interface {
+	APIKeyAuthenticator
+	IssueAPIKey(ctx context.Context, req *dto.CreateAPIKeyRequest) (*dto.IssuedAPIKeyResponse, error)
+	ListAPIKeys(ctx context.Context) ([]dto.APIKeyResponse, error)
+	// RotateAPIKey replaces the key of an API key; the previous key stops working at once
+	RotateAPIKey(ctx context.Context, id string) (*dto.IssuedAPIKeyResponse, error)
+	RevokeAPIKey(ctx context.Context, id string) (*dto.APIKeyResponse, error)
+}
+
+type AuditUseCase interface {
+	Auditor
+	ListAuditEntries(ctx context.Context, req *dto.AuditQueryRequest) (*dto.AuditPageResponse, error)
+}
+
+type CustomerUseCase interface {
+	CreateCustomer(ctx context.Context, req *dto.CustomerRequest) (*dto.CustomerResponse, error)
+	GetCustomerByID(ctx context.Context, id string) (*dto.CustomerResponse, error)
+	ListCustomers(ctx context.Context) ([]dto.CustomerResponse, error)
+	UpdateCustomer(ctx context.Context, id string, req *dto.CustomerRequest) (*dto.CustomerResponse, error)
+	DeleteCustomer(ctx context.Context, id string) error
+	ListCustomerOrders(ctx context.Context, id string) ([]dto.OrderResponse, error)
+}
+
+type ShipmentUseCase interface {
+	CreateShipment(ctx context.Context, orderID string, req *dto.CreateShipmentRequest) (*dto.ShipmentResponse, error)
+	ListShipments(ctx context.Context, orderID string) ([]dto.ShipmentResponse, error)
+	MarkDelivered(ctx context.Context, orderID, shipmentID string, req *dto.DeliverShipmentRequest) (*dto.ShipmentResponse, error)
+}
+
+type ReturnUseCase interface {
+	RequestReturn(ctx context.Context, orderID string, req *dto.CreateReturnRequest) (*dto.ReturnResponse, error)
+	ListReturns(ctx context.Context, orderID string) ([]dto.ReturnResponse, error)
+	ApproveReturn(ctx context.Context, orderID, returnID string) (*dto.ReturnResponse, error)
+	RejectReturn(ctx context.Context, orderID, returnID string, req *dto.RejectReturnRequest) (*dto.ReturnResponse, error)
 }
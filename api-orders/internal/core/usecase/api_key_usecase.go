@@ -0,0 +1,204 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/dto"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+const (
+	apiKeyMarker    = "rma_"
+	apiKeyPrefixLen = len(apiKeyMarker) + 6
+
+	// lastUsedResolution limits the writes of last_used_at to one per key and period
+	lastUsedResolution = time.Minute
+)
+
+type apiKeyUseCase struct {
+	repository ports.APIKeyRepository
+	logger     *zap.Logger
+}
+
+func NewAPIKeyUseCase(repository ports.APIKeyRepository, logger *zap.Logger) ports.APIKeyUseCase {
+	return &apiKeyUseCase{
+		repository: repository,
+		logger:     logger,
+	}
+}
+
+func (uc *apiKeyUseCase) IssueAPIKey(ctx context.Context, req *dto.CreateAPIKeyRequest) (*dto.IssuedAPIKeyResponse, error) {
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, domain.ErrInvalidRequest.WithDetail("Expiration must be in the future")
+	}
+
+	secret, prefix, hash, err := generateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	// keys are bound to the tenant they are issued in
+	tenantID, _ := domain.TenantFromContext(ctx)
+
+	key := &domain.APIKey{
+		TenantID:  tenantID,
+		Name:      req.Name,
+		Prefix:    prefix,
+		Hash:      hash,
+		Scopes:    uniqueScopes(req.Scopes),
+		ExpiresAt: req.ExpiresAt,
+	}
+
+	if err := uc.repository.Create(ctx, key); err != nil {
+		return nil, err
+	}
+
+	return &dto.IssuedAPIKeyResponse{APIKeyResponse: *dto.ToAPIKeyResponse(key), Key: secret}, nil
+}
+
+func (uc *apiKeyUseCase) ListAPIKeys(ctx context.Context) ([]dto.APIKeyResponse, error) {
+	keys, err := uc.repository.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.APIKeyResponse, 0, len(keys))
+	for i := range keys {
+		responses = append(responses, *dto.ToAPIKeyResponse(&keys[i]))
+	}
+
+	return responses, nil
+}
+
+func (uc *apiKeyUseCase) RotateAPIKey(ctx context.Context, id string) (*dto.IssuedAPIKeyResponse, error) {
+	key, err := uc.findAPIKey(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if key.RevokedAt != nil {
+		return nil, domain.ErrInvalidTransition.WithDetail("Revoked API keys cannot be rotated")
+	}
+
+	secret, prefix, hash, err := generateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.repository.Rotate(ctx, key.ID, prefix, hash); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrInvalidTransition.WithDetail("Revoked API keys cannot be rotated")
+		}
+		return nil, err
+	}
+
+	if key, err = uc.repository.FindByID(ctx, key.ID); err != nil {
+		return nil, err
+	}
+
+	return &dto.IssuedAPIKeyResponse{APIKeyResponse: *dto.ToAPIKeyResponse(key), Key: secret}, nil
+}
+
+func (uc *apiKeyUseCase) RevokeAPIKey(ctx context.Context, id string) (*dto.APIKeyResponse, error) {
+	key, err := uc.findAPIKey(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if key.RevokedAt != nil {
+		return dto.ToAPIKeyResponse(key), nil
+	}
+
+	if err := uc.repository.Revoke(ctx, key.ID); err != nil && err != mongo.ErrNoDocuments {
+		return nil, err
+	}
+
+	if key, err = uc.repository.FindByID(ctx, key.ID); err != nil {
+		return nil, err
+	}
+
+	return dto.ToAPIKeyResponse(key), nil
+}
+
+// Authenticate resolves the principal of an API key and records its use. The use
+// is informational: failing to record it does not fail the request.
+func (uc *apiKeyUseCase) Authenticate(ctx context.Context, secret string) (*domain.Principal, error) {
+	key, err := uc.repository.FindByHash(ctx, hashAPIKey(secret))
+	if err == mongo.ErrNoDocuments {
+		return nil, domain.ErrInvalidAPIKey
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if status := key.StatusAt(now); status != domain.APIKeyStatusActive {
+		return nil, fmt.Errorf("%w: key %s is %s", domain.ErrInvalidAPIKey, key.Prefix, status)
+	}
+
+	// the request has no tenant yet; the key is updated in the tenant it is bound to
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= lastUsedResolution {
+		if err := uc.repository.TouchLastUsed(domain.ContextWithTenant(ctx, key.TenantID), key.ID, now); err != nil {
+			uc.logger.Warn("Failed to record API key use", zap.String("prefix", key.Prefix), zap.Error(err))
+		}
+	}
+
+	return key.Principal(), nil
+}
+
+func (uc *apiKeyUseCase) findAPIKey(ctx context.Context, id string) (*domain.APIKey, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, domain.ErrAPIKeyNotFound.WithDetail("Invalid API key ID")
+	}
+
+	key, err := uc.repository.FindByID(ctx, objectID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrAPIKeyNotFound
+		}
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// generateAPIKey returns a new random key with its display prefix and stored hash
+func generateAPIKey() (secret, prefix, hash string, err error) {
+	random := make([]byte, 32)
+	if _, err := rand.Read(random); err != nil {
+		return "", "", "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+
+	secret = apiKeyMarker + base64.RawURLEncoding.EncodeToString(random)
+	return secret, secret[:apiKeyPrefixLen], hashAPIKey(secret), nil
+}
+
+// hashAPIKey hashes a key for storage and lookup. Keys are random and long, so a
+// fast unsalted hash is enough to keep them unusable if the collection leaks.
+func hashAPIKey(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func uniqueScopes(scopes []string) []string {
+	seen := make(map[string]bool, len(scopes))
+	unique := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if !seen[scope] {
+			seen[scope] = true
+			unique = append(unique, scope)
+		}
+	}
+	return unique
+}
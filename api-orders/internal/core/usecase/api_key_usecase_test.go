@@ -0,0 +1,218 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/dto"
+	"github.com/gvillela7/rank-my-app/internal/core/usecase"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// mockAPIKeyRepository scopes the keys to the tenant of the context, except
+// FindByHash, like the repositories
+type mockAPIKeyRepository struct {
+	keys     []*domain.APIKey
+	touched  int
+	touchErr error
+}
+
+func (m *mockAPIKeyRepository) Create(ctx context.Context, key *domain.APIKey) error {
+	key.ID = primitive.NewObjectID()
+	key.TenantID, _ = domain.TenantFromContext(ctx)
+	key.CreatedAt = time.Now()
+	m.keys = append(m.keys, key)
+	return nil
+}
+
+func (m *mockAPIKeyRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*domain.APIKey, error) {
+	tenantID, _ := domain.TenantFromContext(ctx)
+	for _, key := range m.keys {
+		if key.ID == id && key.TenantID == tenantID {
+			copied := *key
+			return &copied, nil
+		}
+	}
+	return nil, mongo.ErrNoDocuments
+}
+
+func (m *mockAPIKeyRepository) FindByHash(ctx context.Context, hash string) (*domain.APIKey, error) {
+	for _, key := range m.keys {
+		if key.Hash == hash {
+			copied := *key
+			return &copied, nil
+		}
+	}
+	return nil, mongo.ErrNoDocuments
+}
+
+func (m *mockAPIKeyRepository) List(ctx context.Context) ([]domain.APIKey, error) {
+	tenantID, _ := domain.TenantFromContext(ctx)
+	keys := make([]domain.APIKey, 0, len(m.keys))
+	for _, key := range m.keys {
+		if key.TenantID == tenantID {
+			keys = append(keys, *key)
+		}
+	}
+	return keys, nil
+}
+
+func (m *mockAPIKeyRepository) Rotate(ctx context.Context, id primitive.ObjectID, prefix, hash string) error {
+	for _, key := range m.keys {
+		if key.ID == id && key.RevokedAt == nil {
+			now := time.Now()
+			key.Prefix, key.Hash, key.RotatedAt = prefix, hash, &now
+			return nil
+		}
+	}
+	return mongo.ErrNoDocuments
+}
+
+func (m *mockAPIKeyRepository) Revoke(ctx context.Context, id primitive.ObjectID) error {
+	for _, key := range m.keys {
+		if key.ID == id && key.RevokedAt == nil {
+			now := time.Now()
+			key.RevokedAt = &now
+			return nil
+		}
+	}
+	return mongo.ErrNoDocuments
+}
+
+func (m *mockAPIKeyRepository) TouchLastUsed(ctx context.Context, id primitive.ObjectID, at time.Time) error {
+	if m.touchErr != nil {
+		return m.touchErr
+	}
+	for _, key := range m.keys {
+		if key.ID == id {
+			key.LastUsedAt = &at
+			m.touched++
+		}
+	}
+	return nil
+}
+
+func TestAPIKeyUseCase_IssueAPIKey_StoresOnlyHash(t *testing.T) {
+	repo := &mockAPIKeyRepository{}
+	uc := usecase.NewAPIKeyUseCase(repo, zap.NewNop())
+
+	issued, err := uc.IssueAPIKey(context.Background(), &dto.CreateAPIKeyRequest{
+		Name:   "Parceiro Marketplace",
+		Scopes: []string{domain.ScopeOrdersWrite, domain.ScopeOrdersWrite, domain.ScopeOrdersRead},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	stored := repo.keys[0]
+	if stored.Hash == issued.Key || stored.Hash == "" {
+		t.Error("Expected the key to be stored hashed")
+	}
+	if issued.Prefix != issued.Key[:len(issued.Prefix)] {
+		t.Errorf("Expected prefix %s to start the key", issued.Prefix)
+	}
+	if len(stored.Scopes) != 2 {
+		t.Errorf("Expected duplicated scopes to be removed, got %v", stored.Scopes)
+	}
+
+	principal, err := uc.Authenticate(context.Background(), issued.Key)
+	if err != nil {
+		t.Fatalf("Expected issued key to authenticate, got: %v", err)
+	}
+	if principal.APIKeyID != stored.ID.Hex() || !principal.HasScope(domain.ScopeOrdersWrite) || principal.HasScope(domain.ScopeProductsRead) {
+		t.Errorf("Unexpected principal %+v", principal)
+	}
+
+	// uses within lastUsedResolution are not written again
+	if _, err := uc.Authenticate(context.Background(), issued.Key); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if repo.touched != 1 || stored.LastUsedAt == nil {
+		t.Errorf("Expected last use recorded once, got %d", repo.touched)
+	}
+}
+
+func TestAPIKeyUseCase_Authenticate_RejectsRotatedRevokedAndExpiredKeys(t *testing.T) {
+	repo := &mockAPIKeyRepository{}
+	uc := usecase.NewAPIKeyUseCase(repo, zap.NewNop())
+	ctx := context.Background()
+
+	issued, _ := uc.IssueAPIKey(ctx, &dto.CreateAPIKeyRequest{Name: "ERP", Scopes: []string{domain.ScopeOrdersWrite}})
+	rotated, err := uc.RotateAPIKey(ctx, issued.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if _, err := uc.Authenticate(ctx, issued.Key); !errors.Is(err, domain.ErrInvalidAPIKey) {
+		t.Errorf("Expected previous key rejected after rotation, got %v", err)
+	}
+	if _, err := uc.Authenticate(ctx, rotated.Key); err != nil {
+		t.Errorf("Expected rotated key accepted, got %v", err)
+	}
+
+	if _, err := uc.RevokeAPIKey(ctx, issued.ID); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if _, err := uc.Authenticate(ctx, rotated.Key); !errors.Is(err, domain.ErrInvalidAPIKey) {
+		t.Errorf("Expected revoked key rejected, got %v", err)
+	}
+
+	expiring, _ := uc.IssueAPIKey(ctx, &dto.CreateAPIKeyRequest{Name: "Temporária", Scopes: []string{domain.ScopeOrdersRead}})
+	expired := time.Now().Add(-time.Minute)
+	repo.keys[1].ExpiresAt = &expired
+	if _, err := uc.Authenticate(ctx, expiring.Key); !errors.Is(err, domain.ErrInvalidAPIKey) {
+		t.Errorf("Expected expired key rejected, got %v", err)
+	}
+}
+
+func TestAPIKeyUseCase_IssueAPIKey_BindsKeyToTenant(t *testing.T) {
+	repo := &mockAPIKeyRepository{}
+	uc := usecase.NewAPIKeyUseCase(repo, zap.NewNop())
+	ctx := domain.ContextWithTenant(context.Background(), "store-a")
+
+	issued, err := uc.IssueAPIKey(ctx, &dto.CreateAPIKeyRequest{Name: "ERP", Scopes: []string{domain.ScopeOrdersWrite}})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	principal, err := uc.Authenticate(context.Background(), issued.Key)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if principal.TenantID != "store-a" {
+		t.Errorf("Expected principal bound to store-a, got %q", principal.TenantID)
+	}
+
+	// other tenants neither list nor manage the key
+	otherTenant := domain.ContextWithTenant(context.Background(), "store-b")
+	if keys, _ := uc.ListAPIKeys(otherTenant); len(keys) != 0 {
+		t.Errorf("Expected no keys listed for store-b, got %d", len(keys))
+	}
+	if _, err := uc.RevokeAPIKey(otherTenant, issued.ID); err == nil {
+		t.Error("Expected store-b unable to revoke the key of store-a")
+	}
+}
+
+func TestAPIKeyUseCase_Authenticate_IgnoresFailureToRecordUse(t *testing.T) {
+	repo := &mockAPIKeyRepository{}
+	uc := usecase.NewAPIKeyUseCase(repo, zap.NewNop())
+
+	issued, err := uc.IssueAPIKey(context.Background(), &dto.CreateAPIKeyRequest{Name: "ERP", Scopes: []string{domain.ScopeOrdersWrite}})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	repo.touchErr = errors.New("write concern timeout")
+	principal, err := uc.Authenticate(context.Background(), issued.Key)
+	if err != nil {
+		t.Fatalf("Expected the key to authenticate, got: %v", err)
+	}
+	if principal.APIKeyID != issued.ID {
+		t.Errorf("Expected principal of key %s, got %+v", issued.ID, principal)
+	}
+}
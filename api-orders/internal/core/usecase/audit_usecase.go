@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/dto"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+)
+
+type auditUseCase struct {
+	repository ports.AuditRepository
+}
+
+func NewAuditUseCase(repository ports.AuditRepository) ports.AuditUseCase {
+	return &auditUseCase{
+		repository: repository,
+	}
+}
+
+func (uc *auditUseCase) Record(ctx context.Context, action, resourceType, resourceID string, changes []domain.AuditChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	entry := &domain.AuditEntry{
+		Actor:        actorOf(ctx),
+		Source:       domain.AuditSourceAPI,
+		Action:       action,
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		Changes:      changes,
+		OccurredAt:   time.Now(),
+	}
+
+	if info, ok := domain.RequestInfoFromContext(ctx); ok {
+		entry.RequestID = info.ID
+		entry.ClientIP = info.ClientIP
+	}
+
+	return uc.repository.Append(ctx, entry)
+}
+
+// actorOf returns who acts in ctx: the authenticated principal, anonymous for
+// requests without one and the system for background jobs, which run without request
+func actorOf(ctx context.Context) string {
+	if principal, ok := domain.PrincipalFromContext(ctx); ok {
+		return principal.Subject
+	}
+	if _, ok := domain.RequestInfoFromContext(ctx); ok {
+		return domain.AuditActorAnonymous
+	}
+	return domain.AuditActorSystem
+}
+
+func (uc *auditUseCase) ListAuditEntries(ctx context.Context, req *dto.AuditQueryRequest) (*dto.AuditPageResponse, error) {
+	if req.From != nil && req.To != nil && req.From.After(*req.To) {
+		return nil, domain.ErrInvalidRequest.WithDetail("from must not be after to")
+	}
+
+	filter := domain.AuditFilter{
+		Actor:        req.Actor,
+		Action:       req.Action,
+		ResourceType: req.ResourceType,
+		ResourceID:   req.ResourceID,
+		RequestID:    req.RequestID,
+		From:         req.From,
+		To:           req.To,
+		Offset:       req.Offset,
+		Limit:        req.Limit,
+	}
+	if filter.Limit == 0 {
+		filter.Limit = defaultSearchLimit
+	}
+
+	entries, total, err := uc.repository.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.AuditEntryResponse, len(entries))
+	for i := range entries {
+		items[i] = *dto.ToAuditEntryResponse(&entries[i])
+	}
+
+	return &dto.AuditPageResponse{
+		Items:  items,
+		Total:  total,
+		Offset: filter.Offset,
+		Limit:  filter.Limit,
+	}, nil
+}
@@ -0,0 +1,63 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/usecase"
+)
+
+type mockAuditRepository struct {
+	entries []domain.AuditEntry
+}
+
+func (m *mockAuditRepository) Append(ctx context.Context, entry *domain.AuditEntry) error {
+	m.entries = append(m.entries, *entry)
+	return nil
+}
+
+func (m *mockAuditRepository) Find(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, int, error) {
+	return m.entries, len(m.entries), nil
+}
+
+func TestAuditUseCase_Record_AttributesChangeToPrincipalAndRequest(t *testing.T) {
+	repo := &mockAuditRepository{}
+	uc := usecase.NewAuditUseCase(repo)
+
+	ctx := domain.ContextWithRequestInfo(context.Background(), domain.RequestInfo{ID: "req-1", ClientIP: "203.0.113.10"})
+	ctx = domain.ContextWithPrincipal(ctx, &domain.Principal{Subject: "user-1", Roles: []string{domain.RoleOperator}})
+
+	changes := domain.AuditDiff(
+		map[string]interface{}{"status": domain.OrderStatusShipped, "version": int64(3), "updated_at": "before"},
+		map[string]interface{}{"status": domain.OrderStatusDelivered, "version": int64(4), "updated_at": "after"},
+	)
+	if err := uc.Record(ctx, domain.AuditOrderStatusUpdated, domain.AuditResourceOrder, "order-1", changes); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(repo.entries) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(repo.entries))
+	}
+	entry := repo.entries[0]
+	if entry.Actor != "user-1" || entry.RequestID != "req-1" || entry.ClientIP != "203.0.113.10" || entry.Source != domain.AuditSourceAPI {
+		t.Errorf("Unexpected attribution %+v", entry)
+	}
+	if len(entry.Changes) != 2 || entry.Changes[0].Field != "status" || entry.Changes[0].After != domain.OrderStatusDelivered {
+		t.Errorf("Expected status and version changes without updated_at, got %+v", entry.Changes)
+	}
+}
+
+func TestAuditUseCase_Record_SkipsMutationsWithoutChanges(t *testing.T) {
+	repo := &mockAuditRepository{}
+	uc := usecase.NewAuditUseCase(repo)
+
+	snapshot := map[string]interface{}{"status": domain.OrderStatusShipped}
+	if err := uc.Record(context.Background(), domain.AuditOrderStatusUpdated, domain.AuditResourceOrder, "order-1", domain.AuditDiff(snapshot, snapshot)); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(repo.entries) != 0 {
+		t.Errorf("Expected nothing recorded, got %+v", repo.entries)
+	}
+}
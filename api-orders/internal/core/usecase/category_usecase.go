@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/dto"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type categoryUseCase struct {
+	repository ports.CategoryRepository
+}
+
+func NewCategoryUseCase(repository ports.CategoryRepository) ports.CategoryUseCase {
+	return &categoryUseCase{
+		repository: repository,
+	}
+}
+
+func (uc *categoryUseCase) CreateCategory(ctx context.Context, req *dto.CategoryRequest) (*dto.CategoryResponse, error) {
+	category := &domain.Category{
+		Name: req.Name,
+		Path: make([]primitive.ObjectID, 0),
+	}
+
+	if req.ParentID != "" {
+		parentID, err := primitive.ObjectIDFromHex(req.ParentID)
+		if err != nil {
+			return nil, domain.ErrUnknownCategory.Wrap("Invalid parent category ID", err)
+		}
+
+		parent, err := uc.repository.FindByID(ctx, parentID)
+		if err != nil {
+			if err == mongo.ErrNoDocuments {
+				return nil, domain.ErrUnknownCategory.Wrap("Parent category not found", err)
+			}
+			return nil, err
+		}
+
+		category.ParentID = &parent.ID
+		category.Path = parent.Lineage()
+	}
+
+	if err := uc.repository.Create(ctx, category); err != nil {
+		return nil, err
+	}
+
+	return dto.ToCategoryResponse(category), nil
+}
+
+func (uc *categoryUseCase) ListCategories(ctx context.Context) ([]dto.CategoryResponse, error) {
+	categories, err := uc.repository.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.CategoryResponse, len(categories))
+	for i := range categories {
+		responses[i] = *dto.ToCategoryResponse(&categories[i])
+	}
+
+	return responses, nil
+}
@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/dto"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type couponUseCase struct {
+	repository ports.CouponRepository
+}
+
+func NewCouponUseCase(repository ports.CouponRepository) ports.CouponUseCase {
+	return &couponUseCase{
+		repository: repository,
+	}
+}
+
+func (uc *couponUseCase) CreateCoupon(ctx context.Context, req *dto.CreateCouponRequest) (*dto.CouponResponse, error) {
+	code := strings.ToUpper(req.Code)
+
+	if _, err := uc.repository.FindByCode(ctx, code); err == nil {
+		return nil, domain.ErrDuplicateCoupon.Detailf("Coupon code already exists: %s", code)
+	} else if err != mongo.ErrNoDocuments {
+		return nil, fmt.Errorf("failed to check coupon code: %w", err)
+	}
+
+	coupon := &domain.Coupon{Code: code}
+	if err := applyCouponRequest(coupon, &req.UpdateCouponRequest); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repository.Create(ctx, coupon); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, domain.ErrDuplicateCoupon.Detailf("Coupon code already exists: %s", code)
+		}
+		return nil, err
+	}
+
+	return dto.ToCouponResponse(coupon), nil
+}
+
+func (uc *couponUseCase) GetCouponByID(ctx context.Context, id string) (*dto.CouponResponse, error) {
+	coupon, err := uc.findCoupon(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ToCouponResponse(coupon), nil
+}
+
+func (uc *couponUseCase) ListCoupons(ctx context.Context) ([]dto.CouponResponse, error) {
+	coupons, err := uc.repository.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.CouponResponse, len(coupons))
+	for i := range coupons {
+		responses[i] = *dto.ToCouponResponse(&coupons[i])
+	}
+
+	return responses, nil
+}
+
+func (uc *couponUseCase) UpdateCoupon(ctx context.Context, id string, req *dto.UpdateCouponRequest) (*dto.CouponResponse, error) {
+	coupon, err := uc.findCoupon(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyCouponRequest(coupon, req); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repository.Update(ctx, coupon); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrCouponNotFound
+		}
+		return nil, err
+	}
+
+	return dto.ToCouponResponse(coupon), nil
+}
+
+func (uc *couponUseCase) DeleteCoupon(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrCouponNotFound.WithDetail("Invalid coupon ID")
+	}
+
+	if err := uc.repository.Delete(ctx, objectID); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return domain.ErrCouponNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (uc *couponUseCase) findCoupon(ctx context.Context, id string) (*domain.Coupon, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, domain.ErrCouponNotFound.WithDetail("Invalid coupon ID")
+	}
+
+	coupon, err := uc.repository.FindByID(ctx, objectID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrCouponNotFound
+		}
+		return nil, err
+	}
+
+	return coupon, nil
+}
+
+func applyCouponRequest(coupon *domain.Coupon, req *dto.UpdateCouponRequest) error {
+	if req.Type == domain.CouponTypePercentage && req.Value > 100 {
+		return domain.ErrInvalidRequest.WithDetail("Percentage coupons cannot exceed 100")
+	}
+
+	if req.ValidFrom != nil && req.ValidUntil != nil && req.ValidUntil.Before(*req.ValidFrom) {
+		return domain.ErrInvalidRequest.WithDetail("valid_until must be after valid_from")
+	}
+
+	currency := req.Currency
+	if currency == "" {
+		currency = domain.DefaultCurrency
+	}
+
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+
+	coupon.Type = req.Type
+	coupon.Value = req.Value
+	coupon.Currency = currency
+	coupon.MinOrderValue = req.MinOrderValue
+	coupon.ProductIDs = req.ProductIDs
+	coupon.CategoryIDs = req.CategoryIDs
+	coupon.ValidFrom = req.ValidFrom
+	coupon.ValidUntil = req.ValidUntil
+	coupon.MaxUses = req.MaxUses
+	coupon.MaxUsesPerCustomer = req.MaxUsesPerCustomer
+	coupon.Active = active
+
+	return nil
+}
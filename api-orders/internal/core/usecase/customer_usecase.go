@@ -0,0 +1,198 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/dto"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type customerUseCase struct {
+	repository      ports.CustomerRepository
+	orderRepository ports.OrderRepository
+}
+
+func NewCustomerUseCase(repository ports.CustomerRepository, orderRepository ports.OrderRepository) ports.CustomerUseCase {
+	return &customerUseCase{
+		repository:      repository,
+		orderRepository: orderRepository,
+	}
+}
+
+func (uc *customerUseCase) CreateCustomer(ctx context.Context, req *dto.CustomerRequest) (*dto.CustomerResponse, error) {
+	customer := &domain.Customer{}
+	applyCustomerRequest(customer, req)
+
+	if err := uc.checkUniqueness(ctx, customer); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repository.Create(ctx, customer); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, domain.ErrDuplicateCustomer
+		}
+		return nil, err
+	}
+
+	return dto.ToCustomerResponse(customer), nil
+}
+
+func (uc *customerUseCase) GetCustomerByID(ctx context.Context, id string) (*dto.CustomerResponse, error) {
+	customer, err := uc.findCustomer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ToCustomerResponse(customer), nil
+}
+
+func (uc *customerUseCase) ListCustomers(ctx context.Context) ([]dto.CustomerResponse, error) {
+	customers, err := uc.repository.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.CustomerResponse, len(customers))
+	for i := range customers {
+		responses[i] = *dto.ToCustomerResponse(&customers[i])
+	}
+
+	return responses, nil
+}
+
+func (uc *customerUseCase) UpdateCustomer(ctx context.Context, id string, req *dto.CustomerRequest) (*dto.CustomerResponse, error) {
+	customer, err := uc.findCustomer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	applyCustomerRequest(customer, req)
+
+	if err := uc.checkUniqueness(ctx, customer); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repository.Update(ctx, customer); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrCustomerNotFound
+		}
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, domain.ErrDuplicateCustomer
+		}
+		return nil, err
+	}
+
+	return dto.ToCustomerResponse(customer), nil
+}
+
+func (uc *customerUseCase) DeleteCustomer(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrCustomerNotFound.WithDetail("Invalid customer ID")
+	}
+
+	if err := uc.repository.Delete(ctx, objectID); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return domain.ErrCustomerNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (uc *customerUseCase) ListCustomerOrders(ctx context.Context, id string) ([]dto.OrderResponse, error) {
+	customer, err := uc.findCustomer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	orders, err := uc.orderRepository.FindByCustomerID(ctx, customer.ID.Hex())
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.OrderResponse, len(orders))
+	for i := range orders {
+		responses[i] = *dto.ToOrderResponse(&orders[i])
+	}
+
+	return responses, nil
+}
+
+func (uc *customerUseCase) findCustomer(ctx context.Context, id string) (*domain.Customer, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, domain.ErrCustomerNotFound.WithDetail("Invalid customer ID")
+	}
+
+	customer, err := uc.repository.FindByID(ctx, objectID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrCustomerNotFound
+		}
+		return nil, err
+	}
+
+	return customer, nil
+}
+
+// checkUniqueness rejects an email or document already used by another customer
+func (uc *customerUseCase) checkUniqueness(ctx context.Context, customer *domain.Customer) error {
+	existing, err := uc.repository.FindByEmailOrDocument(ctx, customer.Email, customer.Document)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil
+		}
+		return fmt.Errorf("failed to check customer uniqueness: %w", err)
+	}
+
+	if existing.ID != customer.ID {
+		return domain.ErrDuplicateCustomer
+	}
+
+	return nil
+}
+
+func applyCustomerRequest(customer *domain.Customer, req *dto.CustomerRequest) {
+	addresses := make([]domain.Address, len(req.Addresses))
+	hasDefault := false
+	for i, address := range req.Addresses {
+		country := strings.ToUpper(address.Country)
+		if country == "" {
+			country = "BR"
+		}
+
+		// only the first address flagged as default is kept as default
+		isDefault := address.Default && !hasDefault
+		hasDefault = hasDefault || isDefault
+
+		addresses[i] = domain.Address{
+			ID:         primitive.NewObjectID(),
+			Label:      address.Label,
+			Street:     address.Street,
+			Number:     address.Number,
+			Complement: address.Complement,
+			District:   address.District,
+			City:       address.City,
+			State:      strings.ToUpper(address.State),
+			ZipCode:    address.ZipCode,
+			Country:    country,
+			Default:    isDefault,
+		}
+	}
+
+	if !hasDefault && len(addresses) > 0 {
+		addresses[0].Default = true
+	}
+
+	customer.Name = req.Name
+	customer.Email = strings.ToLower(req.Email)
+	customer.Document = domain.NormalizeDocument(req.Document)
+	customer.Addresses = addresses
+}
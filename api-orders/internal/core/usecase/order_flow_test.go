@@ -0,0 +1,101 @@
+package usecase_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gvillela7/rank-my-app/inprocess"
+	"github.com/gvillela7/rank-my-app/internal/adapter/exchangerate"
+	"github.com/gvillela7/rank-my-app/internal/adapter/messages/producers"
+	"github.com/gvillela7/rank-my-app/internal/adapter/repository/memory"
+	"github.com/gvillela7/rank-my-app/internal/adapter/tax"
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/dto"
+	"github.com/gvillela7/rank-my-app/internal/core/usecase"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.uber.org/zap"
+)
+
+// receive waits for the next delivery of the queue
+func receive(t *testing.T, broker *inprocess.Broker, queue string) amqp.Delivery {
+	t.Helper()
+
+	select {
+	case delivery := <-broker.Consume(queue):
+		return delivery
+	case <-time.After(time.Second):
+		t.Fatalf("Expected a message on %s", queue)
+		return amqp.Delivery{}
+	}
+}
+
+func TestOrderFlow_CreatedOrderIsPublished(t *testing.T) {
+	ctx := domain.ContextWithTenant(context.Background(), "acme")
+	ctx = domain.ContextWithRequestInfo(ctx, domain.RequestInfo{ID: "req-1"})
+
+	broker := inprocess.NewBroker(10)
+	producer := producers.NewInProcessProducer(broker, memory.NewPublishedOrderRepository(), zap.NewNop())
+	products := memory.NewProductRepository()
+	orders := memory.NewOrderRepository()
+
+	uc := usecase.NewOrderUseCase(
+		orders,
+		products,
+		producer,
+		exchangerate.NewStaticProvider("BRL", nil),
+		memory.NewCouponRepository(),
+		tax.NewRuleTableCalculator("SP", nil),
+		memory.NewCustomerRepository(),
+		memory.NewShipmentRepository(),
+		memory.NewStockMovementRepository(),
+		memory.NewPriceChangeRepository(),
+		memory.NewSequenceRepository(),
+		testOrderNumbers,
+	)
+
+	product := &domain.Product{Name: "Teclado", Price: 100, Currency: "BRL", Quantity: 5}
+	if err := products.Create(ctx, product); err != nil {
+		t.Fatalf("Expected the product to be created, got: %v", err)
+	}
+
+	created, err := uc.CreateOrder(ctx, &dto.CreateOrderRequest{
+		Items: []dto.OrderItemRequest{{ProductID: product.ID.Hex(), Quantity: 2}},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	delivery := receive(t, broker, "order-status")
+	if delivery.Headers[domain.TenantHeader] != "acme" || delivery.CorrelationId != "req-1" {
+		t.Errorf("Expected tenant acme and correlation req-1, got %v and %s", delivery.Headers[domain.TenantHeader], delivery.CorrelationId)
+	}
+
+	var message producers.OrderStatusMessage
+	if err := json.Unmarshal(delivery.Body, &message); err != nil {
+		t.Fatalf("Expected an order status message, got: %v", err)
+	}
+	if message.OrderID != created.ID || message.Status != domain.OrderStatusCreated {
+		t.Errorf("Expected %s to be published as %s, got %+v", created.ID, domain.OrderStatusCreated, message)
+	}
+	if err := delivery.Ack(false); err != nil {
+		t.Fatalf("Expected the delivery to be acknowledged, got: %v", err)
+	}
+	if broker.Len("order-status") != 0 {
+		t.Errorf("Expected an empty queue, got %d messages", broker.Len("order-status"))
+	}
+
+	stocked, err := products.FindByID(ctx, product.ID)
+	if err != nil {
+		t.Fatalf("Expected the product to be found, got: %v", err)
+	}
+	if stocked.Quantity != 3 {
+		t.Errorf("Expected 3 units left in stock, got %d", stocked.Quantity)
+	}
+
+	other := domain.ContextWithTenant(context.Background(), "globex")
+	if _, err := uc.GetOrderByID(other, created.ID); err == nil {
+		t.Error("Expected the order to be hidden from another tenant")
+	}
+}
@@ -2,12 +2,12 @@ package usecase
 
 import (
 	"context"
-	"crypto/rand"
-	"encoding/hex"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
-	"github.com/gvillela7/rank-my-app/internal/adapter/http/handlers"
 	"github.com/gvillela7/rank-my-app/internal/core/domain"
 	"github.com/gvillela7/rank-my-app/internal/core/dto"
 	"github.com/gvillela7/rank-my-app/internal/core/ports"
@@ -15,59 +15,170 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
+// declinedOrderBatch is how many declined orders are released at a time
+const declinedOrderBatch = 100
+
 type orderUseCase struct {
-	orderRepository   ports.OrderRepository
-	productRepository ports.ProductRepository
-	messageProducer   ports.MessageProducer
+	orderRepository      ports.OrderRepository
+	productRepository    ports.ProductRepository
+	messageProducer      ports.MessageProducer
+	exchangeRateProvider ports.ExchangeRateProvider
+	couponRepository     ports.CouponRepository
+	taxCalculator        ports.TaxCalculator
+	customerRepository   ports.CustomerRepository
+	shipmentRepository   ports.ShipmentRepository
+	priceRepository      ports.PriceChangeRepository
+	sequenceRepository   ports.SequenceRepository
+	orderNumbers         domain.OrderNumberFormat
+	ledger               *stockLedger
 }
 
-func NewOrderUseCase(orderRepository ports.OrderRepository, productRepository ports.ProductRepository, messageProducer ports.MessageProducer) ports.OrderUseCase {
+func NewOrderUseCase(orderRepository ports.OrderRepository, productRepository ports.ProductRepository, messageProducer ports.MessageProducer, exchangeRateProvider ports.ExchangeRateProvider, couponRepository ports.CouponRepository, taxCalculator ports.TaxCalculator, customerRepository ports.CustomerRepository, shipmentRepository ports.ShipmentRepository, movementRepository ports.StockMovementRepository, priceRepository ports.PriceChangeRepository, sequenceRepository ports.SequenceRepository, orderNumbers domain.OrderNumberFormat) ports.OrderUseCase {
 	return &orderUseCase{
-		orderRepository:   orderRepository,
-		productRepository: productRepository,
-		messageProducer:   messageProducer,
+		orderRepository:      orderRepository,
+		productRepository:    productRepository,
+		messageProducer:      messageProducer,
+		exchangeRateProvider: exchangeRateProvider,
+		couponRepository:     couponRepository,
+		taxCalculator:        taxCalculator,
+		customerRepository:   customerRepository,
+		shipmentRepository:   shipmentRepository,
+		priceRepository:      priceRepository,
+		sequenceRepository:   sequenceRepository,
+		orderNumbers:         orderNumbers,
+		ledger:               newStockLedger(productRepository, movementRepository, messageProducer),
 	}
 }
 
 func (uc *orderUseCase) CreateOrder(ctx context.Context, req *dto.CreateOrderRequest) (*dto.OrderResponse, error) {
 
+	var shippingAddress *domain.Address
+	if req.CustomerID != "" {
+		var err error
+		shippingAddress, err = uc.resolveShippingAddress(ctx, req.CustomerID, req.ShippingAddressID)
+		if err != nil {
+			return nil, err
+		}
+	} else if req.ShippingAddressID != "" {
+		return nil, domain.ErrInvalidRequest.WithDetail("shipping_address_id requires a customer_id")
+	}
+
+	currency := req.Currency
+	if currency == "" {
+		currency = domain.DefaultCurrency
+	}
+	rates := make(map[string]*domain.ExchangeRate)
+	orderedAt := time.Now()
+
 	items := make([]domain.OrderItem, len(req.Items))
 	for i, itemReq := range req.Items {
-		productID, err := primitive.ObjectIDFromHex(itemReq.ProductID)
+		product, variant, err := uc.resolveItem(ctx, itemReq)
 		if err != nil {
-			return nil, handlers.NotFoundError(fmt.Sprintf("Invalid product ID: %s", itemReq.ProductID))
+			return nil, err
 		}
 
-		product, err := uc.productRepository.FindByID(ctx, productID)
+		product, err = uc.withEffectivePrice(ctx, product, orderedAt)
 		if err != nil {
-			if err == mongo.ErrNoDocuments {
-				return nil, handlers.NotFoundError(fmt.Sprintf("Product not found: %s", itemReq.ProductID))
-			}
-			return nil, fmt.Errorf("failed to fetch product: %w", err)
+			return nil, err
+		}
+
+		available, basePrice, sku := product.Quantity, product.Price, product.SKU
+		if variant != nil {
+			available, basePrice, sku = variant.Quantity, product.VariantPrice(variant), variant.SKU
 		}
 
-		if product.Quantity < itemReq.Quantity {
-			return nil, fmt.Errorf("insufficient stock for product %s: available %d, requested %d",
-				product.Name, product.Quantity, itemReq.Quantity)
+		if available < itemReq.Quantity {
+			return nil, domain.ErrInsufficientStock.Detailf("Insufficient stock for product %s: available %d, requested %d",
+				product.Name, available, itemReq.Quantity)
+		}
+
+		price, err := uc.priceIn(ctx, product, variant, currency, rates)
+		if err != nil {
+			return nil, err
 		}
 
 		items[i] = domain.OrderItem{
-			ProductID:   itemReq.ProductID,
-			ProductName: product.Name,
-			Price:       product.Price,
-			Quantity:    itemReq.Quantity,
+			ProductID:    product.ID.Hex(),
+			SKU:          sku,
+			ProductName:  product.Name,
+			Price:        price,
+			Quantity:     itemReq.Quantity,
+			BasePrice:    basePrice,
+			BaseCurrency: product.BaseCurrency(),
+			TaxClass:     product.EffectiveTaxClass(),
+		}
+		for _, categoryID := range product.CategoryPath {
+			items[i].CategoryPath = append(items[i].CategoryPath, categoryID.Hex())
+		}
+		if variant != nil {
+			items[i].VariantID = variant.ID.Hex()
 		}
 	}
 
 	order := &domain.Order{
-		OrderNumber: generateOrderNumber(),
-		Items:       items,
-		Status:      "criado",
+		CustomerID:      req.CustomerID,
+		ShippingAddress: shippingAddress,
+		Items:           items,
+		Currency:        currency,
+		Status:          domain.OrderStatusCreated,
+	}
+
+	if principal, ok := domain.PrincipalFromContext(ctx); ok {
+		order.APIKeyID = principal.APIKeyID
 	}
 
 	order.CalculateTotal()
 
+	var coupon *domain.Coupon
+	if req.CouponCode != "" {
+		var err error
+		coupon, err = uc.applyCoupon(ctx, order, strings.ToUpper(req.CouponCode), rates)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	order.ExchangeRates = appliedRates(rates)
+
+	region := req.DestinationRegion
+	if region == "" && shippingAddress != nil {
+		region = shippingAddress.State
+	}
+
+	taxes, err := uc.taxCalculator.Calculate(ctx, region, order.Items)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate taxes: %w", err)
+	}
+	order.ApplyTaxes(taxes)
+
+	// numbers are drawn before anything is reserved; orders that fail afterwards
+	// leave a gap in the sequence
+	order.OrderNumber, err = uc.nextOrderNumber(ctx, orderedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if coupon != nil {
+		if err := uc.couponRepository.Redeem(ctx, coupon.ID, order.CustomerID); err != nil {
+			if errors.Is(err, domain.ErrCouponUsageLimitReached) {
+				return nil, domain.ErrCouponUsageLimitReached.Detailf("Coupon usage limit reached: %s", coupon.Code)
+			}
+			return nil, fmt.Errorf("failed to redeem coupon: %w", err)
+		}
+	}
+
+	if err := uc.reserveStock(ctx, order); err != nil {
+		if coupon != nil {
+			_ = uc.couponRepository.Release(ctx, coupon.ID, order.CustomerID)
+		}
+		return nil, err
+	}
+
 	if err := uc.orderRepository.Create(ctx, order); err != nil {
+		uc.releaseStock(ctx, order, order.Items)
+		if coupon != nil {
+			_ = uc.couponRepository.Release(ctx, coupon.ID, order.CustomerID)
+		}
 		return nil, err
 	}
 
@@ -83,13 +194,32 @@ func (uc *orderUseCase) GetOrderByID(ctx context.Context, id string) (*dto.Order
 
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return nil, handlers.NotFoundError("Invalid order ID")
+		return nil, domain.ErrOrderNotFound.WithDetail("Invalid order ID")
 	}
 
 	order, err := uc.orderRepository.FindByID(ctx, objectID)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, handlers.NotFoundError("Order not found")
+			return nil, domain.ErrOrderNotFound
+		}
+		return nil, err
+	}
+
+	return dto.ToOrderResponse(order), nil
+}
+
+// GetOrderByNumber finds an order by its order number. Numbers whose check digit
+// does not match are reported as mistyped.
+func (uc *orderUseCase) GetOrderByNumber(ctx context.Context, orderNumber string) (*dto.OrderResponse, error) {
+
+	order, err := uc.orderRepository.FindByOrderNumber(ctx, orderNumber)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			// numbers issued before the current format are looked up all the same
+			if !uc.orderNumbers.Verify(orderNumber) {
+				return nil, domain.ErrOrderNotFound.WithDetail("Invalid order number: check digit does not match")
+			}
+			return nil, domain.ErrOrderNotFound
 		}
 		return nil, err
 	}
@@ -97,21 +227,42 @@ func (uc *orderUseCase) GetOrderByID(ctx context.Context, id string) (*dto.Order
 	return dto.ToOrderResponse(order), nil
 }
 
-func (uc *orderUseCase) UpdateOrderStatus(ctx context.Context, id string, req *dto.UpdateOrderStatusRequest) (*dto.OrderResponse, error) {
+func (uc *orderUseCase) UpdateOrderStatus(ctx context.Context, id string, req *dto.UpdateOrderStatusRequest, ifMatch *int64) (*dto.OrderResponse, error) {
 
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return nil, handlers.NotFoundError("Invalid order ID")
+		return nil, domain.ErrOrderNotFound.WithDetail("Invalid order ID")
 	}
 
-	if err := uc.orderRepository.UpdateStatus(ctx, objectID, req.Status); err != nil {
+	order, err := uc.orderRepository.FindByID(ctx, objectID)
+	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, handlers.NotFoundError("Order not found")
+			return nil, domain.ErrOrderNotFound
 		}
 		return nil, err
 	}
 
-	order, err := uc.orderRepository.FindByID(ctx, objectID)
+	if err := checkVersion(ifMatch, order.Version); err != nil {
+		return nil, err
+	}
+
+	if req.Status == domain.OrderStatusShipped && order.Status != domain.OrderStatusShipped {
+		if err := uc.checkFullyShipped(ctx, order); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.orderRepository.UpdateStatus(ctx, objectID, req.Status, order.Version); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrOrderNotFound
+		}
+		if errors.Is(err, domain.ErrVersionConflict) {
+			return nil, modifiedConcurrently()
+		}
+		return nil, err
+	}
+
+	order, err = uc.orderRepository.FindByID(ctx, objectID)
 	if err != nil {
 		return nil, err
 	}
@@ -125,10 +276,402 @@ func (uc *orderUseCase) UpdateOrderStatus(ctx context.Context, id string, req *d
 	return dto.ToOrderResponse(order), nil
 }
 
-func generateOrderNumber() string {
-	bytes := make([]byte, 4)
-	if _, err := rand.Read(bytes); err != nil {
-		return fmt.Sprintf("ORD-%d", primitive.NewObjectID().Timestamp().Unix())
+// checkFullyShipped rejects marking an order as shipped by hand before shipments
+// cover every ordered unit; recording the shipments moves the order by itself
+func (uc *orderUseCase) checkFullyShipped(ctx context.Context, order *domain.Order) error {
+	shipments, err := uc.shipmentRepository.FindByOrderID(ctx, order.ID.Hex())
+	if err != nil {
+		return fmt.Errorf("failed to fetch shipments: %w", err)
+	}
+
+	if !fullyShipped(order, shipments) {
+		return domain.ErrInvalidTransition.WithDetail("Order cannot be marked enviado before every item is shipped; record its shipments instead")
+	}
+	return nil
+}
+
+// ReleaseDeclinedOrders gives back the stock and the coupon of every order whose
+// payment was declined. Each line is cancelled once, so an order left half released
+// by a failure is completed by the next run; the coupon is given back by the run
+// marking the order released. Orders of every tenant are released together, each
+// one in the scope of its own tenant.
+func (uc *orderUseCase) ReleaseDeclinedOrders(ctx context.Context) (int, error) {
+	released := 0
+	for {
+		orders, err := uc.orderRepository.FindUnreleasedDeclined(ctx, declinedOrderBatch)
+		if err != nil {
+			return released, fmt.Errorf("failed to fetch declined orders: %w", err)
+		}
+
+		for i := range orders {
+			tenantCtx := domain.ContextWithTenant(ctx, orders[i].TenantID)
+			ok, err := uc.release(tenantCtx, &orders[i])
+			if err != nil {
+				return released, err
+			}
+			if ok {
+				released++
+			}
+		}
+
+		if len(orders) < declinedOrderBatch {
+			return released, nil
+		}
+	}
+}
+
+func (uc *orderUseCase) release(ctx context.Context, order *domain.Order) (bool, error) {
+	for _, item := range order.Items {
+		productID, err := primitive.ObjectIDFromHex(item.ProductID)
+		if err != nil {
+			continue
+		}
+		variantID, err := variantObjectID(item.VariantID)
+		if err != nil {
+			continue
+		}
+
+		_, err = uc.ledger.move(ctx, &domain.StockMovement{
+			ProductID: productID,
+			VariantID: variantID,
+			Type:      domain.StockMovementCancellation,
+			Quantity:  item.Quantity,
+			Reason:    "Payment declined",
+			Actor:     domain.StockActorSystem,
+			Reference: order.OrderNumber,
+		})
+		if mongo.IsDuplicateKeyError(err) || err == mongo.ErrNoDocuments {
+			// released by a previous run, or the product is gone
+			continue
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to release stock of order %s: %w", order.OrderNumber, err)
+		}
+	}
+
+	if err := uc.orderRepository.MarkReleased(ctx, order.ID, time.Now()); err != nil {
+		if err == mongo.ErrNoDocuments {
+			// released by another instance in the meantime
+			return false, nil
+		}
+		return false, err
+	}
+
+	if order.CouponCode != "" {
+		coupon, err := uc.couponRepository.FindByCode(ctx, order.CouponCode)
+		if err == mongo.ErrNoDocuments {
+			return true, nil
+		}
+		if err != nil {
+			return true, fmt.Errorf("failed to fetch coupon of order %s: %w", order.OrderNumber, err)
+		}
+		if err := uc.couponRepository.Release(ctx, coupon.ID, order.CustomerID); err != nil {
+			return true, fmt.Errorf("failed to release coupon of order %s: %w", order.OrderNumber, err)
+		}
+	}
+
+	return true, nil
+}
+
+// reserveStock takes the ordered units out of stock, releasing what was already
+// reserved when one of the items is no longer available
+func (uc *orderUseCase) reserveStock(ctx context.Context, order *domain.Order) error {
+	for i, item := range order.Items {
+		productID, err := primitive.ObjectIDFromHex(item.ProductID)
+		if err != nil {
+			return domain.ErrProductNotFound.Detailf("Invalid product ID: %s", item.ProductID)
+		}
+		variantID, err := variantObjectID(item.VariantID)
+		if err != nil {
+			return domain.ErrVariantNotFound.Detailf("Invalid variant ID: %s", item.VariantID)
+		}
+
+		_, err = uc.ledger.move(ctx, &domain.StockMovement{
+			ProductID: productID,
+			VariantID: variantID,
+			Type:      domain.StockMovementReservation,
+			Quantity:  -item.Quantity,
+			Reason:    "Order created",
+			Actor:     stockActor(order),
+			Reference: order.OrderNumber,
+		})
+		if err != nil {
+			uc.releaseStock(ctx, order, order.Items[:i])
+			if errors.Is(err, domain.ErrInsufficientStock) {
+				return domain.ErrInsufficientStock.Wrap(fmt.Sprintf("Insufficient stock for product %s", item.ProductName), err)
+			}
+			return fmt.Errorf("failed to reserve stock: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// releaseStock puts reserved items back in stock when the order could not be created
+func (uc *orderUseCase) releaseStock(ctx context.Context, order *domain.Order, items []domain.OrderItem) {
+	for _, item := range items {
+		productID, err := primitive.ObjectIDFromHex(item.ProductID)
+		if err != nil {
+			continue
+		}
+		variantID, err := variantObjectID(item.VariantID)
+		if err != nil {
+			continue
+		}
+
+		_, _ = uc.ledger.move(ctx, &domain.StockMovement{
+			ProductID: productID,
+			VariantID: variantID,
+			Type:      domain.StockMovementCancellation,
+			Quantity:  item.Quantity,
+			Reason:    "Order creation failed",
+			Actor:     domain.StockActorSystem,
+			Reference: order.OrderNumber,
+		})
+	}
+}
+
+// stockActor returns the customer placing the order, or the system for anonymous orders
+func stockActor(order *domain.Order) string {
+	if order.CustomerID != "" {
+		return order.CustomerID
+	}
+	return domain.StockActorSystem
+}
+
+// resolveShippingAddress validates the customer and returns a snapshot of the
+// requested address, or of the default one when addressID is empty
+func (uc *orderUseCase) resolveShippingAddress(ctx context.Context, customerID, addressID string) (*domain.Address, error) {
+	customerObjectID, err := primitive.ObjectIDFromHex(customerID)
+	if err != nil {
+		return nil, domain.ErrCustomerNotFound.Detailf("Invalid customer ID: %s", customerID)
+	}
+
+	customer, err := uc.customerRepository.FindByID(ctx, customerObjectID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrCustomerNotFound.Detailf("Customer not found: %s", customerID)
+		}
+		return nil, fmt.Errorf("failed to fetch customer: %w", err)
+	}
+
+	var addressObjectID primitive.ObjectID
+	if addressID != "" {
+		addressObjectID, err = primitive.ObjectIDFromHex(addressID)
+		if err != nil {
+			return nil, domain.ErrAddressNotFound.Detailf("Invalid shipping address ID: %s", addressID)
+		}
+	}
+
+	address, ok := customer.ShippingAddress(addressObjectID)
+	if !ok {
+		if addressID != "" {
+			return nil, domain.ErrAddressNotFound.Detailf("Shipping address not found: %s", addressID)
+		}
+		return nil, nil
+	}
+
+	snapshot := *address
+	return &snapshot, nil
+}
+
+// resolveItem finds the product and variant of an order item by variant_id, sku or
+// product_id, in that order. Products with variants can only be ordered by variant.
+func (uc *orderUseCase) resolveItem(ctx context.Context, itemReq dto.OrderItemRequest) (*domain.Product, *domain.ProductVariant, error) {
+	var (
+		product *domain.Product
+		variant *domain.ProductVariant
+		err     error
+	)
+
+	switch {
+	case itemReq.VariantID != "":
+		variantID, parseErr := primitive.ObjectIDFromHex(itemReq.VariantID)
+		if parseErr != nil {
+			return nil, nil, domain.ErrVariantNotFound.Detailf("Invalid variant ID: %s", itemReq.VariantID)
+		}
+		product, err = uc.productRepository.FindByVariantID(ctx, variantID)
+		if err == nil {
+			variant = product.Variant(variantID)
+		}
+	case itemReq.SKU != "":
+		product, err = uc.productRepository.FindBySKU(ctx, itemReq.SKU)
+		if err == nil {
+			variant = product.VariantBySKU(itemReq.SKU)
+		}
+	default:
+		productID, parseErr := primitive.ObjectIDFromHex(itemReq.ProductID)
+		if parseErr != nil {
+			return nil, nil, domain.ErrProductNotFound.Detailf("Invalid product ID: %s", itemReq.ProductID)
+		}
+		product, err = uc.productRepository.FindByID(ctx, productID)
+	}
+
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil, domain.ErrProductNotFound.Detailf("Product not found: %s", itemIdentifier(itemReq))
+		}
+		return nil, nil, fmt.Errorf("failed to fetch product: %w", err)
+	}
+
+	if itemReq.ProductID != "" && itemReq.ProductID != product.ID.Hex() {
+		return nil, nil, domain.ErrInvalidRequest.Detailf("Item %s does not belong to product %s", itemIdentifier(itemReq), itemReq.ProductID)
+	}
+	if variant == nil && product.HasVariants() {
+		return nil, nil, domain.ErrInvalidRequest.Detailf("Product %s has variants; inform sku or variant_id", product.Name)
+	}
+
+	return product, variant, nil
+}
+
+// itemIdentifier returns the identifier the client used for an order item
+func itemIdentifier(itemReq dto.OrderItemRequest) string {
+	switch {
+	case itemReq.VariantID != "":
+		return itemReq.VariantID
+	case itemReq.SKU != "":
+		return itemReq.SKU
+	}
+	return itemReq.ProductID
+}
+
+// withEffectivePrice returns the product with the base price effective at the given
+// time, covering scheduled changes the price scheduler has not applied yet. Changes
+// scheduled before the base currency of the product changed are converted to it.
+func (uc *orderUseCase) withEffectivePrice(ctx context.Context, product *domain.Product, at time.Time) (*domain.Product, error) {
+	change, err := uc.priceRepository.FindEffective(ctx, product.ID, at)
+	if err == mongo.ErrNoDocuments {
+		return product, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find effective price of product %s: %w", product.ID.Hex(), err)
+	}
+
+	price, err := priceInBaseCurrency(ctx, uc.exchangeRateProvider, change, product)
+	if err != nil {
+		return nil, err
+	}
+
+	if price == product.Price {
+		return product, nil
+	}
+
+	effective := *product
+	effective.Price = price
+	return &effective, nil
+}
+
+// priceIn returns the product or variant price in the order currency. Products prefer
+// an explicit price list entry; variants with their own price and products without
+// an entry convert the base price. Rates are fetched once per source currency and
+// collected in rates so they can be snapshotted on the order.
+func (uc *orderUseCase) priceIn(ctx context.Context, product *domain.Product, variant *domain.ProductVariant, currency string, rates map[string]*domain.ExchangeRate) (float64, error) {
+	basePrice := product.Price
+	if variant != nil && variant.Price != nil {
+		basePrice = *variant.Price
+	} else if price, ok := product.PriceIn(currency); ok {
+		return price, nil
+	}
+
+	if currency == product.BaseCurrency() {
+		return basePrice, nil
+	}
+
+	rate, err := uc.exchangeRate(ctx, product.BaseCurrency(), currency, rates)
+	if err != nil {
+		return 0, err
+	}
+
+	return rate.Convert(basePrice), nil
+}
+
+// exchangeRate returns the from->to rate, fetching it only once per order
+func (uc *orderUseCase) exchangeRate(ctx context.Context, from, to string, rates map[string]*domain.ExchangeRate) (*domain.ExchangeRate, error) {
+	if rate, ok := rates[from]; ok {
+		return rate, nil
+	}
+
+	rate, err := uc.exchangeRateProvider.GetRate(ctx, from, to)
+	if err != nil {
+		if errors.Is(err, domain.ErrExchangeRateNotFound) {
+			return nil, domain.ErrExchangeRateNotFound.Wrap(fmt.Sprintf("Unsupported currency conversion: %s to %s", from, to), err)
+		}
+		return nil, fmt.Errorf("failed to fetch exchange rate: %w", err)
+	}
+	rates[from] = rate
+
+	return rate, nil
+}
+
+// applyCoupon validates the coupon against the order and applies its discount.
+// Usage limits are only checked here for a fast failure; they are enforced atomically on Redeem.
+func (uc *orderUseCase) applyCoupon(ctx context.Context, order *domain.Order, code string, rates map[string]*domain.ExchangeRate) (*domain.Coupon, error) {
+	coupon, err := uc.couponRepository.FindByCode(ctx, code)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrCouponNotFound.Detailf("Coupon not found: %s", code)
+		}
+		return nil, fmt.Errorf("failed to fetch coupon: %w", err)
+	}
+
+	if !coupon.IsValidAt(time.Now()) {
+		return nil, domain.ErrCouponNotApplicable.Detailf("Coupon is not valid: %s", code)
+	}
+
+	if coupon.MaxUses > 0 && coupon.UsedCount >= coupon.MaxUses {
+		return nil, domain.ErrCouponUsageLimitReached.Detailf("Coupon usage limit reached: %s", code)
+	}
+
+	if coupon.MaxUsesPerCustomer > 0 {
+		if order.CustomerID == "" {
+			return nil, domain.ErrCouponNotApplicable.Detailf("Coupon %s requires a customer_id", code)
+		}
+		if coupon.CustomerUsage[order.CustomerID] >= coupon.MaxUsesPerCustomer {
+			return nil, domain.ErrCouponUsageLimitReached.Detailf("Coupon usage limit reached for customer: %s", code)
+		}
+	}
+
+	rate := 1.0
+	if coupon.Currency != "" && coupon.Currency != order.Currency && (coupon.Type == domain.CouponTypeFixed || coupon.MinOrderValue > 0) {
+		exchangeRate, err := uc.exchangeRate(ctx, coupon.Currency, order.Currency, rates)
+		if err != nil {
+			return nil, err
+		}
+		rate = exchangeRate.Rate
+	}
+
+	if order.Subtotal < domain.RoundMoney(coupon.MinOrderValue*rate) {
+		return nil, domain.ErrCouponNotApplicable.Detailf("Order does not reach the minimum value for coupon %s", code)
+	}
+
+	discount := coupon.Discount(order.Items, rate)
+	if discount == 0 {
+		return nil, domain.ErrCouponNotApplicable.Detailf("Coupon %s does not apply to any item of the order", code)
+	}
+
+	order.CouponCode = coupon.Code
+	order.ApplyDiscount(discount, coupon.AppliesTo)
+
+	return coupon, nil
+}
+
+func appliedRates(rates map[string]*domain.ExchangeRate) []domain.ExchangeRate {
+	applied := make([]domain.ExchangeRate, 0, len(rates))
+	for _, rate := range rates {
+		applied = append(applied, *rate)
+	}
+	sort.Slice(applied, func(i, j int) bool {
+		return applied[i].From < applied[j].From
+	})
+	return applied
+}
+
+// nextOrderNumber draws the number of an order placed at orderedAt from the
+// sequence of its day
+func (uc *orderUseCase) nextOrderNumber(ctx context.Context, orderedAt time.Time) (string, error) {
+	seq, err := uc.sequenceRepository.Next(ctx, uc.orderNumbers.Sequence(orderedAt))
+	if err != nil {
+		return "", fmt.Errorf("failed to draw order number: %w", err)
 	}
-	return fmt.Sprintf("ORD-%s", hex.EncodeToString(bytes))
+	return uc.orderNumbers.Format(orderedAt, seq), nil
 }
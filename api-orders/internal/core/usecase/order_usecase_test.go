@@ -0,0 +1,583 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/adapter/exchangerate"
+	"github.com/gvillela7/rank-my-app/internal/adapter/repository/memory"
+	"github.com/gvillela7/rank-my-app/internal/adapter/tax"
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/dto"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"github.com/gvillela7/rank-my-app/internal/core/usecase"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Mock Message Producer
+type mockMessageProducer struct {
+	statuses       []string
+	shipmentEvents []string
+	returnEvents   []string
+	stockAlerts    []string
+	lastStockAlert *domain.StockAlertEvent
+}
+
+func (m *mockMessageProducer) PublishOrderStatus(ctx context.Context, orderID, status string, timestamp float64) error {
+	m.statuses = append(m.statuses, status)
+	return nil
+}
+
+func (m *mockMessageProducer) PublishShipmentEvent(ctx context.Context, event *domain.ShipmentEvent) error {
+	m.shipmentEvents = append(m.shipmentEvents, event.Event)
+	return nil
+}
+
+func (m *mockMessageProducer) PublishReturnEvent(ctx context.Context, event *domain.ReturnEvent) error {
+	m.returnEvents = append(m.returnEvents, event.Event)
+	return nil
+}
+
+func (m *mockMessageProducer) PublishStockAlert(ctx context.Context, event *domain.StockAlertEvent) error {
+	m.stockAlerts = append(m.stockAlerts, event.Event)
+	m.lastStockAlert = event
+	return nil
+}
+
+// testCtx carries the tenant the memory repositories are scoped to
+var testCtx = domain.ContextWithTenant(context.Background(), "acme")
+
+func newTestProduct(price float64, quantity int) *domain.Product {
+	return &domain.Product{
+		Name:     "Mouse Gamer",
+		Quantity: quantity,
+		Price:    price,
+		Currency: "BRL",
+	}
+}
+
+// createTestProduct stores product in the tenant of testCtx
+func createTestProduct(t *testing.T, products ports.ProductRepository, product *domain.Product) *domain.Product {
+	t.Helper()
+
+	if err := products.Create(testCtx, product); err != nil {
+		t.Fatalf("Expected the product to be created, got: %v", err)
+	}
+	return product
+}
+
+// findTestProduct reads product back as stored
+func findTestProduct(t *testing.T, products ports.ProductRepository, id primitive.ObjectID) *domain.Product {
+	t.Helper()
+
+	product, err := products.FindByID(testCtx, id)
+	if err != nil {
+		t.Fatalf("Expected the product to be found, got: %v", err)
+	}
+	return product
+}
+
+// findTestOrder reads order back as stored
+func findTestOrder(t *testing.T, orders ports.OrderRepository, id primitive.ObjectID) *domain.Order {
+	t.Helper()
+
+	order, err := orders.FindByID(testCtx, id)
+	if err != nil {
+		t.Fatalf("Expected the order to be found, got: %v", err)
+	}
+	return order
+}
+
+var testOrderNumbers = domain.OrderNumberFormat{Prefix: "ORD", DateLayout: "20060102", Digits: 6, CheckDigit: true, Location: time.UTC}
+
+// orderFixture is an order use case over memory repositories
+type orderFixture struct {
+	uc        ports.OrderUseCase
+	orders    ports.OrderRepository
+	products  ports.ProductRepository
+	coupons   ports.CouponRepository
+	customers ports.CustomerRepository
+	shipments ports.ShipmentRepository
+	prices    ports.PriceChangeRepository
+}
+
+// newOrderFixture taxes the orders with the rules, none by default
+func newOrderFixture(taxRules ...tax.Rule) *orderFixture {
+	f := &orderFixture{
+		orders:    memory.NewOrderRepository(),
+		products:  memory.NewProductRepository(),
+		coupons:   memory.NewCouponRepository(),
+		customers: memory.NewCustomerRepository(),
+		shipments: memory.NewShipmentRepository(),
+		prices:    memory.NewPriceChangeRepository(),
+	}
+	rates := exchangerate.NewStaticProvider("BRL", map[string]float64{"USD": 0.2})
+	taxes := tax.NewRuleTableCalculator("SP", taxRules)
+
+	f.uc = usecase.NewOrderUseCase(f.orders, f.products, &mockMessageProducer{}, rates, f.coupons, taxes, f.customers, f.shipments, memory.NewStockMovementRepository(), f.prices, memory.NewSequenceRepository(), testOrderNumbers)
+	return f
+}
+
+// createCoupon stores coupon in the tenant of testCtx
+func (f *orderFixture) createCoupon(t *testing.T, coupon *domain.Coupon) *domain.Coupon {
+	t.Helper()
+
+	if err := f.coupons.Create(testCtx, coupon); err != nil {
+		t.Fatalf("Expected the coupon to be created, got: %v", err)
+	}
+	return coupon
+}
+
+func (f *orderFixture) usedCount(t *testing.T, id primitive.ObjectID) int {
+	t.Helper()
+
+	coupon, err := f.coupons.FindByID(testCtx, id)
+	if err != nil {
+		t.Fatalf("Expected the coupon to be found, got: %v", err)
+	}
+	return coupon.UsedCount
+}
+
+func TestOrderUseCase_CreateOrder_ConvertsCurrency(t *testing.T) {
+	f := newOrderFixture()
+	product := createTestProduct(t, f.products, newTestProduct(100, 10))
+
+	resp, err := f.uc.CreateOrder(testCtx, &dto.CreateOrderRequest{
+		Items:    []dto.OrderItemRequest{{ProductID: product.ID.Hex(), Quantity: 2}},
+		Currency: "USD",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if resp.Total != 40 {
+		t.Errorf("Expected total 40, got %f", resp.Total)
+	}
+
+	if len(resp.ExchangeRates) != 1 || resp.ExchangeRates[0].Rate != 0.2 {
+		t.Errorf("Expected applied BRL->USD rate snapshot, got %+v", resp.ExchangeRates)
+	}
+}
+
+func TestOrderUseCase_CreateOrder_AppliesPercentageCoupon(t *testing.T) {
+	f := newOrderFixture()
+	product := createTestProduct(t, f.products, newTestProduct(100, 10))
+	coupon := f.createCoupon(t, &domain.Coupon{
+		Code:     "DESCONTO10",
+		Type:     domain.CouponTypePercentage,
+		Value:    10,
+		Currency: "BRL",
+		Active:   true,
+	})
+
+	resp, err := f.uc.CreateOrder(testCtx, &dto.CreateOrderRequest{
+		Items:      []dto.OrderItemRequest{{ProductID: product.ID.Hex(), Quantity: 3}},
+		CouponCode: "desconto10",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if resp.Subtotal != 300 || resp.DiscountTotal != 30 || resp.Total != 270 {
+		t.Errorf("Expected 300/30/270, got %f/%f/%f", resp.Subtotal, resp.DiscountTotal, resp.Total)
+	}
+
+	if resp.CouponCode != "DESCONTO10" {
+		t.Errorf("Expected coupon code DESCONTO10, got %s", resp.CouponCode)
+	}
+
+	if used := f.usedCount(t, coupon.ID); used != 1 {
+		t.Errorf("Expected coupon to be redeemed once, got %d", used)
+	}
+}
+
+func TestOrderUseCase_CreateOrder_TaxesDiscountedLines(t *testing.T) {
+	f := newOrderFixture(tax.Rule{TaxClass: "*", Region: "*", Rate: 0.2})
+	discounted := createTestProduct(t, f.products, newTestProduct(100, 10))
+	other := createTestProduct(t, f.products, newTestProduct(50, 10))
+	f.createCoupon(t, &domain.Coupon{
+		Code:       "DESCONTO10",
+		Type:       domain.CouponTypePercentage,
+		Value:      10,
+		Currency:   "BRL",
+		ProductIDs: []string{discounted.ID.Hex()},
+		Active:     true,
+	})
+
+	resp, err := f.uc.CreateOrder(testCtx, &dto.CreateOrderRequest{
+		Items: []dto.OrderItemRequest{
+			{ProductID: discounted.ID.Hex(), Quantity: 2},
+			{ProductID: other.ID.Hex(), Quantity: 1},
+		},
+		CouponCode: "DESCONTO10",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	// the discounted line is taxed on 180, not 200
+	if resp.Items[0].Discount != 20 || resp.Items[0].TaxAmount != 36 || resp.Items[1].TaxAmount != 10 {
+		t.Errorf("Expected line discount 20 and taxes 36/10, got %+v", resp.Items)
+	}
+	if resp.Subtotal != 250 || resp.DiscountTotal != 20 || resp.TaxTotal != 46 || resp.Total != 276 {
+		t.Errorf("Expected 250/20/46/276, got %f/%f/%f/%f", resp.Subtotal, resp.DiscountTotal, resp.TaxTotal, resp.Total)
+	}
+}
+
+func TestOrderUseCase_CreateOrder_AppliesCategoryCouponToSubcategories(t *testing.T) {
+	f := newOrderFixture()
+	parent, child := primitive.NewObjectID(), primitive.NewObjectID()
+	mouse := newTestProduct(100, 10)
+	mouse.CategoryPath = []primitive.ObjectID{parent, child}
+	createTestProduct(t, f.products, mouse)
+	book := createTestProduct(t, f.products, newTestProduct(50, 10))
+	f.createCoupon(t, &domain.Coupon{
+		Code:        "PERIFERICOS10",
+		Type:        domain.CouponTypePercentage,
+		Value:       10,
+		Currency:    "BRL",
+		CategoryIDs: []string{parent.Hex()},
+		Active:      true,
+	})
+
+	resp, err := f.uc.CreateOrder(testCtx, &dto.CreateOrderRequest{
+		Items: []dto.OrderItemRequest{
+			{ProductID: mouse.ID.Hex(), Quantity: 2},
+			{ProductID: book.ID.Hex(), Quantity: 1},
+		},
+		CouponCode: "PERIFERICOS10",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	// only the mouse, in a subcategory of the coupon category, is discounted
+	if resp.Subtotal != 250 || resp.DiscountTotal != 20 || resp.Total != 230 {
+		t.Errorf("Expected 250/20/230, got %f/%f/%f", resp.Subtotal, resp.DiscountTotal, resp.Total)
+	}
+}
+
+func TestOrderUseCase_CreateOrder_RejectsExpiredCoupon(t *testing.T) {
+	f := newOrderFixture()
+	product := createTestProduct(t, f.products, newTestProduct(100, 10))
+	expired := time.Now().Add(-time.Hour)
+	coupon := f.createCoupon(t, &domain.Coupon{
+		Code:       "EXPIRADO",
+		Type:       domain.CouponTypeFixed,
+		Value:      50,
+		Currency:   "BRL",
+		ValidUntil: &expired,
+		Active:     true,
+	})
+
+	_, err := f.uc.CreateOrder(testCtx, &dto.CreateOrderRequest{
+		Items:      []dto.OrderItemRequest{{ProductID: product.ID.Hex(), Quantity: 1}},
+		CouponCode: "EXPIRADO",
+	})
+
+	if !errors.Is(err, domain.ErrCouponNotApplicable) {
+		t.Fatalf("Expected ErrCouponNotApplicable, got %v", err)
+	}
+
+	if findTestProduct(t, f.products, product.ID).Quantity != 10 || f.usedCount(t, coupon.ID) != 0 {
+		t.Error("Expected no stock reserved and no coupon redeemed")
+	}
+}
+
+func TestOrderUseCase_CreateOrder_CouponUsageLimitReached(t *testing.T) {
+	f := newOrderFixture()
+	product := createTestProduct(t, f.products, newTestProduct(100, 10))
+	f.createCoupon(t, &domain.Coupon{
+		Code:      "ESGOTADO",
+		Type:      domain.CouponTypeFixed,
+		Value:     20,
+		Currency:  "BRL",
+		MaxUses:   5,
+		UsedCount: 5,
+		Active:    true,
+	})
+
+	_, err := f.uc.CreateOrder(testCtx, &dto.CreateOrderRequest{
+		Items:      []dto.OrderItemRequest{{ProductID: product.ID.Hex(), Quantity: 1}},
+		CouponCode: "ESGOTADO",
+	})
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+
+	if quantity := findTestProduct(t, f.products, product.ID).Quantity; quantity != 10 {
+		t.Errorf("Expected the reserved stock to be released, got %d units", quantity)
+	}
+}
+
+func TestOrderUseCase_CreateOrder_SnapshotsShippingAddress(t *testing.T) {
+	f := newOrderFixture()
+	product := createTestProduct(t, f.products, newTestProduct(100, 10))
+	customer := &domain.Customer{
+		Name:  "Maria da Silva",
+		Email: "maria@example.com",
+		Addresses: []domain.Address{
+			{ID: primitive.NewObjectID(), Street: "Rua A", City: "Niterói", State: "RJ"},
+			{ID: primitive.NewObjectID(), Street: "Av. Paulista", City: "São Paulo", State: "SP", Default: true},
+		},
+	}
+	if err := f.customers.Create(testCtx, customer); err != nil {
+		t.Fatalf("Expected the customer to be created, got: %v", err)
+	}
+
+	resp, err := f.uc.CreateOrder(testCtx, &dto.CreateOrderRequest{
+		CustomerID: customer.ID.Hex(),
+		Items:      []dto.OrderItemRequest{{ProductID: product.ID.Hex(), Quantity: 1}},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if resp.ShippingAddress == nil || resp.ShippingAddress.Street != "Av. Paulista" {
+		t.Fatalf("Expected default address snapshot, got %+v", resp.ShippingAddress)
+	}
+
+	if resp.TaxRegion != "SP" {
+		t.Errorf("Expected tax region from shipping address, got %s", resp.TaxRegion)
+	}
+
+	customer.Addresses[1].Street = "Outra Rua"
+	if err := f.customers.Update(testCtx, customer); err != nil {
+		t.Fatalf("Expected the customer to be updated, got: %v", err)
+	}
+	id, _ := primitive.ObjectIDFromHex(resp.ID)
+	if findTestOrder(t, f.orders, id).ShippingAddress.Street != "Av. Paulista" {
+		t.Error("Expected shipping address to be a snapshot")
+	}
+}
+
+func TestOrderUseCase_CreateOrder_UnknownCustomer(t *testing.T) {
+	f := newOrderFixture()
+	product := createTestProduct(t, f.products, newTestProduct(100, 10))
+
+	_, err := f.uc.CreateOrder(testCtx, &dto.CreateOrderRequest{
+		CustomerID: primitive.NewObjectID().Hex(),
+		Items:      []dto.OrderItemRequest{{ProductID: product.ID.Hex(), Quantity: 1}},
+	})
+
+	if !errors.Is(err, domain.ErrCustomerNotFound) {
+		t.Fatalf("Expected ErrCustomerNotFound, got %v", err)
+	}
+
+	if quantity := findTestProduct(t, f.products, product.ID).Quantity; quantity != 10 {
+		t.Errorf("Expected no stock reserved, got %d units", quantity)
+	}
+}
+
+func TestOrderUseCase_CreateOrder_UsesEffectiveScheduledPrice(t *testing.T) {
+	f := newOrderFixture()
+	product := createTestProduct(t, f.products, newTestProduct(100, 10))
+	// effective a minute ago, not applied by the scheduler yet
+	if err := f.prices.Create(testCtx, &domain.PriceChange{
+		ProductID:   product.ID,
+		Price:       80,
+		Currency:    "BRL",
+		Status:      domain.PriceChangeScheduled,
+		EffectiveAt: time.Now().Add(-time.Minute),
+	}); err != nil {
+		t.Fatalf("Expected the price change to be created, got: %v", err)
+	}
+
+	resp, err := f.uc.CreateOrder(testCtx, &dto.CreateOrderRequest{
+		Items: []dto.OrderItemRequest{{ProductID: product.ID.Hex(), Quantity: 2}},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if resp.Items[0].Price != 80 || resp.Total != 160 {
+		t.Errorf("Expected effective price 80 and total 160, got %f/%f", resp.Items[0].Price, resp.Total)
+	}
+}
+
+func TestOrderUseCase_CreateOrder_ConvertsScheduledPriceOfAnotherCurrency(t *testing.T) {
+	f := newOrderFixture()
+	product := createTestProduct(t, f.products, newTestProduct(100, 10))
+	// scheduled while the product was priced in USD
+	if err := f.prices.Create(testCtx, &domain.PriceChange{
+		ProductID:   product.ID,
+		Price:       16,
+		Currency:    "USD",
+		Status:      domain.PriceChangeScheduled,
+		EffectiveAt: time.Now().Add(-time.Minute),
+	}); err != nil {
+		t.Fatalf("Expected the price change to be created, got: %v", err)
+	}
+
+	resp, err := f.uc.CreateOrder(testCtx, &dto.CreateOrderRequest{
+		Items: []dto.OrderItemRequest{{ProductID: product.ID.Hex(), Quantity: 1}},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if resp.Items[0].Price != 80 {
+		t.Errorf("Expected scheduled USD 16 converted to BRL 80, got %f", resp.Items[0].Price)
+	}
+}
+
+func TestOrderUseCase_UpdateOrderStatus_RejectsStaleVersion(t *testing.T) {
+	f := newOrderFixture()
+	order := &domain.Order{Status: domain.OrderStatusCreated}
+	if err := f.orders.Create(testCtx, order); err != nil {
+		t.Fatalf("Expected the order to be created, got: %v", err)
+	}
+
+	stale := order.Version - 1
+	_, err := f.uc.UpdateOrderStatus(testCtx, order.ID.Hex(), &dto.UpdateOrderStatusRequest{Status: "enviado"}, &stale)
+
+	if !errors.Is(err, domain.ErrVersionConflict) {
+		t.Fatalf("Expected ErrVersionConflict, got %v", err)
+	}
+	if stored := findTestOrder(t, f.orders, order.ID); stored.Status != domain.OrderStatusCreated || stored.Version != order.Version {
+		t.Errorf("Expected order untouched, got status %s version %d", stored.Status, stored.Version)
+	}
+}
+
+func TestOrderUseCase_UpdateOrderStatus_IncrementsVersion(t *testing.T) {
+	f := newOrderFixture()
+	order := &domain.Order{Status: domain.OrderStatusCreated}
+	if err := f.orders.Create(testCtx, order); err != nil {
+		t.Fatalf("Expected the order to be created, got: %v", err)
+	}
+
+	current := order.Version
+	resp, err := f.uc.UpdateOrderStatus(testCtx, order.ID.Hex(), &dto.UpdateOrderStatusRequest{Status: "em_processamento"}, &current)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if resp.Status != "em_processamento" || resp.Version != current+1 {
+		t.Errorf("Expected status em_processamento and version %d, got %s/%d", current+1, resp.Status, resp.Version)
+	}
+}
+
+func TestOrderUseCase_UpdateOrderStatus_RejectsShippedBeforeFulfillment(t *testing.T) {
+	f := newOrderFixture()
+	productID := primitive.NewObjectID().Hex()
+	order := newProcessingOrder(t, f.orders, productID, 2)
+	shipped := &dto.UpdateOrderStatusRequest{Status: "enviado"}
+
+	_ = f.shipments.Create(testCtx, &domain.Shipment{OrderID: order.ID.Hex(), Items: []domain.ShipmentItem{{ProductID: productID, Quantity: 1}}})
+	if _, err := f.uc.UpdateOrderStatus(testCtx, order.ID.Hex(), shipped, nil); !errors.Is(err, domain.ErrInvalidTransition) {
+		t.Fatalf("Expected ErrInvalidTransition with a unit left to ship, got %v", err)
+	}
+	if status := findTestOrder(t, f.orders, order.ID).Status; status != domain.OrderStatusProcessing {
+		t.Errorf("Expected status to remain %s, got %s", domain.OrderStatusProcessing, status)
+	}
+
+	_ = f.shipments.Create(testCtx, &domain.Shipment{OrderID: order.ID.Hex(), Items: []domain.ShipmentItem{{ProductID: productID, Quantity: 1}}})
+	if _, err := f.uc.UpdateOrderStatus(testCtx, order.ID.Hex(), shipped, nil); err != nil {
+		t.Fatalf("Expected no error once every unit is shipped, got: %v", err)
+	}
+}
+
+func TestOrderUseCase_CreateOrder_AttributesAPIKey(t *testing.T) {
+	f := newOrderFixture()
+	product := createTestProduct(t, f.products, newTestProduct(100, 10))
+
+	key := &domain.APIKey{ID: primitive.NewObjectID(), Scopes: []string{domain.ScopeOrdersWrite}}
+	ctx := domain.ContextWithPrincipal(testCtx, key.Principal())
+
+	resp, err := f.uc.CreateOrder(ctx, &dto.CreateOrderRequest{
+		Items: []dto.OrderItemRequest{{ProductID: product.ID.Hex(), Quantity: 1}},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	id, _ := primitive.ObjectIDFromHex(resp.ID)
+	if resp.APIKeyID != key.ID.Hex() || findTestOrder(t, f.orders, id).APIKeyID != key.ID.Hex() {
+		t.Errorf("Expected order attributed to API key %s, got %q", key.ID.Hex(), resp.APIKeyID)
+	}
+}
+
+func TestOrderUseCase_GetOrderByNumber(t *testing.T) {
+	f := newOrderFixture()
+	product := createTestProduct(t, f.products, newTestProduct(100, 10))
+
+	var created []*dto.OrderResponse
+	for i := 0; i < 2; i++ {
+		resp, err := f.uc.CreateOrder(testCtx, &dto.CreateOrderRequest{
+			Items: []dto.OrderItemRequest{{ProductID: product.ID.Hex(), Quantity: 1}},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		created = append(created, resp)
+	}
+
+	number := created[1].OrderNumber
+	if parts := strings.Split(number, "-"); len(parts) != 3 || !strings.HasPrefix(parts[2], "000002") {
+		t.Fatalf("Expected the second order of the day to be numbered 000002, got %s", number)
+	}
+
+	resp, err := f.uc.GetOrderByNumber(testCtx, number)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if resp.ID != created[1].ID {
+		t.Errorf("Expected order %s, got %s", created[1].ID, resp.ID)
+	}
+
+	mistyped := strings.Replace(number, "-000002", "-000003", 1)
+	_, err = f.uc.GetOrderByNumber(testCtx, mistyped)
+	if _, detail, _ := domain.Describe(err); !errors.Is(err, domain.ErrOrderNotFound) || !strings.Contains(detail, "check digit") {
+		t.Errorf("Expected ErrOrderNotFound reporting the check digit, got: %v", err)
+	}
+}
+
+func TestOrderUseCase_ReleaseDeclinedOrders_GivesBackStockAndCoupon(t *testing.T) {
+	f := newOrderFixture()
+	product := createTestProduct(t, f.products, newTestProduct(100, 10))
+	coupon := f.createCoupon(t, &domain.Coupon{
+		Code:     "DESCONTO10",
+		Type:     domain.CouponTypePercentage,
+		Value:    10,
+		Currency: "BRL",
+		Active:   true,
+	})
+
+	resp, err := f.uc.CreateOrder(testCtx, &dto.CreateOrderRequest{
+		Items:      []dto.OrderItemRequest{{ProductID: product.ID.Hex(), Quantity: 3}},
+		CouponCode: "DESCONTO10",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	id, _ := primitive.ObjectIDFromHex(resp.ID)
+	if err := f.orders.UpdateStatus(testCtx, id, domain.OrderStatusPaymentDeclined, resp.Version); err != nil {
+		t.Fatalf("Expected the payment to be declined, got: %v", err)
+	}
+
+	released, err := f.uc.ReleaseDeclinedOrders(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if released != 1 {
+		t.Errorf("Expected 1 order released, got %d", released)
+	}
+	if quantity := findTestProduct(t, f.products, product.ID).Quantity; quantity != 10 {
+		t.Errorf("Expected the reserved stock back to 10, got %d", quantity)
+	}
+	if used := f.usedCount(t, coupon.ID); used != 0 {
+		t.Errorf("Expected the coupon given back, got %d uses", used)
+	}
+
+	// a released order is not released again
+	released, err = f.uc.ReleaseDeclinedOrders(context.Background())
+	if err != nil || released != 0 {
+		t.Errorf("Expected nothing left to release, got %d (%v)", released, err)
+	}
+	if quantity := findTestProduct(t, f.products, product.ID).Quantity; quantity != 10 {
+		t.Errorf("Expected stock to stay at 10, got %d", quantity)
+	}
+}
@@ -0,0 +1,274 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/dto"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	// duePriceBatch is how many due price changes are loaded at a time
+	duePriceBatch = 100
+
+	// maxVersionRetries is how many times the scheduler sets a price when
+	// concurrent changes keep bumping the product version
+	maxVersionRetries = 3
+)
+
+type priceUseCase struct {
+	repository           ports.PriceChangeRepository
+	productRepository    ports.ProductRepository
+	exchangeRateProvider ports.ExchangeRateProvider
+}
+
+func NewPriceUseCase(repository ports.PriceChangeRepository, productRepository ports.ProductRepository, exchangeRateProvider ports.ExchangeRateProvider) ports.PriceUseCase {
+	return &priceUseCase{
+		repository:           repository,
+		productRepository:    productRepository,
+		exchangeRateProvider: exchangeRateProvider,
+	}
+}
+
+func (uc *priceUseCase) ChangePrice(ctx context.Context, productID string, req *dto.PriceChangeRequest, ifMatch *int64) (*dto.PriceChangeResponse, error) {
+	product, err := uc.findProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkVersion(ifMatch, product.Version); err != nil {
+		return nil, err
+	}
+
+	if req.EffectiveAt != nil && req.EffectiveAt.After(time.Now()) {
+		change := &domain.PriceChange{
+			ProductID:   product.ID,
+			Price:       req.Price,
+			Currency:    product.BaseCurrency(),
+			Status:      domain.PriceChangeScheduled,
+			Reason:      req.Reason,
+			Actor:       req.Actor,
+			EffectiveAt: *req.EffectiveAt,
+		}
+		if err := uc.repository.Create(ctx, change); err != nil {
+			return nil, err
+		}
+		return dto.ToPriceChangeResponse(change), nil
+	}
+
+	previousPrice := product.Price
+	if err := uc.productRepository.SetPrice(ctx, product.ID, req.Price, product.Version); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrProductNotFound
+		}
+		if errors.Is(err, domain.ErrVersionConflict) {
+			return nil, modifiedConcurrently()
+		}
+		return nil, err
+	}
+
+	product.Price = req.Price
+	product.Version++
+	change := domain.NewAppliedPriceChange(product, &previousPrice, req.Reason, req.Actor)
+	if err := uc.repository.Create(ctx, change); err != nil {
+		_ = uc.productRepository.SetPrice(ctx, product.ID, previousPrice, product.Version)
+		return nil, fmt.Errorf("failed to record price change: %w", err)
+	}
+
+	return dto.ToPriceChangeResponse(change), nil
+}
+
+func (uc *priceUseCase) ListPrices(ctx context.Context, productID string) ([]dto.PriceChangeResponse, error) {
+	product, err := uc.findProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := uc.repository.FindByProductID(ctx, product.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.PriceChangeResponse, len(changes))
+	for i := range changes {
+		responses[i] = *dto.ToPriceChangeResponse(&changes[i])
+	}
+
+	return responses, nil
+}
+
+func (uc *priceUseCase) CancelPriceChange(ctx context.Context, productID, changeID string) (*dto.PriceChangeResponse, error) {
+	objectID, err := primitive.ObjectIDFromHex(changeID)
+	if err != nil {
+		return nil, domain.ErrPriceChangeNotFound.WithDetail("Invalid price change ID")
+	}
+
+	change, err := uc.repository.FindByID(ctx, objectID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrPriceChangeNotFound
+		}
+		return nil, err
+	}
+
+	if change.ProductID.Hex() != productID {
+		return nil, domain.ErrPriceChangeNotFound
+	}
+
+	if err := uc.repository.Cancel(ctx, objectID); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrInvalidTransition.Detailf("Price change already %s", change.Status)
+		}
+		return nil, err
+	}
+
+	change.Status = domain.PriceChangeCancelled
+	return dto.ToPriceChangeResponse(change), nil
+}
+
+// ApplyDuePrices applies every scheduled change that became effective. Each product
+// ends with the latest effective price, so a late change never overrides a newer one.
+// Changes claimed by another instance in the meantime are skipped. Changes of every
+// tenant are due together; each one is applied in the scope of its own tenant.
+func (uc *priceUseCase) ApplyDuePrices(ctx context.Context) (int, error) {
+	applied := 0
+	for {
+		now := time.Now()
+		changes, err := uc.repository.FindDue(ctx, now, duePriceBatch)
+		if err != nil {
+			return applied, fmt.Errorf("failed to fetch due price changes: %w", err)
+		}
+
+		for i := range changes {
+			tenantCtx := domain.ContextWithTenant(ctx, changes[i].TenantID)
+			ok, err := uc.apply(tenantCtx, &changes[i], now)
+			if err != nil {
+				return applied, err
+			}
+			if ok {
+				applied++
+			}
+		}
+
+		if len(changes) < duePriceBatch {
+			return applied, nil
+		}
+	}
+}
+
+func (uc *priceUseCase) apply(ctx context.Context, change *domain.PriceChange, now time.Time) (bool, error) {
+	product, err := uc.productRepository.FindByID(ctx, change.ProductID)
+	if err == mongo.ErrNoDocuments {
+		// the product is gone, so is its schedule
+		if err := uc.repository.Cancel(ctx, change.ID); err != nil && err != mongo.ErrNoDocuments {
+			return false, err
+		}
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	// the change stays scheduled, and is retried, until the product has its price
+	previousPrice := product.Price
+	if err := uc.setEffectivePrice(ctx, product, now); err != nil {
+		return false, err
+	}
+
+	change.PreviousPrice = &previousPrice
+	change.AppliedAt = &now
+	if err := uc.repository.MarkApplied(ctx, change); err != nil {
+		if err == mongo.ErrNoDocuments {
+			// cancelled in the meantime, so the price it set may no longer be effective
+			return false, uc.resetEffectivePrice(ctx, change.ProductID, now)
+		}
+		return false, fmt.Errorf("failed to apply price change %s: %w", change.ID.Hex(), err)
+	}
+
+	return true, nil
+}
+
+// resetEffectivePrice reloads the product and sets its price effective at the given time
+func (uc *priceUseCase) resetEffectivePrice(ctx context.Context, productID primitive.ObjectID, at time.Time) error {
+	product, err := uc.productRepository.FindByID(ctx, productID)
+	if err == mongo.ErrNoDocuments {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to reload product %s: %w", productID.Hex(), err)
+	}
+	return uc.setEffectivePrice(ctx, product, at)
+}
+
+// setEffectivePrice sets the product price effective at the given time, reloading
+// the product when a concurrent change bumps its version in the meantime
+func (uc *priceUseCase) setEffectivePrice(ctx context.Context, product *domain.Product, at time.Time) error {
+	effective, err := uc.repository.FindEffective(ctx, product.ID, at)
+	if err != nil {
+		return fmt.Errorf("failed to find effective price of product %s: %w", product.ID.Hex(), err)
+	}
+
+	for attempt := 1; ; attempt++ {
+		price, err := priceInBaseCurrency(ctx, uc.exchangeRateProvider, effective, product)
+		if err != nil {
+			return err
+		}
+		if price == product.Price {
+			return nil
+		}
+
+		err = uc.productRepository.SetPrice(ctx, product.ID, price, product.Version)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, domain.ErrVersionConflict) || attempt == maxVersionRetries {
+			return fmt.Errorf("failed to set price of product %s: %w", product.ID.Hex(), err)
+		}
+
+		if product, err = uc.productRepository.FindByID(ctx, product.ID); err != nil {
+			return fmt.Errorf("failed to reload product %s: %w", effective.ProductID.Hex(), err)
+		}
+	}
+}
+
+// priceInBaseCurrency returns the price of the change in the current base currency of
+// the product, which differs from the currency of the change when the base currency
+// changed after the change was scheduled
+func priceInBaseCurrency(ctx context.Context, provider ports.ExchangeRateProvider, change *domain.PriceChange, product *domain.Product) (float64, error) {
+	if change.Currency == "" || change.Currency == product.BaseCurrency() {
+		return change.Price, nil
+	}
+
+	rate, err := provider.GetRate(ctx, change.Currency, product.BaseCurrency())
+	if err != nil {
+		if errors.Is(err, domain.ErrExchangeRateNotFound) {
+			return 0, domain.ErrExchangeRateNotFound.Wrap(fmt.Sprintf("Unsupported currency conversion: %s to %s", change.Currency, product.BaseCurrency()), err)
+		}
+		return 0, fmt.Errorf("failed to fetch exchange rate: %w", err)
+	}
+
+	return rate.Convert(change.Price), nil
+}
+
+func (uc *priceUseCase) findProduct(ctx context.Context, productID string) (*domain.Product, error) {
+	objectID, err := primitive.ObjectIDFromHex(productID)
+	if err != nil {
+		return nil, domain.ErrProductNotFound.WithDetail("Invalid product ID")
+	}
+
+	product, err := uc.productRepository.FindByID(ctx, objectID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrProductNotFound
+		}
+		return nil, err
+	}
+
+	return product, nil
+}
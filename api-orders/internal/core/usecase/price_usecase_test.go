@@ -0,0 +1,170 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/adapter/exchangerate"
+	"github.com/gvillela7/rank-my-app/internal/adapter/repository/memory"
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/dto"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"github.com/gvillela7/rank-my-app/internal/core/usecase"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// createPriceChanges stores changes in the tenant of ctx and returns their IDs
+func createPriceChanges(t *testing.T, ctx context.Context, prices ports.PriceChangeRepository, changes ...domain.PriceChange) []primitive.ObjectID {
+	t.Helper()
+
+	ids := make([]primitive.ObjectID, 0, len(changes))
+	for i := range changes {
+		if err := prices.Create(ctx, &changes[i]); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ids = append(ids, changes[i].ID)
+	}
+	return ids
+}
+
+func priceChangeStatus(t *testing.T, prices ports.PriceChangeRepository, id primitive.ObjectID) string {
+	t.Helper()
+
+	change, err := prices.FindByID(testCtx, id)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return change.Status
+}
+
+func TestPriceUseCase_ChangePrice_SchedulesFutureChange(t *testing.T) {
+	products := memory.NewProductRepository()
+	product := createTestProduct(t, products, newTestProduct(100, 10))
+	uc := usecase.NewPriceUseCase(memory.NewPriceChangeRepository(), products, exchangerate.NewStaticProvider("BRL", nil))
+
+	effectiveAt := time.Now().Add(24 * time.Hour)
+	resp, err := uc.ChangePrice(testCtx, product.ID.Hex(), &dto.PriceChangeRequest{
+		Price:       80,
+		EffectiveAt: &effectiveAt,
+		Reason:      "Promoção",
+		Actor:       "maria.comercial",
+	}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.Status != domain.PriceChangeScheduled {
+		t.Errorf("expected scheduled change, got %s", resp.Status)
+	}
+	if price := findTestProduct(t, products, product.ID).Price; price != 100 {
+		t.Errorf("expected product price untouched, got %v", price)
+	}
+}
+
+func TestPriceUseCase_ChangePrice_AppliesImmediateChange(t *testing.T) {
+	products := memory.NewProductRepository()
+	product := createTestProduct(t, products, newTestProduct(100, 10))
+	uc := usecase.NewPriceUseCase(memory.NewPriceChangeRepository(), products, exchangerate.NewStaticProvider("BRL", nil))
+
+	resp, err := uc.ChangePrice(testCtx, product.ID.Hex(), &dto.PriceChangeRequest{
+		Price:  120,
+		Reason: "Reajuste",
+		Actor:  "maria.comercial",
+	}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.Status != domain.PriceChangeApplied || resp.PreviousPrice == nil || *resp.PreviousPrice != 100 {
+		t.Errorf("expected applied change from 100, got %+v", resp)
+	}
+	if price := findTestProduct(t, products, product.ID).Price; price != 120 {
+		t.Errorf("expected product price 120, got %v", price)
+	}
+}
+
+func TestPriceUseCase_ApplyDuePrices_KeepsLatestEffectivePrice(t *testing.T) {
+	products := memory.NewProductRepository()
+	product := createTestProduct(t, products, newTestProduct(100, 10))
+	now := time.Now()
+	priceRepo := memory.NewPriceChangeRepository()
+	ids := createPriceChanges(t, testCtx, priceRepo,
+		domain.PriceChange{ProductID: product.ID, Price: 90, Status: domain.PriceChangeScheduled, EffectiveAt: now.Add(-2 * time.Hour)},
+		domain.PriceChange{ProductID: product.ID, Price: 100, Status: domain.PriceChangeApplied, EffectiveAt: now.Add(-time.Hour)},
+		domain.PriceChange{ProductID: product.ID, Price: 70, Status: domain.PriceChangeScheduled, EffectiveAt: now.Add(time.Hour)},
+	)
+	uc := usecase.NewPriceUseCase(priceRepo, products, exchangerate.NewStaticProvider("BRL", nil))
+
+	applied, err := uc.ApplyDuePrices(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if applied != 1 {
+		t.Errorf("expected 1 change applied, got %d", applied)
+	}
+	if first, last := priceChangeStatus(t, priceRepo, ids[0]), priceChangeStatus(t, priceRepo, ids[2]); first != domain.PriceChangeApplied || last != domain.PriceChangeScheduled {
+		t.Errorf("unexpected statuses: %s/%s", first, last)
+	}
+	// the late change is recorded but the newer price applied an hour ago stays
+	if price := findTestProduct(t, products, product.ID).Price; price != 100 {
+		t.Errorf("expected product price untouched, got %v", price)
+	}
+}
+
+func TestPriceUseCase_ApplyDuePrices_AppliesChangesInTheirTenant(t *testing.T) {
+	storeA := domain.ContextWithTenant(context.Background(), "store-a")
+	products := memory.NewProductRepository()
+	product := newTestProduct(100, 10)
+	if err := products.Create(storeA, product); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	priceRepo := memory.NewPriceChangeRepository()
+	createPriceChanges(t, storeA, priceRepo,
+		domain.PriceChange{ProductID: product.ID, Price: 90, Status: domain.PriceChangeScheduled, EffectiveAt: time.Now().Add(-time.Minute)},
+	)
+	uc := usecase.NewPriceUseCase(priceRepo, products, exchangerate.NewStaticProvider("BRL", nil))
+
+	applied, err := uc.ApplyDuePrices(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	stored, err := products.FindByID(storeA, product.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if applied != 1 || stored.Price != 90 {
+		t.Errorf("expected the change applied in tenant store-a, got %d applied and price %v", applied, stored.Price)
+	}
+}
+
+func TestPriceUseCase_ApplyDuePrices_KeepsChangeScheduledWhenPriceFails(t *testing.T) {
+	products := &unreliableProductRepository{ProductRepository: memory.NewProductRepository(), setPriceErr: errors.New("connection reset")}
+	product := createTestProduct(t, products, newTestProduct(100, 10))
+	priceRepo := memory.NewPriceChangeRepository()
+	ids := createPriceChanges(t, testCtx, priceRepo,
+		domain.PriceChange{ProductID: product.ID, Price: 90, Status: domain.PriceChangeScheduled, EffectiveAt: time.Now().Add(-time.Minute)},
+	)
+	uc := usecase.NewPriceUseCase(priceRepo, products, exchangerate.NewStaticProvider("BRL", nil))
+
+	if _, err := uc.ApplyDuePrices(context.Background()); err == nil {
+		t.Fatal("expected error when the product price cannot be set")
+	}
+	if status := priceChangeStatus(t, priceRepo, ids[0]); status != domain.PriceChangeScheduled {
+		t.Fatalf("expected the change to stay scheduled, got %s", status)
+	}
+
+	// the next run retries the change
+	products.setPriceErr = nil
+	applied, err := uc.ApplyDuePrices(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	price, status := findTestProduct(t, products, product.ID).Price, priceChangeStatus(t, priceRepo, ids[0])
+	if applied != 1 || price != 90 || status != domain.PriceChangeApplied {
+		t.Errorf("expected the change applied on retry, got %d applied, price %v and status %s", applied, price, status)
+	}
+}
@@ -0,0 +1,171 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/dto"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ImportProduct creates the product of an import row, or updates the product that
+// already has its SKU. Optional fields left empty in the row keep their current
+// value, and quantities are brought to the row quantities through import movements.
+func (uc *productUseCase) ImportProduct(ctx context.Context, req *dto.CreateProductRequest) (bool, error) {
+	sku := strings.TrimSpace(req.SKU)
+	if sku == "" {
+		return false, domain.ErrInvalidRequest.WithDetail("SKU is required to import a product")
+	}
+
+	product, err := uc.repository.FindBySKU(ctx, sku)
+	if err == mongo.ErrNoDocuments {
+		if _, err := uc.CreateProduct(ctx, req); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if product.SKU != sku {
+		return false, domain.ErrDuplicateSKU.Detailf("SKU %s belongs to a variant of product %s", sku, product.ID.Hex())
+	}
+
+	previousPrice := product.Price
+	targets, err := uc.applyImportRow(ctx, product, req)
+	if err != nil {
+		return false, err
+	}
+
+	if err := uc.repository.Update(ctx, product); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return false, domain.ErrProductNotFound
+		}
+		if errors.Is(err, domain.ErrVersionConflict) {
+			return false, domain.ErrConflict.WithDetail("Product was modified during the import; import the row again")
+		}
+		return false, err
+	}
+
+	if product.Price != previousPrice {
+		change := domain.NewAppliedPriceChange(product, &previousPrice, "Product import", domain.StockActorSystem)
+		if err := uc.priceRepository.Create(ctx, change); err != nil {
+			return false, fmt.Errorf("failed to record price history of product %s: %w", sku, err)
+		}
+	}
+
+	for _, target := range targets {
+		if target.delta == 0 {
+			continue
+		}
+		_, err := uc.ledger.move(ctx, &domain.StockMovement{
+			ProductID: product.ID,
+			VariantID: target.variantID,
+			Type:      domain.StockMovementImport,
+			Quantity:  target.delta,
+			Reason:    "Product import",
+			Actor:     domain.StockActorSystem,
+		})
+		if err != nil {
+			return false, fmt.Errorf("failed to import stock of product %s: %w", sku, err)
+		}
+	}
+
+	return false, nil
+}
+
+// importTarget is the stock change an import row makes to a product or variant
+type importTarget struct {
+	variantID *primitive.ObjectID
+	delta     int
+}
+
+// applyImportRow copies the row onto the product and returns the stock changes it
+// makes. Variants are matched by SKU and cannot be added or removed by an import.
+func (uc *productUseCase) applyImportRow(ctx context.Context, product *domain.Product, req *dto.CreateProductRequest) ([]importTarget, error) {
+	if product.HasVariants() != (len(req.Variants) > 0) {
+		if product.HasVariants() {
+			return nil, domain.ErrInvalidRequest.WithDetail("Product has variants; inform them to import its stock")
+		}
+		return nil, domain.ErrInvalidRequest.WithDetail("Variants cannot be added to an existing product")
+	}
+
+	product.Name = req.Name
+	product.Description = req.Description
+	product.Price = req.Price
+	if req.Currency != "" {
+		product.Currency = req.Currency
+	}
+	if req.TaxClass != "" {
+		product.TaxClass = req.TaxClass
+	}
+	if req.ReorderThreshold != 0 {
+		product.ReorderThreshold = req.ReorderThreshold
+	}
+	if len(req.Prices) > 0 {
+		product.Prices = make([]domain.ProductPrice, 0, len(req.Prices))
+		for _, price := range req.Prices {
+			product.Prices = append(product.Prices, domain.ProductPrice{
+				Currency: price.Currency,
+				Amount:   price.Amount,
+			})
+		}
+	}
+	if len(req.Tags) > 0 {
+		product.Tags = normalizeTags(req.Tags)
+	}
+	if len(req.Attributes) > 0 {
+		product.Attributes = req.Attributes
+	}
+	if req.CategoryID != "" {
+		category, err := uc.findCategory(ctx, req.CategoryID)
+		if err != nil {
+			return nil, err
+		}
+		product.CategoryID = &category.ID
+		product.CategoryPath = category.Lineage()
+	}
+
+	if !product.HasVariants() {
+		return []importTarget{{delta: req.Quantity - product.Quantity}}, nil
+	}
+
+	targets := make([]importTarget, 0, len(req.Variants))
+	seen := make(map[string]bool, len(req.Variants))
+	for _, variantReq := range req.Variants {
+		sku := strings.TrimSpace(variantReq.SKU)
+		if seen[sku] {
+			return nil, domain.ErrInvalidRequest.Detailf("Duplicated SKU: %s", sku)
+		}
+		seen[sku] = true
+
+		variant := product.VariantBySKU(sku)
+		if variant == nil {
+			return nil, domain.ErrInvalidRequest.Detailf("Variant %s not found in the product", sku)
+		}
+		if variantReq.Price != nil {
+			variant.Price = variantReq.Price
+		}
+		if len(variantReq.Attributes) > 0 {
+			variant.Attributes = variantReq.Attributes
+		}
+		variantID := variant.ID
+		targets = append(targets, importTarget{
+			variantID: &variantID,
+			delta:     variantReq.Quantity - variant.Quantity,
+		})
+	}
+	return targets, nil
+}
+
+// ExportProducts calls fn with every product in the row format of the import
+func (uc *productUseCase) ExportProducts(ctx context.Context, fn func(row *dto.CreateProductRequest) error) error {
+	return uc.repository.Each(ctx, func(product *domain.Product) error {
+		return fn(dto.ToProductImportRow(product))
+	})
+}
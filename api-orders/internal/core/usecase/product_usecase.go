@@ -2,41 +2,252 @@ package usecase
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/gvillela7/rank-my-app/internal/core/domain"
 	"github.com/gvillela7/rank-my-app/internal/core/dto"
 	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
+// defaultSearchLimit is the page size of a product search without limit
+const defaultSearchLimit = 20
+
 type productUseCase struct {
-	repository ports.ProductRepository
+	repository         ports.ProductRepository
+	categoryRepository ports.CategoryRepository
+	priceRepository    ports.PriceChangeRepository
+	ledger             *stockLedger
 }
 
-func NewProductUseCase(repository ports.ProductRepository) ports.ProductUseCase {
+func NewProductUseCase(repository ports.ProductRepository, movementRepository ports.StockMovementRepository, categoryRepository ports.CategoryRepository, priceRepository ports.PriceChangeRepository) ports.ProductUseCase {
 	return &productUseCase{
-		repository: repository,
+		repository:         repository,
+		categoryRepository: categoryRepository,
+		priceRepository:    priceRepository,
+		ledger:             newStockLedger(repository, movementRepository, nil),
 	}
 }
 
 func (uc *productUseCase) CreateProduct(ctx context.Context, req *dto.CreateProductRequest) (*dto.ProductResponse, error) {
+	currency := req.Currency
+	if currency == "" {
+		currency = domain.DefaultCurrency
+	}
+
+	prices := make([]domain.ProductPrice, 0, len(req.Prices))
+	for _, price := range req.Prices {
+		prices = append(prices, domain.ProductPrice{
+			Currency: price.Currency,
+			Amount:   price.Amount,
+		})
+	}
+
+	taxClass := req.TaxClass
+	if taxClass == "" {
+		taxClass = domain.DefaultTaxClass
+	}
+
 	product := &domain.Product{
-		Name:        req.Name,
-		Description: req.Description,
-		Quantity:    req.Quantity,
-		Price:       req.Price,
+		Name:             req.Name,
+		SKU:              strings.TrimSpace(req.SKU),
+		Description:      req.Description,
+		Quantity:         req.Quantity,
+		Price:            req.Price,
+		Currency:         currency,
+		Prices:           prices,
+		TaxClass:         taxClass,
+		ReorderThreshold: req.ReorderThreshold,
+		Tags:             normalizeTags(req.Tags),
+		Attributes:       req.Attributes,
+	}
+
+	if len(req.Variants) > 0 {
+		product.Quantity = 0
+		for _, variantReq := range req.Variants {
+			product.Variants = append(product.Variants, domain.ProductVariant{
+				ID:         primitive.NewObjectID(),
+				SKU:        strings.TrimSpace(variantReq.SKU),
+				Attributes: variantReq.Attributes,
+				Price:      variantReq.Price,
+				Quantity:   variantReq.Quantity,
+			})
+			product.Quantity += variantReq.Quantity
+		}
+	}
+
+	if err := uc.checkSKUs(ctx, product); err != nil {
+		return nil, err
+	}
+
+	if req.CategoryID != "" {
+		category, err := uc.findCategory(ctx, req.CategoryID)
+		if err != nil {
+			return nil, err
+		}
+		product.CategoryID = &category.ID
+		product.CategoryPath = category.Lineage()
 	}
 
 	if err := uc.repository.Create(ctx, product); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, domain.ErrDuplicateSKU
+		}
 		return nil, err
 	}
 
-	return dto.ToProductResponse(
-		product.ID,
-		product.Name,
-		product.Description,
-		product.Quantity,
-		product.Price,
-		product.CreatedAt,
-		product.UpdatedAt,
-	), nil
+	if err := uc.ledger.recordInitialStock(ctx, product, "Product created"); err != nil {
+		return nil, err
+	}
+
+	change := domain.NewAppliedPriceChange(product, nil, "Product created", domain.StockActorSystem)
+	if err := uc.priceRepository.Create(ctx, change); err != nil {
+		return nil, fmt.Errorf("failed to record price history: %w", err)
+	}
+
+	return dto.ToProductResponse(product), nil
+}
+
+func (uc *productUseCase) GetProductByID(ctx context.Context, id string) (*dto.ProductResponse, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, domain.ErrProductNotFound.WithDetail("Invalid product ID")
+	}
+
+	product, err := uc.repository.FindByID(ctx, objectID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrProductNotFound
+		}
+		return nil, err
+	}
+
+	return dto.ToProductResponse(product), nil
+}
+
+// checkSKUs rejects SKUs repeated in the product or already used by another product
+func (uc *productUseCase) checkSKUs(ctx context.Context, product *domain.Product) error {
+	seen := make(map[string]bool)
+	for _, sku := range product.SKUs() {
+		if seen[sku] {
+			return domain.ErrInvalidRequest.Detailf("Duplicated SKU: %s", sku)
+		}
+		seen[sku] = true
+
+		_, err := uc.repository.FindBySKU(ctx, sku)
+		if err == nil {
+			return domain.ErrDuplicateSKU.Detailf("SKU already registered: %s", sku)
+		}
+		if err != mongo.ErrNoDocuments {
+			return err
+		}
+	}
+	return nil
+}
+
+func (uc *productUseCase) SearchProducts(ctx context.Context, req *dto.ProductSearchRequest) (*dto.ProductSearchResponse, error) {
+	if req.MinPrice != nil && req.MaxPrice != nil && *req.MinPrice > *req.MaxPrice {
+		return nil, domain.ErrInvalidRequest.WithDetail("min_price must not be greater than max_price")
+	}
+
+	filter := domain.ProductFilter{
+		Text:     strings.TrimSpace(req.Query),
+		Tag:      strings.ToLower(strings.TrimSpace(req.Tag)),
+		MinPrice: req.MinPrice,
+		MaxPrice: req.MaxPrice,
+		InStock:  req.InStock,
+		Offset:   req.Offset,
+		Limit:    req.Limit,
+	}
+	if filter.Limit == 0 {
+		filter.Limit = defaultSearchLimit
+	}
+	if req.Category != "" {
+		categoryID, err := primitive.ObjectIDFromHex(req.Category)
+		if err != nil {
+			return nil, domain.ErrUnknownCategory.Wrap("Invalid category ID", err)
+		}
+		filter.CategoryID = &categoryID
+	}
+
+	result, err := uc.repository.Search(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := uc.categoryRepository.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]string, len(categories))
+	for _, category := range categories {
+		names[category.ID.Hex()] = category.Name
+	}
+
+	items := make([]dto.ProductResponse, len(result.Products))
+	for i := range result.Products {
+		items[i] = *dto.ToProductResponse(&result.Products[i])
+	}
+
+	categoryFacets := make([]dto.CategoryFacetResponse, len(result.CategoryFacets))
+	for i, facet := range result.CategoryFacets {
+		categoryFacets[i] = dto.CategoryFacetResponse{
+			ID:    facet.Value,
+			Name:  names[facet.Value],
+			Count: facet.Count,
+		}
+	}
+
+	tagFacets := make([]dto.FacetCountResponse, len(result.TagFacets))
+	for i, facet := range result.TagFacets {
+		tagFacets[i] = dto.FacetCountResponse{
+			Value: facet.Value,
+			Count: facet.Count,
+		}
+	}
+
+	return &dto.ProductSearchResponse{
+		Items:  items,
+		Total:  result.Total,
+		Offset: filter.Offset,
+		Limit:  filter.Limit,
+		Facets: dto.ProductFacetsResponse{
+			Categories: categoryFacets,
+			Tags:       tagFacets,
+		},
+	}, nil
+}
+
+func (uc *productUseCase) findCategory(ctx context.Context, id string) (*domain.Category, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, domain.ErrUnknownCategory.Wrap("Invalid category ID", err)
+	}
+
+	category, err := uc.categoryRepository.FindByID(ctx, objectID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrUnknownCategory.Wrap("Category not found", err)
+		}
+		return nil, err
+	}
+
+	return category, nil
+}
+
+// normalizeTags lowercases and trims the tags, dropping empty and repeated ones
+func normalizeTags(tags []string) []string {
+	seen := make(map[string]bool, len(tags))
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
 }
@@ -5,41 +5,64 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/gvillela7/rank-my-app/internal/adapter/repository/memory"
 	"github.com/gvillela7/rank-my-app/internal/core/domain"
 	"github.com/gvillela7/rank-my-app/internal/core/dto"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
 	"github.com/gvillela7/rank-my-app/internal/core/usecase"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
-// Mock Repository
-type mockProductRepository struct {
-	createFunc   func(ctx context.Context, product *domain.Product) error
-	findByIDFunc func(ctx context.Context, id primitive.ObjectID) (*domain.Product, error)
+// unreliableProductRepository fails the calls of the memory repository it wraps
+// with the errors set
+type unreliableProductRepository struct {
+	ports.ProductRepository
+	createErr   error
+	setPriceErr error
+	adjustErr   map[primitive.ObjectID]error
 }
 
-func (m *mockProductRepository) Create(ctx context.Context, product *domain.Product) error {
-	if m.createFunc != nil {
-		return m.createFunc(ctx, product)
+func (r *unreliableProductRepository) Create(ctx context.Context, product *domain.Product) error {
+	if r.createErr != nil {
+		return r.createErr
 	}
-	return nil
+	return r.ProductRepository.Create(ctx, product)
 }
 
-func (m *mockProductRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*domain.Product, error) {
-	if m.findByIDFunc != nil {
-		return m.findByIDFunc(ctx, id)
+func (r *unreliableProductRepository) SetPrice(ctx context.Context, id primitive.ObjectID, price float64, version int64) error {
+	if r.setPriceErr != nil {
+		return r.setPriceErr
 	}
-	return nil, nil
+	return r.ProductRepository.SetPrice(ctx, id, price, version)
 }
 
-func TestProductUseCase_CreateProduct_Success(t *testing.T) {
-	mockRepo := &mockProductRepository{
-		createFunc: func(ctx context.Context, product *domain.Product) error {
-			product.ID = primitive.NewObjectID()
-			return nil
-		},
+func (r *unreliableProductRepository) AdjustQuantity(ctx context.Context, id primitive.ObjectID, delta int) (*domain.Product, error) {
+	if err := r.adjustErr[id]; err != nil {
+		return nil, err
 	}
+	return r.ProductRepository.AdjustQuantity(ctx, id, delta)
+}
 
-	uc := usecase.NewProductUseCase(mockRepo)
+// productFixture is a product use case over memory repositories
+type productFixture struct {
+	uc         ports.ProductUseCase
+	products   ports.ProductRepository
+	movements  ports.StockMovementRepository
+	categories ports.CategoryRepository
+}
+
+func newProductFixture(products ports.ProductRepository) *productFixture {
+	f := &productFixture{
+		products:   products,
+		movements:  memory.NewStockMovementRepository(),
+		categories: memory.NewCategoryRepository(),
+	}
+	f.uc = usecase.NewProductUseCase(f.products, f.movements, f.categories, memory.NewPriceChangeRepository())
+	return f
+}
+
+func TestProductUseCase_CreateProduct_Success(t *testing.T) {
+	f := newProductFixture(memory.NewProductRepository())
 
 	req := &dto.CreateProductRequest{
 		Name:        "Test Product",
@@ -48,7 +71,7 @@ func TestProductUseCase_CreateProduct_Success(t *testing.T) {
 		Price:       99.99,
 	}
 
-	resp, err := uc.CreateProduct(context.Background(), req)
+	resp, err := f.uc.CreateProduct(testCtx, req)
 
 	if err != nil {
 		t.Fatalf("Expected no error, got: %v", err)
@@ -70,13 +93,7 @@ func TestProductUseCase_CreateProduct_Success(t *testing.T) {
 func TestProductUseCase_CreateProduct_RepositoryError(t *testing.T) {
 	expectedErr := errors.New("database connection failed")
 
-	mockRepo := &mockProductRepository{
-		createFunc: func(ctx context.Context, product *domain.Product) error {
-			return expectedErr
-		},
-	}
-
-	uc := usecase.NewProductUseCase(mockRepo)
+	f := newProductFixture(&unreliableProductRepository{ProductRepository: memory.NewProductRepository(), createErr: expectedErr})
 
 	req := &dto.CreateProductRequest{
 		Name:        "Test Product",
@@ -85,7 +102,7 @@ func TestProductUseCase_CreateProduct_RepositoryError(t *testing.T) {
 		Price:       99.99,
 	}
 
-	resp, err := uc.CreateProduct(context.Background(), req)
+	resp, err := f.uc.CreateProduct(testCtx, req)
 
 	if err == nil {
 		t.Fatal("Expected error, got nil")
@@ -99,3 +116,201 @@ func TestProductUseCase_CreateProduct_RepositoryError(t *testing.T) {
 		t.Error("Expected nil response on error")
 	}
 }
+
+func TestProductUseCase_CreateProduct_ClassifiesInCategoryTree(t *testing.T) {
+	f := newProductFixture(memory.NewProductRepository())
+	categories := usecase.NewCategoryUseCase(f.categories)
+
+	parent, err := categories.CreateCategory(testCtx, &dto.CategoryRequest{Name: "Informática"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	child, err := categories.CreateCategory(testCtx, &dto.CategoryRequest{Name: "Periféricos", ParentID: parent.ID})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	resp, err := f.uc.CreateProduct(testCtx, &dto.CreateProductRequest{
+		Name:        "Mouse Gamer",
+		Description: "Mouse Gamer RGB",
+		Quantity:    1,
+		Price:       199.90,
+		CategoryID:  child.ID,
+		Tags:        []string{" Gamer", "RGB", "gamer"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	id, _ := primitive.ObjectIDFromHex(resp.ID)
+	created := findTestProduct(t, f.products, id)
+	if len(created.CategoryPath) != 2 || created.CategoryPath[0].Hex() != parent.ID || created.CategoryPath[1].Hex() != child.ID {
+		t.Errorf("expected category path [%s %s], got %v", parent.ID, child.ID, created.CategoryPath)
+	}
+	if len(resp.Tags) != 2 || resp.Tags[0] != "gamer" || resp.Tags[1] != "rgb" {
+		t.Errorf("expected normalized tags [gamer rgb], got %v", resp.Tags)
+	}
+}
+
+func TestProductUseCase_CreateProduct_UnknownCategory(t *testing.T) {
+	f := newProductFixture(memory.NewProductRepository())
+
+	_, err := f.uc.CreateProduct(testCtx, &dto.CreateProductRequest{
+		Name:        "Mouse Gamer",
+		Description: "Mouse Gamer RGB",
+		Quantity:    1,
+		Price:       199.90,
+		CategoryID:  primitive.NewObjectID().Hex(),
+	})
+	if err == nil {
+		t.Fatal("expected error for unknown category")
+	}
+}
+
+func TestProductUseCase_SearchProducts_BuildsFilter(t *testing.T) {
+	f := newProductFixture(memory.NewProductRepository())
+	gamer := newTestProduct(199.90, 3)
+	gamer.Tags = []string{"gamer"}
+	createTestProduct(t, f.products, gamer)
+	office := newTestProduct(59.90, 3)
+	office.Name = "Mouse Office"
+	office.Tags = []string{"escritorio"}
+	createTestProduct(t, f.products, office)
+	soldOut := newTestProduct(249.90, 0)
+	soldOut.Name = "Mouse Gamer Pro"
+	soldOut.Tags = []string{"gamer"}
+	createTestProduct(t, f.products, soldOut)
+
+	minPrice, maxPrice := 100.0, 50.0
+	_, err := f.uc.SearchProducts(testCtx, &dto.ProductSearchRequest{MinPrice: &minPrice, MaxPrice: &maxPrice})
+	if err == nil {
+		t.Fatal("expected error when min_price is greater than max_price")
+	}
+
+	resp, err := f.uc.SearchProducts(testCtx, &dto.ProductSearchRequest{Query: " mouse ", Tag: "Gamer", InStock: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.Total != 1 || resp.Items[0].ID != gamer.ID.Hex() {
+		t.Errorf("expected only the gamer mouse in stock, got %+v", resp.Items)
+	}
+	if resp.Limit != 20 {
+		t.Errorf("expected default limit 20, got %d", resp.Limit)
+	}
+}
+
+func TestProductUseCase_CreateProduct_WithVariants(t *testing.T) {
+	f := newProductFixture(memory.NewProductRepository())
+
+	override := 219.90
+	resp, err := f.uc.CreateProduct(testCtx, &dto.CreateProductRequest{
+		Name:        "Mouse Gamer",
+		Description: "Mouse Gamer RGB",
+		Price:       199.90,
+		Variants: []dto.ProductVariantRequest{
+			{SKU: "MOUSE-PRETO", Attributes: map[string]string{"cor": "preto"}, Quantity: 3},
+			{SKU: "MOUSE-BRANCO", Attributes: map[string]string{"cor": "branco"}, Price: &override, Quantity: 2},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.Quantity != 5 {
+		t.Errorf("expected product quantity 5 (sum of variants), got %d", resp.Quantity)
+	}
+	if resp.Variants[0].Price != 199.90 || resp.Variants[1].Price != override {
+		t.Errorf("unexpected variant prices: %+v", resp.Variants)
+	}
+
+	id, _ := primitive.ObjectIDFromHex(resp.ID)
+	movements, err := f.movements.FindByProductID(testCtx, id)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(movements) != 2 || movements[0].VariantID == nil {
+		t.Errorf("expected one initial movement per variant, got %+v", movements)
+	}
+}
+
+func TestProductUseCase_CreateProduct_DuplicatedSKU(t *testing.T) {
+	f := newProductFixture(memory.NewProductRepository())
+	existing := newTestProduct(100, 1)
+	existing.SKU = "MOUSE-PRETO"
+	createTestProduct(t, f.products, existing)
+
+	_, err := f.uc.CreateProduct(testCtx, &dto.CreateProductRequest{
+		Name:        "Mouse Gamer",
+		Description: "Mouse Gamer RGB",
+		Price:       199.90,
+		Variants:    []dto.ProductVariantRequest{{SKU: "MOUSE-PRETO", Quantity: 1}},
+	})
+
+	if !errors.Is(err, domain.ErrDuplicateSKU) {
+		t.Fatalf("expected ErrDuplicateSKU, got %v", err)
+	}
+}
+
+func TestProductUseCase_ImportProduct_CreatesUnknownSKU(t *testing.T) {
+	f := newProductFixture(memory.NewProductRepository())
+
+	created, err := f.uc.ImportProduct(testCtx, &dto.CreateProductRequest{
+		Name:        "Mouse Gamer",
+		SKU:         "MOUSE-RGB",
+		Description: "Mouse Gamer RGB",
+		Quantity:    10,
+		Price:       199.90,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !created {
+		t.Error("expected product to be created")
+	}
+	if product, err := f.products.FindBySKU(testCtx, "MOUSE-RGB"); err != nil || product.Quantity != 10 {
+		t.Errorf("expected the product stored with 10 units, got %+v (%v)", product, err)
+	}
+}
+
+func TestProductUseCase_ImportProduct_UpdatesExistingSKU(t *testing.T) {
+	f := newProductFixture(memory.NewProductRepository())
+	existing := newTestProduct(199.90, 10)
+	existing.SKU = "MOUSE-RGB"
+	existing.Tags = []string{"gamer"}
+	createTestProduct(t, f.products, existing)
+
+	created, err := f.uc.ImportProduct(testCtx, &dto.CreateProductRequest{
+		Name:        "Mouse Gamer Pro",
+		SKU:         "MOUSE-RGB",
+		Description: "Mouse Gamer RGB",
+		Quantity:    4,
+		Price:       179.90,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if created {
+		t.Error("expected existing product to be updated")
+	}
+
+	updated := findTestProduct(t, f.products, existing.ID)
+	if updated.Name != "Mouse Gamer Pro" || updated.Price != 179.90 {
+		t.Errorf("expected catalog fields replaced, got %+v", updated)
+	}
+	if len(updated.Tags) != 1 {
+		t.Errorf("expected tags kept when the row has none, got %v", updated.Tags)
+	}
+
+	movements, err := f.movements.FindByProductID(testCtx, existing.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(movements) != 1 {
+		t.Fatalf("expected one import movement, got %d", len(movements))
+	}
+	movement := movements[0]
+	if movement.Type != domain.StockMovementImport || movement.Quantity != -6 || movement.BalanceAfter != 4 {
+		t.Errorf("unexpected import movement: %+v", movement)
+	}
+}
@@ -0,0 +1,277 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/dto"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type returnUseCase struct {
+	repository      ports.ReturnRepository
+	orderRepository ports.OrderRepository
+	ledger          *stockLedger
+	messageProducer ports.MessageProducer
+}
+
+func NewReturnUseCase(repository ports.ReturnRepository, orderRepository ports.OrderRepository, productRepository ports.ProductRepository, movementRepository ports.StockMovementRepository, messageProducer ports.MessageProducer) ports.ReturnUseCase {
+	return &returnUseCase{
+		repository:      repository,
+		orderRepository: orderRepository,
+		ledger:          newStockLedger(productRepository, movementRepository, messageProducer),
+		messageProducer: messageProducer,
+	}
+}
+
+func (uc *returnUseCase) RequestReturn(ctx context.Context, orderID string, req *dto.CreateReturnRequest) (*dto.ReturnResponse, error) {
+	order, err := uc.findOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if order.Status != domain.OrderStatusDelivered {
+		return nil, domain.ErrInvalidTransition.Detailf("Order in status %s cannot be returned", order.Status)
+	}
+
+	returns, err := uc.repository.FindByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch returns: %w", err)
+	}
+
+	returnable := order.OrderedQuantities()
+	for key, quantity := range domain.ReturnedQuantities(returns) {
+		returnable[key] -= quantity
+	}
+
+	ret := &domain.ReturnRequest{
+		OrderID:    orderID,
+		CustomerID: order.CustomerID,
+		Items:      make([]domain.ReturnItem, len(req.Items)),
+		Status:     domain.ReturnStatusRequested,
+		Currency:   order.Currency,
+	}
+
+	refundTotal := 0.0
+	for i, itemReq := range req.Items {
+		key := domain.ItemKey(itemReq.ProductID, itemReq.VariantID)
+		quantity, ok := returnable[key]
+		if !ok {
+			return nil, domain.ErrInvalidRequest.Detailf("Product %s is not part of the order", key)
+		}
+		if itemReq.Quantity > quantity {
+			return nil, domain.ErrInvalidRequest.Detailf("Quantity for product %s exceeds the %d units left to return", key, quantity)
+		}
+		returnable[key] -= itemReq.Quantity
+
+		unitRefund, _ := order.UnitRefund(key)
+		refundAmount := domain.RoundMoney(unitRefund * float64(itemReq.Quantity))
+		refundTotal += refundAmount
+
+		ret.Items[i] = domain.ReturnItem{
+			ProductID:    itemReq.ProductID,
+			VariantID:    itemReq.VariantID,
+			Quantity:     itemReq.Quantity,
+			Reason:       itemReq.Reason,
+			UnitRefund:   unitRefund,
+			RefundAmount: refundAmount,
+		}
+	}
+	ret.RefundTotal = domain.RoundMoney(refundTotal)
+
+	if err := uc.repository.Create(ctx, ret); err != nil {
+		return nil, err
+	}
+
+	_ = uc.messageProducer.PublishReturnEvent(ctx, domain.NewReturnEvent(domain.ReturnEventRequested, ret))
+
+	return dto.ToReturnResponse(ret), nil
+}
+
+func (uc *returnUseCase) ListReturns(ctx context.Context, orderID string) ([]dto.ReturnResponse, error) {
+	if _, err := uc.findOrder(ctx, orderID); err != nil {
+		return nil, err
+	}
+
+	returns, err := uc.repository.FindByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.ReturnResponse, len(returns))
+	for i := range returns {
+		responses[i] = *dto.ToReturnResponse(&returns[i])
+	}
+
+	return responses, nil
+}
+
+// ApproveReturn puts the returned units back in stock and then accepts the return,
+// so a failed restock leaves it pending and the approval can be repeated. Each line is
+// restocked by a single movement referencing the return, which a repeated or
+// concurrent approval skips. The refund itself is issued by manager-status when it
+// receives the approval event.
+func (uc *returnUseCase) ApproveReturn(ctx context.Context, orderID, returnID string) (*dto.ReturnResponse, error) {
+	ret, err := uc.pending(ctx, orderID, returnID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.restock(ctx, ret); err != nil {
+		return nil, err
+	}
+
+	if err := uc.resolve(ctx, ret, domain.ReturnStatusApproved, ""); err != nil {
+		return nil, err
+	}
+
+	_ = uc.messageProducer.PublishReturnEvent(ctx, domain.NewReturnEvent(domain.ReturnEventApproved, ret))
+
+	return dto.ToReturnResponse(ret), nil
+}
+
+func (uc *returnUseCase) RejectReturn(ctx context.Context, orderID, returnID string, req *dto.RejectReturnRequest) (*dto.ReturnResponse, error) {
+	ret, err := uc.pending(ctx, orderID, returnID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.resolve(ctx, ret, domain.ReturnStatusRejected, req.Reason); err != nil {
+		return nil, err
+	}
+
+	_ = uc.messageProducer.PublishReturnEvent(ctx, domain.NewReturnEvent(domain.ReturnEventRejected, ret))
+
+	return dto.ToReturnResponse(ret), nil
+}
+
+// restock appends a return movement for every line of the return not restocked yet.
+// Lines of the same product or variant are restocked together.
+func (uc *returnUseCase) restock(ctx context.Context, ret *domain.ReturnRequest) error {
+	restocked, err := uc.ledger.movementRepository.FindByReference(ctx, domain.StockMovementReturn, ret.ID.Hex())
+	if err != nil {
+		return fmt.Errorf("failed to fetch restock movements: %w", err)
+	}
+
+	done := make(map[string]bool, len(restocked))
+	for _, movement := range restocked {
+		done[movementKey(&movement)] = true
+	}
+
+	lines := make([]domain.ReturnItem, 0, len(ret.Items))
+	quantities := make(map[string]int, len(ret.Items))
+	for _, item := range ret.Items {
+		key := domain.ItemKey(item.ProductID, item.VariantID)
+		if _, ok := quantities[key]; !ok {
+			lines = append(lines, item)
+		}
+		quantities[key] += item.Quantity
+	}
+
+	for _, item := range lines {
+		key := domain.ItemKey(item.ProductID, item.VariantID)
+		if done[key] {
+			continue
+		}
+
+		productID, err := primitive.ObjectIDFromHex(item.ProductID)
+		if err != nil {
+			return fmt.Errorf("invalid product ID %s: %w", item.ProductID, err)
+		}
+		variantID, err := variantObjectID(item.VariantID)
+		if err != nil {
+			return err
+		}
+		_, err = uc.ledger.move(ctx, &domain.StockMovement{
+			ProductID: productID,
+			VariantID: variantID,
+			Type:      domain.StockMovementReturn,
+			Quantity:  quantities[key],
+			Reason:    item.Reason,
+			Actor:     domain.StockActorSystem,
+			Reference: ret.ID.Hex(),
+		})
+		if mongo.IsDuplicateKeyError(err) {
+			// restocked by a concurrent approval
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to restock product %s: %w", item.ProductID, err)
+		}
+	}
+
+	return nil
+}
+
+// movementKey returns the order line a stock movement belongs to
+func movementKey(movement *domain.StockMovement) string {
+	variantID := ""
+	if movement.VariantID != nil {
+		variantID = movement.VariantID.Hex()
+	}
+	return domain.ItemKey(movement.ProductID.Hex(), variantID)
+}
+
+// pending returns a return of the order still waiting for a decision
+func (uc *returnUseCase) pending(ctx context.Context, orderID, returnID string) (*domain.ReturnRequest, error) {
+	objectID, err := primitive.ObjectIDFromHex(returnID)
+	if err != nil {
+		return nil, domain.ErrReturnNotFound.WithDetail("Invalid return ID")
+	}
+
+	ret, err := uc.repository.FindByID(ctx, objectID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrReturnNotFound
+		}
+		return nil, err
+	}
+
+	if ret.OrderID != orderID {
+		return nil, domain.ErrReturnNotFound
+	}
+
+	if ret.Status != domain.ReturnStatusRequested {
+		return nil, domain.ErrInvalidTransition.Detailf("Return already %s", ret.Status)
+	}
+
+	return ret, nil
+}
+
+// resolve moves a pending return to status
+func (uc *returnUseCase) resolve(ctx context.Context, ret *domain.ReturnRequest, status, reason string) error {
+	resolvedAt := time.Now()
+	ret.Status = status
+	ret.RejectionReason = reason
+	ret.ResolvedAt = &resolvedAt
+
+	if err := uc.repository.Resolve(ctx, ret); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return domain.ErrInvalidTransition.WithDetail("Return already resolved")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (uc *returnUseCase) findOrder(ctx context.Context, orderID string) (*domain.Order, error) {
+	objectID, err := primitive.ObjectIDFromHex(orderID)
+	if err != nil {
+		return nil, domain.ErrOrderNotFound.WithDetail("Invalid order ID")
+	}
+
+	order, err := uc.orderRepository.FindByID(ctx, objectID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrOrderNotFound
+		}
+		return nil, err
+	}
+
+	return order, nil
+}
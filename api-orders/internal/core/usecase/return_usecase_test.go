@@ -0,0 +1,147 @@
+package usecase_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gvillela7/rank-my-app/internal/adapter/repository/memory"
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/dto"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"github.com/gvillela7/rank-my-app/internal/core/usecase"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func newDeliveredOrder(t *testing.T, orderRepo ports.OrderRepository, productID primitive.ObjectID) *domain.Order {
+	order := &domain.Order{
+		Items: []domain.OrderItem{{
+			ProductID: productID.Hex(),
+			Price:     100,
+			Quantity:  2,
+			TaxAmount: 36,
+		}},
+		Currency: "BRL",
+		Status:   domain.OrderStatusDelivered,
+	}
+	order.CalculateTotal()
+	order.ApplyDiscount(20, nil)
+	if err := orderRepo.Create(testCtx, order); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return order
+}
+
+func returnStatus(t *testing.T, returns ports.ReturnRepository, id string) string {
+	t.Helper()
+
+	returnID, _ := primitive.ObjectIDFromHex(id)
+	ret, err := returns.FindByID(testCtx, returnID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return ret.Status
+}
+
+func TestReturnUseCase_ApproveRefundsPaidPriceAndRestocks(t *testing.T) {
+	orderRepo := memory.NewOrderRepository()
+	productRepo := memory.NewProductRepository()
+	product := createTestProduct(t, productRepo, newTestProduct(100, 0))
+	producer := &mockMessageProducer{}
+	order := newDeliveredOrder(t, orderRepo, product.ID)
+	uc := usecase.NewReturnUseCase(memory.NewReturnRepository(), orderRepo, productRepo, memory.NewStockMovementRepository(), producer)
+
+	ret, err := uc.RequestReturn(testCtx, order.ID.Hex(), &dto.CreateReturnRequest{
+		Items: []dto.ReturnItemRequest{{ProductID: product.ID.Hex(), Quantity: 1, Reason: "Produto com defeito"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// 100 price + 18 exclusive tax per unit - 10 prorated discount
+	if ret.RefundTotal != 108 {
+		t.Errorf("expected refund total 108, got %.2f", ret.RefundTotal)
+	}
+
+	if _, err := uc.ApproveReturn(testCtx, order.ID.Hex(), ret.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if quantity := findTestProduct(t, productRepo, product.ID).Quantity; quantity != 1 {
+		t.Errorf("expected 1 unit restocked, got %d", quantity)
+	}
+
+	if _, err := uc.ApproveReturn(testCtx, order.ID.Hex(), ret.ID); err == nil {
+		t.Error("expected error approving an already approved return, got nil")
+	}
+	if len(producer.returnEvents) != 2 {
+		t.Errorf("expected 2 return events, got %v", producer.returnEvents)
+	}
+}
+
+func TestReturnUseCase_RequestReturn_RejectsQuantityAlreadyReturned(t *testing.T) {
+	productID := primitive.NewObjectID()
+	orderRepo := memory.NewOrderRepository()
+	order := newDeliveredOrder(t, orderRepo, productID)
+	uc := usecase.NewReturnUseCase(memory.NewReturnRepository(), orderRepo, memory.NewProductRepository(), memory.NewStockMovementRepository(), &mockMessageProducer{})
+
+	req := &dto.CreateReturnRequest{
+		Items: []dto.ReturnItemRequest{{ProductID: productID.Hex(), Quantity: 2, Reason: "Arrependimento"}},
+	}
+	if _, err := uc.RequestReturn(testCtx, order.ID.Hex(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := uc.RequestReturn(testCtx, order.ID.Hex(), req); err == nil {
+		t.Error("expected error returning more units than ordered, got nil")
+	}
+}
+
+func TestReturnUseCase_ApproveReturn_RetriesFailedRestockOnce(t *testing.T) {
+	productRepo := &unreliableProductRepository{ProductRepository: memory.NewProductRepository()}
+	first := createTestProduct(t, productRepo, newTestProduct(100, 0))
+	second := createTestProduct(t, productRepo, newTestProduct(50, 0))
+
+	orderRepo := memory.NewOrderRepository()
+	order := &domain.Order{
+		Items: []domain.OrderItem{
+			{ProductID: first.ID.Hex(), Price: 100, Quantity: 1},
+			{ProductID: second.ID.Hex(), Price: 50, Quantity: 2},
+		},
+		Status: domain.OrderStatusDelivered,
+	}
+	order.CalculateTotal()
+	if err := orderRepo.Create(testCtx, order); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	productRepo.adjustErr = map[primitive.ObjectID]error{second.ID: errors.New("connection reset")}
+	returns := memory.NewReturnRepository()
+	uc := usecase.NewReturnUseCase(returns, orderRepo, productRepo, memory.NewStockMovementRepository(), &mockMessageProducer{})
+
+	ret, err := uc.RequestReturn(testCtx, order.ID.Hex(), &dto.CreateReturnRequest{
+		Items: []dto.ReturnItemRequest{
+			{ProductID: first.ID.Hex(), Quantity: 1, Reason: "Defeito"},
+			{ProductID: second.ID.Hex(), Quantity: 2, Reason: "Defeito"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := uc.ApproveReturn(testCtx, order.ID.Hex(), ret.ID); err == nil {
+		t.Fatal("expected the restock failure, got nil")
+	}
+	if status := returnStatus(t, returns, ret.ID); status != domain.ReturnStatusRequested {
+		t.Errorf("expected the return to stay %s, got %s", domain.ReturnStatusRequested, status)
+	}
+
+	productRepo.adjustErr = nil
+	if _, err := uc.ApproveReturn(testCtx, order.ID.Hex(), ret.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	firstQuantity, secondQuantity := findTestProduct(t, productRepo, first.ID).Quantity, findTestProduct(t, productRepo, second.ID).Quantity
+	if firstQuantity != 1 || secondQuantity != 2 {
+		t.Errorf("expected 1 and 2 units restocked, got %d and %d", firstQuantity, secondQuantity)
+	}
+	if status := returnStatus(t, returns, ret.ID); status != domain.ReturnStatusApproved {
+		t.Errorf("expected status %s, got %s", domain.ReturnStatusApproved, status)
+	}
+}
@@ -0,0 +1,296 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/dto"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type shipmentUseCase struct {
+	repository      ports.ShipmentRepository
+	orderRepository ports.OrderRepository
+	messageProducer ports.MessageProducer
+}
+
+func NewShipmentUseCase(repository ports.ShipmentRepository, orderRepository ports.OrderRepository, messageProducer ports.MessageProducer) ports.ShipmentUseCase {
+	return &shipmentUseCase{
+		repository:      repository,
+		orderRepository: orderRepository,
+		messageProducer: messageProducer,
+	}
+}
+
+// CreateShipment records a shipment of the items left to ship. The order update is
+// conditioned on the version read with the shipments, so when a concurrent shipment
+// wins the race this one is discarded and checked again against what is left.
+func (uc *shipmentUseCase) CreateShipment(ctx context.Context, orderID string, req *dto.CreateShipmentRequest) (*dto.ShipmentResponse, error) {
+	for attempt := 1; ; attempt++ {
+		order, err := uc.findOrder(ctx, orderID)
+		if err != nil {
+			return nil, err
+		}
+
+		if order.Status != domain.OrderStatusProcessing && order.Status != domain.OrderStatusShipped {
+			return nil, domain.ErrInvalidTransition.Detailf("Order in status %s cannot be shipped", order.Status)
+		}
+
+		shipments, err := uc.repository.FindByOrderID(ctx, orderID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch shipments: %w", err)
+		}
+
+		items, err := shipmentItems(order, shipments, req.Items)
+		if err != nil {
+			return nil, err
+		}
+
+		shippedAt := time.Now()
+		if req.ShippedAt != nil {
+			shippedAt = *req.ShippedAt
+		}
+
+		shipment := &domain.Shipment{
+			OrderID:      orderID,
+			Carrier:      req.Carrier,
+			TrackingCode: req.TrackingCode,
+			Items:        items,
+			Status:       domain.ShipmentStatusShipped,
+			ShippedAt:    shippedAt,
+		}
+
+		if err := uc.repository.Create(ctx, shipment); err != nil {
+			return nil, err
+		}
+
+		shipments = append(shipments, *shipment)
+		statusChanged, err := uc.updateFulfillment(ctx, order, shipments, domain.OrderStatusShipped)
+		if errors.Is(err, domain.ErrVersionConflict) {
+			if err := uc.repository.Delete(ctx, shipment.ID); err != nil {
+				return nil, fmt.Errorf("failed to discard shipment %s: %w", shipment.ID.Hex(), err)
+			}
+			if attempt == maxVersionRetries {
+				return nil, modifiedConcurrently()
+			}
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		uc.publish(ctx, order, statusChanged, domain.NewShipmentEvent(domain.ShipmentEventCreated, shipment, order.Status))
+
+		return dto.ToShipmentResponse(shipment), nil
+	}
+}
+
+func (uc *shipmentUseCase) ListShipments(ctx context.Context, orderID string) ([]dto.ShipmentResponse, error) {
+	if _, err := uc.findOrder(ctx, orderID); err != nil {
+		return nil, err
+	}
+
+	shipments, err := uc.repository.FindByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.ShipmentResponse, len(shipments))
+	for i := range shipments {
+		responses[i] = *dto.ToShipmentResponse(&shipments[i])
+	}
+
+	return responses, nil
+}
+
+func (uc *shipmentUseCase) MarkDelivered(ctx context.Context, orderID, shipmentID string, req *dto.DeliverShipmentRequest) (*dto.ShipmentResponse, error) {
+	order, err := uc.findOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	objectID, err := primitive.ObjectIDFromHex(shipmentID)
+	if err != nil {
+		return nil, domain.ErrShipmentNotFound.WithDetail("Invalid shipment ID")
+	}
+
+	shipment, err := uc.repository.FindByID(ctx, objectID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrShipmentNotFound
+		}
+		return nil, err
+	}
+
+	if shipment.OrderID != orderID {
+		return nil, domain.ErrShipmentNotFound
+	}
+
+	if shipment.Status == domain.ShipmentStatusDelivered {
+		return nil, domain.ErrInvalidTransition.WithDetail("Shipment already delivered")
+	}
+
+	deliveredAt := time.Now()
+	if req.DeliveredAt != nil {
+		deliveredAt = *req.DeliveredAt
+	}
+
+	shipment.Status = domain.ShipmentStatusDelivered
+	shipment.DeliveredAt = &deliveredAt
+	shipment.ReceivedBy = req.ReceivedBy
+	shipment.ProofURL = req.ProofURL
+
+	if err := uc.repository.MarkDelivered(ctx, shipment); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrInvalidTransition.WithDetail("Shipment already delivered")
+		}
+		return nil, err
+	}
+
+	// the shipment is delivered already; a concurrent change of the order only
+	// requires summarizing the shipments again
+	var statusChanged bool
+	for attempt := 1; ; attempt++ {
+		shipments, err := uc.repository.FindByOrderID(ctx, orderID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch shipments: %w", err)
+		}
+
+		status := order.Status
+		if fullyDelivered(order, shipments) {
+			status = domain.OrderStatusDelivered
+		}
+
+		statusChanged, err = uc.updateFulfillment(ctx, order, shipments, status)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, domain.ErrVersionConflict) || attempt == maxVersionRetries {
+			return nil, err
+		}
+
+		if order, err = uc.findOrder(ctx, orderID); err != nil {
+			return nil, err
+		}
+	}
+
+	uc.publish(ctx, order, statusChanged, domain.NewShipmentEvent(domain.ShipmentEventDelivered, shipment, order.Status))
+
+	return dto.ToShipmentResponse(shipment), nil
+}
+
+func (uc *shipmentUseCase) findOrder(ctx context.Context, orderID string) (*domain.Order, error) {
+	objectID, err := primitive.ObjectIDFromHex(orderID)
+	if err != nil {
+		return nil, domain.ErrOrderNotFound.WithDetail("Invalid order ID")
+	}
+
+	order, err := uc.orderRepository.FindByID(ctx, objectID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrOrderNotFound
+		}
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// updateFulfillment stores the shipment summaries on the order and moves it to status,
+// reporting whether the status changed. It fails with domain.ErrVersionConflict when
+// the order changed since it was read.
+func (uc *shipmentUseCase) updateFulfillment(ctx context.Context, order *domain.Order, shipments []domain.Shipment, status string) (bool, error) {
+	summaries := make([]domain.ShipmentSummary, len(shipments))
+	for i := range shipments {
+		summaries[i] = shipments[i].Summary()
+	}
+
+	changed := order.Status != status
+	if err := uc.orderRepository.UpdateFulfillment(ctx, order.ID, summaries, status, order.Version); err != nil {
+		return false, fmt.Errorf("failed to update order fulfillment: %w", err)
+	}
+
+	order.Shipments = summaries
+	order.Status = status
+	order.Version++
+	order.UpdatedAt = time.Now()
+
+	return changed, nil
+}
+
+// publish notifies the order status change, when there is one, and the shipment event.
+// The shipment is already persisted, so publishing failures do not fail the request.
+func (uc *shipmentUseCase) publish(ctx context.Context, order *domain.Order, statusChanged bool, event *domain.ShipmentEvent) {
+	if statusChanged {
+		timestamp := float64(time.Now().UnixNano()) / 1e9
+		_ = uc.messageProducer.PublishOrderStatus(ctx, order.ID.Hex(), order.Status, timestamp)
+	}
+
+	_ = uc.messageProducer.PublishShipmentEvent(ctx, event)
+}
+
+// shipmentItems validates the requested items against what is left to ship.
+// An empty request ships every remaining unit.
+func shipmentItems(order *domain.Order, shipments []domain.Shipment, requested []dto.ShipmentItemRequest) ([]domain.ShipmentItem, error) {
+	remaining := order.OrderedQuantities()
+	for key, quantity := range domain.ShippedQuantities(shipments) {
+		remaining[key] -= quantity
+	}
+
+	items := make([]domain.ShipmentItem, 0)
+	if len(requested) == 0 {
+		for _, item := range order.Items {
+			if quantity := remaining[item.Key()]; quantity > 0 {
+				items = append(items, domain.ShipmentItem{ProductID: item.ProductID, VariantID: item.VariantID, Quantity: quantity})
+				remaining[item.Key()] = 0
+			}
+		}
+		if len(items) == 0 {
+			return nil, domain.ErrInvalidRequest.WithDetail("Order has no items left to ship")
+		}
+		return items, nil
+	}
+
+	for _, itemReq := range requested {
+		key := domain.ItemKey(itemReq.ProductID, itemReq.VariantID)
+		quantity, ok := remaining[key]
+		if !ok {
+			return nil, domain.ErrInvalidRequest.Detailf("Product %s is not part of the order", key)
+		}
+		if itemReq.Quantity > quantity {
+			return nil, domain.ErrInvalidRequest.Detailf("Quantity for product %s exceeds the %d units left to ship", key, quantity)
+		}
+		remaining[key] -= itemReq.Quantity
+		items = append(items, domain.ShipmentItem{ProductID: itemReq.ProductID, VariantID: itemReq.VariantID, Quantity: itemReq.Quantity})
+	}
+
+	return items, nil
+}
+
+// fullyDelivered reports whether every ordered unit was shipped and every shipment delivered
+func fullyDelivered(order *domain.Order, shipments []domain.Shipment) bool {
+	for _, shipment := range shipments {
+		if shipment.Status != domain.ShipmentStatusDelivered {
+			return false
+		}
+	}
+
+	return fullyShipped(order, shipments)
+}
+
+// fullyShipped reports whether the shipments cover every ordered unit
+func fullyShipped(order *domain.Order, shipments []domain.Shipment) bool {
+	shipped := domain.ShippedQuantities(shipments)
+	for key, quantity := range order.OrderedQuantities() {
+		if shipped[key] < quantity {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,152 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/adapter/repository/memory"
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/dto"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"github.com/gvillela7/rank-my-app/internal/core/usecase"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// racingOrderRepository runs race before the first fulfillment update, as a
+// concurrent request would
+type racingOrderRepository struct {
+	ports.OrderRepository
+	race func()
+}
+
+func (r *racingOrderRepository) UpdateFulfillment(ctx context.Context, id primitive.ObjectID, shipments []domain.ShipmentSummary, status string, version int64) error {
+	if race := r.race; race != nil {
+		r.race = nil
+		race()
+	}
+	return r.OrderRepository.UpdateFulfillment(ctx, id, shipments, status, version)
+}
+
+func newProcessingOrder(t *testing.T, orderRepo ports.OrderRepository, productID string, quantity int) *domain.Order {
+	order := &domain.Order{
+		Items:  []domain.OrderItem{{ProductID: productID, Quantity: quantity}},
+		Status: domain.OrderStatusProcessing,
+	}
+	if err := orderRepo.Create(testCtx, order); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return order
+}
+
+func TestShipmentUseCase_PartialShipmentsUntilDelivered(t *testing.T) {
+	productID := primitive.NewObjectID().Hex()
+	orderRepo := memory.NewOrderRepository()
+	producer := &mockMessageProducer{}
+	order := newProcessingOrder(t, orderRepo, productID, 3)
+	uc := usecase.NewShipmentUseCase(memory.NewShipmentRepository(), orderRepo, producer)
+
+	first, err := uc.CreateShipment(testCtx, order.ID.Hex(), &dto.CreateShipmentRequest{
+		Carrier:      "Correios",
+		TrackingCode: "BR1",
+		Items:        []dto.ShipmentItemRequest{{ProductID: productID, Quantity: 2}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status := findTestOrder(t, orderRepo, order.ID).Status; status != domain.OrderStatusShipped {
+		t.Errorf("expected status %s, got %s", domain.OrderStatusShipped, status)
+	}
+
+	second, err := uc.CreateShipment(testCtx, order.ID.Hex(), &dto.CreateShipmentRequest{
+		Carrier:      "Correios",
+		TrackingCode: "BR2",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(second.Items) != 1 || second.Items[0].Quantity != 1 {
+		t.Errorf("expected the remaining unit to be shipped, got %+v", second.Items)
+	}
+
+	if _, err := uc.MarkDelivered(testCtx, order.ID.Hex(), first.ID, &dto.DeliverShipmentRequest{ReceivedBy: "Maria"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status := findTestOrder(t, orderRepo, order.ID).Status; status != domain.OrderStatusShipped {
+		t.Errorf("expected status %s while a shipment is in transit, got %s", domain.OrderStatusShipped, status)
+	}
+
+	if _, err := uc.MarkDelivered(testCtx, order.ID.Hex(), second.ID, &dto.DeliverShipmentRequest{ReceivedBy: "Maria"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	delivered := findTestOrder(t, orderRepo, order.ID)
+	if delivered.Status != domain.OrderStatusDelivered {
+		t.Errorf("expected status %s, got %s", domain.OrderStatusDelivered, delivered.Status)
+	}
+	if len(delivered.Shipments) != 2 {
+		t.Errorf("expected 2 shipment summaries on the order, got %d", len(delivered.Shipments))
+	}
+	if len(producer.statuses) != 2 || len(producer.shipmentEvents) != 4 {
+		t.Errorf("expected 2 status and 4 shipment events, got %v and %v", producer.statuses, producer.shipmentEvents)
+	}
+}
+
+func TestShipmentUseCase_CreateShipment_RejectsExcessQuantity(t *testing.T) {
+	productID := primitive.NewObjectID().Hex()
+	orderRepo := memory.NewOrderRepository()
+	order := newProcessingOrder(t, orderRepo, productID, 1)
+	uc := usecase.NewShipmentUseCase(memory.NewShipmentRepository(), orderRepo, &mockMessageProducer{})
+
+	_, err := uc.CreateShipment(testCtx, order.ID.Hex(), &dto.CreateShipmentRequest{
+		Carrier:      "Correios",
+		TrackingCode: "BR1",
+		Items:        []dto.ShipmentItemRequest{{ProductID: productID, Quantity: 2}},
+	})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if status := findTestOrder(t, orderRepo, order.ID).Status; status != domain.OrderStatusProcessing {
+		t.Errorf("expected status to remain %s, got %s", domain.OrderStatusProcessing, status)
+	}
+}
+
+func TestShipmentUseCase_CreateShipment_DiscardsShipmentLosingTheRace(t *testing.T) {
+	productID := primitive.NewObjectID().Hex()
+	orders := memory.NewOrderRepository()
+	orderRepo := &racingOrderRepository{OrderRepository: orders}
+	shipmentRepo := memory.NewShipmentRepository()
+	order := newProcessingOrder(t, orders, productID, 1)
+	uc := usecase.NewShipmentUseCase(shipmentRepo, orderRepo, &mockMessageProducer{})
+
+	// another request ships the only unit between this one's check and its write
+	concurrent := &domain.Shipment{
+		OrderID: order.ID.Hex(),
+		Items:   []domain.ShipmentItem{{ProductID: productID, Quantity: 1}},
+		Status:  domain.ShipmentStatusShipped,
+	}
+	orderRepo.race = func() {
+		if err := shipmentRepo.Create(testCtx, concurrent); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		summary := domain.ShipmentSummary{ShipmentID: concurrent.ID.Hex(), Status: concurrent.Status, ShippedAt: time.Now()}
+		if err := orders.UpdateFulfillment(testCtx, order.ID, []domain.ShipmentSummary{summary}, domain.OrderStatusShipped, order.Version); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	_, err := uc.CreateShipment(testCtx, order.ID.Hex(), &dto.CreateShipmentRequest{
+		Carrier:      "Correios",
+		TrackingCode: "BR1",
+	})
+	if !errors.Is(err, domain.ErrInvalidRequest) {
+		t.Fatalf("expected %v, got %v", domain.ErrInvalidRequest, err)
+	}
+	shipments, err := shipmentRepo.FindByOrderID(testCtx, order.ID.Hex())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(shipments) != 1 || shipments[0].ID != concurrent.ID {
+		t.Errorf("expected only the concurrent shipment to remain, got %d shipments", len(shipments))
+	}
+}
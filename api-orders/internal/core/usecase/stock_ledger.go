@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// stockLedger changes product quantities only together with a stock movement, so
+// every change to products.quantity has a reason and an actor. Movements that take
+// a product to its reorder threshold or out of stock publish a stock alert, unless
+// the ledger has no message producer.
+type stockLedger struct {
+	productRepository  ports.ProductRepository
+	movementRepository ports.StockMovementRepository
+	messageProducer    ports.MessageProducer
+}
+
+func newStockLedger(productRepository ports.ProductRepository, movementRepository ports.StockMovementRepository, messageProducer ports.MessageProducer) *stockLedger {
+	return &stockLedger{
+		productRepository:  productRepository,
+		movementRepository: movementRepository,
+		messageProducer:    messageProducer,
+	}
+}
+
+// move applies the movement quantity to the product, or to the product variant when
+// the movement has one, and appends the movement. The quantity change is reverted
+// when the movement cannot be recorded.
+func (l *stockLedger) move(ctx context.Context, movement *domain.StockMovement) (*domain.Product, error) {
+	product, err := l.adjust(ctx, movement, movement.Quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	movement.BalanceAfter = product.Quantity
+	if movement.VariantID != nil {
+		if variant := product.Variant(*movement.VariantID); variant != nil {
+			movement.BalanceAfter = variant.Quantity
+		}
+	}
+
+	if err := l.movementRepository.Create(ctx, movement); err != nil {
+		_, _ = l.adjust(ctx, movement, -movement.Quantity)
+		return nil, fmt.Errorf("failed to record stock movement: %w", err)
+	}
+
+	if event := product.StockAlert(movement); event != "" && l.messageProducer != nil {
+		_ = l.messageProducer.PublishStockAlert(ctx, domain.NewStockAlertEvent(event, product, movement))
+	}
+
+	return product, nil
+}
+
+func (l *stockLedger) adjust(ctx context.Context, movement *domain.StockMovement, delta int) (*domain.Product, error) {
+	if movement.VariantID != nil {
+		return l.productRepository.AdjustVariantQuantity(ctx, movement.ProductID, *movement.VariantID, delta)
+	}
+	return l.productRepository.AdjustQuantity(ctx, movement.ProductID, delta)
+}
+
+// record appends a movement for a quantity already stored on the product,
+// such as the initial stock of a new product
+func (l *stockLedger) record(ctx context.Context, movement *domain.StockMovement) error {
+	if err := l.movementRepository.Create(ctx, movement); err != nil {
+		return fmt.Errorf("failed to record stock movement: %w", err)
+	}
+	return nil
+}
+
+// recordInitialStock records the stored quantity of the product as its initial
+// stock, one movement per variant for products with variants
+func (l *stockLedger) recordInitialStock(ctx context.Context, product *domain.Product, reason string) error {
+	if !product.HasVariants() {
+		if product.Quantity == 0 {
+			return nil
+		}
+		return l.record(ctx, &domain.StockMovement{
+			ProductID:    product.ID,
+			Type:         domain.StockMovementInitial,
+			Quantity:     product.Quantity,
+			BalanceAfter: product.Quantity,
+			Reason:       reason,
+			Actor:        domain.StockActorSystem,
+		})
+	}
+
+	for _, variant := range product.Variants {
+		if variant.Quantity == 0 {
+			continue
+		}
+		variantID := variant.ID
+		err := l.record(ctx, &domain.StockMovement{
+			ProductID:    product.ID,
+			VariantID:    &variantID,
+			Type:         domain.StockMovementInitial,
+			Quantity:     variant.Quantity,
+			BalanceAfter: variant.Quantity,
+			Reason:       reason,
+			Actor:        domain.StockActorSystem,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// variantObjectID parses the variant ID of an order line, returning nil for lines without variant
+func variantObjectID(variantID string) (*primitive.ObjectID, error) {
+	if variantID == "" {
+		return nil, nil
+	}
+	objectID, err := primitive.ObjectIDFromHex(variantID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid variant ID %s: %w", variantID, err)
+	}
+	return &objectID, nil
+}
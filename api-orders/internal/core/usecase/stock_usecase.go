@@ -0,0 +1,198 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/dto"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type stockUseCase struct {
+	productRepository  ports.ProductRepository
+	movementRepository ports.StockMovementRepository
+	ledger             *stockLedger
+}
+
+// NewStockUseCase creates the stock use case. messageProducer may be nil when stock
+// alerts are not needed, as in the reconciliation command.
+func NewStockUseCase(productRepository ports.ProductRepository, movementRepository ports.StockMovementRepository, messageProducer ports.MessageProducer) ports.StockUseCase {
+	return &stockUseCase{
+		productRepository:  productRepository,
+		movementRepository: movementRepository,
+		ledger:             newStockLedger(productRepository, movementRepository, messageProducer),
+	}
+}
+
+func (uc *stockUseCase) AdjustStock(ctx context.Context, productID string, req *dto.StockAdjustmentRequest) (*dto.StockMovementResponse, error) {
+	objectID, err := primitive.ObjectIDFromHex(productID)
+	if err != nil {
+		return nil, domain.ErrProductNotFound.WithDetail("Invalid product ID")
+	}
+
+	product, err := uc.productRepository.FindByID(ctx, objectID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrProductNotFound
+		}
+		return nil, err
+	}
+
+	variantID, err := adjustedVariant(product, req.VariantID)
+	if err != nil {
+		return nil, err
+	}
+
+	movement := &domain.StockMovement{
+		ProductID: objectID,
+		VariantID: variantID,
+		Type:      domain.StockMovementAdjustment,
+		Quantity:  req.Quantity,
+		Reason:    req.Reason,
+		Actor:     actorOf(ctx),
+	}
+
+	if _, err := uc.ledger.move(ctx, movement); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrProductNotFound
+		}
+		if errors.Is(err, domain.ErrInsufficientStock) {
+			return nil, domain.ErrInsufficientStock.Wrap("Adjustment would make the product stock negative", err)
+		}
+		return nil, err
+	}
+
+	return dto.ToStockMovementResponse(movement), nil
+}
+
+// adjustedVariant checks the variant of a stock adjustment: products with variants
+// must be adjusted through one of them, products without variants never are
+func adjustedVariant(product *domain.Product, variantID string) (*primitive.ObjectID, error) {
+	if variantID == "" {
+		if product.HasVariants() {
+			return nil, domain.ErrInvalidRequest.WithDetail("Product has variants; inform the variant_id to adjust")
+		}
+		return nil, nil
+	}
+
+	objectID, err := primitive.ObjectIDFromHex(variantID)
+	if err != nil || product.Variant(objectID) == nil {
+		return nil, domain.ErrVariantNotFound
+	}
+	return &objectID, nil
+}
+
+func (uc *stockUseCase) SetReorderThreshold(ctx context.Context, productID string, req *dto.ReorderThresholdRequest, ifMatch *int64) (*dto.ProductResponse, error) {
+	objectID, err := primitive.ObjectIDFromHex(productID)
+	if err != nil {
+		return nil, domain.ErrProductNotFound.WithDetail("Invalid product ID")
+	}
+
+	product, err := uc.productRepository.FindByID(ctx, objectID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrProductNotFound
+		}
+		return nil, err
+	}
+
+	if err := checkVersion(ifMatch, product.Version); err != nil {
+		return nil, err
+	}
+
+	if err := uc.productRepository.SetReorderThreshold(ctx, objectID, *req.ReorderThreshold, product.Version); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrProductNotFound
+		}
+		if errors.Is(err, domain.ErrVersionConflict) {
+			return nil, modifiedConcurrently()
+		}
+		return nil, err
+	}
+
+	product, err = uc.productRepository.FindByID(ctx, objectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ToProductResponse(product), nil
+}
+
+func (uc *stockUseCase) ListMovements(ctx context.Context, productID string) ([]dto.StockMovementResponse, error) {
+	objectID, err := primitive.ObjectIDFromHex(productID)
+	if err != nil {
+		return nil, domain.ErrProductNotFound.WithDetail("Invalid product ID")
+	}
+
+	if _, err := uc.productRepository.FindByID(ctx, objectID); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrProductNotFound
+		}
+		return nil, err
+	}
+
+	movements, err := uc.movementRepository.FindByProductID(ctx, objectID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.StockMovementResponse, len(movements))
+	for i := range movements {
+		responses[i] = *dto.ToStockMovementResponse(&movements[i])
+	}
+
+	return responses, nil
+}
+
+// Reconcile reports the products whose quantity differs from their ledger. With apply,
+// products without movements, created before the ledger existed, get their current
+// quantity recorded as opening balance; the others are set to the ledger balance.
+func (uc *stockUseCase) Reconcile(ctx context.Context, apply bool) ([]dto.StockDriftResponse, error) {
+	balances, err := uc.movementRepository.SumByProduct(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum stock movements: %w", err)
+	}
+
+	products, err := uc.productRepository.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list products: %w", err)
+	}
+
+	drifts := make([]dto.StockDriftResponse, 0)
+	for i := range products {
+		product := &products[i]
+		balance, tracked := balances[product.ID]
+		drift := domain.StockDrift{
+			ProductID:      product.ID,
+			ProductName:    product.Name,
+			Quantity:       product.Quantity,
+			LedgerQuantity: balance,
+			Untracked:      !tracked,
+		}
+		if drift.Drift() == 0 {
+			continue
+		}
+
+		switch {
+		case !apply:
+		case !tracked:
+			if err := uc.ledger.recordInitialStock(ctx, product, "Opening balance recorded by reconciliation"); err != nil {
+				return nil, fmt.Errorf("failed to record opening balance of product %s: %w", product.ID.Hex(), err)
+			}
+		case !product.HasVariants():
+			// the quantity of a product with variants is the sum of its variants, so
+			// it is only reported and must be fixed through variant adjustments
+			if err := uc.productRepository.SetQuantity(ctx, product.ID, drift.LedgerQuantity); err != nil {
+				return nil, fmt.Errorf("failed to fix quantity of product %s: %w", product.ID.Hex(), err)
+			}
+		}
+
+		drifts = append(drifts, *dto.ToStockDriftResponse(&drift))
+	}
+
+	return drifts, nil
+}
@@ -0,0 +1,150 @@
+package usecase_test
+
+import (
+	"testing"
+
+	"github.com/gvillela7/rank-my-app/internal/adapter/repository/memory"
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/dto"
+	"github.com/gvillela7/rank-my-app/internal/core/usecase"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestStockUseCase_AdjustStock_PublishesLowStockAlert(t *testing.T) {
+	products := memory.NewProductRepository()
+	product := newTestProduct(100, 10)
+	product.ReorderThreshold = 5
+	createTestProduct(t, products, product)
+	movementRepo := memory.NewStockMovementRepository()
+	producer := &mockMessageProducer{}
+	uc := usecase.NewStockUseCase(products, movementRepo, producer)
+
+	ctx := domain.ContextWithPrincipal(testCtx, &domain.Principal{Subject: "joao.estoque"})
+	movement, err := uc.AdjustStock(ctx, product.ID.Hex(), &dto.StockAdjustmentRequest{
+		Quantity: -6,
+		Reason:   "Avaria",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if movement.Actor != "joao.estoque" {
+		t.Errorf("expected the principal as actor, got %q", movement.Actor)
+	}
+	if movement.BalanceAfter != 4 {
+		t.Errorf("expected balance 4, got %d", movement.BalanceAfter)
+	}
+	movements, err := movementRepo.FindByProductID(testCtx, product.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(movements) != 1 || movements[0].Type != domain.StockMovementAdjustment {
+		t.Errorf("expected one manual adjustment recorded, got %+v", movements)
+	}
+	if len(producer.stockAlerts) != 1 || producer.stockAlerts[0] != domain.StockEventLowStock {
+		t.Errorf("expected a low stock alert, got %v", producer.stockAlerts)
+	}
+}
+
+func TestStockUseCase_AdjustStock_PublishesOutOfStockAlert(t *testing.T) {
+	products := memory.NewProductRepository()
+	product := newTestProduct(100, 3)
+	product.ReorderThreshold = 5
+	createTestProduct(t, products, product)
+	producer := &mockMessageProducer{}
+	uc := usecase.NewStockUseCase(products, memory.NewStockMovementRepository(), producer)
+
+	_, err := uc.AdjustStock(testCtx, product.ID.Hex(), &dto.StockAdjustmentRequest{
+		Quantity: -3,
+		Reason:   "Inventário",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(producer.stockAlerts) != 1 || producer.stockAlerts[0] != domain.StockEventOutOfStock {
+		t.Errorf("expected only an out of stock alert, got %v", producer.stockAlerts)
+	}
+}
+
+func TestStockUseCase_AdjustStock_PublishesVariantAlert(t *testing.T) {
+	products := memory.NewProductRepository()
+	product := newTestProduct(100, 13)
+	product.ReorderThreshold = 2
+	product.Variants = []domain.ProductVariant{
+		{ID: primitive.NewObjectID(), SKU: "MOUSE-PRETO", Quantity: 3},
+		{ID: primitive.NewObjectID(), SKU: "MOUSE-BRANCO", Quantity: 10},
+	}
+	createTestProduct(t, products, product)
+	producer := &mockMessageProducer{}
+	uc := usecase.NewStockUseCase(products, memory.NewStockMovementRepository(), producer)
+
+	// the product keeps 10 units, above its threshold, but the black mouse sells out
+	_, err := uc.AdjustStock(testCtx, product.ID.Hex(), &dto.StockAdjustmentRequest{
+		VariantID: product.Variants[0].ID.Hex(),
+		Quantity:  -3,
+		Reason:    "Avaria",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(producer.stockAlerts) != 1 || producer.stockAlerts[0] != domain.StockEventOutOfStock {
+		t.Fatalf("expected an out of stock alert for the variant, got %v", producer.stockAlerts)
+	}
+	alert := producer.lastStockAlert
+	if alert.VariantID != product.Variants[0].ID.Hex() || alert.SKU != "MOUSE-PRETO" || alert.Quantity != 0 {
+		t.Errorf("expected the alert of MOUSE-PRETO with 0 units, got %+v", alert)
+	}
+}
+
+func TestStockUseCase_AdjustStock_InsufficientStock(t *testing.T) {
+	products := memory.NewProductRepository()
+	product := createTestProduct(t, products, newTestProduct(100, 2))
+	producer := &mockMessageProducer{}
+	uc := usecase.NewStockUseCase(products, memory.NewStockMovementRepository(), producer)
+
+	_, err := uc.AdjustStock(testCtx, product.ID.Hex(), &dto.StockAdjustmentRequest{
+		Quantity: -3,
+		Reason:   "Avaria",
+	})
+	if err == nil {
+		t.Fatal("expected error when the adjustment makes the stock negative")
+	}
+	if len(producer.stockAlerts) != 0 {
+		t.Errorf("expected no alert, got %v", producer.stockAlerts)
+	}
+}
+
+func TestStockUseCase_Reconcile_RecordsOpeningBalanceOfUntrackedProducts(t *testing.T) {
+	products := memory.NewProductRepository()
+	untracked := createTestProduct(t, products, newTestProduct(100, 7))
+	drifted := createTestProduct(t, products, newTestProduct(100, 5))
+	movementRepo := memory.NewStockMovementRepository()
+	if err := movementRepo.Create(testCtx, &domain.StockMovement{ProductID: drifted.ID, Type: domain.StockMovementInitial, Quantity: 3}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	uc := usecase.NewStockUseCase(products, movementRepo, nil)
+
+	drifts, err := uc.Reconcile(testCtx, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(drifts) != 2 || !drifts[0].Untracked || drifts[1].Untracked {
+		t.Fatalf("expected the untracked and the drifted product, got %+v", drifts)
+	}
+	if quantity := findTestProduct(t, products, untracked.ID).Quantity; quantity != 7 {
+		t.Errorf("expected the quantity 7 of the untracked product to be kept, got %d", quantity)
+	}
+	if quantity := findTestProduct(t, products, drifted.ID).Quantity; quantity != 3 {
+		t.Errorf("expected the drifted product set to its ledger balance 3, got %d", quantity)
+	}
+	opening, err := movementRepo.FindByProductID(testCtx, untracked.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(opening) != 1 || opening[0].Type != domain.StockMovementInitial || opening[0].Quantity != 7 {
+		t.Errorf("expected an initial stock movement of 7 for the untracked product, got %+v", opening)
+	}
+}
@@ -0,0 +1,18 @@
+package usecase
+
+import "github.com/gvillela7/rank-my-app/internal/core/domain"
+
+// checkVersion rejects a change conditioned on a version other than the current one.
+// A nil ifMatch means the request carried no precondition.
+func checkVersion(ifMatch *int64, version int64) error {
+	if ifMatch != nil && *ifMatch != version {
+		return domain.ErrVersionConflict.Detailf("Resource is at version %d", version)
+	}
+	return nil
+}
+
+// modifiedConcurrently is returned when a version-conditioned update lost the race
+// against another change made after the resource was read
+func modifiedConcurrently() error {
+	return domain.ErrVersionConflict
+}
@@ -2,10 +2,17 @@ package mongo
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strconv"
 
 	config "github.com/gvillela7/rank-my-app/configs"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
 )
 
 // MongoDBConnection implements the MongoDBConnection interface
@@ -16,7 +23,13 @@ type MongoDBConnection struct {
 
 func NewMongoDBConnection(ctx context.Context) (*MongoDBConnection, error) {
 	cfg := config.GetDBMongo()
-	client, err := mongo.Connect(ctx, options.Client().ApplyURI(cfg.URI))
+
+	opts, err := clientOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := mongo.Connect(ctx, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -33,6 +46,103 @@ func NewMongoDBConnection(ctx context.Context) (*MongoDBConnection, error) {
 	}, nil
 }
 
+// clientOptions applies the URI, then the settings of the configuration. Zero
+// sizes and timeouts, and an empty read preference or write concern, keep the
+// value of the URI or the driver default.
+func clientOptions(cfg config.DBMongo) (*options.ClientOptions, error) {
+	opts := options.Client().ApplyURI(cfg.URI)
+
+	if cfg.MaxPoolSize > 0 {
+		opts.SetMaxPoolSize(cfg.MaxPoolSize)
+	}
+	if cfg.MinPoolSize > 0 {
+		opts.SetMinPoolSize(cfg.MinPoolSize)
+	}
+	if cfg.MaxConnIdleTime > 0 {
+		opts.SetMaxConnIdleTime(cfg.MaxConnIdleTime)
+	}
+	if cfg.ConnectTimeout > 0 {
+		opts.SetConnectTimeout(cfg.ConnectTimeout)
+	}
+	if cfg.ServerSelectionTimeout > 0 {
+		opts.SetServerSelectionTimeout(cfg.ServerSelectionTimeout)
+	}
+	if cfg.SocketTimeout > 0 {
+		opts.SetSocketTimeout(cfg.SocketTimeout)
+	}
+
+	if cfg.ReadPreference != "" {
+		mode, err := readpref.ModeFromString(cfg.ReadPreference)
+		if err != nil {
+			return nil, fmt.Errorf("invalid mongo.read_preference: %w", err)
+		}
+		readPref, err := readpref.New(mode)
+		if err != nil {
+			return nil, fmt.Errorf("invalid mongo.read_preference: %w", err)
+		}
+		opts.SetReadPreference(readPref)
+	}
+
+	if cfg.WriteConcern != "" {
+		writeConcern, err := parseWriteConcern(cfg.WriteConcern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid mongo.write_concern: %w", err)
+		}
+		opts.SetWriteConcern(writeConcern)
+	}
+
+	if cfg.TLS.Enabled {
+		tlsConfig, err := newTLSConfig(cfg.TLS)
+		if err != nil {
+			return nil, err
+		}
+		opts.SetTLSConfig(tlsConfig)
+	}
+
+	return opts, opts.Validate()
+}
+
+// parseWriteConcern accepts "majority" or the number of members acknowledging writes
+func parseWriteConcern(value string) (*writeconcern.WriteConcern, error) {
+	if value == "majority" {
+		return writeconcern.Majority(), nil
+	}
+
+	w, err := strconv.Atoi(value)
+	if err != nil || w < 0 {
+		return nil, fmt.Errorf("%q is neither majority nor a number of members", value)
+	}
+	return &writeconcern.WriteConcern{W: w}, nil
+}
+
+// newTLSConfig verifies the server with the CA file, the system roots without one,
+// and authenticates the client with its certificate when one is configured
+func newTLSConfig(cfg config.MongoTLSConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if cfg.CAFile != "" {
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read mongo.tls.ca_file: %w", err)
+		}
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("mongo.tls.ca_file %s holds no PEM certificate", cfg.CAFile)
+		}
+		tlsConfig.RootCAs = roots
+	}
+
+	if cfg.CertFile != "" || cfg.KeyFile != "" {
+		certificate, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load mongo.tls client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	return tlsConfig, nil
+}
+
 // IsConnected checks if the database is connected
 func (m *MongoDBConnection) IsConnected(ctx context.Context) bool {
 	if m.client == nil {
@@ -0,0 +1,409 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// tenantCollections were written without tenant before multi-tenancy
+var tenantCollections = []string{"products", "orders", "published_orders", "price_history"}
+
+// scopedCollections were shared by every tenant until version 6
+var scopedCollections = []string{"customers", "coupons", "categories", "shipments", "returns", "stock_movements", "api_keys", "stock_alerts"}
+
+// All returns the migrations of the database shared by api-orders and
+// manager-status. Documents written before multi-tenancy are assigned to
+// defaultTenant.
+func All(defaultTenant string) []Migration {
+	return []Migration{
+		{
+			Version:     1,
+			Description: "assign documents without tenant to the default tenant",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				return backfillTenant(ctx, db, tenantCollections, defaultTenant)
+			},
+		},
+		{
+			Version:     2,
+			Description: "store published_orders.order_id as ObjectID",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				return convertOrderID(ctx, db, "string", "objectId")
+			},
+			Down: func(ctx context.Context, db *mongo.Database) error {
+				return convertOrderID(ctx, db, "objectId", "string")
+			},
+		},
+		{
+			Version:     3,
+			Description: "create indexes",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				if err := dropIndexes(ctx, db, legacyIndexes); err != nil {
+					return err
+				}
+				return createIndexes(ctx, db, append(indexes, sharedIndexes...))
+			},
+			Down: func(ctx context.Context, db *mongo.Database) error {
+				if err := dropIndexes(ctx, db, append(indexes, sharedIndexes...)); err != nil {
+					return err
+				}
+				return createIndexes(ctx, db, legacyIndexes)
+			},
+		},
+		{
+			Version:     4,
+			Description: "validate orders, products and published_orders with JSON schemas",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				for collection, schema := range schemas {
+					if err := setValidator(ctx, db, collection, schema); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+			Down: func(ctx context.Context, db *mongo.Database) error {
+				for collection := range schemas {
+					if err := removeValidator(ctx, db, collection); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		{
+			Version:     5,
+			Description: "restock each line of a return once",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				return createIndexes(ctx, db, returnRestockIndexes)
+			},
+			Down: func(ctx context.Context, db *mongo.Database) error {
+				return dropIndexes(ctx, db, returnRestockIndexes)
+			},
+		},
+		{
+			Version:     6,
+			Description: "scope customers, coupons, categories, shipments, returns, stock movements, API keys and stock alerts to tenants",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				if err := backfillTenant(ctx, db, scopedCollections, defaultTenant); err != nil {
+					return err
+				}
+				if err := dropIndexes(ctx, db, append(sharedIndexes, returnRestockIndexes...)); err != nil {
+					return err
+				}
+				return createIndexes(ctx, db, scopedIndexes)
+			},
+			Down: func(ctx context.Context, db *mongo.Database) error {
+				if err := dropIndexes(ctx, db, scopedIndexes); err != nil {
+					return err
+				}
+				return createIndexes(ctx, db, append(sharedIndexes, returnRestockIndexes...))
+			},
+		},
+		{
+			Version:     7,
+			Description: "give back the stock of each declined order once",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				return createIndexes(ctx, db, releaseIndexes)
+			},
+			Down: func(ctx context.Context, db *mongo.Database) error {
+				return dropIndexes(ctx, db, releaseIndexes)
+			},
+		},
+	}
+}
+
+// backfillTenant cannot be reverted: backfilled documents are indistinguishable
+// from the ones the default tenant wrote since
+func backfillTenant(ctx context.Context, db *mongo.Database, collections []string, defaultTenant string) error {
+	filter := bson.M{"tenant_id": bson.M{"$exists": false}}
+
+	if defaultTenant == "" {
+		for _, collection := range collections {
+			count, err := db.Collection(collection).CountDocuments(ctx, filter)
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				return errors.New("documents without tenant found but no default tenant is configured")
+			}
+		}
+		return nil
+	}
+	if err := domain.ValidateTenant(defaultTenant); err != nil {
+		return fmt.Errorf("invalid default tenant %q: %w", defaultTenant, err)
+	}
+
+	for _, collection := range collections {
+		update := bson.M{"$set": bson.M{"tenant_id": defaultTenant}}
+		if _, err := db.Collection(collection).UpdateMany(ctx, filter, update); err != nil {
+			return fmt.Errorf("failed to backfill tenant of %s: %w", collection, err)
+		}
+	}
+	return nil
+}
+
+// convertOrderID converts the order_id of the published orders between BSON types.
+// Values that cannot be converted are left as they are.
+func convertOrderID(ctx context.Context, db *mongo.Database, from, to string) error {
+	filter := bson.M{"order_id": bson.M{"$type": from}}
+	update := mongo.Pipeline{{{Key: "$set", Value: bson.M{
+		"order_id": bson.M{"$convert": bson.M{"input": "$order_id", "to": to, "onError": "$order_id"}},
+	}}}}
+
+	if _, err := db.Collection("published_orders").UpdateMany(ctx, filter, update); err != nil {
+		return fmt.Errorf("failed to convert published_orders.order_id to %s: %w", to, err)
+	}
+	return nil
+}
+
+func named(name string) *options.IndexOptions {
+	return options.Index().SetName(name)
+}
+
+// legacyIndexes were created by hand before the migrations. A collection has at
+// most one text index, so the tenant one replaces the product text index.
+var legacyIndexes = []index{
+	{"products", mongo.IndexModel{
+		Keys:    bson.D{{Key: "name", Value: "text"}, {Key: "description", Value: "text"}},
+		Options: named("name_text_description_text"),
+	}},
+}
+
+// indexes serve the queries of both services. Tenant-owned collections lead with
+// tenant_id, which every query of theirs filters on.
+var indexes = []index{
+	{"products", mongo.IndexModel{
+		Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "name", Value: "text"}, {Key: "description", Value: "text"}},
+		Options: named("tenant_text"),
+	}},
+	{"products", mongo.IndexModel{
+		Keys: bson.D{{Key: "tenant_id", Value: 1}, {Key: "sku", Value: 1}},
+		Options: named("tenant_sku_unique").SetUnique(true).
+			SetPartialFilterExpression(bson.M{"sku": bson.M{"$type": "string"}}),
+	}},
+	{"products", mongo.IndexModel{
+		Keys: bson.D{{Key: "tenant_id", Value: 1}, {Key: "variants.sku", Value: 1}},
+		Options: named("tenant_variant_sku_unique").SetUnique(true).
+			SetPartialFilterExpression(bson.M{"variants.sku": bson.M{"$type": "string"}}),
+	}},
+	{"products", mongo.IndexModel{
+		Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "variants._id", Value: 1}},
+		Options: named("tenant_variant"),
+	}},
+	{"orders", mongo.IndexModel{
+		Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "order_number", Value: 1}},
+		Options: named("tenant_order_number_unique").SetUnique(true),
+	}},
+	{"orders", mongo.IndexModel{
+		Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "customer_id", Value: 1}, {Key: "created_at", Value: -1}},
+		Options: named("tenant_customer_created"),
+	}},
+	{"published_orders", mongo.IndexModel{
+		Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "order_id", Value: 1}},
+		Options: named("tenant_order"),
+	}},
+	{"price_history", mongo.IndexModel{
+		Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "product_id", Value: 1}, {Key: "effective_at", Value: -1}},
+		Options: named("tenant_product_effective"),
+	}},
+	{"price_history", mongo.IndexModel{
+		Keys:    bson.D{{Key: "status", Value: 1}, {Key: "effective_at", Value: 1}},
+		Options: named("status_effective"),
+	}},
+	{"audit_log", mongo.IndexModel{
+		Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "occurred_at", Value: -1}},
+		Options: named("tenant_occurred"),
+	}},
+	{"audit_log", mongo.IndexModel{
+		Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "resource_type", Value: 1}, {Key: "resource_id", Value: 1}, {Key: "occurred_at", Value: -1}},
+		Options: named("tenant_resource_occurred"),
+	}},
+	{"audit_log", mongo.IndexModel{
+		Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "request_id", Value: 1}},
+		Options: named("tenant_request"),
+	}},
+	{"api_keys", mongo.IndexModel{
+		Keys:    bson.D{{Key: "hash", Value: 1}},
+		Options: named("hash_unique").SetUnique(true),
+	}},
+	{"rate_limits", mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: named("expires_ttl").SetExpireAfterSeconds(0),
+	}},
+	{"payments", mongo.IndexModel{
+		Keys:    bson.D{{Key: "order_id", Value: 1}},
+		Options: named("order"),
+	}},
+}
+
+// returnRestockIndexes make the restock of an approved return idempotent: a
+// repeated approval cannot put the same return line back in stock twice
+var returnRestockIndexes = []index{
+	{"stock_movements", mongo.IndexModel{
+		Keys: bson.D{{Key: "reference", Value: 1}, {Key: "product_id", Value: 1}, {Key: "variant_id", Value: 1}},
+		Options: named("return_line_unique").SetUnique(true).
+			SetPartialFilterExpression(bson.M{"type": domain.StockMovementReturn}),
+	}},
+}
+
+// releaseIndexes serve the job giving back the stock of declined orders, which
+// polls every tenant, and make it idempotent: a line of an order is cancelled once
+var releaseIndexes = []index{
+	{"orders", mongo.IndexModel{
+		Keys:    bson.D{{Key: "status", Value: 1}, {Key: "released_at", Value: 1}, {Key: "created_at", Value: 1}},
+		Options: named("status_released_created"),
+	}},
+	{"stock_movements", mongo.IndexModel{
+		Keys: bson.D{{Key: "tenant_id", Value: 1}, {Key: "reference", Value: 1}, {Key: "product_id", Value: 1}, {Key: "variant_id", Value: 1}},
+		Options: named("tenant_cancellation_line_unique").SetUnique(true).
+			SetPartialFilterExpression(bson.M{"type": domain.StockMovementCancellation}),
+	}},
+}
+
+// sharedIndexes, created by version 3 with returnRestockIndexes of version 5, are
+// unique across tenants or lack the tenant_id every query filters on since version
+// 6; scopedIndexes replace them
+var sharedIndexes = []index{
+	{"coupons", mongo.IndexModel{
+		Keys:    bson.D{{Key: "code", Value: 1}},
+		Options: named("code_unique").SetUnique(true),
+	}},
+	{"customers", mongo.IndexModel{
+		Keys:    bson.D{{Key: "email", Value: 1}},
+		Options: named("email_unique").SetUnique(true),
+	}},
+	{"customers", mongo.IndexModel{
+		Keys: bson.D{{Key: "document", Value: 1}},
+		Options: named("document_unique").SetUnique(true).
+			SetPartialFilterExpression(bson.M{"document": bson.M{"$gt": ""}}),
+	}},
+	{"stock_movements", mongo.IndexModel{
+		Keys:    bson.D{{Key: "product_id", Value: 1}, {Key: "created_at", Value: -1}},
+		Options: named("product_created"),
+	}},
+	{"shipments", mongo.IndexModel{
+		Keys:    bson.D{{Key: "order_id", Value: 1}, {Key: "shipped_at", Value: 1}},
+		Options: named("order_shipped"),
+	}},
+	{"returns", mongo.IndexModel{
+		Keys:    bson.D{{Key: "order_id", Value: 1}, {Key: "created_at", Value: 1}},
+		Options: named("order_created"),
+	}},
+	{"stock_alerts", mongo.IndexModel{
+		Keys:    bson.D{{Key: "received_at", Value: -1}},
+		Options: named("received"),
+	}},
+}
+
+var scopedIndexes = []index{
+	{"coupons", mongo.IndexModel{
+		Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "code", Value: 1}},
+		Options: named("tenant_code_unique").SetUnique(true),
+	}},
+	{"customers", mongo.IndexModel{
+		Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "email", Value: 1}},
+		Options: named("tenant_email_unique").SetUnique(true),
+	}},
+	{"customers", mongo.IndexModel{
+		Keys: bson.D{{Key: "tenant_id", Value: 1}, {Key: "document", Value: 1}},
+		Options: named("tenant_document_unique").SetUnique(true).
+			SetPartialFilterExpression(bson.M{"document": bson.M{"$gt": ""}}),
+	}},
+	{"categories", mongo.IndexModel{
+		Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "name", Value: 1}},
+		Options: named("tenant_name"),
+	}},
+	{"stock_movements", mongo.IndexModel{
+		Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "product_id", Value: 1}, {Key: "created_at", Value: -1}},
+		Options: named("tenant_product_created"),
+	}},
+	{"stock_movements", mongo.IndexModel{
+		Keys: bson.D{{Key: "tenant_id", Value: 1}, {Key: "reference", Value: 1}, {Key: "product_id", Value: 1}, {Key: "variant_id", Value: 1}},
+		Options: named("tenant_return_line_unique").SetUnique(true).
+			SetPartialFilterExpression(bson.M{"type": domain.StockMovementReturn}),
+	}},
+	{"shipments", mongo.IndexModel{
+		Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "order_id", Value: 1}, {Key: "shipped_at", Value: 1}},
+		Options: named("tenant_order_shipped"),
+	}},
+	{"returns", mongo.IndexModel{
+		Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "order_id", Value: 1}, {Key: "created_at", Value: 1}},
+		Options: named("tenant_order_created"),
+	}},
+	{"api_keys", mongo.IndexModel{
+		Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "created_at", Value: -1}},
+		Options: named("tenant_created"),
+	}},
+	{"stock_alerts", mongo.IndexModel{
+		Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "received_at", Value: -1}},
+		Options: named("tenant_received"),
+	}},
+}
+
+var (
+	number   = bson.M{"bsonType": bson.A{"double", "int", "long", "decimal"}}
+	integer  = bson.M{"bsonType": bson.A{"int", "long"}}
+	text     = bson.M{"bsonType": "string"}
+	required = bson.M{"bsonType": "string", "minLength": 1}
+)
+
+// schemas hold the fields both services rely on; other fields are free
+var schemas = map[string]bson.M{
+	"orders": {
+		"bsonType": "object",
+		"required": bson.A{"tenant_id", "order_number", "items", "status", "total"},
+		"properties": bson.M{
+			"tenant_id":    required,
+			"order_number": required,
+			"status": bson.M{"enum": bson.A{
+				domain.OrderStatusCreated,
+				domain.OrderStatusProcessing,
+				domain.OrderStatusShipped,
+				domain.OrderStatusDelivered,
+				domain.OrderStatusPaymentDeclined,
+			}},
+			"items": bson.M{
+				"bsonType": "array",
+				"minItems": 1,
+				"items": bson.M{
+					"bsonType": "object",
+					"required": bson.A{"product_id", "price", "quantity"},
+					"properties": bson.M{
+						"product_id": required,
+						"price":      number,
+						"quantity":   bson.M{"bsonType": bson.A{"int", "long"}, "minimum": 1},
+					},
+				},
+			},
+			"total":   number,
+			"version": integer,
+		},
+	},
+	"products": {
+		"bsonType": "object",
+		"required": bson.A{"tenant_id", "name", "price", "quantity"},
+		"properties": bson.M{
+			"tenant_id": required,
+			"name":      required,
+			"sku":       text,
+			"price":     bson.M{"bsonType": bson.A{"double", "int", "long", "decimal"}, "minimum": 0},
+			"quantity":  integer,
+			"version":   integer,
+		},
+	},
+	"published_orders": {
+		"bsonType": "object",
+		"required": bson.A{"tenant_id", "order_id", "published", "order_status"},
+		"properties": bson.M{
+			"tenant_id":    required,
+			"order_id":     bson.M{"bsonType": "objectId"},
+			"published":    bson.M{"bsonType": "bool"},
+			"order_status": text,
+		},
+	},
+}
@@ -0,0 +1,18 @@
+package migrations_test
+
+import (
+	"testing"
+
+	"github.com/gvillela7/rank-my-app/internal/infra/database/mongo/migrations"
+)
+
+func TestAll_VersionsAreSequential(t *testing.T) {
+	for i, migration := range migrations.All("default") {
+		if migration.Version != i+1 {
+			t.Errorf("Expected migration %d to have version %d, got %d", i, i+1, migration.Version)
+		}
+		if migration.Up == nil || migration.Description == "" {
+			t.Errorf("Expected migration %d to have Up and a description", migration.Version)
+		}
+	}
+}
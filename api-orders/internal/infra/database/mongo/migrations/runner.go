@@ -0,0 +1,197 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// collection recording the applied migrations, one document per version
+	historyCollection = "schema_migrations"
+	lockID            = "lock"
+	// lockTTL bounds how long a crashed runner keeps the others out
+	lockTTL = 15 * time.Minute
+)
+
+// ErrLocked is returned while another runner is applying migrations
+var ErrLocked = errors.New("migrations are being applied by another process")
+
+// Migration is a versioned change of the indexes, validators or documents of the
+// database. Down reverts Up; migrations without Down are irreversible.
+type Migration struct {
+	Version     int
+	Description string
+	Up          func(ctx context.Context, db *mongo.Database) error
+	Down        func(ctx context.Context, db *mongo.Database) error
+}
+
+// Status is a migration and when it was applied, nil when it is pending
+type Status struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+type historyDocument struct {
+	Version     int       `bson:"_id"`
+	Description string    `bson:"description"`
+	AppliedAt   time.Time `bson:"applied_at"`
+}
+
+// Runner applies migrations in version order and records them in the
+// schema_migrations collection. Runners of several replicas exclude each other
+// with a lock document, so they can all run at startup.
+type Runner struct {
+	db         *mongo.Database
+	history    *mongo.Collection
+	migrations []Migration
+}
+
+func NewRunner(db *mongo.Database, migrations []Migration) *Runner {
+	sorted := append([]Migration(nil), migrations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+
+	return &Runner{
+		db:         db,
+		history:    db.Collection(historyCollection),
+		migrations: sorted,
+	}
+}
+
+// Status lists every migration, oldest first, with the time it was applied
+func (r *Runner) Status(ctx context.Context) ([]Status, error) {
+	applied, err := r.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, len(r.migrations))
+	for i, migration := range r.migrations {
+		statuses[i] = Status{Migration: migration}
+		if doc, ok := applied[migration.Version]; ok {
+			appliedAt := doc.AppliedAt
+			statuses[i].AppliedAt = &appliedAt
+		}
+	}
+	return statuses, nil
+}
+
+// Up applies the pending migrations, oldest first, and returns them. It stops at
+// the first failure, leaving the later migrations pending.
+func (r *Runner) Up(ctx context.Context) ([]Migration, error) {
+	release, err := r.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	applied, err := r.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	done := make([]Migration, 0)
+	for _, migration := range r.migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+
+		if err := migration.Up(ctx, r.db); err != nil {
+			return done, fmt.Errorf("migration %d (%s): %w", migration.Version, migration.Description, err)
+		}
+
+		doc := historyDocument{Version: migration.Version, Description: migration.Description, AppliedAt: time.Now()}
+		if _, err := r.history.InsertOne(ctx, doc); err != nil {
+			return done, fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+		}
+		done = append(done, migration)
+	}
+
+	return done, nil
+}
+
+// Down reverts the latest steps applied migrations, newest first, and returns them
+func (r *Runner) Down(ctx context.Context, steps int) ([]Migration, error) {
+	release, err := r.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	applied, err := r.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	done := make([]Migration, 0, steps)
+	for i := len(r.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+		migration := r.migrations[i]
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+		if migration.Down == nil {
+			return done, fmt.Errorf("migration %d (%s) is irreversible", migration.Version, migration.Description)
+		}
+
+		if err := migration.Down(ctx, r.db); err != nil {
+			return done, fmt.Errorf("reverting migration %d (%s): %w", migration.Version, migration.Description, err)
+		}
+
+		if _, err := r.history.DeleteOne(ctx, bson.M{"_id": migration.Version}); err != nil {
+			return done, fmt.Errorf("failed to unrecord migration %d: %w", migration.Version, err)
+		}
+		done = append(done, migration)
+	}
+
+	return done, nil
+}
+
+// applied returns the history of the applied migrations by version
+func (r *Runner) applied(ctx context.Context) (map[int]historyDocument, error) {
+	cursor, err := r.history.Find(ctx, bson.M{"_id": bson.M{"$type": "number"}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migration history: %w", err)
+	}
+
+	var docs []historyDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("failed to read migration history: %w", err)
+	}
+
+	applied := make(map[int]historyDocument, len(docs))
+	for _, doc := range docs {
+		applied[doc.Version] = doc
+	}
+	return applied, nil
+}
+
+// lock takes the lock document, {_id: "lock", holder, expires_at}, replacing it
+// when its holder let it expire, and returns the function releasing it
+func (r *Runner) lock(ctx context.Context) (func(), error) {
+	holder, _ := os.Hostname()
+	holder = fmt.Sprintf("%s:%d", holder, os.Getpid())
+	now := time.Now()
+
+	filter := bson.M{"_id": lockID, "expires_at": bson.M{"$lt": now}}
+	update := bson.M{"$set": bson.M{"holder": holder, "expires_at": now.Add(lockTTL)}}
+
+	_, err := r.history.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		// the lock exists and has not expired
+		return nil, ErrLocked
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to take migration lock: %w", err)
+	}
+
+	return func() {
+		_, _ = r.history.DeleteOne(context.Background(), bson.M{"_id": lockID, "holder": holder})
+	}, nil
+}
@@ -0,0 +1,91 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// server error codes the helpers tolerate
+const (
+	codeNamespaceNotFound = 26
+	codeIndexNotFound     = 27
+)
+
+// index is an index of a collection. Indexes are named, so Down drops exactly the
+// ones Up created.
+type index struct {
+	collection string
+	model      mongo.IndexModel
+}
+
+func createIndexes(ctx context.Context, db *mongo.Database, indexes []index) error {
+	for _, idx := range indexes {
+		if _, err := db.Collection(idx.collection).Indexes().CreateOne(ctx, idx.model); err != nil {
+			return fmt.Errorf("failed to create index %s on %s: %w", *idx.model.Options.Name, idx.collection, err)
+		}
+	}
+	return nil
+}
+
+func dropIndexes(ctx context.Context, db *mongo.Database, indexes []index) error {
+	for _, idx := range indexes {
+		_, err := db.Collection(idx.collection).Indexes().DropOne(ctx, *idx.model.Options.Name)
+		if err != nil && !hasCode(err, codeIndexNotFound, codeNamespaceNotFound) {
+			return fmt.Errorf("failed to drop index %s on %s: %w", *idx.model.Options.Name, idx.collection, err)
+		}
+	}
+	return nil
+}
+
+// setValidator sets the JSON schema of a collection, creating the collection when
+// it does not exist. The moderate level checks inserts and updates of valid
+// documents, so legacy documents stay writable until they are fixed.
+func setValidator(ctx context.Context, db *mongo.Database, collection string, schema bson.M) error {
+	command := bson.D{
+		{Key: "collMod", Value: collection},
+		{Key: "validator", Value: bson.M{"$jsonSchema": schema}},
+		{Key: "validationLevel", Value: "moderate"},
+		{Key: "validationAction", Value: "error"},
+	}
+
+	err := db.RunCommand(ctx, command).Err()
+	if hasCode(err, codeNamespaceNotFound) {
+		command[0] = bson.E{Key: "create", Value: collection}
+		err = db.RunCommand(ctx, command).Err()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to set validator of %s: %w", collection, err)
+	}
+	return nil
+}
+
+func removeValidator(ctx context.Context, db *mongo.Database, collection string) error {
+	command := bson.D{
+		{Key: "collMod", Value: collection},
+		{Key: "validator", Value: bson.M{}},
+		{Key: "validationLevel", Value: "off"},
+	}
+
+	err := db.RunCommand(ctx, command).Err()
+	if err != nil && !hasCode(err, codeNamespaceNotFound) {
+		return fmt.Errorf("failed to remove validator of %s: %w", collection, err)
+	}
+	return nil
+}
+
+func hasCode(err error, codes ...int) bool {
+	var serverErr mongo.ServerError
+	if !errors.As(err, &serverErr) {
+		return false
+	}
+	for _, code := range codes {
+		if serverErr.HasErrorCode(code) {
+			return true
+		}
+	}
+	return false
+}
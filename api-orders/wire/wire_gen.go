@@ -8,20 +8,36 @@ package wire
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 	"github.com/gvillela7/rank-my-app/configs"
+	"github.com/gvillela7/rank-my-app/inprocess"
+	"github.com/gvillela7/rank-my-app/internal/adapter/audit"
+	"github.com/gvillela7/rank-my-app/internal/adapter/auth"
+	"github.com/gvillela7/rank-my-app/internal/adapter/exchangerate"
 	"github.com/gvillela7/rank-my-app/internal/adapter/http/handlers"
+	"github.com/gvillela7/rank-my-app/internal/adapter/http/middleware"
 	"github.com/gvillela7/rank-my-app/internal/adapter/http/routes"
+	"github.com/gvillela7/rank-my-app/internal/adapter/http/validation"
 	"github.com/gvillela7/rank-my-app/internal/adapter/messages/producers"
+	"github.com/gvillela7/rank-my-app/internal/adapter/ratelimit"
+	"github.com/gvillela7/rank-my-app/internal/adapter/repository/memory"
 	mongo3 "github.com/gvillela7/rank-my-app/internal/adapter/repository/mongo"
+	"github.com/gvillela7/rank-my-app/internal/adapter/scheduler"
+	"github.com/gvillela7/rank-my-app/internal/adapter/tax"
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
 	"github.com/gvillela7/rank-my-app/internal/core/ports"
 	"github.com/gvillela7/rank-my-app/internal/core/usecase"
 	"github.com/gvillela7/rank-my-app/internal/infra/database/mongo"
+	"github.com/gvillela7/rank-my-app/internal/infra/database/mongo/migrations"
 	"github.com/gvillela7/rank-my-app/internal/infra/rabbitmq"
 	mongo2 "go.mongodb.org/mongo-driver/mongo"
 	"go.uber.org/zap"
 	"strconv"
+	"strings"
+	"time"
 )
 
 // Injectors from wire.go:
@@ -31,19 +47,23 @@ func InitializeApp(ctx context.Context) (*App, func(), error) {
 	if err != nil {
 		return nil, nil, err
 	}
-	database, err := ProvideMongoDatabase(mongoDBConnection)
+	logger, err := ProvideLogger()
 	if err != nil {
 		return nil, nil, err
 	}
-	productRepository := ProvideProductRepository(database)
-	productUseCase := ProvideProductUseCase(productRepository)
-	validate := ProvideValidator()
-	logger, err := ProvideLogger()
+	database, err := ProvideMongoDatabase(ctx, mongoDBConnection, logger)
 	if err != nil {
 		return nil, nil, err
 	}
+	auditRepository := ProvideAuditRepository(database)
+	auditUseCase := ProvideAuditUseCase(auditRepository)
+	productRepository := ProvideProductRepository(database, auditUseCase, logger)
+	stockMovementRepository := ProvideStockMovementRepository(database)
+	categoryRepository := ProvideCategoryRepository(database)
+	priceChangeRepository := ProvidePriceChangeRepository(database)
+	productUseCase := ProvideProductUseCase(productRepository, stockMovementRepository, categoryRepository, priceChangeRepository)
+	validate := ProvideValidator()
 	productHandler := ProvideProductHandler(productUseCase, validate, logger)
-	orderRepository := ProvideOrderRepository(database)
 	rabbitMQConnection, err := ProvideRabbitMQConnection(logger)
 	if err != nil {
 		return nil, nil, err
@@ -53,88 +73,461 @@ func InitializeApp(ctx context.Context) (*App, func(), error) {
 	if err != nil {
 		return nil, nil, err
 	}
-	orderUseCase := ProvideOrderUseCase(orderRepository, productRepository, messageProducer)
+	stockUseCase := ProvideStockUseCase(productRepository, stockMovementRepository, messageProducer)
+	stockHandler := ProvideStockHandler(stockUseCase, validate, logger)
+	exchangeRateProvider, err := ProvideExchangeRateProvider(logger)
+	if err != nil {
+		return nil, nil, err
+	}
+	priceUseCase := ProvidePriceUseCase(priceChangeRepository, productRepository, exchangeRateProvider)
+	priceHandler := ProvidePriceHandler(priceUseCase, validate, logger)
+	categoryUseCase := ProvideCategoryUseCase(categoryRepository)
+	categoryHandler := ProvideCategoryHandler(categoryUseCase, validate, logger)
+	orderRepository := ProvideOrderRepository(database, auditUseCase, logger)
+	couponRepository := ProvideCouponRepository(database)
+	taxCalculator := ProvideTaxCalculator()
+	customerRepository := ProvideCustomerRepository(database)
+	shipmentRepository := ProvideShipmentRepository(database)
+	sequenceRepository := ProvideSequenceRepository(database)
+	orderNumberFormat, err := ProvideOrderNumberFormat()
+	if err != nil {
+		return nil, nil, err
+	}
+	orderUseCase := ProvideOrderUseCase(orderRepository, productRepository, messageProducer, exchangeRateProvider, couponRepository, taxCalculator, customerRepository, shipmentRepository, stockMovementRepository, priceChangeRepository, sequenceRepository, orderNumberFormat)
 	orderHandler := ProvideOrderHandler(orderUseCase, validate, logger)
+	couponUseCase := ProvideCouponUseCase(couponRepository)
+	couponHandler := ProvideCouponHandler(couponUseCase, validate, logger)
+	customerUseCase := ProvideCustomerUseCase(customerRepository, orderRepository)
+	customerHandler := ProvideCustomerHandler(customerUseCase, validate, logger)
+	shipmentUseCase := ProvideShipmentUseCase(shipmentRepository, orderRepository, messageProducer)
+	shipmentHandler := ProvideShipmentHandler(shipmentUseCase, validate, logger)
+	returnRepository := ProvideReturnRepository(database)
+	returnUseCase := ProvideReturnUseCase(returnRepository, orderRepository, productRepository, stockMovementRepository, messageProducer)
+	returnHandler := ProvideReturnHandler(returnUseCase, validate, logger)
+	apiKeyRepository := ProvideAPIKeyRepository(database)
+	apiKeyUseCase := ProvideAPIKeyUseCase(apiKeyRepository, logger)
+	apiKeyHandler := ProvideAPIKeyHandler(apiKeyUseCase, validate, logger)
+	auditHandler := ProvideAuditHandler(auditUseCase, validate, logger)
 	healthHandler := ProvideHealthHandler(rabbitMQConnection)
-	engine := ProvideRouter(productHandler, orderHandler, healthHandler, logger)
-	app := ProvideApp(engine, mongoDBConnection, rabbitMQConnection)
+	tokenVerifier, err := ProvideTokenVerifier()
+	if err != nil {
+		return nil, nil, err
+	}
+	rateLimiter, err := ProvideRateLimiter(database)
+	if err != nil {
+		return nil, nil, err
+	}
+	translations, err := ProvideTranslations(validate)
+	if err != nil {
+		return nil, nil, err
+	}
+	engine := ProvideRouter(productHandler, stockHandler, priceHandler, categoryHandler, orderHandler, couponHandler, customerHandler, shipmentHandler, returnHandler, apiKeyHandler, auditHandler, healthHandler, tokenVerifier, apiKeyUseCase, rateLimiter, translations, logger)
+	priceScheduler := ProvidePriceScheduler(priceUseCase, logger)
+	orderReleaseScheduler := ProvideOrderReleaseScheduler(orderUseCase, logger)
+	app := ProvideApp(engine, mongoDBConnection, rabbitMQConnection, priceScheduler, orderReleaseScheduler)
 	return app, func() {
 	}, nil
 }
 
 // wire.go:
 
+// App is the API. DB is nil when the documents are kept in memory and
+// RabbitMQConn when messages go to the in-process broker.
 type App struct {
-	Router       *gin.Engine
-	DB           *mongo.MongoDBConnection
-	RabbitMQConn *rabbitmq.RabbitMQConnection
+	Router         *gin.Engine
+	DB             *mongo.MongoDBConnection
+	RabbitMQConn   *rabbitmq.RabbitMQConnection
+	PriceScheduler *scheduler.PriceScheduler
+	OrderScheduler *scheduler.OrderReleaseScheduler
 }
 
-func ProvideApp(router *gin.Engine, conn *mongo.MongoDBConnection, rabbitConn *rabbitmq.RabbitMQConnection) *App {
+// memoryStorage reports whether the repositories keep their documents in memory
+// instead of MongoDB
+func memoryStorage() bool {
+	return config.GetStorageConfig().Driver == "memory"
+}
+
+func ProvideApp(router *gin.Engine, conn *mongo.MongoDBConnection, rabbitConn *rabbitmq.RabbitMQConnection, priceScheduler *scheduler.PriceScheduler, orderScheduler *scheduler.OrderReleaseScheduler) *App {
 	return &App{
-		Router:       router,
-		DB:           conn,
-		RabbitMQConn: rabbitConn,
+		Router:         router,
+		DB:             conn,
+		RabbitMQConn:   rabbitConn,
+		PriceScheduler: priceScheduler,
+		OrderScheduler: orderScheduler,
 	}
 }
 
+// ProvideMongoConnection returns nil when the documents are kept in memory
 func ProvideMongoConnection(ctx context.Context) (*mongo.MongoDBConnection, error) {
-	return mongo.NewMongoDBConnection(ctx)
+	switch driver := config.GetStorageConfig().Driver; driver {
+	case "mongo":
+		return mongo.NewMongoDBConnection(ctx)
+	case "memory":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unsupported storage driver %q", driver)
+	}
 }
 
-func ProvideMongoDatabase(conn *mongo.MongoDBConnection) (*mongo2.Database, error) {
-	return conn.Client()
+// ProvideMongoDatabase bounds every repository call by mongo.operation_timeout and
+// applies the pending migrations first when mongo.migrate_on_startup is enabled
+func ProvideMongoDatabase(ctx context.Context, conn *mongo.MongoDBConnection, logger *zap.Logger) (*mongo2.Database, error) {
+	if conn == nil {
+		return nil, nil
+	}
+
+	db, err := conn.Client()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := config.GetDBMongo()
+	mongo3.SetOperationTimeout(cfg.OperationTimeout)
+
+	if !cfg.MigrateOnStartup {
+		return db, nil
+	}
+
+	applied, err := migrations.NewRunner(db, migrations.All(config.GetTenantConfig().Default)).Up(ctx)
+	for _, migration := range applied {
+		logger.Info("Applied migration", zap.Int("version", migration.Version), zap.String("description", migration.Description))
+	}
+	if errors.Is(err, migrations.ErrLocked) {
+
+		logger.Warn("Skipped migrations", zap.Error(err))
+		return db, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply migrations: %w", err)
+	}
+
+	return db, nil
 }
 
 func ProvideValidator() *validator.Validate {
-	return validator.New()
+	return validation.New()
+}
+
+func ProvideTranslations(validate *validator.Validate) (*validation.Translations, error) {
+	return validation.NewTranslations(validate)
 }
 
 func ProvideLogger() (*zap.Logger, error) {
 	return zap.NewProduction()
 }
 
-func ProvideProductRepository(db *mongo2.Database) ports.ProductRepository {
-	return mongo3.NewProductRepository(db)
+// ProvideProductRepository records every product mutation in the audit trail
+func ProvideProductRepository(db *mongo2.Database, auditor ports.AuditUseCase, logger *zap.Logger) ports.ProductRepository {
+	if memoryStorage() {
+		return audit.NewProductRepository(memory.NewProductRepository(), auditor, logger)
+	}
+	return audit.NewProductRepository(mongo3.NewProductRepository(db), auditor, logger)
 }
 
-func ProvideProductUseCase(repo ports.ProductRepository) ports.ProductUseCase {
-	return usecase.NewProductUseCase(repo)
+func ProvideProductUseCase(repo ports.ProductRepository, movementRepo ports.StockMovementRepository, categoryRepo ports.CategoryRepository, priceRepo ports.PriceChangeRepository) ports.ProductUseCase {
+	return usecase.NewProductUseCase(repo, movementRepo, categoryRepo, priceRepo)
 }
 
 func ProvideProductHandler(uc ports.ProductUseCase, validator2 *validator.Validate, logger *zap.Logger) *handlers.ProductHandler {
 	return handlers.NewProductHandler(uc, validator2, logger)
 }
 
-func ProvideOrderRepository(db *mongo2.Database) ports.OrderRepository {
-	return mongo3.NewOrderRepository(db)
+func ProvideStockMovementRepository(db *mongo2.Database) ports.StockMovementRepository {
+	if memoryStorage() {
+		return memory.NewStockMovementRepository()
+	}
+	return mongo3.NewStockMovementRepository(db)
+}
+
+func ProvideStockUseCase(productRepo ports.ProductRepository, movementRepo ports.StockMovementRepository, messageProducer ports.MessageProducer) ports.StockUseCase {
+	return usecase.NewStockUseCase(productRepo, movementRepo, messageProducer)
+}
+
+func ProvideStockHandler(uc ports.StockUseCase, validator2 *validator.Validate, logger *zap.Logger) *handlers.StockHandler {
+	return handlers.NewStockHandler(uc, validator2, logger)
+}
+
+func ProvidePriceChangeRepository(db *mongo2.Database) ports.PriceChangeRepository {
+	if memoryStorage() {
+		return memory.NewPriceChangeRepository()
+	}
+	return mongo3.NewPriceChangeRepository(db)
+}
+
+func ProvidePriceUseCase(repo ports.PriceChangeRepository, productRepo ports.ProductRepository, exchangeRateProvider ports.ExchangeRateProvider) ports.PriceUseCase {
+	return usecase.NewPriceUseCase(repo, productRepo, exchangeRateProvider)
+}
+
+func ProvidePriceHandler(uc ports.PriceUseCase, validator2 *validator.Validate, logger *zap.Logger) *handlers.PriceHandler {
+	return handlers.NewPriceHandler(uc, validator2, logger)
+}
+
+func ProvidePriceScheduler(uc ports.PriceUseCase, logger *zap.Logger) *scheduler.PriceScheduler {
+	cfg := config.GetPriceConfig()
+	return scheduler.NewPriceScheduler(uc, cfg.SchedulerInterval, logger)
+}
+
+// ProvideOrderRepository records every order mutation in the audit trail
+func ProvideOrderRepository(db *mongo2.Database, auditor ports.AuditUseCase, logger *zap.Logger) ports.OrderRepository {
+	if memoryStorage() {
+		return audit.NewOrderRepository(memory.NewOrderRepository(), auditor, logger)
+	}
+	return audit.NewOrderRepository(mongo3.NewOrderRepository(db), auditor, logger)
+}
+
+func ProvideSequenceRepository(db *mongo2.Database) ports.SequenceRepository {
+	if memoryStorage() {
+		return memory.NewSequenceRepository()
+	}
+	return mongo3.NewSequenceRepository(db)
 }
 
-func ProvideOrderUseCase(orderRepo ports.OrderRepository, productRepo ports.ProductRepository, messageProducer ports.MessageProducer) ports.OrderUseCase {
-	return usecase.NewOrderUseCase(orderRepo, productRepo, messageProducer)
+// ProvideOrderNumberFormat dates order numbers in the time zone of the API
+func ProvideOrderNumberFormat() (domain.OrderNumberFormat, error) {
+	cfg := config.GetOrderNumberConfig()
+
+	if cfg.Digits < 1 {
+		return domain.OrderNumberFormat{}, fmt.Errorf("order_number.digits must be at least 1, got %d", cfg.Digits)
+	}
+
+	location, err := time.LoadLocation(config.GetAPIConfig().TimeZone)
+	if err != nil {
+		return domain.OrderNumberFormat{}, fmt.Errorf("invalid api.timezone: %w", err)
+	}
+
+	return domain.OrderNumberFormat{
+		Prefix:     cfg.Prefix,
+		DateLayout: cfg.DateLayout,
+		Digits:     cfg.Digits,
+		CheckDigit: cfg.CheckDigit,
+		Location:   location,
+	}, nil
+}
+
+func ProvideOrderUseCase(orderRepo ports.OrderRepository, productRepo ports.ProductRepository, messageProducer ports.MessageProducer, exchangeRateProvider ports.ExchangeRateProvider, couponRepo ports.CouponRepository, taxCalculator ports.TaxCalculator, customerRepo ports.CustomerRepository, shipmentRepo ports.ShipmentRepository, movementRepo ports.StockMovementRepository, priceRepo ports.PriceChangeRepository, sequenceRepo ports.SequenceRepository, orderNumbers domain.OrderNumberFormat) ports.OrderUseCase {
+	return usecase.NewOrderUseCase(orderRepo, productRepo, messageProducer, exchangeRateProvider, couponRepo, taxCalculator, customerRepo, shipmentRepo, movementRepo, priceRepo, sequenceRepo, orderNumbers)
+}
+
+func ProvideOrderReleaseScheduler(uc ports.OrderUseCase, logger *zap.Logger) *scheduler.OrderReleaseScheduler {
+	cfg := config.GetOrderConfig()
+	return scheduler.NewOrderReleaseScheduler(uc, cfg.ReleaseInterval, logger)
 }
 
 func ProvideOrderHandler(uc ports.OrderUseCase, validator2 *validator.Validate, logger *zap.Logger) *handlers.OrderHandler {
 	return handlers.NewOrderHandler(uc, validator2, logger)
 }
 
+func ProvideCouponRepository(db *mongo2.Database) ports.CouponRepository {
+	if memoryStorage() {
+		return memory.NewCouponRepository()
+	}
+	return mongo3.NewCouponRepository(db)
+}
+
+func ProvideCouponUseCase(repo ports.CouponRepository) ports.CouponUseCase {
+	return usecase.NewCouponUseCase(repo)
+}
+
+func ProvideCouponHandler(uc ports.CouponUseCase, validator2 *validator.Validate, logger *zap.Logger) *handlers.CouponHandler {
+	return handlers.NewCouponHandler(uc, validator2, logger)
+}
+
+func ProvideCategoryRepository(db *mongo2.Database) ports.CategoryRepository {
+	if memoryStorage() {
+		return memory.NewCategoryRepository()
+	}
+	return mongo3.NewCategoryRepository(db)
+}
+
+func ProvideCategoryUseCase(repo ports.CategoryRepository) ports.CategoryUseCase {
+	return usecase.NewCategoryUseCase(repo)
+}
+
+func ProvideCategoryHandler(uc ports.CategoryUseCase, validator2 *validator.Validate, logger *zap.Logger) *handlers.CategoryHandler {
+	return handlers.NewCategoryHandler(uc, validator2, logger)
+}
+
+func ProvideCustomerRepository(db *mongo2.Database) ports.CustomerRepository {
+	if memoryStorage() {
+		return memory.NewCustomerRepository()
+	}
+	return mongo3.NewCustomerRepository(db)
+}
+
+func ProvideCustomerUseCase(repo ports.CustomerRepository, orderRepo ports.OrderRepository) ports.CustomerUseCase {
+	return usecase.NewCustomerUseCase(repo, orderRepo)
+}
+
+func ProvideCustomerHandler(uc ports.CustomerUseCase, validator2 *validator.Validate, logger *zap.Logger) *handlers.CustomerHandler {
+	return handlers.NewCustomerHandler(uc, validator2, logger)
+}
+
+func ProvideShipmentRepository(db *mongo2.Database) ports.ShipmentRepository {
+	if memoryStorage() {
+		return memory.NewShipmentRepository()
+	}
+	return mongo3.NewShipmentRepository(db)
+}
+
+func ProvideShipmentUseCase(repo ports.ShipmentRepository, orderRepo ports.OrderRepository, messageProducer ports.MessageProducer) ports.ShipmentUseCase {
+	return usecase.NewShipmentUseCase(repo, orderRepo, messageProducer)
+}
+
+func ProvideShipmentHandler(uc ports.ShipmentUseCase, validator2 *validator.Validate, logger *zap.Logger) *handlers.ShipmentHandler {
+	return handlers.NewShipmentHandler(uc, validator2, logger)
+}
+
+func ProvideReturnRepository(db *mongo2.Database) ports.ReturnRepository {
+	if memoryStorage() {
+		return memory.NewReturnRepository()
+	}
+	return mongo3.NewReturnRepository(db)
+}
+
+func ProvideReturnUseCase(repo ports.ReturnRepository, orderRepo ports.OrderRepository, productRepo ports.ProductRepository, movementRepo ports.StockMovementRepository, messageProducer ports.MessageProducer) ports.ReturnUseCase {
+	return usecase.NewReturnUseCase(repo, orderRepo, productRepo, movementRepo, messageProducer)
+}
+
+func ProvideReturnHandler(uc ports.ReturnUseCase, validator2 *validator.Validate, logger *zap.Logger) *handlers.ReturnHandler {
+	return handlers.NewReturnHandler(uc, validator2, logger)
+}
+
+func ProvideAPIKeyRepository(db *mongo2.Database) ports.APIKeyRepository {
+	if memoryStorage() {
+		return memory.NewAPIKeyRepository()
+	}
+	return mongo3.NewAPIKeyRepository(db)
+}
+
+func ProvideAPIKeyUseCase(repo ports.APIKeyRepository, logger *zap.Logger) ports.APIKeyUseCase {
+	return usecase.NewAPIKeyUseCase(repo, logger)
+}
+
+func ProvideAPIKeyHandler(uc ports.APIKeyUseCase, validator2 *validator.Validate, logger *zap.Logger) *handlers.APIKeyHandler {
+	return handlers.NewAPIKeyHandler(uc, validator2, logger)
+}
+
+func ProvideAuditRepository(db *mongo2.Database) ports.AuditRepository {
+	if memoryStorage() {
+		return memory.NewAuditRepository()
+	}
+	return mongo3.NewAuditRepository(db)
+}
+
+func ProvideAuditUseCase(repo ports.AuditRepository) ports.AuditUseCase {
+	return usecase.NewAuditUseCase(repo)
+}
+
+func ProvideAuditHandler(uc ports.AuditUseCase, validator2 *validator.Validate, logger *zap.Logger) *handlers.AuditHandler {
+	return handlers.NewAuditHandler(uc, validator2, logger)
+}
+
 func ProvideHealthHandler(rabbitConn *rabbitmq.RabbitMQConnection) *handlers.HealthHandler {
 	return handlers.NewHealthHandler(rabbitConn)
 }
 
-func ProvideRouter(productHandler *handlers.ProductHandler, orderHandler *handlers.OrderHandler, healthHandler *handlers.HealthHandler, logger *zap.Logger) *gin.Engine {
+func ProvideRouter(productHandler *handlers.ProductHandler, stockHandler *handlers.StockHandler, priceHandler *handlers.PriceHandler, categoryHandler *handlers.CategoryHandler, orderHandler *handlers.OrderHandler, couponHandler *handlers.CouponHandler, customerHandler *handlers.CustomerHandler, shipmentHandler *handlers.ShipmentHandler, returnHandler *handlers.ReturnHandler, apiKeyHandler *handlers.APIKeyHandler, auditHandler *handlers.AuditHandler, healthHandler *handlers.HealthHandler, tokenVerifier ports.TokenVerifier, apiKeys ports.APIKeyUseCase, rateLimiter ports.RateLimiter, translations *validation.Translations, logger *zap.Logger) *gin.Engine {
 	cfg := config.GetAPIConfig()
+	rateLimitCfg := config.GetRateLimitConfig()
+	tenantCfg := config.GetTenantConfig()
+
+	rateLimits := middleware.RateLimitPolicy{
+		Default: rateLimit(rateLimitCfg.Default),
+		IP:      rateLimit(rateLimitCfg.IP),
+		Routes:  make(map[string]domain.RateLimit, len(rateLimitCfg.Routes)),
+	}
+	for _, route := range rateLimitCfg.Routes {
+		rateLimits.Routes[strings.ToUpper(route.Method)+" "+route.Path] = rateLimit(route)
+	}
+
 	return routes.SetupRouter(&routes.RouterConfig{
-		ProductHandler: productHandler,
-		OrderHandler:   orderHandler,
-		HealthHandler:  healthHandler,
-		Logger:         logger,
-		AllowOrigin:    cfg.Origin,
-		Environment:    cfg.Environment,
+		ProductHandler:  productHandler,
+		StockHandler:    stockHandler,
+		PriceHandler:    priceHandler,
+		CategoryHandler: categoryHandler,
+		OrderHandler:    orderHandler,
+		CouponHandler:   couponHandler,
+		CustomerHandler: customerHandler,
+		ShipmentHandler: shipmentHandler,
+		ReturnHandler:   returnHandler,
+		APIKeyHandler:   apiKeyHandler,
+		AuditHandler:    auditHandler,
+		HealthHandler:   healthHandler,
+		Logger:          logger,
+		AllowOrigin:     cfg.Origin,
+		Environment:     cfg.Environment,
+		RequireIfMatch:  cfg.RequireIfMatch,
+		TokenVerifier:   tokenVerifier,
+		APIKeys:         apiKeys,
+		RateLimiter:     rateLimiter,
+		RateLimits:      rateLimits,
+		TenantHeader:    tenantCfg.Header,
+		DefaultTenant:   tenantCfg.Default,
+		Translations:    translations,
 	})
 }
 
+func rateLimit(rule config.RateLimitRuleConfig) domain.RateLimit {
+	return domain.RateLimit{
+		Requests: rule.Requests,
+		Period:   rule.Period,
+		Burst:    rule.Burst,
+	}
+}
+
+// ProvideRateLimiter returns nil when rate limiting is disabled
+func ProvideRateLimiter(db *mongo2.Database) (ports.RateLimiter, error) {
+	cfg := config.GetRateLimitConfig()
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	switch cfg.Backend {
+	case "memory":
+		return ratelimit.NewMemoryLimiter(), nil
+	case "mongo":
+		if memoryStorage() {
+			return nil, errors.New("ratelimit.backend mongo requires storage.driver mongo")
+		}
+		return ratelimit.NewMongoLimiter(db), nil
+	default:
+		return nil, fmt.Errorf("unsupported rate limit backend %q", cfg.Backend)
+	}
+}
+
+// ProvideTokenVerifier returns nil when authentication is disabled
+func ProvideTokenVerifier() (ports.TokenVerifier, error) {
+	cfg := config.GetAuthConfig()
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	opts := auth.Options{
+		Issuer:   cfg.Issuer,
+		Audience: cfg.Audience,
+		Leeway:   cfg.Leeway,
+	}
+
+	switch cfg.Algorithm {
+	case "HS256":
+		return auth.NewHMACVerifier(cfg.Secret, opts)
+	case "RS256":
+		return auth.NewJWKSVerifier(cfg.JWKSFile, opts)
+	default:
+		return nil, fmt.Errorf("unsupported auth algorithm %q", cfg.Algorithm)
+	}
+}
+
+// ProvideRabbitMQConnection returns nil when messages go to the in-process broker
 func ProvideRabbitMQConnection(logger *zap.Logger) (*rabbitmq.RabbitMQConnection, error) {
+	switch driver := config.GetBrokerConfig().Driver; driver {
+	case "rabbitmq":
+	case "memory":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unsupported broker driver %q", driver)
+	}
+
 	cfg := config.GetRabbitMQConfig()
 
 	port, err := strconv.Atoi(cfg.Port)
@@ -154,9 +547,47 @@ func ProvideRabbitMQConnection(logger *zap.Logger) (*rabbitmq.RabbitMQConnection
 }
 
 func ProvidePublishedOrderRepository(db *mongo2.Database, logger *zap.Logger) ports.PublishedOrderRepository {
+	if memoryStorage() {
+		return memory.NewPublishedOrderRepository()
+	}
 	return mongo3.NewPublishedOrderRepository(db, logger)
 }
 
+// ProvideMessageProducer publishes to the in-process broker when broker.driver is memory
 func ProvideMessageProducer(rabbitConn *rabbitmq.RabbitMQConnection, publishedOrderRepo ports.PublishedOrderRepository, logger *zap.Logger) (ports.MessageProducer, error) {
+	cfg := config.GetBrokerConfig()
+	if cfg.Driver == "memory" {
+		logger.Info("Publishing to the in-process broker", zap.Int("queue_capacity", cfg.QueueCapacity))
+		return producers.NewInProcessProducer(inprocess.NewBroker(cfg.QueueCapacity), publishedOrderRepo, logger), nil
+	}
 	return producers.NewOrderProducer(rabbitConn, publishedOrderRepo, logger)
 }
+
+func ProvideExchangeRateProvider(logger *zap.Logger) (ports.ExchangeRateProvider, error) {
+	cfg := config.GetExchangeConfig()
+
+	switch cfg.Provider {
+	case "file":
+		return exchangerate.NewFileProvider(cfg.RatesFile)
+	case "http":
+		return exchangerate.NewHTTPProvider(cfg.HTTPURL, cfg.BaseCurrency, cfg.HTTPTimeout, cfg.CacheTTL, logger), nil
+	default:
+		return exchangerate.NewStaticProvider(cfg.BaseCurrency, cfg.Rates), nil
+	}
+}
+
+func ProvideTaxCalculator() ports.TaxCalculator {
+	cfg := config.GetTaxConfig()
+
+	rules := make([]tax.Rule, len(cfg.Rules))
+	for i, rule := range cfg.Rules {
+		rules[i] = tax.Rule{
+			TaxClass:  rule.TaxClass,
+			Region:    rule.Region,
+			Rate:      rule.Rate,
+			Inclusive: rule.Inclusive,
+		}
+	}
+
+	return tax.NewRuleTableCalculator(cfg.DefaultRegion, rules)
+}
@@ -0,0 +1,172 @@
+package inprocess
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// ErrQueueFull is returned when a queue already holds as many messages as it can
+var ErrQueueFull = errors.New("queue is full")
+
+// Broker is an in-process stand-in for RabbitMQ, for local development and tests.
+// Messages are routed to the queue named after their routing key, as the bindings
+// of the orders exchange do, and wait there until they are consumed. Deliveries are
+// amqp.Delivery values, so consumers written against RabbitMQ consume them
+// unchanged. Nack and Reject with requeue put the message back at the end of its
+// queue; without requeue the message is dropped, there are no dead letter queues.
+type Broker struct {
+	mu       sync.Mutex
+	capacity int
+	queues   map[string]*queue
+}
+
+// queue holds the messages of a routing key and the deliveries not acknowledged yet
+type queue struct {
+	name       string
+	deliveries chan amqp.Delivery
+
+	mu      sync.Mutex
+	lastTag uint64
+	unacked map[uint64]amqp.Delivery
+}
+
+// NewBroker creates a broker whose queues hold up to capacity messages each
+func NewBroker(capacity int) *Broker {
+	return &Broker{
+		capacity: capacity,
+		queues:   make(map[string]*queue),
+	}
+}
+
+// Publish routes the message to the queue of key, failing with ErrQueueFull when
+// the queue is full
+func (b *Broker) Publish(ctx context.Context, exchange, key string, msg amqp.Publishing) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	timestamp := msg.Timestamp
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+
+	return b.queue(key).push(amqp.Delivery{
+		Headers:         msg.Headers,
+		ContentType:     msg.ContentType,
+		ContentEncoding: msg.ContentEncoding,
+		DeliveryMode:    msg.DeliveryMode,
+		Priority:        msg.Priority,
+		CorrelationId:   msg.CorrelationId,
+		ReplyTo:         msg.ReplyTo,
+		Expiration:      msg.Expiration,
+		MessageId:       msg.MessageId,
+		Timestamp:       timestamp,
+		Type:            msg.Type,
+		UserId:          msg.UserId,
+		AppId:           msg.AppId,
+		Exchange:        exchange,
+		RoutingKey:      key,
+		Body:            msg.Body,
+	})
+}
+
+// Consume returns the deliveries of the queue name. Consumers of the same queue
+// share its messages, each message going to one of them.
+func (b *Broker) Consume(name string) <-chan amqp.Delivery {
+	return b.queue(name).deliveries
+}
+
+// Len returns how many messages wait in the queue name
+func (b *Broker) Len(name string) int {
+	return len(b.queue(name).deliveries)
+}
+
+func (b *Broker) queue(name string) *queue {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	q, ok := b.queues[name]
+	if !ok {
+		q = &queue{
+			name:       name,
+			deliveries: make(chan amqp.Delivery, b.capacity),
+			unacked:    make(map[uint64]amqp.Delivery),
+		}
+		b.queues[name] = q
+	}
+	return q
+}
+
+// push tags the delivery and enqueues it, keeping it until it is acknowledged
+func (q *queue) push(delivery amqp.Delivery) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.lastTag++
+	delivery.Acknowledger = q
+	delivery.DeliveryTag = q.lastTag
+
+	select {
+	case q.deliveries <- delivery:
+		q.unacked[delivery.DeliveryTag] = delivery
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", ErrQueueFull, q.name)
+	}
+}
+
+func (q *queue) Ack(tag uint64, multiple bool) error {
+	_, err := q.settle(tag, multiple)
+	return err
+}
+
+func (q *queue) Nack(tag uint64, multiple bool, requeue bool) error {
+	settled, err := q.settle(tag, multiple)
+	if err != nil || !requeue {
+		return err
+	}
+
+	for _, delivery := range settled {
+		delivery.Redelivered = true
+		if err := q.push(delivery); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (q *queue) Reject(tag uint64, requeue bool) error {
+	return q.Nack(tag, false, requeue)
+}
+
+// settle removes the delivery tag, or every delivery up to tag when multiple, from
+// the unacknowledged ones and returns them in tag order
+func (q *queue) settle(tag uint64, multiple bool) ([]amqp.Delivery, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if !multiple {
+		delivery, ok := q.unacked[tag]
+		if !ok {
+			return nil, fmt.Errorf("unknown delivery tag %d on queue %s", tag, q.name)
+		}
+		delete(q.unacked, tag)
+		return []amqp.Delivery{delivery}, nil
+	}
+
+	settled := make([]amqp.Delivery, 0)
+	for t, delivery := range q.unacked {
+		if t <= tag {
+			settled = append(settled, delivery)
+			delete(q.unacked, t)
+		}
+	}
+	sort.Slice(settled, func(i, j int) bool { return settled[i].DeliveryTag < settled[j].DeliveryTag })
+	return settled, nil
+}
@@ -0,0 +1,42 @@
+package inprocess_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gvillela7/rank-my-app/inprocess"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+func TestBroker_RequeuesNackedMessages(t *testing.T) {
+	broker := inprocess.NewBroker(1)
+	ctx := context.Background()
+
+	if err := broker.Publish(ctx, "orders", "order-status", amqp.Publishing{Body: []byte("1")}); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if err := broker.Publish(ctx, "orders", "order-status", amqp.Publishing{Body: []byte("2")}); !errors.Is(err, inprocess.ErrQueueFull) {
+		t.Fatalf("Expected ErrQueueFull, got: %v", err)
+	}
+
+	delivery := <-broker.Consume("order-status")
+	if err := delivery.Nack(false, true); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	redelivered := <-broker.Consume("order-status")
+	if !redelivered.Redelivered || string(redelivered.Body) != "1" {
+		t.Errorf("Expected message 1 to be redelivered, got %+v", redelivered)
+	}
+
+	if err := redelivered.Ack(false); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if err := redelivered.Ack(false); err == nil {
+		t.Error("Expected a second ack of the delivery to fail")
+	}
+	if broker.Len("order-status") != 0 {
+		t.Errorf("Expected an empty queue, got %d messages", broker.Len("order-status"))
+	}
+}
@@ -0,0 +1,5 @@
+module github.com/gvillela7/rank-my-app/inprocess
+
+go 1.25.3
+
+require github.com/rabbitmq/amqp091-go v1.10.0
@@ -40,12 +40,33 @@ func main() {
 			logger.Error("Failed to close consumer", zap.Error(err))
 		}
 
-		if err := app.DB.Disconnect(shutdownCtx); err != nil {
-			logger.Error("Failed to disconnect from MongoDB", zap.Error(err))
+		if err := app.ShipmentConsumer.Close(); err != nil {
+			logger.Error("Failed to close shipment consumer", zap.Error(err))
 		}
 
-		if err := app.RabbitMQConn.Close(shutdownCtx); err != nil {
-			logger.Error("Failed to close RabbitMQ connection", zap.Error(err))
+		if err := app.ReturnConsumer.Close(); err != nil {
+			logger.Error("Failed to close return consumer", zap.Error(err))
+		}
+
+		if err := app.StockConsumer.Close(); err != nil {
+			logger.Error("Failed to close stock consumer", zap.Error(err))
+		}
+
+		if err := app.AdminServer.Shutdown(shutdownCtx); err != nil {
+			logger.Error("Failed to shutdown admin API", zap.Error(err))
+		}
+
+		// nil with the memory storage and broker drivers
+		if app.DB != nil {
+			if err := app.DB.Disconnect(shutdownCtx); err != nil {
+				logger.Error("Failed to disconnect from MongoDB", zap.Error(err))
+			}
+		}
+
+		if app.RabbitMQConn != nil {
+			if err := app.RabbitMQConn.Close(shutdownCtx); err != nil {
+				logger.Error("Failed to close RabbitMQ connection", zap.Error(err))
+			}
 		}
 
 		logger.Info("All resources closed")
@@ -65,6 +86,45 @@ func main() {
 		}
 	}()
 
+	go func() {
+		logger.Info("Starting shipment consumer...")
+		if err := app.ShipmentConsumer.ConsumeShipmentEvents(consumerCtx); err != nil {
+			if err == context.Canceled {
+				logger.Info("Shipment consumer stopped by context cancellation")
+			} else {
+				logger.Error("Shipment consumer error", zap.Error(err))
+			}
+		}
+	}()
+
+	go func() {
+		logger.Info("Starting return consumer...")
+		if err := app.ReturnConsumer.ConsumeReturnEvents(consumerCtx); err != nil {
+			if err == context.Canceled {
+				logger.Info("Return consumer stopped by context cancellation")
+			} else {
+				logger.Error("Return consumer error", zap.Error(err))
+			}
+		}
+	}()
+
+	go func() {
+		logger.Info("Starting stock consumer...")
+		if err := app.StockConsumer.ConsumeStockAlerts(consumerCtx); err != nil {
+			if err == context.Canceled {
+				logger.Info("Stock consumer stopped by context cancellation")
+			} else {
+				logger.Error("Stock consumer error", zap.Error(err))
+			}
+		}
+	}()
+
+	go func() {
+		if err := app.AdminServer.Start(); err != nil {
+			logger.Error("Admin API error", zap.Error(err))
+		}
+	}()
+
 	logger.Info("Manager Status Consumer is running. Press Ctrl+C to stop.")
 
 	quit := make(chan os.Signal, 1)
@@ -2,6 +2,7 @@ package config
 
 import (
 	"errors"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -12,6 +13,10 @@ type config struct {
 	API      APIConfig
 	DBMongo  DBMongo
 	RabbitMQ RabbitMQConfig
+	Payment  PaymentConfig
+	Admin    AdminConfig
+	Storage  StorageConfig
+	Broker   BrokerConfig
 }
 
 type APIConfig struct {
@@ -25,8 +30,25 @@ type APIConfig struct {
 }
 
 type DBMongo struct {
-	URI      string
-	Database string
+	URI                    string
+	Database               string
+	MaxPoolSize            uint64
+	MinPoolSize            uint64
+	MaxConnIdleTime        time.Duration
+	ConnectTimeout         time.Duration
+	ServerSelectionTimeout time.Duration
+	SocketTimeout          time.Duration
+	OperationTimeout       time.Duration
+	ReadPreference         string
+	WriteConcern           string
+	TLS                    MongoTLSConfig
+}
+
+type MongoTLSConfig struct {
+	Enabled  bool
+	CAFile   string
+	CertFile string
+	KeyFile  string
 }
 
 type RabbitMQConfig struct {
@@ -37,6 +59,30 @@ type RabbitMQConfig struct {
 	VHost    string
 }
 
+type PaymentConfig struct {
+	Provider         string
+	FakeDeclineAbove float64
+}
+
+// AdminConfig holds the bearer tokens of the admin API by tenant. Each token
+// reads the data of its tenant only; without tokens every admin route answers 401.
+type AdminConfig struct {
+	Tokens map[string]string
+}
+
+// StorageConfig selects where the repositories keep their documents: "mongo" or
+// "memory", which needs no database and loses everything on exit
+type StorageConfig struct {
+	Driver string
+}
+
+// BrokerConfig selects where the consumers read messages from: "rabbitmq" or
+// "memory", an in-process broker holding up to QueueCapacity messages per queue
+type BrokerConfig struct {
+	Driver        string
+	QueueCapacity int
+}
+
 func init() {
 	//Service
 	viper.SetDefault("api.port", "8000")
@@ -49,6 +95,19 @@ func init() {
 	//MongoDB
 	viper.SetDefault("mongo.uri", "")
 	viper.SetDefault("mongo.database", "")
+	viper.SetDefault("mongo.max_pool_size", 100)
+	viper.SetDefault("mongo.min_pool_size", 0)
+	viper.SetDefault("mongo.max_conn_idle_time", "5m")
+	viper.SetDefault("mongo.connect_timeout", "10s")
+	viper.SetDefault("mongo.server_selection_timeout", "10s")
+	viper.SetDefault("mongo.socket_timeout", "30s")
+	viper.SetDefault("mongo.operation_timeout", "10s")
+	viper.SetDefault("mongo.read_preference", "primary")
+	viper.SetDefault("mongo.write_concern", "")
+	viper.SetDefault("mongo.tls.enabled", false)
+	viper.SetDefault("mongo.tls.ca_file", "")
+	viper.SetDefault("mongo.tls.cert_file", "")
+	viper.SetDefault("mongo.tls.key_file", "")
 
 	//RabbitMQ
 	viper.SetDefault("rabbitmq.host", "localhost")
@@ -57,6 +116,15 @@ func init() {
 	viper.SetDefault("rabbitmq.password", "guest")
 	viper.SetDefault("rabbitmq.vhost", "/")
 
+	//Payment
+	viper.SetDefault("payment.provider", "fake")
+	viper.SetDefault("payment.fake_decline_above", 0)
+
+	//Drivers
+	viper.SetDefault("storage.driver", "mongo")
+	viper.SetDefault("broker.driver", "rabbitmq")
+	viper.SetDefault("broker.queue_capacity", 1000)
+
 }
 
 func Load(viperPath ...string) error {
@@ -88,8 +156,23 @@ func Load(viperPath ...string) error {
 	}
 
 	cfg.DBMongo = DBMongo{
-		URI:      viper.GetString("mongo.uri"),
-		Database: viper.GetString("mongo.database"),
+		URI:                    viper.GetString("mongo.uri"),
+		Database:               viper.GetString("mongo.database"),
+		MaxPoolSize:            viper.GetUint64("mongo.max_pool_size"),
+		MinPoolSize:            viper.GetUint64("mongo.min_pool_size"),
+		MaxConnIdleTime:        viper.GetDuration("mongo.max_conn_idle_time"),
+		ConnectTimeout:         viper.GetDuration("mongo.connect_timeout"),
+		ServerSelectionTimeout: viper.GetDuration("mongo.server_selection_timeout"),
+		SocketTimeout:          viper.GetDuration("mongo.socket_timeout"),
+		OperationTimeout:       viper.GetDuration("mongo.operation_timeout"),
+		ReadPreference:         viper.GetString("mongo.read_preference"),
+		WriteConcern:           viper.GetString("mongo.write_concern"),
+		TLS: MongoTLSConfig{
+			Enabled:  viper.GetBool("mongo.tls.enabled"),
+			CAFile:   viper.GetString("mongo.tls.ca_file"),
+			CertFile: viper.GetString("mongo.tls.cert_file"),
+			KeyFile:  viper.GetString("mongo.tls.key_file"),
+		},
 	}
 
 	cfg.RabbitMQ = RabbitMQConfig{
@@ -100,6 +183,24 @@ func Load(viperPath ...string) error {
 		VHost:    viper.GetString("rabbitmq.vhost"),
 	}
 
+	cfg.Payment = PaymentConfig{
+		Provider:         viper.GetString("payment.provider"),
+		FakeDeclineAbove: viper.GetFloat64("payment.fake_decline_above"),
+	}
+
+	cfg.Admin = AdminConfig{
+		Tokens: viper.GetStringMapString("admin.tokens"),
+	}
+
+	cfg.Storage = StorageConfig{
+		Driver: viper.GetString("storage.driver"),
+	}
+
+	cfg.Broker = BrokerConfig{
+		Driver:        viper.GetString("broker.driver"),
+		QueueCapacity: viper.GetInt("broker.queue_capacity"),
+	}
+
 	return nil
 }
 
@@ -114,3 +215,19 @@ func GetDBMongo() DBMongo {
 func GetRabbitMQConfig() RabbitMQConfig {
 	return cfg.RabbitMQ
 }
+
+func GetPaymentConfig() PaymentConfig {
+	return cfg.Payment
+}
+
+func GetAdminConfig() AdminConfig {
+	return cfg.Admin
+}
+
+func GetStorageConfig() StorageConfig {
+	return cfg.Storage
+}
+
+func GetBrokerConfig() BrokerConfig {
+	return cfg.Broker
+}
@@ -0,0 +1,43 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// orderRepository records in the audit trail the status changes made through the
+// wrapped repository. Reads pass through.
+type orderRepository struct {
+	ports.OrderRepository
+	recorder
+}
+
+func NewOrderRepository(repository ports.OrderRepository, auditRepository ports.AuditRepository, logger *zap.Logger) ports.OrderRepository {
+	return &orderRepository{
+		OrderRepository: repository,
+		recorder:        recorder{repository: auditRepository, logger: logger},
+	}
+}
+
+func (r *orderRepository) UpdateStatus(ctx context.Context, id primitive.ObjectID, status string) error {
+	before := r.current(ctx, id)
+	if err := r.OrderRepository.UpdateStatus(ctx, id, status); err != nil {
+		return err
+	}
+
+	r.record(ctx, domain.AuditOrderStatusUpdated, domain.AuditResourceOrder, id.Hex(), before, r.current(ctx, id))
+	return nil
+}
+
+// current returns the stored order, nil when it cannot be read
+func (r *orderRepository) current(ctx context.Context, id primitive.ObjectID) *domain.Order {
+	order, err := r.OrderRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil
+	}
+	return order
+}
@@ -0,0 +1,34 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.uber.org/zap"
+)
+
+// paymentRepository records in the audit trail the payments saved through the
+// wrapped repository. Reads pass through.
+type paymentRepository struct {
+	ports.PaymentRepository
+	recorder
+}
+
+func NewPaymentRepository(repository ports.PaymentRepository, auditRepository ports.AuditRepository, logger *zap.Logger) ports.PaymentRepository {
+	return &paymentRepository{
+		PaymentRepository: repository,
+		recorder:          recorder{repository: auditRepository, logger: logger},
+	}
+}
+
+func (r *paymentRepository) Save(ctx context.Context, payment *domain.Payment) error {
+	// a failed read leaves the previous payment unknown, as if there was none
+	before, _ := r.PaymentRepository.FindByOrderID(ctx, payment.OrderID)
+	if err := r.PaymentRepository.Save(ctx, payment); err != nil {
+		return err
+	}
+
+	r.record(ctx, domain.AuditPaymentSaved, domain.AuditResourcePayment, payment.ID.Hex(), before, payment)
+	return nil
+}
@@ -0,0 +1,141 @@
+package admin
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.uber.org/zap"
+)
+
+// response mirrors the envelope used by the api-orders HTTP API
+type response struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data,omitempty"`
+	Message string      `json:"message,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// Server is the read-only admin API of manager-status. Admin routes require a
+// bearer token of tokens, keyed by tenant, and serve the data of that tenant.
+type Server struct {
+	httpServer   *http.Server
+	tokens       map[string]string
+	alertUseCase ports.StockAlertUseCase
+	logger       *zap.Logger
+}
+
+// NewServer creates the admin API listening on addr
+func NewServer(addr string, tokens map[string]string, alertUseCase ports.StockAlertUseCase, logger *zap.Logger) *Server {
+	s := &Server{
+		tokens:       tokens,
+		alertUseCase: alertUseCase,
+		logger:       logger,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /health", s.health)
+	mux.HandleFunc("GET /admin/stock-alerts", s.authenticate(s.listStockAlerts))
+
+	s.httpServer = &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	return s
+}
+
+// Handler returns the handler of the admin routes
+func (s *Server) Handler() http.Handler {
+	return s.httpServer.Handler
+}
+
+// Start serves requests until Shutdown is called
+func (s *Server) Start() error {
+	s.logger.Info("Starting admin API", zap.String("addr", s.httpServer.Addr))
+
+	if err := s.httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Shutdown stops the server, waiting for in-flight requests
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.httpServer.Shutdown(ctx)
+}
+
+// authenticate scopes the request to the tenant of its bearer token, answering
+// 401 Unauthorized to requests without a known token
+func (s *Server) authenticate(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		tenantID := ""
+		if ok && token != "" {
+			tenantID = s.tenantOf(token)
+		}
+
+		if tenantID == "" {
+			s.logger.Warn("Rejected unauthenticated admin request", zap.String("path", r.URL.Path))
+			w.Header().Set("WWW-Authenticate", `Bearer realm="manager-status"`)
+			s.write(w, http.StatusUnauthorized, response{
+				Success: false,
+				Message: "Missing or invalid admin token",
+			})
+			return
+		}
+
+		next(w, r.WithContext(domain.ContextWithTenant(r.Context(), tenantID)))
+	}
+}
+
+// tenantOf returns the tenant of token, comparing every token in constant time
+func (s *Server) tenantOf(token string) string {
+	tenantID := ""
+	for tenant, expected := range s.tokens {
+		if expected != "" && subtle.ConstantTimeCompare([]byte(token), []byte(expected)) == 1 {
+			tenantID = tenant
+		}
+	}
+	return tenantID
+}
+
+func (s *Server) health(w http.ResponseWriter, r *http.Request) {
+	s.write(w, http.StatusOK, response{Success: true, Message: "Service is healthy"})
+}
+
+// listStockAlerts returns the most recent stock alerts, filtered by the product_id query parameter
+func (s *Server) listStockAlerts(w http.ResponseWriter, r *http.Request) {
+	productID := r.URL.Query().Get("product_id")
+
+	alerts, err := s.alertUseCase.ListAlerts(r.Context(), productID)
+	if err != nil {
+		s.logger.Error("Failed to list stock alerts", zap.Error(err))
+		s.write(w, http.StatusInternalServerError, response{
+			Success: false,
+			Message: "Failed to list stock alerts",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	s.write(w, http.StatusOK, response{
+		Success: true,
+		Data:    alerts,
+		Message: "Stock alerts retrieved successfully",
+	})
+}
+
+func (s *Server) write(w http.ResponseWriter, status int, body response) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		s.logger.Error("Failed to write response", zap.Error(err))
+	}
+}
@@ -0,0 +1,60 @@
+package admin_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gvillela7/rank-my-app/internal/adapter/http/admin"
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/dto"
+	"go.uber.org/zap"
+)
+
+type mockStockAlertUseCase struct {
+	tenants []string
+}
+
+func (m *mockStockAlertUseCase) ProcessStockAlert(ctx context.Context, message *dto.StockAlertMessage) error {
+	return nil
+}
+
+func (m *mockStockAlertUseCase) ListAlerts(ctx context.Context, productID string) ([]dto.StockAlertResponse, error) {
+	tenantID, _ := domain.TenantFromContext(ctx)
+	m.tenants = append(m.tenants, tenantID)
+	return []dto.StockAlertResponse{}, nil
+}
+
+func TestServer_StockAlertsRequireTenantToken(t *testing.T) {
+	useCase := &mockStockAlertUseCase{}
+	server := admin.NewServer(":0", map[string]string{"loja-a": "token-a", "loja-b": "token-b"}, useCase, zap.NewNop())
+
+	for _, authorization := range []string{"", "Bearer", "Bearer token-c", "token-a"} {
+		req := httptest.NewRequest(http.MethodGet, "/admin/stock-alerts", nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		rec := httptest.NewRecorder()
+		server.Handler().ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("expected 401 for Authorization %q, got %d", authorization, rec.Code)
+		}
+	}
+	if len(useCase.tenants) != 0 {
+		t.Fatalf("expected no alert listed without a valid token, got %v", useCase.tenants)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/stock-alerts", nil)
+	req.Header.Set("Authorization", "Bearer token-b")
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	if len(useCase.tenants) != 1 || useCase.tenants[0] != "loja-b" {
+		t.Errorf("expected the alerts of loja-b listed, got %v", useCase.tenants)
+	}
+}
@@ -0,0 +1,185 @@
+package consumers
+
+import (
+	"fmt"
+
+	"github.com/gvillela7/rank-my-app/inprocess"
+	"github.com/gvillela7/rank-my-app/internal/infra/rabbitmq"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.uber.org/zap"
+)
+
+// Broker declares the queues of the consumers and delivers their messages. It is
+// RabbitMQ, or the in-process broker when broker.driver is memory.
+type Broker interface {
+	declare(spec queueSpec) error
+	consume(spec queueSpec) (<-chan amqp.Delivery, error)
+	cancel(consumerTag string) error
+}
+
+type rabbitMQBroker struct {
+	rabbitMQConn *rabbitmq.RabbitMQConnection
+	logger       *zap.Logger
+}
+
+// NewRabbitMQBroker creates a Broker consuming from RabbitMQ
+func NewRabbitMQBroker(rabbitMQConn *rabbitmq.RabbitMQConnection, logger *zap.Logger) Broker {
+	return &rabbitMQBroker{
+		rabbitMQConn: rabbitMQConn,
+		logger:       logger,
+	}
+}
+
+// declare declares exchange, queue, and bindings
+func (b *rabbitMQBroker) declare(spec queueSpec) error {
+	channel, err := b.rabbitMQConn.GetChannel()
+	if err != nil {
+		return fmt.Errorf("failed to get channel: %w", err)
+	}
+
+	err = channel.ExchangeDeclare(
+		exchangeName,
+		exchangeType,
+		true,
+		false,
+		false,
+		false,
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to declare exchange: %w", err)
+	}
+
+	err = channel.ExchangeDeclare(
+		spec.dlx,
+		"fanout",
+		true,
+		false,
+		false,
+		false,
+		nil,
+	)
+	if err != nil {
+		b.logger.Warn("Failed to declare DLX exchange (may already exist)", zap.Error(err))
+	} else {
+		b.logger.Info("Dead Letter Exchange declared", zap.String("exchange", spec.dlx))
+	}
+
+	dlq := spec.queue + ".dlq"
+	_, err = channel.QueueDeclare(
+		dlq,
+		true,
+		false,
+		false,
+		false,
+		nil,
+	)
+	if err != nil {
+		b.logger.Warn("Failed to declare DLQ (may already exist)", zap.Error(err))
+	} else {
+		err = channel.QueueBind(
+			dlq,
+			"",
+			spec.dlx,
+			false,
+			nil,
+		)
+		if err != nil {
+			b.logger.Warn("Failed to bind DLQ to DLX", zap.Error(err))
+		} else {
+			b.logger.Info("Dead Letter Queue bound to DLX", zap.String("queue", dlq))
+		}
+	}
+
+	args := amqp.Table{
+		"x-dead-letter-exchange": spec.dlx,
+	}
+
+	_, err = channel.QueueDeclare(
+		spec.queue,
+		true,
+		false,
+		false,
+		false,
+		args,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to declare queue: %w", err)
+	}
+
+	err = channel.QueueBind(
+		spec.queue,
+		spec.routingKey,
+		exchangeName,
+		false,
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to bind queue: %w", err)
+	}
+
+	b.logger.Info("Queue bound to exchange",
+		zap.String("queue", spec.queue),
+		zap.String("exchange", exchangeName),
+		zap.String("routing_key", spec.routingKey),
+	)
+
+	return nil
+}
+
+// consume starts consuming messages from the queue, one unacknowledged at a time
+func (b *rabbitMQBroker) consume(spec queueSpec) (<-chan amqp.Delivery, error) {
+	channel, err := b.rabbitMQConn.GetChannel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get channel: %w", err)
+	}
+
+	if err := channel.Qos(1, 0, false); err != nil {
+		return nil, fmt.Errorf("failed to set QoS: %w", err)
+	}
+
+	deliveries, err := channel.Consume(
+		spec.queue,
+		spec.consumerTag,
+		false,
+		false,
+		false,
+		false,
+		nil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start consuming: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+func (b *rabbitMQBroker) cancel(consumerTag string) error {
+	channel, err := b.rabbitMQConn.GetChannel()
+	if err != nil {
+		return fmt.Errorf("failed to get channel: %w", err)
+	}
+	return channel.Cancel(consumerTag, false)
+}
+
+type inProcessBroker struct {
+	broker *inprocess.Broker
+}
+
+// NewInProcessBroker creates a Broker consuming from the in-process broker. Its
+// queues need no declaring and messages rejected without requeue are dropped.
+func NewInProcessBroker(broker *inprocess.Broker) Broker {
+	return &inProcessBroker{broker: broker}
+}
+
+func (b *inProcessBroker) declare(spec queueSpec) error {
+	return nil
+}
+
+func (b *inProcessBroker) consume(spec queueSpec) (<-chan amqp.Delivery, error) {
+	return b.broker.Consume(spec.queue), nil
+}
+
+func (b *inProcessBroker) cancel(consumerTag string) error {
+	return nil
+}
@@ -0,0 +1,70 @@
+package consumers_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gvillela7/rank-my-app/inprocess"
+	"github.com/gvillela7/rank-my-app/internal/adapter/messages/consumers"
+	"github.com/gvillela7/rank-my-app/internal/adapter/payment"
+	"github.com/gvillela7/rank-my-app/internal/adapter/repository/memory"
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/dto"
+	"github.com/gvillela7/rank-my-app/internal/core/usecase"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+func TestOrderConsumer_AuthorizesPaymentOfCreatedOrders(t *testing.T) {
+	order := domain.Order{ID: primitive.NewObjectID(), TenantID: "acme", Total: 100, Currency: "BRL", Status: domain.OrderStatusCreated}
+	orders := memory.NewOrderRepository(order)
+	payments := memory.NewPaymentRepository()
+
+	broker := inprocess.NewBroker(10)
+	uc := usecase.NewOrderUseCase(orders, memory.NewPublishedOrderRepository(), payments, payment.NewFakeGateway(0), zap.NewNop())
+	consumer := consumers.NewOrderConsumer(consumers.NewInProcessBroker(broker), uc, zap.NewNop())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = consumer.ConsumeOrderStatus(ctx) }()
+
+	body, _ := json.Marshal(dto.OrderStatusMessage{OrderID: order.ID.Hex(), Status: domain.OrderStatusCreated, Timestamp: time.Now()})
+	// without tenant the message cannot be matched to the order and is dropped
+	if err := broker.Publish(ctx, "orders", "order-status", amqp.Publishing{Body: body}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	headers := amqp.Table{domain.TenantHeader: "acme"}
+	if err := broker.Publish(ctx, "orders", "order-status", amqp.Publishing{Headers: headers, Body: body}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tenantCtx := domain.ContextWithTenant(context.Background(), "acme")
+	deadline := time.Now().Add(time.Second)
+	for {
+		stored, err := orders.FindByID(tenantCtx, order.ID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if stored.Status == domain.OrderStatusProcessing {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected status %s, got %s", domain.OrderStatusProcessing, stored.Status)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	authorized, err := payments.FindByOrderID(tenantCtx, order.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if authorized == nil || authorized.Status != domain.PaymentStatusAuthorized || len(authorized.Attempts) != 1 {
+		t.Errorf("expected one authorized payment attempt, got %+v", authorized)
+	}
+	if broker.Len("order-status") != 0 {
+		t.Errorf("expected an empty queue, got %d messages", broker.Len("order-status"))
+	}
+}
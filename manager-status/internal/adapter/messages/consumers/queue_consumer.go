@@ -0,0 +1,173 @@
+package consumers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.uber.org/zap"
+)
+
+// queueSpec describes a queue bound to the orders exchange with its own dead letter exchange
+type queueSpec struct {
+	queue       string
+	routingKey  string
+	dlx         string
+	consumerTag string
+}
+
+// queueConsumer holds the plumbing shared by the consumers: it declares the queue and
+// its DLQ, consumes with manual acks and reconnects when the channel closes.
+// Every delivery is passed to handle, which must ack or nack it.
+type queueConsumer struct {
+	spec       queueSpec
+	broker     Broker
+	logger     *zap.Logger
+	handle     func(ctx context.Context, delivery amqp.Delivery)
+	mu         sync.Mutex
+	deliveries <-chan amqp.Delivery
+}
+
+// consume declares the infrastructure and processes messages until ctx is cancelled
+func (c *queueConsumer) consume(ctx context.Context) error {
+	c.logger.Info("Starting consumer",
+		zap.String("queue", c.spec.queue),
+		zap.String("exchange", exchangeName),
+	)
+
+	if err := c.broker.declare(c.spec); err != nil {
+		return fmt.Errorf("failed to setup infrastructure: %w", err)
+	}
+
+	if err := c.startConsuming(); err != nil {
+		return fmt.Errorf("failed to start consuming: %w", err)
+	}
+
+	c.logger.Info("Consumer is ready to process messages", zap.String("queue", c.spec.queue))
+	return c.processMessages(ctx)
+}
+
+// startConsuming starts consuming messages from the queue
+func (c *queueConsumer) startConsuming() error {
+	deliveries, err := c.broker.consume(c.spec)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.deliveries = deliveries
+	c.mu.Unlock()
+
+	c.logger.Info("Started consuming messages",
+		zap.String("consumer_tag", c.spec.consumerTag),
+	)
+
+	return nil
+}
+
+// processMessages processes incoming messages
+func (c *queueConsumer) processMessages(ctx context.Context) error {
+	c.mu.Lock()
+	deliveries := c.deliveries
+	c.mu.Unlock()
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.logger.Info("Context cancelled, stopping consumer", zap.String("queue", c.spec.queue))
+			return ctx.Err()
+
+		case delivery, ok := <-deliveries:
+			if !ok {
+				c.logger.Warn("Delivery channel closed, attempting to reconnect", zap.String("queue", c.spec.queue))
+
+				time.Sleep(5 * time.Second)
+
+				if err := c.startConsuming(); err != nil {
+					c.logger.Error("Failed to reconnect", zap.Error(err))
+					return fmt.Errorf("failed to reconnect: %w", err)
+				}
+
+				c.mu.Lock()
+				deliveries = c.deliveries
+				c.mu.Unlock()
+
+				continue
+			}
+
+			deliveryCtx, ok := deliveryContext(ctx, delivery)
+			if !ok {
+				rejectWithoutTenant(c.logger, delivery)
+				continue
+			}
+
+			c.handle(deliveryCtx, delivery)
+		}
+	}
+}
+
+// deliveryContext scopes ctx to the tenant api-orders sends in the tenant_id header,
+// so that the order repositories only touch the documents of that tenant. The
+// correlation ID, the X-Request-ID of the originating request, is carried along to
+// the audit trail.
+func deliveryContext(ctx context.Context, delivery amqp.Delivery) (context.Context, bool) {
+	tenantID, ok := delivery.Headers[domain.TenantHeader].(string)
+	if !ok || tenantID == "" {
+		return ctx, false
+	}
+
+	ctx = domain.ContextWithTenant(ctx, tenantID)
+	if delivery.CorrelationId != "" {
+		ctx = domain.ContextWithRequestID(ctx, delivery.CorrelationId)
+	}
+	return ctx, true
+}
+
+// rejectWithoutTenant sends to the DLQ a message without tenant, which cannot be
+// matched to any order
+func rejectWithoutTenant(logger *zap.Logger, delivery amqp.Delivery) {
+	logger.Error("Message without tenant, sending to DLQ",
+		zap.String("message_id", delivery.MessageId),
+		zap.String("routing_key", delivery.RoutingKey),
+	)
+	_ = delivery.Nack(false, false)
+}
+
+// settle acks the delivery, or nacks it when processing failed. Messages for unknown
+// orders go to the DLQ; any other error requeues the message.
+func (c *queueConsumer) settle(delivery amqp.Delivery, err error, fields ...zap.Field) {
+	if err != nil {
+		c.logger.Error("Failed to process message", append(fields, zap.Error(err))...)
+
+		if isOrderNotFoundError(err) {
+			c.logger.Warn("Order not found, sending to DLQ", fields...)
+			_ = delivery.Nack(false, false)
+		} else {
+			c.logger.Warn("Temporary error, requeuing message", fields...)
+			_ = delivery.Nack(false, true)
+		}
+		return
+	}
+
+	if err := delivery.Ack(false); err != nil {
+		c.logger.Error("Failed to acknowledge message", append(fields, zap.Error(err))...)
+		return
+	}
+
+	c.logger.Info("Message processed successfully", fields...)
+}
+
+// close cancels the consumer
+func (c *queueConsumer) close() error {
+	c.logger.Info("Closing consumer", zap.String("consumer_tag", c.spec.consumerTag))
+
+	if err := c.broker.cancel(c.spec.consumerTag); err != nil {
+		c.logger.Warn("Failed to cancel consumer", zap.Error(err))
+	}
+
+	c.logger.Info("Consumer closed", zap.String("consumer_tag", c.spec.consumerTag))
+	return nil
+}
@@ -0,0 +1,67 @@
+package consumers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gvillela7/rank-my-app/internal/core/dto"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.uber.org/zap"
+)
+
+type returnConsumer struct {
+	*queueConsumer
+	useCase ports.ReturnUseCase
+}
+
+// NewReturnConsumer creates a new instance of ReturnConsumer
+func NewReturnConsumer(
+	broker Broker,
+	useCase ports.ReturnUseCase,
+	logger *zap.Logger,
+) ports.ReturnConsumer {
+	c := &returnConsumer{useCase: useCase}
+	c.queueConsumer = &queueConsumer{
+		spec: queueSpec{
+			queue:       "order-return",
+			routingKey:  "order-return",
+			dlx:         "orders.return.dlx",
+			consumerTag: "manager-status-return-consumer",
+		},
+		broker: broker,
+		logger: logger,
+		handle: c.handleMessage,
+	}
+	return c
+}
+
+// ConsumeReturnEvents starts consuming messages from the order-return queue
+func (c *returnConsumer) ConsumeReturnEvents(ctx context.Context) error {
+	return c.consume(ctx)
+}
+
+// handleMessage processes a single message
+func (c *returnConsumer) handleMessage(ctx context.Context, delivery amqp.Delivery) {
+	var message dto.ReturnEventMessage
+	if err := json.Unmarshal(delivery.Body, &message); err != nil {
+		c.logger.Error("Failed to unmarshal message",
+			zap.Error(err),
+			zap.ByteString("body", delivery.Body),
+		)
+		_ = delivery.Nack(false, false)
+		return
+	}
+
+	err := c.useCase.ProcessReturnEvent(ctx, &message)
+	c.settle(delivery, err,
+		zap.String("event", message.Event),
+		zap.String("return_id", message.ReturnID),
+		zap.String("order_id", message.OrderID),
+	)
+}
+
+// Close gracefully shuts down the consumer
+func (c *returnConsumer) Close() error {
+	return c.close()
+}
@@ -0,0 +1,67 @@
+package consumers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gvillela7/rank-my-app/internal/core/dto"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.uber.org/zap"
+)
+
+type shipmentConsumer struct {
+	*queueConsumer
+	useCase ports.ShipmentUseCase
+}
+
+// NewShipmentConsumer creates a new instance of ShipmentConsumer
+func NewShipmentConsumer(
+	broker Broker,
+	useCase ports.ShipmentUseCase,
+	logger *zap.Logger,
+) ports.ShipmentConsumer {
+	c := &shipmentConsumer{useCase: useCase}
+	c.queueConsumer = &queueConsumer{
+		spec: queueSpec{
+			queue:       "order-shipment",
+			routingKey:  "order-shipment",
+			dlx:         "orders.shipment.dlx",
+			consumerTag: "manager-status-shipment-consumer",
+		},
+		broker: broker,
+		logger: logger,
+		handle: c.handleMessage,
+	}
+	return c
+}
+
+// ConsumeShipmentEvents starts consuming messages from the order-shipment queue
+func (c *shipmentConsumer) ConsumeShipmentEvents(ctx context.Context) error {
+	return c.consume(ctx)
+}
+
+// handleMessage processes a single message
+func (c *shipmentConsumer) handleMessage(ctx context.Context, delivery amqp.Delivery) {
+	var message dto.ShipmentEventMessage
+	if err := json.Unmarshal(delivery.Body, &message); err != nil {
+		c.logger.Error("Failed to unmarshal message",
+			zap.Error(err),
+			zap.ByteString("body", delivery.Body),
+		)
+		_ = delivery.Nack(false, false)
+		return
+	}
+
+	err := c.useCase.ProcessShipmentEvent(ctx, &message)
+	c.settle(delivery, err,
+		zap.String("event", message.Event),
+		zap.String("shipment_id", message.ShipmentID),
+		zap.String("order_id", message.OrderID),
+	)
+}
+
+// Close gracefully shuts down the consumer
+func (c *shipmentConsumer) Close() error {
+	return c.close()
+}
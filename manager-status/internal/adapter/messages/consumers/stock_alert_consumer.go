@@ -0,0 +1,67 @@
+package consumers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gvillela7/rank-my-app/internal/core/dto"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.uber.org/zap"
+)
+
+type stockAlertConsumer struct {
+	*queueConsumer
+	useCase ports.StockAlertUseCase
+}
+
+// NewStockAlertConsumer creates a new instance of StockAlertConsumer
+func NewStockAlertConsumer(
+	broker Broker,
+	useCase ports.StockAlertUseCase,
+	logger *zap.Logger,
+) ports.StockAlertConsumer {
+	c := &stockAlertConsumer{useCase: useCase}
+	c.queueConsumer = &queueConsumer{
+		spec: queueSpec{
+			queue:       "product-stock",
+			routingKey:  "product-stock",
+			dlx:         "orders.stock.dlx",
+			consumerTag: "manager-status-stock-consumer",
+		},
+		broker: broker,
+		logger: logger,
+		handle: c.handleMessage,
+	}
+	return c
+}
+
+// ConsumeStockAlerts starts consuming messages from the product-stock queue
+func (c *stockAlertConsumer) ConsumeStockAlerts(ctx context.Context) error {
+	return c.consume(ctx)
+}
+
+// handleMessage processes a single message
+func (c *stockAlertConsumer) handleMessage(ctx context.Context, delivery amqp.Delivery) {
+	var message dto.StockAlertMessage
+	if err := json.Unmarshal(delivery.Body, &message); err != nil {
+		c.logger.Error("Failed to unmarshal message",
+			zap.Error(err),
+			zap.ByteString("body", delivery.Body),
+		)
+		_ = delivery.Nack(false, false)
+		return
+	}
+
+	err := c.useCase.ProcessStockAlert(ctx, &message)
+	c.settle(delivery, err,
+		zap.String("event", message.Event),
+		zap.String("product_id", message.ProductID),
+		zap.String("movement_id", message.MovementID),
+	)
+}
+
+// Close gracefully shuts down the consumer
+func (c *stockAlertConsumer) Close() error {
+	return c.close()
+}
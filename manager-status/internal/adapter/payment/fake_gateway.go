@@ -0,0 +1,76 @@
+package payment
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+)
+
+const (
+	FakeGatewayName = "fake"
+
+	fakeTransactionPrefix = "fake_"
+)
+
+type fakeGateway struct {
+	declineAbove float64
+}
+
+// NewFakeGateway creates an in-process gateway for local, dev and test environments.
+// It is deterministic: authorizations are approved unless the amount is not positive
+// or exceeds declineAbove (0 disables the limit), and transaction IDs derive from the order ID.
+func NewFakeGateway(declineAbove float64) ports.PaymentGateway {
+	return &fakeGateway{
+		declineAbove: declineAbove,
+	}
+}
+
+func (g *fakeGateway) Name() string {
+	return FakeGatewayName
+}
+
+func (g *fakeGateway) Authorize(ctx context.Context, orderID string, amount float64, currency string) (*domain.PaymentResult, error) {
+	if amount <= 0 {
+		return &domain.PaymentResult{Reason: "invalid_amount"}, nil
+	}
+
+	if g.declineAbove > 0 && amount > g.declineAbove {
+		return &domain.PaymentResult{Reason: "insufficient_funds"}, nil
+	}
+
+	return &domain.PaymentResult{
+		Approved:      true,
+		TransactionID: fmt.Sprintf("%sauth_%s", fakeTransactionPrefix, orderID),
+	}, nil
+}
+
+func (g *fakeGateway) Capture(ctx context.Context, transactionID string, amount float64) (*domain.PaymentResult, error) {
+	return g.settle(transactionID, amount)
+}
+
+func (g *fakeGateway) Void(ctx context.Context, transactionID string) (*domain.PaymentResult, error) {
+	if !strings.HasPrefix(transactionID, fakeTransactionPrefix) {
+		return &domain.PaymentResult{Reason: "unknown_transaction"}, nil
+	}
+
+	return &domain.PaymentResult{Approved: true, TransactionID: transactionID}, nil
+}
+
+func (g *fakeGateway) Refund(ctx context.Context, transactionID string, amount float64) (*domain.PaymentResult, error) {
+	return g.settle(transactionID, amount)
+}
+
+func (g *fakeGateway) settle(transactionID string, amount float64) (*domain.PaymentResult, error) {
+	if !strings.HasPrefix(transactionID, fakeTransactionPrefix) {
+		return &domain.PaymentResult{Reason: "unknown_transaction"}, nil
+	}
+
+	if amount <= 0 {
+		return &domain.PaymentResult{Reason: "invalid_amount"}, nil
+	}
+
+	return &domain.PaymentResult{Approved: true, TransactionID: transactionID}, nil
+}
@@ -0,0 +1,38 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// auditRepository only inserts: the audit trail is append-only
+type auditRepository struct {
+	table *table[domain.AuditEntry]
+}
+
+// NewAuditRepository creates a new instance of AuditRepository
+func NewAuditRepository() ports.AuditRepository {
+	return &auditRepository{
+		table: newTenantTable[domain.AuditEntry](),
+	}
+}
+
+// Append stores an entry in the audit trail of the tenant of ctx
+func (r *auditRepository) Append(ctx context.Context, entry *domain.AuditEntry) error {
+	tenantID, err := r.table.tenant(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to append audit entry: %w", err)
+	}
+
+	entry.ID = primitive.NewObjectID()
+	entry.TenantID = tenantID
+
+	if err := r.table.insert(tenantID, entry); err != nil {
+		return fmt.Errorf("failed to append audit entry: %w", err)
+	}
+	return nil
+}
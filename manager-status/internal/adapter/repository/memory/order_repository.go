@@ -0,0 +1,42 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type orderRepository struct {
+	table *table[domain.Order]
+}
+
+// NewOrderRepository creates an OrderRepository holding orders, each in the tenant
+// of its TenantID. Orders are created by api-orders, so a repository of its own
+// only has the orders given here.
+func NewOrderRepository(orders ...domain.Order) ports.OrderRepository {
+	r := &orderRepository{
+		table: newTenantTable[domain.Order](),
+	}
+	for _, order := range orders {
+		r.table.rows = append(r.table.rows, row[domain.Order]{tenant: order.TenantID, doc: &order})
+	}
+	return r
+}
+
+func (r *orderRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*domain.Order, error) {
+	return r.table.find(ctx, func(order *domain.Order) bool {
+		return order.ID == id
+	})
+}
+
+func (r *orderRepository) UpdateStatus(ctx context.Context, id primitive.ObjectID, status string) error {
+	return r.table.update(ctx, func(order *domain.Order) bool {
+		return order.ID == id
+	}, func(order *domain.Order) {
+		order.Status = status
+		order.UpdatedAt = time.Now()
+	})
+}
@@ -0,0 +1,48 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type paymentRepository struct {
+	table *table[domain.Payment]
+}
+
+// NewPaymentRepository creates a new instance of PaymentRepository
+func NewPaymentRepository() ports.PaymentRepository {
+	return &paymentRepository{
+		table: newTable[domain.Payment](),
+	}
+}
+
+// FindByOrderID retrieves the payment of an order, returning nil when there is none
+func (r *paymentRepository) FindByOrderID(ctx context.Context, orderID primitive.ObjectID) (*domain.Payment, error) {
+	payment, err := r.table.find(ctx, func(payment *domain.Payment) bool {
+		return payment.OrderID == orderID
+	})
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find payment: %w", err)
+	}
+	return payment, nil
+}
+
+// Save creates or replaces a payment record
+func (r *paymentRepository) Save(ctx context.Context, payment *domain.Payment) error {
+	err := r.table.upsert(ctx, payment, func(existing *domain.Payment) bool {
+		return existing.ID == payment.ID
+	}, false)
+	if err != nil {
+		return fmt.Errorf("failed to save payment: %w", err)
+	}
+	return nil
+}
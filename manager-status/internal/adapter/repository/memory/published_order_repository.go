@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type publishedOrderRepository struct {
+	table *table[domain.PublishedOrder]
+}
+
+// NewPublishedOrderRepository creates a new instance of PublishedOrderRepository
+func NewPublishedOrderRepository() ports.PublishedOrderRepository {
+	return &publishedOrderRepository{
+		table: newTenantTable[domain.PublishedOrder](),
+	}
+}
+
+func (r *publishedOrderRepository) Create(ctx context.Context, publishedOrder *domain.PublishedOrder) error {
+	tenantID, err := r.table.tenant(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create published order record: %w", err)
+	}
+	publishedOrder.TenantID = tenantID
+
+	if err := r.table.insert(tenantID, publishedOrder); err != nil {
+		return fmt.Errorf("failed to create published order record: %w", err)
+	}
+	return nil
+}
+
+// FindByOrderID returns nil when the order has no published order record
+func (r *publishedOrderRepository) FindByOrderID(ctx context.Context, orderID primitive.ObjectID) (*domain.PublishedOrder, error) {
+	publishedOrder, err := r.table.find(ctx, byPublishedOrderID(orderID))
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find published order record: %w", err)
+	}
+	return publishedOrder, nil
+}
+
+func (r *publishedOrderRepository) UpdatePublishedStatus(ctx context.Context, orderID primitive.ObjectID, published bool) error {
+	err := r.table.update(ctx, byPublishedOrderID(orderID), func(publishedOrder *domain.PublishedOrder) {
+		publishedOrder.Published = published
+		publishedOrder.PublishedAt = time.Now()
+	})
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return fmt.Errorf("no published order record found for order_id: %s", orderID.Hex())
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update published order status: %w", err)
+	}
+	return nil
+}
+
+func byPublishedOrderID(orderID primitive.ObjectID) func(publishedOrder *domain.PublishedOrder) bool {
+	return func(publishedOrder *domain.PublishedOrder) bool { return publishedOrder.OrderID == orderID }
+}
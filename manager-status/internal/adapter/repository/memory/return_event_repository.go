@@ -0,0 +1,32 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+)
+
+type returnEventRepository struct {
+	table *table[domain.ReturnEvent]
+}
+
+// NewReturnEventRepository creates a new instance of ReturnEventRepository
+func NewReturnEventRepository() ports.ReturnEventRepository {
+	return &returnEventRepository{
+		table: newTable[domain.ReturnEvent](),
+	}
+}
+
+// Save stores a return event. Events are keyed by return and event type, so a
+// redelivered message does not create a duplicate record.
+func (r *returnEventRepository) Save(ctx context.Context, event *domain.ReturnEvent) error {
+	err := r.table.upsert(ctx, event, func(existing *domain.ReturnEvent) bool {
+		return existing.ReturnID == event.ReturnID && existing.Event == event.Event
+	}, true)
+	if err != nil {
+		return fmt.Errorf("failed to save return event: %w", err)
+	}
+	return nil
+}
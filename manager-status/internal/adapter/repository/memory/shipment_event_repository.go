@@ -0,0 +1,32 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+)
+
+type shipmentEventRepository struct {
+	table *table[domain.ShipmentEvent]
+}
+
+// NewShipmentEventRepository creates a new instance of ShipmentEventRepository
+func NewShipmentEventRepository() ports.ShipmentEventRepository {
+	return &shipmentEventRepository{
+		table: newTable[domain.ShipmentEvent](),
+	}
+}
+
+// Save stores a shipment event. Events are keyed by shipment and event type, so a
+// redelivered message does not create a duplicate record.
+func (r *shipmentEventRepository) Save(ctx context.Context, event *domain.ShipmentEvent) error {
+	err := r.table.upsert(ctx, event, func(existing *domain.ShipmentEvent) bool {
+		return existing.ShipmentID == event.ShipmentID && existing.Event == event.Event
+	}, true)
+	if err != nil {
+		return fmt.Errorf("failed to save shipment event: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+)
+
+type stockAlertRepository struct {
+	table *table[domain.StockAlert]
+}
+
+// NewStockAlertRepository creates a new instance of StockAlertRepository
+func NewStockAlertRepository() ports.StockAlertRepository {
+	return &stockAlertRepository{
+		table: newTenantTable[domain.StockAlert](),
+	}
+}
+
+// Save stores a stock alert. Alerts are keyed by the stock movement that raised them,
+// so a redelivered message does not create a duplicate record.
+func (r *stockAlertRepository) Save(ctx context.Context, alert *domain.StockAlert) error {
+	tenantID, err := r.table.tenant(ctx)
+	if err != nil {
+		return err
+	}
+	alert.TenantID = tenantID
+
+	err = r.table.upsert(ctx, alert, func(existing *domain.StockAlert) bool {
+		return existing.MovementID == alert.MovementID && existing.Event == alert.Event
+	}, true)
+	if err != nil {
+		return fmt.Errorf("failed to save stock alert: %w", err)
+	}
+	return nil
+}
+
+// List returns the most recent alerts first, optionally filtered by product
+func (r *stockAlertRepository) List(ctx context.Context, productID string, limit int64) ([]domain.StockAlert, error) {
+	alerts, err := r.table.filter(ctx, func(alert *domain.StockAlert) bool {
+		return productID == "" || alert.ProductID == productID
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(alerts, func(i, j int) bool { return alerts[i].ReceivedAt.After(alerts[j].ReceivedAt) })
+	if limit > 0 && int64(len(alerts)) > limit {
+		alerts = alerts[:limit]
+	}
+	return alerts, nil
+}
@@ -0,0 +1,173 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// table holds the documents of a collection in insertion order. Documents are
+// copied through BSON on the way in and out, so callers never share memory with
+// the table. The documents of a tenant-owned table are restricted to the tenant
+// of the context, like tenantFilter does, and its operations fail with
+// domain.ErrMissingTenant when the context carries none. Not found is
+// mongo.ErrNoDocuments, as from the MongoDB repositories.
+type table[T any] struct {
+	mu      sync.RWMutex
+	tenants bool
+	rows    []row[T]
+}
+
+type row[T any] struct {
+	tenant string
+	doc    *T
+}
+
+func newTable[T any]() *table[T] {
+	return &table[T]{}
+}
+
+func newTenantTable[T any]() *table[T] {
+	return &table[T]{tenants: true}
+}
+
+// tenant returns the tenant of ctx, which repositories stamp on the documents
+// they insert. Tables that are not tenant-owned have no tenant.
+func (t *table[T]) tenant(ctx context.Context) (string, error) {
+	if !t.tenants {
+		return "", nil
+	}
+	tenantID, ok := domain.TenantFromContext(ctx)
+	if !ok {
+		return "", domain.ErrMissingTenant
+	}
+	return tenantID, nil
+}
+
+// insert appends doc to the documents of tenantID
+func (t *table[T]) insert(tenantID string, doc *T) error {
+	stored, err := clone(doc)
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.rows = append(t.rows, row[T]{tenant: tenantID, doc: stored})
+	return nil
+}
+
+// upsert replaces the first document of the tenant that matches with doc, or
+// appends doc when none does. With keep, a matching document is left as it is,
+// as $setOnInsert does.
+func (t *table[T]) upsert(ctx context.Context, doc *T, match func(doc *T) bool, keep bool) error {
+	tenantID, err := t.tenant(ctx)
+	if err != nil {
+		return err
+	}
+	stored, err := clone(doc)
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for i, r := range t.rows {
+		if r.tenant == tenantID && match(r.doc) {
+			if !keep {
+				t.rows[i].doc = stored
+			}
+			return nil
+		}
+	}
+
+	t.rows = append(t.rows, row[T]{tenant: tenantID, doc: stored})
+	return nil
+}
+
+// find returns the first document of the tenant that matches
+func (t *table[T]) find(ctx context.Context, match func(doc *T) bool) (*T, error) {
+	tenantID, err := t.tenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	for _, r := range t.rows {
+		if r.tenant == tenantID && match(r.doc) {
+			return clone(r.doc)
+		}
+	}
+	return nil, mongo.ErrNoDocuments
+}
+
+// filter returns the documents of the tenant that match, in insertion order
+func (t *table[T]) filter(ctx context.Context, match func(doc *T) bool) ([]T, error) {
+	tenantID, err := t.tenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	docs := make([]T, 0)
+	for _, r := range t.rows {
+		if r.tenant != tenantID || !match(r.doc) {
+			continue
+		}
+		doc, err := clone(r.doc)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, *doc)
+	}
+	return docs, nil
+}
+
+// update applies change to the first document of the tenant that matches
+func (t *table[T]) update(ctx context.Context, match func(doc *T) bool, change func(doc *T)) error {
+	tenantID, err := t.tenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for i, r := range t.rows {
+		if r.tenant != tenantID || !match(r.doc) {
+			continue
+		}
+
+		updated, err := clone(r.doc)
+		if err != nil {
+			return err
+		}
+		change(updated)
+		t.rows[i].doc = updated
+		return nil
+	}
+	return mongo.ErrNoDocuments
+}
+
+// clone copies doc through BSON
+func clone[T any](doc *T) (*T, error) {
+	data, err := bson.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	var copied T
+	if err := bson.Unmarshal(data, &copied); err != nil {
+		return nil, err
+	}
+	return &copied, nil
+}
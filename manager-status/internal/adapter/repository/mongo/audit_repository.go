@@ -0,0 +1,42 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// auditRepository only inserts: the audit trail is append-only
+type auditRepository struct {
+	collection *mongo.Collection
+}
+
+// NewAuditRepository creates a new instance of AuditRepository
+func NewAuditRepository(db *mongo.Database) ports.AuditRepository {
+	return &auditRepository{
+		collection: db.Collection("audit_log"),
+	}
+}
+
+// Append stores an entry in the audit trail of the tenant of ctx
+func (r *auditRepository) Append(ctx context.Context, entry *domain.AuditEntry) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tenantID, ok := domain.TenantFromContext(ctx)
+	if !ok {
+		return fmt.Errorf("failed to append audit entry: %w", domain.ErrMissingTenant)
+	}
+
+	entry.ID = primitive.NewObjectID()
+	entry.TenantID = tenantID
+
+	if _, err := r.collection.InsertOne(ctx, entry); err != nil {
+		return fmt.Errorf("failed to append audit entry: %w", err)
+	}
+	return nil
+}
@@ -9,9 +9,15 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
 	"go.uber.org/zap"
 )
 
+// orderWrites are acknowledged once a majority of the replica set has them, so a
+// failover cannot roll back an order the client was told about
+var orderWrites = options.Collection().SetWriteConcern(writeconcern.Majority())
+
 type orderRepository struct {
 	collection *mongo.Collection
 	logger     *zap.Logger
@@ -19,18 +25,26 @@ type orderRepository struct {
 
 func NewOrderRepository(db *mongo.Database, logger *zap.Logger) ports.OrderRepository {
 	return &orderRepository{
-		collection: db.Collection("orders"),
+		collection: db.Collection("orders", orderWrites),
 		logger:     logger,
 	}
 }
 
 func (r *orderRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*domain.Order, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
 	r.logger.Info("Finding order by ID",
 		zap.String("order_id", id.Hex()),
 	)
 
+	filter, err := tenantFilter(ctx, bson.M{"_id": id})
+	if err != nil {
+		return nil, err
+	}
+
 	var order domain.Order
-	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&order)
+	err = r.collection.FindOne(ctx, filter).Decode(&order)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			r.logger.Warn("Order not found",
@@ -54,19 +68,29 @@ func (r *orderRepository) FindByID(ctx context.Context, id primitive.ObjectID) (
 }
 
 func (r *orderRepository) UpdateStatus(ctx context.Context, id primitive.ObjectID, status string) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
 	r.logger.Info("Updating order status",
 		zap.String("order_id", id.Hex()),
 		zap.String("new_status", status),
 	)
 
+	// the version is shared with api-orders, where it backs the order ETag
 	update := bson.M{
 		"$set": bson.M{
 			"status":     status,
 			"updated_at": time.Now(),
 		},
+		"$inc": bson.M{"version": 1},
+	}
+
+	filter, err := tenantFilter(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
 	}
 
-	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	result, err := r.collection.UpdateOne(ctx, filter, update)
 	if err != nil {
 		r.logger.Error("Failed to update order status",
 			zap.String("order_id", id.Hex()),
@@ -0,0 +1,74 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+type paymentRepository struct {
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewPaymentRepository creates a new instance of PaymentRepository
+func NewPaymentRepository(db *mongo.Database, logger *zap.Logger) ports.PaymentRepository {
+	return &paymentRepository{
+		collection: db.Collection("payments"),
+		logger:     logger,
+	}
+}
+
+// FindByOrderID retrieves the payment of an order, returning nil when there is none
+func (r *paymentRepository) FindByOrderID(ctx context.Context, orderID primitive.ObjectID) (*domain.Payment, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	var payment domain.Payment
+	err := r.collection.FindOne(ctx, bson.M{"order_id": orderID}).Decode(&payment)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+
+		r.logger.Error("Failed to find payment",
+			zap.String("order_id", orderID.Hex()),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to find payment: %w", err)
+	}
+
+	return &payment, nil
+}
+
+// Save creates or replaces a payment record
+func (r *paymentRepository) Save(ctx context.Context, payment *domain.Payment) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	opts := options.Replace().SetUpsert(true)
+
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": payment.ID}, payment, opts)
+	if err != nil {
+		r.logger.Error("Failed to save payment",
+			zap.String("order_id", payment.OrderID.Hex()),
+			zap.String("status", payment.Status),
+			zap.Error(err),
+		)
+		return fmt.Errorf("failed to save payment: %w", err)
+	}
+
+	r.logger.Info("Payment saved successfully",
+		zap.String("order_id", payment.OrderID.Hex()),
+		zap.String("status", payment.Status),
+	)
+
+	return nil
+}
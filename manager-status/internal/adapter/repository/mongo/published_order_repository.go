@@ -28,12 +28,21 @@ func NewPublishedOrderRepository(db *mongo.Database, logger *zap.Logger) ports.P
 
 // Create saves a new published order record to MongoDB
 func (r *publishedOrderRepository) Create(ctx context.Context, publishedOrder *domain.PublishedOrder) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
 	r.logger.Info("Creating published order record",
 		zap.String("order_id", publishedOrder.OrderID.Hex()),
 		zap.Bool("published", publishedOrder.Published),
 		zap.String("order_status", publishedOrder.OrderStatus),
 	)
 
+	tenantID, ok := domain.TenantFromContext(ctx)
+	if !ok {
+		return fmt.Errorf("failed to create published order record: %w", domain.ErrMissingTenant)
+	}
+	publishedOrder.TenantID = tenantID
+
 	_, err := r.collection.InsertOne(ctx, publishedOrder)
 	if err != nil {
 		r.logger.Error("Failed to create published order record",
@@ -53,14 +62,20 @@ func (r *publishedOrderRepository) Create(ctx context.Context, publishedOrder *d
 
 // FindByOrderID retrieves a published order record by order ID
 func (r *publishedOrderRepository) FindByOrderID(ctx context.Context, orderID primitive.ObjectID) (*domain.PublishedOrder, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
 	r.logger.Info("Finding published order record by order ID",
 		zap.String("order_id", orderID.Hex()),
 	)
 
-	var publishedOrder domain.PublishedOrder
-	filter := bson.M{"order_id": orderID}
+	filter, err := tenantFilter(ctx, bson.M{"order_id": orderID})
+	if err != nil {
+		return nil, err
+	}
 
-	err := r.collection.FindOne(ctx, filter).Decode(&publishedOrder)
+	var publishedOrder domain.PublishedOrder
+	err = r.collection.FindOne(ctx, filter).Decode(&publishedOrder)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			r.logger.Info("Published order record not found",
@@ -86,12 +101,19 @@ func (r *publishedOrderRepository) FindByOrderID(ctx context.Context, orderID pr
 
 // UpdatePublishedStatus updates the published status of a published order record
 func (r *publishedOrderRepository) UpdatePublishedStatus(ctx context.Context, orderID primitive.ObjectID, published bool) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
 	r.logger.Info("Updating published order status",
 		zap.String("order_id", orderID.Hex()),
 		zap.Bool("published", published),
 	)
 
-	filter := bson.M{"order_id": orderID}
+	filter, err := tenantFilter(ctx, bson.M{"order_id": orderID})
+	if err != nil {
+		return err
+	}
+
 	update := bson.M{
 		"$set": bson.M{
 			"published":    published,
@@ -0,0 +1,64 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+type returnEventRepository struct {
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewReturnEventRepository creates a new instance of ReturnEventRepository
+func NewReturnEventRepository(db *mongo.Database, logger *zap.Logger) ports.ReturnEventRepository {
+	return &returnEventRepository{
+		collection: db.Collection("return_events"),
+		logger:     logger,
+	}
+}
+
+// Save stores a return event. Events are keyed by return and event type, so a
+// redelivered message does not create a duplicate record.
+func (r *returnEventRepository) Save(ctx context.Context, event *domain.ReturnEvent) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	r.logger.Info("Saving return event",
+		zap.String("event", event.Event),
+		zap.String("return_id", event.ReturnID),
+		zap.String("order_id", event.OrderID.Hex()),
+	)
+
+	filter := bson.M{
+		"return_id": event.ReturnID,
+		"event":     event.Event,
+	}
+	update := bson.M{"$setOnInsert": event}
+	opts := options.Update().SetUpsert(true)
+
+	result, err := r.collection.UpdateOne(ctx, filter, update, opts)
+	if err != nil {
+		r.logger.Error("Failed to save return event",
+			zap.String("return_id", event.ReturnID),
+			zap.Error(err),
+		)
+		return fmt.Errorf("failed to save return event: %w", err)
+	}
+
+	if result.UpsertedCount == 0 {
+		r.logger.Info("Return event already recorded",
+			zap.String("event", event.Event),
+			zap.String("return_id", event.ReturnID),
+		)
+	}
+
+	return nil
+}
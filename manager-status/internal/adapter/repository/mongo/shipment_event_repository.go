@@ -0,0 +1,64 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+type shipmentEventRepository struct {
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewShipmentEventRepository creates a new instance of ShipmentEventRepository
+func NewShipmentEventRepository(db *mongo.Database, logger *zap.Logger) ports.ShipmentEventRepository {
+	return &shipmentEventRepository{
+		collection: db.Collection("shipment_events"),
+		logger:     logger,
+	}
+}
+
+// Save stores a shipment event. Events are keyed by shipment and event type, so a
+// redelivered message does not create a duplicate record.
+func (r *shipmentEventRepository) Save(ctx context.Context, event *domain.ShipmentEvent) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	r.logger.Info("Saving shipment event",
+		zap.String("event", event.Event),
+		zap.String("shipment_id", event.ShipmentID),
+		zap.String("order_id", event.OrderID.Hex()),
+	)
+
+	filter := bson.M{
+		"shipment_id": event.ShipmentID,
+		"event":       event.Event,
+	}
+	update := bson.M{"$setOnInsert": event}
+	opts := options.Update().SetUpsert(true)
+
+	result, err := r.collection.UpdateOne(ctx, filter, update, opts)
+	if err != nil {
+		r.logger.Error("Failed to save shipment event",
+			zap.String("shipment_id", event.ShipmentID),
+			zap.Error(err),
+		)
+		return fmt.Errorf("failed to save shipment event: %w", err)
+	}
+
+	if result.UpsertedCount == 0 {
+		r.logger.Info("Shipment event already recorded",
+			zap.String("event", event.Event),
+			zap.String("shipment_id", event.ShipmentID),
+		)
+	}
+
+	return nil
+}
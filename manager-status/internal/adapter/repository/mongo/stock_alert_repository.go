@@ -0,0 +1,96 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+type stockAlertRepository struct {
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewStockAlertRepository creates a new instance of StockAlertRepository
+func NewStockAlertRepository(db *mongo.Database, logger *zap.Logger) ports.StockAlertRepository {
+	return &stockAlertRepository{
+		collection: db.Collection("stock_alerts"),
+		logger:     logger,
+	}
+}
+
+// Save stores a stock alert. Alerts are keyed by the stock movement that raised them,
+// so a redelivered message does not create a duplicate record.
+func (r *stockAlertRepository) Save(ctx context.Context, alert *domain.StockAlert) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	r.logger.Info("Saving stock alert",
+		zap.String("event", alert.Event),
+		zap.String("product_id", alert.ProductID),
+		zap.String("movement_id", alert.MovementID),
+	)
+
+	filter, err := tenantFilter(ctx, bson.M{
+		"movement_id": alert.MovementID,
+		"event":       alert.Event,
+	})
+	if err != nil {
+		return err
+	}
+	alert.TenantID, _ = domain.TenantFromContext(ctx)
+	update := bson.M{"$setOnInsert": alert}
+	opts := options.Update().SetUpsert(true)
+
+	result, err := r.collection.UpdateOne(ctx, filter, update, opts)
+	if err != nil {
+		r.logger.Error("Failed to save stock alert",
+			zap.String("product_id", alert.ProductID),
+			zap.Error(err),
+		)
+		return fmt.Errorf("failed to save stock alert: %w", err)
+	}
+
+	if result.UpsertedCount == 0 {
+		r.logger.Info("Stock alert already recorded",
+			zap.String("event", alert.Event),
+			zap.String("movement_id", alert.MovementID),
+		)
+	}
+
+	return nil
+}
+
+// List returns the most recent alerts first, optionally filtered by product
+func (r *stockAlertRepository) List(ctx context.Context, productID string, limit int64) ([]domain.StockAlert, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	filter, err := tenantFilter(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	if productID != "" {
+		filter["product_id"] = productID
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "received_at", Value: -1}}).SetLimit(limit)
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stock alerts: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	alerts := make([]domain.StockAlert, 0)
+	if err := cursor.All(ctx, &alerts); err != nil {
+		return nil, fmt.Errorf("failed to decode stock alerts: %w", err)
+	}
+
+	return alerts, nil
+}
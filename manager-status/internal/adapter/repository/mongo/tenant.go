@@ -0,0 +1,20 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// tenantFilter restricts filter to the tenant of ctx, failing with
+// domain.ErrMissingTenant when ctx carries none. Every query on the orders and
+// published orders of api-orders and on the stock alerts goes through it.
+func tenantFilter(ctx context.Context, filter bson.M) (bson.M, error) {
+	tenantID, ok := domain.TenantFromContext(ctx)
+	if !ok {
+		return nil, domain.ErrMissingTenant
+	}
+	filter["tenant_id"] = tenantID
+	return filter, nil
+}
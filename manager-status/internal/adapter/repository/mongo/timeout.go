@@ -0,0 +1,24 @@
+package mongo
+
+import (
+	"context"
+	"time"
+)
+
+// operationTimeout bounds every repository call whose context has no earlier
+// deadline; zero leaves the calls unbounded
+var operationTimeout time.Duration
+
+// SetOperationTimeout sets the deadline of the repository calls. It is called once,
+// before the repositories serve any call.
+func SetOperationTimeout(timeout time.Duration) {
+	operationTimeout = timeout
+}
+
+// withTimeout bounds ctx by the deadline of a repository call
+func withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if operationTimeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, operationTimeout)
+}
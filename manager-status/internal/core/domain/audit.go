@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	AuditResourceOrder   = "order"
+	AuditResourcePayment = "payment"
+
+	AuditOrderStatusUpdated = "order.status_updated"
+	AuditPaymentSaved       = "payment.saved"
+
+	// consumer-driven changes are made by the system on behalf of api-orders events
+	AuditActorSystem     = "system"
+	AuditSourceConsumers = "manager-status"
+)
+
+// AuditChange is the value of a document field before and after a mutation. Before
+// is missing for created documents.
+type AuditChange struct {
+	Field  string      `bson:"field"`
+	Before interface{} `bson:"before,omitempty"`
+	After  interface{} `bson:"after,omitempty"`
+}
+
+// AuditEntry records a change in the audit trail shared with api-orders, which
+// serves it. Entries are append-only: they are never updated nor deleted.
+type AuditEntry struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty"`
+	TenantID     string             `bson:"tenant_id"`
+	Actor        string             `bson:"actor"`
+	Source       string             `bson:"source"`
+	Action       string             `bson:"action"`
+	ResourceType string             `bson:"resource_type"`
+	ResourceID   string             `bson:"resource_id"`
+	Changes      []AuditChange      `bson:"changes"`
+	RequestID    string             `bson:"request_id,omitempty"`
+	OccurredAt   time.Time          `bson:"occurred_at"`
+}
+
+// AuditDiff returns the fields whose values differ between two snapshots of a
+// document, sorted by name. A nil snapshot stands for a document that does not
+// exist. The update timestamp is left out, every mutation changes it.
+func AuditDiff(before, after map[string]interface{}) []AuditChange {
+	fields := make(map[string]struct{}, len(before)+len(after))
+	for field := range before {
+		fields[field] = struct{}{}
+	}
+	for field := range after {
+		fields[field] = struct{}{}
+	}
+	delete(fields, "updated_at")
+
+	changes := make([]AuditChange, 0)
+	for field := range fields {
+		previous, current := before[field], after[field]
+		if reflect.DeepEqual(previous, current) {
+			continue
+		}
+		changes = append(changes, AuditChange{Field: field, Before: previous, After: current})
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes
+}
+
+type requestIDKey struct{}
+
+// ContextWithRequestID returns a copy of ctx carrying the ID of the api-orders request
+// that published the message being processed
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request ID of ctx, empty when there is none
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
@@ -6,6 +6,14 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+const (
+	OrderStatusCreated         = "criado"
+	OrderStatusProcessing      = "em_processamento"
+	OrderStatusShipped         = "enviado"
+	OrderStatusDelivered       = "entregue"
+	OrderStatusPaymentDeclined = "pagamento_recusado"
+)
+
 type OrderItem struct {
 	ProductID   string  `bson:"product_id"`
 	ProductName string  `bson:"product_name"`
@@ -15,9 +23,11 @@ type OrderItem struct {
 
 type Order struct {
 	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	TenantID    string             `bson:"tenant_id"`
 	OrderNumber string             `bson:"order_number"`
 	Items       []OrderItem        `bson:"items"`
 	Total       float64            `bson:"total"`
+	Currency    string             `bson:"currency"`
 	Status      string             `bson:"status"`
 	CreatedAt   time.Time          `bson:"created_at"`
 	UpdatedAt   time.Time          `bson:"updated_at"`
@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	PaymentStatusAuthorized = "authorized"
+	PaymentStatusDeclined   = "declined"
+	PaymentStatusCaptured   = "captured"
+	PaymentStatusVoided     = "voided"
+	PaymentStatusRefunded   = "refunded"
+
+	PaymentOperationAuthorize = "authorize"
+	PaymentOperationCapture   = "capture"
+	PaymentOperationVoid      = "void"
+	PaymentOperationRefund    = "refund"
+)
+
+// PaymentResult is the answer of a payment gateway to an operation
+type PaymentResult struct {
+	Approved      bool
+	TransactionID string
+	Reason        string
+}
+
+// PaymentAttempt records a single call to the payment gateway
+type PaymentAttempt struct {
+	Operation     string    `bson:"operation"`
+	Amount        float64   `bson:"amount"`
+	Approved      bool      `bson:"approved"`
+	TransactionID string    `bson:"transaction_id,omitempty"`
+	Reason        string    `bson:"reason,omitempty"`
+	Reference     string    `bson:"reference,omitempty"`
+	AttemptedAt   time.Time `bson:"attempted_at"`
+}
+
+// Payment tracks the payment of an order and every gateway attempt made for it
+type Payment struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty"`
+	OrderID        primitive.ObjectID `bson:"order_id"`
+	Gateway        string             `bson:"gateway"`
+	Amount         float64            `bson:"amount"`
+	Currency       string             `bson:"currency"`
+	Status         string             `bson:"status"`
+	TransactionID  string             `bson:"transaction_id,omitempty"`
+	CapturedAmount float64            `bson:"captured_amount"`
+	RefundedAmount float64            `bson:"refunded_amount"`
+	Attempts       []PaymentAttempt   `bson:"attempts"`
+	CreatedAt      time.Time          `bson:"created_at"`
+	UpdatedAt      time.Time          `bson:"updated_at"`
+}
+
+// NewPayment creates a payment for an order that has not been sent to the gateway yet
+func NewPayment(orderID primitive.ObjectID, gateway string, amount float64, currency string) *Payment {
+	return &Payment{
+		ID:        primitive.NewObjectID(),
+		OrderID:   orderID,
+		Gateway:   gateway,
+		Amount:    amount,
+		Currency:  currency,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+}
+
+// Record appends the result of a gateway operation and moves the payment to status when approved.
+// A declined authorization moves the payment to declined; other declined operations keep the status.
+// reference identifies what triggered the operation, such as a return ID.
+func (p *Payment) Record(operation, reference string, amount float64, result *PaymentResult, status string) {
+	p.Attempts = append(p.Attempts, PaymentAttempt{
+		Operation:     operation,
+		Amount:        amount,
+		Approved:      result.Approved,
+		TransactionID: result.TransactionID,
+		Reason:        result.Reason,
+		Reference:     reference,
+		AttemptedAt:   time.Now(),
+	})
+	p.UpdatedAt = time.Now()
+
+	if result.Approved {
+		p.Status = status
+		if result.TransactionID != "" {
+			p.TransactionID = result.TransactionID
+		}
+	} else if operation == PaymentOperationAuthorize {
+		p.Status = PaymentStatusDeclined
+	}
+}
+
+// IsAuthorized reports whether the payment was authorized and can still be captured
+func (p *Payment) IsAuthorized() bool {
+	return p.Status == PaymentStatusAuthorized
+}
+
+// HasApproved reports whether an operation with reference was already approved
+func (p *Payment) HasApproved(operation, reference string) bool {
+	for _, attempt := range p.Attempts {
+		if attempt.Operation == operation && attempt.Reference == reference && attempt.Approved {
+			return true
+		}
+	}
+	return false
+}
+
+// Refundable returns how much of the captured amount can still be refunded
+func (p *Payment) Refundable() float64 {
+	return p.CapturedAmount - p.RefundedAmount
+}
@@ -9,6 +9,7 @@ import (
 // PublishedOrder represents a record of an order publication attempt to RabbitMQ
 type PublishedOrder struct {
 	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	TenantID    string             `bson:"tenant_id"`
 	OrderID     primitive.ObjectID `bson:"order_id"`
 	Published   bool               `bson:"published"`
 	OrderStatus string             `bson:"order_status"`
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	ReturnEventRequested = "return.requested"
+	ReturnEventApproved  = "return.approved"
+	ReturnEventRejected  = "return.rejected"
+)
+
+type ReturnEventItem struct {
+	ProductID    string  `bson:"product_id"`
+	Quantity     int     `bson:"quantity"`
+	Reason       string  `bson:"reason"`
+	RefundAmount float64 `bson:"refund_amount"`
+}
+
+// ReturnEvent represents a return lifecycle event received from api-orders
+type ReturnEvent struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	Event       string             `bson:"event"`
+	ReturnID    string             `bson:"return_id"`
+	OrderID     primitive.ObjectID `bson:"order_id"`
+	Items       []ReturnEventItem  `bson:"items"`
+	RefundTotal float64            `bson:"refund_total"`
+	Currency    string             `bson:"currency"`
+	Reason      string             `bson:"reason,omitempty"`
+	Timestamp   float64            `bson:"ts"`
+	ReceivedAt  time.Time          `bson:"received_at"`
+}
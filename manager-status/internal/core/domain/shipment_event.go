@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ShipmentEventItem struct {
+	ProductID string `bson:"product_id"`
+	Quantity  int    `bson:"quantity"`
+}
+
+// ShipmentEvent represents a shipment event received from api-orders, kept as the
+// tracking history of an order
+type ShipmentEvent struct {
+	ID           primitive.ObjectID  `bson:"_id,omitempty"`
+	Event        string              `bson:"event"`
+	ShipmentID   string              `bson:"shipment_id"`
+	OrderID      primitive.ObjectID  `bson:"order_id"`
+	OrderStatus  string              `bson:"order_status"`
+	Carrier      string              `bson:"carrier"`
+	TrackingCode string              `bson:"tracking_code"`
+	Items        []ShipmentEventItem `bson:"items"`
+	Timestamp    float64             `bson:"ts"`
+	ReceivedAt   time.Time           `bson:"received_at"`
+}
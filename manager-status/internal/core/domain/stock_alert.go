@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// StockAlert represents a low stock or out of stock event received from api-orders.
+// VariantID is set when a variant, not the whole product, crossed the threshold.
+type StockAlert struct {
+	ID               primitive.ObjectID `bson:"_id,omitempty"`
+	TenantID         string             `bson:"tenant_id"`
+	Event            string             `bson:"event"`
+	ProductID        string             `bson:"product_id"`
+	VariantID        string             `bson:"variant_id,omitempty"`
+	SKU              string             `bson:"sku,omitempty"`
+	ProductName      string             `bson:"product_name"`
+	Quantity         int                `bson:"quantity"`
+	ReorderThreshold int                `bson:"reorder_threshold"`
+	MovementID       string             `bson:"movement_id"`
+	Timestamp        float64            `bson:"ts"`
+	ReceivedAt       time.Time          `bson:"received_at"`
+}
@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrMissingTenant = errors.New("missing tenant")
+
+// TenantHeader is the AMQP message header in which api-orders sends the tenant of an event
+const TenantHeader = "tenant_id"
+
+type tenantKey struct{}
+
+// ContextWithTenant returns a copy of ctx scoped to the tenant. Order repositories
+// only read and update the documents of this tenant.
+func ContextWithTenant(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenantID)
+}
+
+// TenantFromContext returns the tenant ctx is scoped to, if any
+func TenantFromContext(ctx context.Context) (string, bool) {
+	tenantID, ok := ctx.Value(tenantKey{}).(string)
+	return tenantID, ok && tenantID != ""
+}
@@ -0,0 +1,21 @@
+package dto
+
+// ReturnItemMessage representa um item devolvido
+type ReturnItemMessage struct {
+	ProductID    string  `json:"product_id"`
+	Quantity     int     `json:"quantity"`
+	Reason       string  `json:"reason"`
+	RefundAmount float64 `json:"refund_amount"`
+}
+
+// ReturnEventMessage representa a mensagem recebida da fila RabbitMQ order-return
+type ReturnEventMessage struct {
+	Event       string              `json:"event" validate:"required,oneof=return.requested return.approved return.rejected"`
+	ReturnID    string              `json:"return_id" validate:"required"`
+	OrderID     string              `json:"order_id" validate:"required"`
+	Items       []ReturnItemMessage `json:"items"`
+	RefundTotal float64             `json:"refund_total"`
+	Currency    string              `json:"currency"`
+	Reason      string              `json:"reason,omitempty"`
+	Timestamp   float64             `json:"ts"`
+}
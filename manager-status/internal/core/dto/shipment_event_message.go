@@ -0,0 +1,19 @@
+package dto
+
+// ShipmentItemMessage representa um item enviado em um shipment
+type ShipmentItemMessage struct {
+	ProductID string `json:"product_id"`
+	Quantity  int    `json:"quantity"`
+}
+
+// ShipmentEventMessage representa a mensagem recebida da fila RabbitMQ order-shipment
+type ShipmentEventMessage struct {
+	Event        string                `json:"event" validate:"required,oneof=shipment.created shipment.delivered"`
+	ShipmentID   string                `json:"shipment_id" validate:"required"`
+	OrderID      string                `json:"order_id" validate:"required"`
+	OrderStatus  string                `json:"order_status"`
+	Carrier      string                `json:"carrier"`
+	TrackingCode string                `json:"tracking_code"`
+	Items        []ShipmentItemMessage `json:"items"`
+	Timestamp    float64               `json:"ts"`
+}
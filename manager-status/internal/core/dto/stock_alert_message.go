@@ -0,0 +1,31 @@
+package dto
+
+import "time"
+
+// StockAlertMessage representa a mensagem recebida da fila RabbitMQ product-stock
+type StockAlertMessage struct {
+	Event            string  `json:"event" validate:"required,oneof=product.low_stock product.out_of_stock"`
+	ProductID        string  `json:"product_id" validate:"required"`
+	VariantID        string  `json:"variant_id,omitempty"`
+	SKU              string  `json:"sku,omitempty"`
+	ProductName      string  `json:"product_name"`
+	Quantity         int     `json:"quantity"`
+	ReorderThreshold int     `json:"reorder_threshold"`
+	MovementID       string  `json:"movement_id" validate:"required"`
+	Timestamp        float64 `json:"ts"`
+}
+
+// StockAlertResponse represents a stock alert exposed by the admin API
+type StockAlertResponse struct {
+	ID               string    `json:"_id" example:"507f1f77bcf86cd799439011"`
+	Event            string    `json:"event" example:"product.low_stock"`
+	ProductID        string    `json:"product_id" example:"698c0a0893c94ce530171bbb"`
+	VariantID        string    `json:"variant_id,omitempty" example:"698c0a0893c94ce530171bbc"`
+	SKU              string    `json:"sku,omitempty" example:"MOUSE-RGB-PRETO"`
+	ProductName      string    `json:"product_name" example:"Mouse Gamer"`
+	Quantity         int       `json:"quantity" example:"4"`
+	ReorderThreshold int       `json:"reorder_threshold" example:"5"`
+	MovementID       string    `json:"movement_id" example:"507f1f77bcf86cd799439015"`
+	Timestamp        float64   `json:"ts" example:"1872367127.98399"`
+	ReceivedAt       time.Time `json:"received_at" example:"2025-02-11T10:30:00Z"`
+}
@@ -8,3 +8,24 @@ type MessageConsumer interface {
 
 	Close() error
 }
+
+// ShipmentConsumer defines the interface for consuming shipment events from a message broker
+type ShipmentConsumer interface {
+	ConsumeShipmentEvents(ctx context.Context) error
+
+	Close() error
+}
+
+// StockAlertConsumer defines the interface for consuming stock alerts from a message broker
+type StockAlertConsumer interface {
+	ConsumeStockAlerts(ctx context.Context) error
+
+	Close() error
+}
+
+// ReturnConsumer defines the interface for consuming return events from a message broker
+type ReturnConsumer interface {
+	ConsumeReturnEvents(ctx context.Context) error
+
+	Close() error
+}
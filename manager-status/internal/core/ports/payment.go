@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+)
+
+// PaymentGateway defines the operations of an external payment provider.
+// A declined operation is reported in the result; errors are reserved for
+// failures talking to the gateway, which may be retried.
+type PaymentGateway interface {
+	Name() string
+	Authorize(ctx context.Context, orderID string, amount float64, currency string) (*domain.PaymentResult, error)
+	Capture(ctx context.Context, transactionID string, amount float64) (*domain.PaymentResult, error)
+	Void(ctx context.Context, transactionID string) (*domain.PaymentResult, error)
+	Refund(ctx context.Context, transactionID string, amount float64) (*domain.PaymentResult, error)
+}
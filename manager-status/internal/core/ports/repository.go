@@ -17,3 +17,27 @@ type PublishedOrderRepository interface {
 	FindByOrderID(ctx context.Context, orderID primitive.ObjectID) (*domain.PublishedOrder, error)
 	UpdatePublishedStatus(ctx context.Context, orderID primitive.ObjectID, published bool) error
 }
+
+type ShipmentEventRepository interface {
+	Save(ctx context.Context, event *domain.ShipmentEvent) error
+}
+
+type PaymentRepository interface {
+	FindByOrderID(ctx context.Context, orderID primitive.ObjectID) (*domain.Payment, error)
+	Save(ctx context.Context, payment *domain.Payment) error
+}
+
+type ReturnEventRepository interface {
+	Save(ctx context.Context, event *domain.ReturnEvent) error
+}
+
+type StockAlertRepository interface {
+	Save(ctx context.Context, alert *domain.StockAlert) error
+	List(ctx context.Context, productID string, limit int64) ([]domain.StockAlert, error)
+}
+
+// AuditRepository appends to the audit trail. It is append-only: entries are never
+// updated nor deleted.
+type AuditRepository interface {
+	Append(ctx context.Context, entry *domain.AuditEntry) error
+}
@@ -9,3 +9,16 @@ import (
 type OrderUseCase interface {
 	ProcessOrderStatusMessage(ctx context.Context, message *dto.OrderStatusMessage) error
 }
+
+type ShipmentUseCase interface {
+	ProcessShipmentEvent(ctx context.Context, message *dto.ShipmentEventMessage) error
+}
+
+type ReturnUseCase interface {
+	ProcessReturnEvent(ctx context.Context, message *dto.ReturnEventMessage) error
+}
+
+type StockAlertUseCase interface {
+	ProcessStockAlert(ctx context.Context, message *dto.StockAlertMessage) error
+	ListAlerts(ctx context.Context, productID string) ([]dto.StockAlertResponse, error)
+}
@@ -17,17 +17,23 @@ import (
 type orderUseCase struct {
 	repository               ports.OrderRepository
 	publishedOrderRepository ports.PublishedOrderRepository
+	paymentRepository        ports.PaymentRepository
+	paymentGateway           ports.PaymentGateway
 	logger                   *zap.Logger
 }
 
 func NewOrderUseCase(
 	repository ports.OrderRepository,
 	publishedOrderRepository ports.PublishedOrderRepository,
+	paymentRepository ports.PaymentRepository,
+	paymentGateway ports.PaymentGateway,
 	logger *zap.Logger,
 ) ports.OrderUseCase {
 	return &orderUseCase{
 		repository:               repository,
 		publishedOrderRepository: publishedOrderRepository,
+		paymentRepository:        paymentRepository,
+		paymentGateway:           paymentGateway,
 		logger:                   logger,
 	}
 }
@@ -79,14 +85,31 @@ func (uc *orderUseCase) ProcessOrderStatusMessage(ctx context.Context, message *
 		zap.String("message_status", message.Status),
 	)
 
-	// Transform status: "criada" or "criado" -> "em_processamento"
-	if message.Status == "criada" || message.Status == "criado" {
-		newStatus = "em_processamento"
-		uc.logger.Info("Transforming status to 'em_processamento'",
+	// Authorize payment for new orders: "criada" or "criado" -> "em_processamento" or "pagamento_recusado"
+	switch message.Status {
+	case "criada", domain.OrderStatusCreated:
+		if order.Status != domain.OrderStatusCreated {
+			// a redelivered message must not move the order back
+			uc.logger.Info("Order already left criado, ignoring message",
+				zap.String("order_id", message.OrderID),
+				zap.String("current_status", order.Status),
+			)
+			return nil
+		}
+		newStatus, err = uc.authorizePayment(ctx, order)
+		if err != nil {
+			return err
+		}
+		uc.logger.Info("Transforming status after payment authorization",
 			zap.String("order_id", message.OrderID),
 			zap.String("from_status", message.Status),
+			zap.String("to_status", newStatus),
 		)
-	} else {
+	case domain.OrderStatusShipped:
+		if err := uc.capturePayment(ctx, order); err != nil {
+			return err
+		}
+	default:
 		uc.logger.Info("No status transformation needed",
 			zap.String("order_id", message.OrderID),
 			zap.String("status", message.Status),
@@ -171,3 +194,83 @@ func (uc *orderUseCase) ProcessOrderStatusMessage(ctx context.Context, message *
 
 	return nil
 }
+
+// authorizePayment authorizes the order total with the payment gateway and returns the
+// status the order moves to. Redelivered messages reuse the stored payment instead of
+// authorizing twice.
+func (uc *orderUseCase) authorizePayment(ctx context.Context, order *domain.Order) (string, error) {
+	payment, err := uc.paymentRepository.FindByOrderID(ctx, order.ID)
+	if err != nil {
+		return "", err
+	}
+
+	if payment != nil {
+		uc.logger.Info("Payment already processed for order",
+			zap.String("order_id", order.ID.Hex()),
+			zap.String("payment_status", payment.Status),
+		)
+		if payment.Status == domain.PaymentStatusDeclined {
+			return domain.OrderStatusPaymentDeclined, nil
+		}
+		return domain.OrderStatusProcessing, nil
+	}
+
+	payment = domain.NewPayment(order.ID, uc.paymentGateway.Name(), order.Total, order.Currency)
+
+	result, err := uc.paymentGateway.Authorize(ctx, order.ID.Hex(), payment.Amount, payment.Currency)
+	if err != nil {
+		uc.logger.Error("Payment gateway authorization failed",
+			zap.String("order_id", order.ID.Hex()),
+			zap.Error(err),
+		)
+		return "", fmt.Errorf("failed to authorize payment: %w", err)
+	}
+
+	payment.Record(domain.PaymentOperationAuthorize, "", payment.Amount, result, domain.PaymentStatusAuthorized)
+	if err := uc.paymentRepository.Save(ctx, payment); err != nil {
+		return "", err
+	}
+
+	if !result.Approved {
+		uc.logger.Warn("Payment declined",
+			zap.String("order_id", order.ID.Hex()),
+			zap.String("reason", result.Reason),
+		)
+		return domain.OrderStatusPaymentDeclined, nil
+	}
+
+	return domain.OrderStatusProcessing, nil
+}
+
+// capturePayment captures an authorized payment once the order is shipped
+func (uc *orderUseCase) capturePayment(ctx context.Context, order *domain.Order) error {
+	payment, err := uc.paymentRepository.FindByOrderID(ctx, order.ID)
+	if err != nil {
+		return err
+	}
+
+	if payment == nil || !payment.IsAuthorized() {
+		return nil
+	}
+
+	result, err := uc.paymentGateway.Capture(ctx, payment.TransactionID, payment.Amount)
+	if err != nil {
+		uc.logger.Error("Payment gateway capture failed",
+			zap.String("order_id", order.ID.Hex()),
+			zap.Error(err),
+		)
+		return fmt.Errorf("failed to capture payment: %w", err)
+	}
+
+	payment.Record(domain.PaymentOperationCapture, "", payment.Amount, result, domain.PaymentStatusCaptured)
+	if result.Approved {
+		payment.CapturedAmount = payment.Amount
+	} else {
+		uc.logger.Warn("Payment capture declined",
+			zap.String("order_id", order.ID.Hex()),
+			zap.String("reason", result.Reason),
+		)
+	}
+
+	return uc.paymentRepository.Save(ctx, payment)
+}
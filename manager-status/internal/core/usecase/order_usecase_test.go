@@ -0,0 +1,196 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/adapter/payment"
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/dto"
+	"github.com/gvillela7/rank-my-app/internal/core/usecase"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+type mockOrderRepository struct {
+	orders map[primitive.ObjectID]*domain.Order
+}
+
+func (m *mockOrderRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*domain.Order, error) {
+	order, ok := m.orders[id]
+	if !ok {
+		return nil, mongo.ErrNoDocuments
+	}
+	copied := *order
+	return &copied, nil
+}
+
+func (m *mockOrderRepository) UpdateStatus(ctx context.Context, id primitive.ObjectID, status string) error {
+	order, ok := m.orders[id]
+	if !ok {
+		return mongo.ErrNoDocuments
+	}
+	order.Status = status
+	return nil
+}
+
+type mockPublishedOrderRepository struct {
+	published map[primitive.ObjectID]*domain.PublishedOrder
+}
+
+func (m *mockPublishedOrderRepository) Create(ctx context.Context, publishedOrder *domain.PublishedOrder) error {
+	m.published[publishedOrder.OrderID] = publishedOrder
+	return nil
+}
+
+func (m *mockPublishedOrderRepository) FindByOrderID(ctx context.Context, orderID primitive.ObjectID) (*domain.PublishedOrder, error) {
+	return m.published[orderID], nil
+}
+
+func (m *mockPublishedOrderRepository) UpdatePublishedStatus(ctx context.Context, orderID primitive.ObjectID, published bool) error {
+	m.published[orderID].Published = published
+	return nil
+}
+
+type mockPaymentRepository struct {
+	payments map[primitive.ObjectID]*domain.Payment
+}
+
+func (m *mockPaymentRepository) FindByOrderID(ctx context.Context, orderID primitive.ObjectID) (*domain.Payment, error) {
+	payment, ok := m.payments[orderID]
+	if !ok {
+		return nil, nil
+	}
+	copied := *payment
+	return &copied, nil
+}
+
+func (m *mockPaymentRepository) Save(ctx context.Context, payment *domain.Payment) error {
+	m.payments[payment.OrderID] = payment
+	return nil
+}
+
+type orderFixture struct {
+	order     *domain.Order
+	orders    *mockOrderRepository
+	payments  *mockPaymentRepository
+	published *mockPublishedOrderRepository
+}
+
+// newOrderFixture stores a created order of total and returns the use case
+// processing its messages with the fake gateway
+func newOrderFixture(total, declineAbove float64) (*orderFixture, func(status string) error) {
+	order := &domain.Order{ID: primitive.NewObjectID(), Total: total, Currency: "BRL", Status: domain.OrderStatusCreated}
+	f := &orderFixture{
+		order:     order,
+		orders:    &mockOrderRepository{orders: map[primitive.ObjectID]*domain.Order{order.ID: order}},
+		payments:  &mockPaymentRepository{payments: map[primitive.ObjectID]*domain.Payment{}},
+		published: &mockPublishedOrderRepository{published: map[primitive.ObjectID]*domain.PublishedOrder{}},
+	}
+	uc := usecase.NewOrderUseCase(f.orders, f.published, f.payments, payment.NewFakeGateway(declineAbove), zap.NewNop())
+
+	process := func(status string) error {
+		return uc.ProcessOrderStatusMessage(context.Background(), &dto.OrderStatusMessage{
+			OrderID:   order.ID.Hex(),
+			Status:    status,
+			Timestamp: time.Now(),
+		})
+	}
+	return f, process
+}
+
+func TestOrderUseCase_ApprovedPaymentMovesOrderToProcessing(t *testing.T) {
+	f, process := newOrderFixture(100, 0)
+
+	if err := process(domain.OrderStatusCreated); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if f.order.Status != domain.OrderStatusProcessing {
+		t.Errorf("expected status %s, got %s", domain.OrderStatusProcessing, f.order.Status)
+	}
+	payment := f.payments.payments[f.order.ID]
+	if payment == nil || payment.Status != domain.PaymentStatusAuthorized || payment.TransactionID == "" {
+		t.Fatalf("expected an authorized payment with a transaction, got %+v", payment)
+	}
+	if published := f.published.published[f.order.ID]; published == nil || published.OrderStatus != domain.OrderStatusProcessing {
+		t.Errorf("expected the published order to record %s, got %+v", domain.OrderStatusProcessing, published)
+	}
+
+	// a redelivered message reuses the payment instead of authorizing again
+	if err := process(domain.OrderStatusCreated); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if attempts := len(f.payments.payments[f.order.ID].Attempts); attempts != 1 {
+		t.Errorf("expected 1 gateway attempt, got %d", attempts)
+	}
+}
+
+func TestOrderUseCase_DeclinedPaymentMovesOrderToPaymentDeclined(t *testing.T) {
+	f, process := newOrderFixture(100, 50)
+
+	if err := process(domain.OrderStatusCreated); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if f.order.Status != domain.OrderStatusPaymentDeclined {
+		t.Errorf("expected status %s, got %s", domain.OrderStatusPaymentDeclined, f.order.Status)
+	}
+	payment := f.payments.payments[f.order.ID]
+	if payment == nil || payment.Status != domain.PaymentStatusDeclined {
+		t.Fatalf("expected a declined payment, got %+v", payment)
+	}
+	if payment.Attempts[0].Reason != "insufficient_funds" {
+		t.Errorf("expected reason insufficient_funds, got %q", payment.Attempts[0].Reason)
+	}
+}
+
+func TestOrderUseCase_ShippedOrderCapturesPayment(t *testing.T) {
+	f, process := newOrderFixture(100, 0)
+
+	if err := process(domain.OrderStatusCreated); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := process(domain.OrderStatusShipped); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if f.order.Status != domain.OrderStatusShipped {
+		t.Errorf("expected status %s, got %s", domain.OrderStatusShipped, f.order.Status)
+	}
+	payment := f.payments.payments[f.order.ID]
+	if payment.Status != domain.PaymentStatusCaptured || payment.CapturedAmount != 100 {
+		t.Errorf("expected 100 captured, got status %s and %f captured", payment.Status, payment.CapturedAmount)
+	}
+
+	// captured payments are not captured again
+	if err := process(domain.OrderStatusShipped); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if attempts := len(f.payments.payments[f.order.ID].Attempts); attempts != 2 {
+		t.Errorf("expected authorize and capture attempts only, got %d", attempts)
+	}
+}
+
+func TestOrderUseCase_RedeliveredCreatedMessageLeavesShippedOrder(t *testing.T) {
+	f, process := newOrderFixture(100, 0)
+
+	if err := process(domain.OrderStatusCreated); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := process(domain.OrderStatusShipped); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := process(domain.OrderStatusCreated); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if f.order.Status != domain.OrderStatusShipped {
+		t.Errorf("expected status to remain %s, got %s", domain.OrderStatusShipped, f.order.Status)
+	}
+	if published := f.published.published[f.order.ID]; published.OrderStatus != domain.OrderStatusProcessing {
+		t.Errorf("expected the published order untouched, got %s", published.OrderStatus)
+	}
+}
@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/dto"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+type returnUseCase struct {
+	orderRepository   ports.OrderRepository
+	eventRepository   ports.ReturnEventRepository
+	paymentRepository ports.PaymentRepository
+	paymentGateway    ports.PaymentGateway
+	logger            *zap.Logger
+}
+
+func NewReturnUseCase(
+	orderRepository ports.OrderRepository,
+	eventRepository ports.ReturnEventRepository,
+	paymentRepository ports.PaymentRepository,
+	paymentGateway ports.PaymentGateway,
+	logger *zap.Logger,
+) ports.ReturnUseCase {
+	return &returnUseCase{
+		orderRepository:   orderRepository,
+		eventRepository:   eventRepository,
+		paymentRepository: paymentRepository,
+		paymentGateway:    paymentGateway,
+		logger:            logger,
+	}
+}
+
+// ProcessReturnEvent records a message from the order-return queue and refunds approved returns
+func (uc *returnUseCase) ProcessReturnEvent(ctx context.Context, message *dto.ReturnEventMessage) error {
+	uc.logger.Info("Processing return event",
+		zap.String("event", message.Event),
+		zap.String("return_id", message.ReturnID),
+		zap.String("order_id", message.OrderID),
+	)
+
+	orderID, err := primitive.ObjectIDFromHex(message.OrderID)
+	if err != nil {
+		uc.logger.Error("Invalid order ID in message - cannot parse to ObjectID",
+			zap.String("order_id_string", message.OrderID),
+			zap.Error(err),
+		)
+		return fmt.Errorf("invalid order ID: %w", err)
+	}
+
+	if _, err := uc.orderRepository.FindByID(ctx, orderID); err != nil {
+		if err == mongo.ErrNoDocuments {
+			uc.logger.Error("Order not found",
+				zap.String("order_id", message.OrderID),
+			)
+			return errors.New("order not found")
+		}
+		return fmt.Errorf("failed to find order: %w", err)
+	}
+
+	if message.Event == domain.ReturnEventApproved {
+		if err := uc.refund(ctx, orderID, message); err != nil {
+			return err
+		}
+	}
+
+	items := make([]domain.ReturnEventItem, len(message.Items))
+	for i, item := range message.Items {
+		items[i] = domain.ReturnEventItem{
+			ProductID:    item.ProductID,
+			Quantity:     item.Quantity,
+			Reason:       item.Reason,
+			RefundAmount: item.RefundAmount,
+		}
+	}
+
+	event := &domain.ReturnEvent{
+		ID:          primitive.NewObjectID(),
+		Event:       message.Event,
+		ReturnID:    message.ReturnID,
+		OrderID:     orderID,
+		Items:       items,
+		RefundTotal: message.RefundTotal,
+		Currency:    message.Currency,
+		Reason:      message.Reason,
+		Timestamp:   message.Timestamp,
+		ReceivedAt:  time.Now(),
+	}
+
+	if err := uc.eventRepository.Save(ctx, event); err != nil {
+		return err
+	}
+
+	uc.logger.Info("Return event processed successfully",
+		zap.String("event", message.Event),
+		zap.String("return_id", message.ReturnID),
+		zap.String("order_id", message.OrderID),
+	)
+
+	return nil
+}
+
+// refund returns the approved amount through the payment gateway. Refunds are
+// referenced by return ID, so a redelivered approval is not refunded twice.
+func (uc *returnUseCase) refund(ctx context.Context, orderID primitive.ObjectID, message *dto.ReturnEventMessage) error {
+	payment, err := uc.paymentRepository.FindByOrderID(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	if payment == nil || payment.Status == domain.PaymentStatusDeclined {
+		uc.logger.Warn("No payment to refund for order",
+			zap.String("order_id", message.OrderID),
+			zap.String("return_id", message.ReturnID),
+		)
+		return nil
+	}
+
+	if payment.HasApproved(domain.PaymentOperationRefund, message.ReturnID) {
+		uc.logger.Info("Return already refunded",
+			zap.String("return_id", message.ReturnID),
+		)
+		return nil
+	}
+
+	amount := message.RefundTotal
+	if refundable := payment.Refundable(); amount > refundable {
+		amount = refundable
+	}
+	if amount <= 0 {
+		uc.logger.Warn("Nothing left to refund for order",
+			zap.String("order_id", message.OrderID),
+			zap.String("return_id", message.ReturnID),
+		)
+		return nil
+	}
+
+	result, err := uc.paymentGateway.Refund(ctx, payment.TransactionID, amount)
+	if err != nil {
+		uc.logger.Error("Payment gateway refund failed",
+			zap.String("order_id", message.OrderID),
+			zap.Error(err),
+		)
+		return fmt.Errorf("failed to refund payment: %w", err)
+	}
+
+	status := payment.Status
+	if result.Approved {
+		payment.RefundedAmount += amount
+		if payment.Refundable() <= 0 {
+			status = domain.PaymentStatusRefunded
+		}
+	} else {
+		uc.logger.Warn("Payment refund declined",
+			zap.String("order_id", message.OrderID),
+			zap.String("reason", result.Reason),
+		)
+	}
+	payment.Record(domain.PaymentOperationRefund, message.ReturnID, amount, result, status)
+
+	return uc.paymentRepository.Save(ctx, payment)
+}
@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/dto"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+type shipmentUseCase struct {
+	orderRepository ports.OrderRepository
+	eventRepository ports.ShipmentEventRepository
+	logger          *zap.Logger
+}
+
+func NewShipmentUseCase(
+	orderRepository ports.OrderRepository,
+	eventRepository ports.ShipmentEventRepository,
+	logger *zap.Logger,
+) ports.ShipmentUseCase {
+	return &shipmentUseCase{
+		orderRepository: orderRepository,
+		eventRepository: eventRepository,
+		logger:          logger,
+	}
+}
+
+// ProcessShipmentEvent records a message from the order-shipment queue in the order tracking history
+func (uc *shipmentUseCase) ProcessShipmentEvent(ctx context.Context, message *dto.ShipmentEventMessage) error {
+	uc.logger.Info("Processing shipment event",
+		zap.String("event", message.Event),
+		zap.String("shipment_id", message.ShipmentID),
+		zap.String("order_id", message.OrderID),
+	)
+
+	orderID, err := primitive.ObjectIDFromHex(message.OrderID)
+	if err != nil {
+		uc.logger.Error("Invalid order ID in message - cannot parse to ObjectID",
+			zap.String("order_id_string", message.OrderID),
+			zap.Error(err),
+		)
+		return fmt.Errorf("invalid order ID: %w", err)
+	}
+
+	if _, err := uc.orderRepository.FindByID(ctx, orderID); err != nil {
+		if err == mongo.ErrNoDocuments {
+			uc.logger.Error("Order not found",
+				zap.String("order_id", message.OrderID),
+			)
+			return errors.New("order not found")
+		}
+		return fmt.Errorf("failed to find order: %w", err)
+	}
+
+	items := make([]domain.ShipmentEventItem, len(message.Items))
+	for i, item := range message.Items {
+		items[i] = domain.ShipmentEventItem{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+		}
+	}
+
+	event := &domain.ShipmentEvent{
+		ID:           primitive.NewObjectID(),
+		Event:        message.Event,
+		ShipmentID:   message.ShipmentID,
+		OrderID:      orderID,
+		OrderStatus:  message.OrderStatus,
+		Carrier:      message.Carrier,
+		TrackingCode: message.TrackingCode,
+		Items:        items,
+		Timestamp:    message.Timestamp,
+		ReceivedAt:   time.Now(),
+	}
+
+	if err := uc.eventRepository.Save(ctx, event); err != nil {
+		return err
+	}
+
+	uc.logger.Info("Shipment event processed successfully",
+		zap.String("event", message.Event),
+		zap.String("shipment_id", message.ShipmentID),
+		zap.String("order_id", message.OrderID),
+		zap.String("order_status", message.OrderStatus),
+	)
+
+	return nil
+}
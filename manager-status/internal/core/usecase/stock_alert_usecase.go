@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/gvillela7/rank-my-app/internal/core/domain"
+	"github.com/gvillela7/rank-my-app/internal/core/dto"
+	"github.com/gvillela7/rank-my-app/internal/core/ports"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// maxStockAlerts caps how many alerts the admin API returns
+const maxStockAlerts = 100
+
+type stockAlertUseCase struct {
+	alertRepository ports.StockAlertRepository
+	logger          *zap.Logger
+}
+
+func NewStockAlertUseCase(
+	alertRepository ports.StockAlertRepository,
+	logger *zap.Logger,
+) ports.StockAlertUseCase {
+	return &stockAlertUseCase{
+		alertRepository: alertRepository,
+		logger:          logger,
+	}
+}
+
+// ProcessStockAlert records a message from the product-stock queue
+func (uc *stockAlertUseCase) ProcessStockAlert(ctx context.Context, message *dto.StockAlertMessage) error {
+	uc.logger.Info("Processing stock alert",
+		zap.String("event", message.Event),
+		zap.String("product_id", message.ProductID),
+		zap.String("variant_id", message.VariantID),
+		zap.Int("quantity", message.Quantity),
+	)
+
+	alert := &domain.StockAlert{
+		ID:               primitive.NewObjectID(),
+		Event:            message.Event,
+		ProductID:        message.ProductID,
+		VariantID:        message.VariantID,
+		SKU:              message.SKU,
+		ProductName:      message.ProductName,
+		Quantity:         message.Quantity,
+		ReorderThreshold: message.ReorderThreshold,
+		MovementID:       message.MovementID,
+		Timestamp:        message.Timestamp,
+		ReceivedAt:       time.Now(),
+	}
+
+	if err := uc.alertRepository.Save(ctx, alert); err != nil {
+		return err
+	}
+
+	uc.logger.Warn("Stock alert recorded",
+		zap.String("event", message.Event),
+		zap.String("product_id", message.ProductID),
+		zap.String("product_name", message.ProductName),
+		zap.Int("quantity", message.Quantity),
+		zap.Int("reorder_threshold", message.ReorderThreshold),
+	)
+
+	return nil
+}
+
+// ListAlerts returns the most recent stock alerts, optionally filtered by product
+func (uc *stockAlertUseCase) ListAlerts(ctx context.Context, productID string) ([]dto.StockAlertResponse, error) {
+	alerts, err := uc.alertRepository.List(ctx, productID, maxStockAlerts)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.StockAlertResponse, len(alerts))
+	for i, alert := range alerts {
+		responses[i] = dto.StockAlertResponse{
+			ID:               alert.ID.Hex(),
+			Event:            alert.Event,
+			ProductID:        alert.ProductID,
+			VariantID:        alert.VariantID,
+			SKU:              alert.SKU,
+			ProductName:      alert.ProductName,
+			Quantity:         alert.Quantity,
+			ReorderThreshold: alert.ReorderThreshold,
+			MovementID:       alert.MovementID,
+			Timestamp:        alert.Timestamp,
+			ReceivedAt:       alert.ReceivedAt,
+		}
+	}
+
+	return responses, nil
+}
@@ -5,11 +5,18 @@ package wire
 
 import (
 	"context"
+	"fmt"
+	"net"
 	"strconv"
 
 	"github.com/google/wire"
 	"github.com/gvillela7/rank-my-app/configs"
+	"github.com/gvillela7/rank-my-app/inprocess"
+	"github.com/gvillela7/rank-my-app/internal/adapter/audit"
+	"github.com/gvillela7/rank-my-app/internal/adapter/http/admin"
 	"github.com/gvillela7/rank-my-app/internal/adapter/messages/consumers"
+	"github.com/gvillela7/rank-my-app/internal/adapter/payment"
+	memoryRepo "github.com/gvillela7/rank-my-app/internal/adapter/repository/memory"
 	mongoRepo "github.com/gvillela7/rank-my-app/internal/adapter/repository/mongo"
 	"github.com/gvillela7/rank-my-app/internal/core/ports"
 	"github.com/gvillela7/rank-my-app/internal/core/usecase"
@@ -19,11 +26,23 @@ import (
 	"go.uber.org/zap"
 )
 
+// App is the consumer service. DB is nil when the documents are kept in memory and
+// RabbitMQConn when messages come from the in-process broker.
 type App struct {
-	Consumer     ports.MessageConsumer
-	DB           *dbMongo.MongoDBConnection
-	RabbitMQConn *rabbitmq.RabbitMQConnection
-	Logger       *zap.Logger
+	Consumer         ports.MessageConsumer
+	ShipmentConsumer ports.ShipmentConsumer
+	ReturnConsumer   ports.ReturnConsumer
+	StockConsumer    ports.StockAlertConsumer
+	AdminServer      *admin.Server
+	DB               *dbMongo.MongoDBConnection
+	RabbitMQConn     *rabbitmq.RabbitMQConnection
+	Logger           *zap.Logger
+}
+
+// memoryStorage reports whether the repositories keep their documents in memory
+// instead of MongoDB
+func memoryStorage() bool {
+	return config.GetStorageConfig().Driver == "memory"
 }
 
 func InitializeApp(ctx context.Context) (*App, func(), error) {
@@ -31,11 +50,25 @@ func InitializeApp(ctx context.Context) (*App, func(), error) {
 		ProvideMongoConnection,
 		ProvideMongoDatabase,
 		ProvideRabbitMQConnection,
+		ProvideBroker,
 		ProvideLogger,
+		ProvideAuditRepository,
 		ProvideOrderRepository,
 		ProvidePublishedOrderRepository,
+		ProvidePaymentRepository,
+		ProvidePaymentGateway,
 		ProvideOrderUseCase,
 		ProvideMessageConsumer,
+		ProvideShipmentEventRepository,
+		ProvideShipmentUseCase,
+		ProvideShipmentConsumer,
+		ProvideReturnEventRepository,
+		ProvideReturnUseCase,
+		ProvideReturnConsumer,
+		ProvideStockAlertRepository,
+		ProvideStockAlertUseCase,
+		ProvideStockAlertConsumer,
+		ProvideAdminServer,
 		ProvideApp,
 	)
 	return nil, nil, nil
@@ -43,23 +76,45 @@ func InitializeApp(ctx context.Context) (*App, func(), error) {
 
 func ProvideApp(
 	consumer ports.MessageConsumer,
+	shipmentConsumer ports.ShipmentConsumer,
+	returnConsumer ports.ReturnConsumer,
+	stockConsumer ports.StockAlertConsumer,
+	adminServer *admin.Server,
 	conn *dbMongo.MongoDBConnection,
 	rabbitConn *rabbitmq.RabbitMQConnection,
 	logger *zap.Logger,
 ) *App {
 	return &App{
-		Consumer:     consumer,
-		DB:           conn,
-		RabbitMQConn: rabbitConn,
-		Logger:       logger,
+		Consumer:         consumer,
+		ShipmentConsumer: shipmentConsumer,
+		ReturnConsumer:   returnConsumer,
+		StockConsumer:    stockConsumer,
+		AdminServer:      adminServer,
+		DB:               conn,
+		RabbitMQConn:     rabbitConn,
+		Logger:           logger,
 	}
 }
 
+// ProvideMongoConnection returns nil when the documents are kept in memory
 func ProvideMongoConnection(ctx context.Context) (*dbMongo.MongoDBConnection, error) {
-	return dbMongo.NewMongoDBConnection(ctx)
+	switch driver := config.GetStorageConfig().Driver; driver {
+	case "mongo":
+		return dbMongo.NewMongoDBConnection(ctx)
+	case "memory":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unsupported storage driver %q", driver)
+	}
 }
 
+// ProvideMongoDatabase also bounds every repository call by mongo.operation_timeout
 func ProvideMongoDatabase(conn *dbMongo.MongoDBConnection) (*mongo.Database, error) {
+	if conn == nil {
+		return nil, nil
+	}
+
+	mongoRepo.SetOperationTimeout(config.GetDBMongo().OperationTimeout)
 	return conn.Client()
 }
 
@@ -67,19 +122,61 @@ func ProvideLogger() (*zap.Logger, error) {
 	return zap.NewProduction()
 }
 
-func ProvideOrderRepository(db *mongo.Database, logger *zap.Logger) ports.OrderRepository {
-	return mongoRepo.NewOrderRepository(db, logger)
+func ProvideAuditRepository(db *mongo.Database) ports.AuditRepository {
+	if memoryStorage() {
+		return memoryRepo.NewAuditRepository()
+	}
+	return mongoRepo.NewAuditRepository(db)
+}
+
+// ProvideOrderRepository starts without orders when the documents are kept in memory,
+// since only api-orders creates them
+func ProvideOrderRepository(db *mongo.Database, auditRepo ports.AuditRepository, logger *zap.Logger) ports.OrderRepository {
+	if memoryStorage() {
+		return audit.NewOrderRepository(memoryRepo.NewOrderRepository(), auditRepo, logger)
+	}
+	return audit.NewOrderRepository(mongoRepo.NewOrderRepository(db, logger), auditRepo, logger)
 }
 
 func ProvideOrderUseCase(
 	repo ports.OrderRepository,
 	publishedOrderRepo ports.PublishedOrderRepository,
+	paymentRepo ports.PaymentRepository,
+	paymentGateway ports.PaymentGateway,
 	logger *zap.Logger,
 ) ports.OrderUseCase {
-	return usecase.NewOrderUseCase(repo, publishedOrderRepo, logger)
+	return usecase.NewOrderUseCase(repo, publishedOrderRepo, paymentRepo, paymentGateway, logger)
+}
+
+func ProvidePaymentRepository(db *mongo.Database, auditRepo ports.AuditRepository, logger *zap.Logger) ports.PaymentRepository {
+	if memoryStorage() {
+		return audit.NewPaymentRepository(memoryRepo.NewPaymentRepository(), auditRepo, logger)
+	}
+	return audit.NewPaymentRepository(mongoRepo.NewPaymentRepository(db, logger), auditRepo, logger)
+}
+
+func ProvidePaymentGateway(logger *zap.Logger) (ports.PaymentGateway, error) {
+	cfg := config.GetPaymentConfig()
+
+	switch cfg.Provider {
+	case payment.FakeGatewayName:
+		logger.Info("Using fake payment gateway", zap.Float64("decline_above", cfg.FakeDeclineAbove))
+		return payment.NewFakeGateway(cfg.FakeDeclineAbove), nil
+	default:
+		return nil, fmt.Errorf("unknown payment provider: %s", cfg.Provider)
+	}
 }
 
+// ProvideRabbitMQConnection returns nil when messages come from the in-process broker
 func ProvideRabbitMQConnection(logger *zap.Logger) (*rabbitmq.RabbitMQConnection, error) {
+	switch driver := config.GetBrokerConfig().Driver; driver {
+	case "rabbitmq":
+	case "memory":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unsupported broker driver %q", driver)
+	}
+
 	cfg := config.GetRabbitMQConfig()
 
 	logger.Info("Loading RabbitMQ configuration",
@@ -106,14 +203,100 @@ func ProvideRabbitMQConnection(logger *zap.Logger) (*rabbitmq.RabbitMQConnection
 	)
 }
 
+// ProvideBroker returns the broker the consumers read from: the in-process broker
+// when broker.driver is memory, RabbitMQ otherwise
+func ProvideBroker(rabbitConn *rabbitmq.RabbitMQConnection, logger *zap.Logger) consumers.Broker {
+	cfg := config.GetBrokerConfig()
+	if cfg.Driver == "memory" {
+		logger.Info("Consuming from the in-process broker", zap.Int("queue_capacity", cfg.QueueCapacity))
+		return consumers.NewInProcessBroker(inprocess.NewBroker(cfg.QueueCapacity))
+	}
+	return consumers.NewRabbitMQBroker(rabbitConn, logger)
+}
+
 func ProvidePublishedOrderRepository(db *mongo.Database, logger *zap.Logger) ports.PublishedOrderRepository {
+	if memoryStorage() {
+		return memoryRepo.NewPublishedOrderRepository()
+	}
 	return mongoRepo.NewPublishedOrderRepository(db, logger)
 }
 
 func ProvideMessageConsumer(
-	rabbitConn *rabbitmq.RabbitMQConnection,
+	broker consumers.Broker,
 	useCase ports.OrderUseCase,
 	logger *zap.Logger,
 ) ports.MessageConsumer {
-	return consumers.NewOrderConsumer(rabbitConn, useCase, logger)
+	return consumers.NewOrderConsumer(broker, useCase, logger)
+}
+
+func ProvideShipmentEventRepository(db *mongo.Database, logger *zap.Logger) ports.ShipmentEventRepository {
+	if memoryStorage() {
+		return memoryRepo.NewShipmentEventRepository()
+	}
+	return mongoRepo.NewShipmentEventRepository(db, logger)
+}
+
+func ProvideShipmentUseCase(
+	orderRepo ports.OrderRepository,
+	eventRepo ports.ShipmentEventRepository,
+	logger *zap.Logger,
+) ports.ShipmentUseCase {
+	return usecase.NewShipmentUseCase(orderRepo, eventRepo, logger)
+}
+
+func ProvideShipmentConsumer(
+	broker consumers.Broker,
+	useCase ports.ShipmentUseCase,
+	logger *zap.Logger,
+) ports.ShipmentConsumer {
+	return consumers.NewShipmentConsumer(broker, useCase, logger)
+}
+
+func ProvideReturnEventRepository(db *mongo.Database, logger *zap.Logger) ports.ReturnEventRepository {
+	if memoryStorage() {
+		return memoryRepo.NewReturnEventRepository()
+	}
+	return mongoRepo.NewReturnEventRepository(db, logger)
+}
+
+func ProvideReturnUseCase(
+	orderRepo ports.OrderRepository,
+	eventRepo ports.ReturnEventRepository,
+	paymentRepo ports.PaymentRepository,
+	paymentGateway ports.PaymentGateway,
+	logger *zap.Logger,
+) ports.ReturnUseCase {
+	return usecase.NewReturnUseCase(orderRepo, eventRepo, paymentRepo, paymentGateway, logger)
+}
+
+func ProvideReturnConsumer(
+	broker consumers.Broker,
+	useCase ports.ReturnUseCase,
+	logger *zap.Logger,
+) ports.ReturnConsumer {
+	return consumers.NewReturnConsumer(broker, useCase, logger)
+}
+
+func ProvideStockAlertRepository(db *mongo.Database, logger *zap.Logger) ports.StockAlertRepository {
+	if memoryStorage() {
+		return memoryRepo.NewStockAlertRepository()
+	}
+	return mongoRepo.NewStockAlertRepository(db, logger)
+}
+
+func ProvideStockAlertUseCase(alertRepo ports.StockAlertRepository, logger *zap.Logger) ports.StockAlertUseCase {
+	return usecase.NewStockAlertUseCase(alertRepo, logger)
+}
+
+func ProvideStockAlertConsumer(
+	broker consumers.Broker,
+	useCase ports.StockAlertUseCase,
+	logger *zap.Logger,
+) ports.StockAlertConsumer {
+	return consumers.NewStockAlertConsumer(broker, useCase, logger)
+}
+
+func ProvideAdminServer(alertUseCase ports.StockAlertUseCase, logger *zap.Logger) *admin.Server {
+	cfg := config.GetAPIConfig()
+	return admin.NewServer(net.JoinHostPort(cfg.Host, cfg.Port), config.GetAdminConfig().Tokens, alertUseCase, logger)
 }
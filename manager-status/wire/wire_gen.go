@@ -8,8 +8,14 @@ package wire
 
 import (
 	"context"
+	"fmt"
 	"github.com/gvillela7/rank-my-app/configs"
+	"github.com/gvillela7/rank-my-app/inprocess"
+	"github.com/gvillela7/rank-my-app/internal/adapter/audit"
+	"github.com/gvillela7/rank-my-app/internal/adapter/http/admin"
 	"github.com/gvillela7/rank-my-app/internal/adapter/messages/consumers"
+	"github.com/gvillela7/rank-my-app/internal/adapter/payment"
+	"github.com/gvillela7/rank-my-app/internal/adapter/repository/memory"
 	mongo3 "github.com/gvillela7/rank-my-app/internal/adapter/repository/mongo"
 	"github.com/gvillela7/rank-my-app/internal/core/ports"
 	"github.com/gvillela7/rank-my-app/internal/core/usecase"
@@ -17,6 +23,7 @@ import (
 	"github.com/gvillela7/rank-my-app/internal/infra/rabbitmq"
 	mongo2 "go.mongodb.org/mongo-driver/mongo"
 	"go.uber.org/zap"
+	"net"
 	"strconv"
 )
 
@@ -31,6 +38,7 @@ func InitializeApp(ctx context.Context) (*App, func(), error) {
 	if err != nil {
 		return nil, nil, err
 	}
+	broker := ProvideBroker(rabbitMQConnection, logger)
 	mongoDBConnection, err := ProvideMongoConnection(ctx)
 	if err != nil {
 		return nil, nil, err
@@ -39,43 +47,92 @@ func InitializeApp(ctx context.Context) (*App, func(), error) {
 	if err != nil {
 		return nil, nil, err
 	}
-	orderRepository := ProvideOrderRepository(database, logger)
+	auditRepository := ProvideAuditRepository(database)
+	orderRepository := ProvideOrderRepository(database, auditRepository, logger)
 	publishedOrderRepository := ProvidePublishedOrderRepository(database, logger)
-	orderUseCase := ProvideOrderUseCase(orderRepository, publishedOrderRepository, logger)
-	messageConsumer := ProvideMessageConsumer(rabbitMQConnection, orderUseCase, logger)
-	app := ProvideApp(messageConsumer, mongoDBConnection, rabbitMQConnection, logger)
+	paymentRepository := ProvidePaymentRepository(database, auditRepository, logger)
+	paymentGateway, err := ProvidePaymentGateway(logger)
+	if err != nil {
+		return nil, nil, err
+	}
+	orderUseCase := ProvideOrderUseCase(orderRepository, publishedOrderRepository, paymentRepository, paymentGateway, logger)
+	messageConsumer := ProvideMessageConsumer(broker, orderUseCase, logger)
+	shipmentEventRepository := ProvideShipmentEventRepository(database, logger)
+	shipmentUseCase := ProvideShipmentUseCase(orderRepository, shipmentEventRepository, logger)
+	shipmentConsumer := ProvideShipmentConsumer(broker, shipmentUseCase, logger)
+	returnEventRepository := ProvideReturnEventRepository(database, logger)
+	returnUseCase := ProvideReturnUseCase(orderRepository, returnEventRepository, paymentRepository, paymentGateway, logger)
+	returnConsumer := ProvideReturnConsumer(broker, returnUseCase, logger)
+	stockAlertRepository := ProvideStockAlertRepository(database, logger)
+	stockAlertUseCase := ProvideStockAlertUseCase(stockAlertRepository, logger)
+	stockAlertConsumer := ProvideStockAlertConsumer(broker, stockAlertUseCase, logger)
+	server := ProvideAdminServer(stockAlertUseCase, logger)
+	app := ProvideApp(messageConsumer, shipmentConsumer, returnConsumer, stockAlertConsumer, server, mongoDBConnection, rabbitMQConnection, logger)
 	return app, func() {
 	}, nil
 }
 
 // wire.go:
 
+// App is the consumer service. DB is nil when the documents are kept in memory and
+// RabbitMQConn when messages come from the in-process broker.
 type App struct {
-	Consumer     ports.MessageConsumer
-	DB           *mongo.MongoDBConnection
-	RabbitMQConn *rabbitmq.RabbitMQConnection
-	Logger       *zap.Logger
+	Consumer         ports.MessageConsumer
+	ShipmentConsumer ports.ShipmentConsumer
+	ReturnConsumer   ports.ReturnConsumer
+	StockConsumer    ports.StockAlertConsumer
+	AdminServer      *admin.Server
+	DB               *mongo.MongoDBConnection
+	RabbitMQConn     *rabbitmq.RabbitMQConnection
+	Logger           *zap.Logger
+}
+
+// memoryStorage reports whether the repositories keep their documents in memory
+// instead of MongoDB
+func memoryStorage() bool {
+	return config.GetStorageConfig().Driver == "memory"
 }
 
 func ProvideApp(
 	consumer ports.MessageConsumer,
+	shipmentConsumer ports.ShipmentConsumer,
+	returnConsumer ports.ReturnConsumer,
+	stockConsumer ports.StockAlertConsumer,
+	adminServer *admin.Server,
 	conn *mongo.MongoDBConnection,
 	rabbitConn *rabbitmq.RabbitMQConnection,
 	logger *zap.Logger,
 ) *App {
 	return &App{
-		Consumer:     consumer,
-		DB:           conn,
-		RabbitMQConn: rabbitConn,
-		Logger:       logger,
+		Consumer:         consumer,
+		ShipmentConsumer: shipmentConsumer,
+		ReturnConsumer:   returnConsumer,
+		StockConsumer:    stockConsumer,
+		AdminServer:      adminServer,
+		DB:               conn,
+		RabbitMQConn:     rabbitConn,
+		Logger:           logger,
 	}
 }
 
+// ProvideMongoConnection returns nil when the documents are kept in memory
 func ProvideMongoConnection(ctx context.Context) (*mongo.MongoDBConnection, error) {
-	return mongo.NewMongoDBConnection(ctx)
+	switch driver := config.GetStorageConfig().Driver; driver {
+	case "mongo":
+		return mongo.NewMongoDBConnection(ctx)
+	case "memory":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unsupported storage driver %q", driver)
+	}
 }
 
+// ProvideMongoDatabase also bounds every repository call by mongo.operation_timeout
 func ProvideMongoDatabase(conn *mongo.MongoDBConnection) (*mongo2.Database, error) {
+	if conn == nil {
+		return nil, nil
+	}
+	mongo3.SetOperationTimeout(config.GetDBMongo().OperationTimeout)
 	return conn.Client()
 }
 
@@ -83,19 +140,61 @@ func ProvideLogger() (*zap.Logger, error) {
 	return zap.NewProduction()
 }
 
-func ProvideOrderRepository(db *mongo2.Database, logger *zap.Logger) ports.OrderRepository {
-	return mongo3.NewOrderRepository(db, logger)
+func ProvideAuditRepository(db *mongo2.Database) ports.AuditRepository {
+	if memoryStorage() {
+		return memory.NewAuditRepository()
+	}
+	return mongo3.NewAuditRepository(db)
+}
+
+// ProvideOrderRepository starts without orders when the documents are kept in memory,
+// since only api-orders creates them
+func ProvideOrderRepository(db *mongo2.Database, auditRepo ports.AuditRepository, logger *zap.Logger) ports.OrderRepository {
+	if memoryStorage() {
+		return audit.NewOrderRepository(memory.NewOrderRepository(), auditRepo, logger)
+	}
+	return audit.NewOrderRepository(mongo3.NewOrderRepository(db, logger), auditRepo, logger)
 }
 
 func ProvideOrderUseCase(
 	repo ports.OrderRepository,
 	publishedOrderRepo ports.PublishedOrderRepository,
+	paymentRepo ports.PaymentRepository,
+	paymentGateway ports.PaymentGateway,
 	logger *zap.Logger,
 ) ports.OrderUseCase {
-	return usecase.NewOrderUseCase(repo, publishedOrderRepo, logger)
+	return usecase.NewOrderUseCase(repo, publishedOrderRepo, paymentRepo, paymentGateway, logger)
+}
+
+func ProvidePaymentRepository(db *mongo2.Database, auditRepo ports.AuditRepository, logger *zap.Logger) ports.PaymentRepository {
+	if memoryStorage() {
+		return audit.NewPaymentRepository(memory.NewPaymentRepository(), auditRepo, logger)
+	}
+	return audit.NewPaymentRepository(mongo3.NewPaymentRepository(db, logger), auditRepo, logger)
 }
 
+func ProvidePaymentGateway(logger *zap.Logger) (ports.PaymentGateway, error) {
+	cfg := config.GetPaymentConfig()
+
+	switch cfg.Provider {
+	case payment.FakeGatewayName:
+		logger.Info("Using fake payment gateway", zap.Float64("decline_above", cfg.FakeDeclineAbove))
+		return payment.NewFakeGateway(cfg.FakeDeclineAbove), nil
+	default:
+		return nil, fmt.Errorf("unknown payment provider: %s", cfg.Provider)
+	}
+}
+
+// ProvideRabbitMQConnection returns nil when messages come from the in-process broker
 func ProvideRabbitMQConnection(logger *zap.Logger) (*rabbitmq.RabbitMQConnection, error) {
+	switch driver := config.GetBrokerConfig().Driver; driver {
+	case "rabbitmq":
+	case "memory":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unsupported broker driver %q", driver)
+	}
+
 	cfg := config.GetRabbitMQConfig()
 
 	logger.Info("Loading RabbitMQ configuration", zap.String("host", cfg.Host), zap.String("port", cfg.Port), zap.String("username", cfg.Username), zap.String("vhost", cfg.VHost))
@@ -116,14 +215,100 @@ func ProvideRabbitMQConnection(logger *zap.Logger) (*rabbitmq.RabbitMQConnection
 	)
 }
 
+// ProvideBroker returns the broker the consumers read from: the in-process broker
+// when broker.driver is memory, RabbitMQ otherwise
+func ProvideBroker(rabbitConn *rabbitmq.RabbitMQConnection, logger *zap.Logger) consumers.Broker {
+	cfg := config.GetBrokerConfig()
+	if cfg.Driver == "memory" {
+		logger.Info("Consuming from the in-process broker", zap.Int("queue_capacity", cfg.QueueCapacity))
+		return consumers.NewInProcessBroker(inprocess.NewBroker(cfg.QueueCapacity))
+	}
+	return consumers.NewRabbitMQBroker(rabbitConn, logger)
+}
+
 func ProvidePublishedOrderRepository(db *mongo2.Database, logger *zap.Logger) ports.PublishedOrderRepository {
+	if memoryStorage() {
+		return memory.NewPublishedOrderRepository()
+	}
 	return mongo3.NewPublishedOrderRepository(db, logger)
 }
 
 func ProvideMessageConsumer(
-	rabbitConn *rabbitmq.RabbitMQConnection,
+	broker consumers.Broker,
 	useCase ports.OrderUseCase,
 	logger *zap.Logger,
 ) ports.MessageConsumer {
-	return consumers.NewOrderConsumer(rabbitConn, useCase, logger)
+	return consumers.NewOrderConsumer(broker, useCase, logger)
+}
+
+func ProvideShipmentEventRepository(db *mongo2.Database, logger *zap.Logger) ports.ShipmentEventRepository {
+	if memoryStorage() {
+		return memory.NewShipmentEventRepository()
+	}
+	return mongo3.NewShipmentEventRepository(db, logger)
+}
+
+func ProvideShipmentUseCase(
+	orderRepo ports.OrderRepository,
+	eventRepo ports.ShipmentEventRepository,
+	logger *zap.Logger,
+) ports.ShipmentUseCase {
+	return usecase.NewShipmentUseCase(orderRepo, eventRepo, logger)
+}
+
+func ProvideShipmentConsumer(
+	broker consumers.Broker,
+	useCase ports.ShipmentUseCase,
+	logger *zap.Logger,
+) ports.ShipmentConsumer {
+	return consumers.NewShipmentConsumer(broker, useCase, logger)
+}
+
+func ProvideReturnEventRepository(db *mongo2.Database, logger *zap.Logger) ports.ReturnEventRepository {
+	if memoryStorage() {
+		return memory.NewReturnEventRepository()
+	}
+	return mongo3.NewReturnEventRepository(db, logger)
+}
+
+func ProvideReturnUseCase(
+	orderRepo ports.OrderRepository,
+	eventRepo ports.ReturnEventRepository,
+	paymentRepo ports.PaymentRepository,
+	paymentGateway ports.PaymentGateway,
+	logger *zap.Logger,
+) ports.ReturnUseCase {
+	return usecase.NewReturnUseCase(orderRepo, eventRepo, paymentRepo, paymentGateway, logger)
+}
+
+func ProvideReturnConsumer(
+	broker consumers.Broker,
+	useCase ports.ReturnUseCase,
+	logger *zap.Logger,
+) ports.ReturnConsumer {
+	return consumers.NewReturnConsumer(broker, useCase, logger)
+}
+
+func ProvideStockAlertRepository(db *mongo2.Database, logger *zap.Logger) ports.StockAlertRepository {
+	if memoryStorage() {
+		return memory.NewStockAlertRepository()
+	}
+	return mongo3.NewStockAlertRepository(db, logger)
+}
+
+func ProvideStockAlertUseCase(alertRepo ports.StockAlertRepository, logger *zap.Logger) ports.StockAlertUseCase {
+	return usecase.NewStockAlertUseCase(alertRepo, logger)
+}
+
+func ProvideStockAlertConsumer(
+	broker consumers.Broker,
+	useCase ports.StockAlertUseCase,
+	logger *zap.Logger,
+) ports.StockAlertConsumer {
+	return consumers.NewStockAlertConsumer(broker, useCase, logger)
+}
+
+func ProvideAdminServer(alertUseCase ports.StockAlertUseCase, logger *zap.Logger) *admin.Server {
+	cfg := config.GetAPIConfig()
+	return admin.NewServer(net.JoinHostPort(cfg.Host, cfg.Port), config.GetAdminConfig().Tokens, alertUseCase, logger)
 }